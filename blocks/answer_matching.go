@@ -0,0 +1,79 @@
+package blocks
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MatchesAnswer reports whether a player's guess matches an accepted answer.
+//
+// Matching always ignores case and surrounding whitespace. Fuzzy matching
+// also ignores punctuation and spacing, and forgives one typo in answers of
+// five or more characters, or two typos from ten characters.
+func MatchesAnswer(guess, answer string, fuzzy bool) bool {
+	guess = strings.ToLower(strings.TrimSpace(guess))
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if guess == answer {
+		return true
+	}
+	if !fuzzy {
+		return false
+	}
+
+	guess = normaliseAnswer(guess)
+	answer = normaliseAnswer(answer)
+	if answer == "" {
+		return guess == ""
+	}
+
+	return levenshtein(guess, answer) <= allowedTypos(answer)
+}
+
+// normaliseAnswer keeps only letters and numbers.
+func normaliseAnswer(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func allowedTypos(answer string) int {
+	const (
+		oneTypoLength  = 5
+		twoTypoLength  = 10
+		maxAllowedTypo = 2
+	)
+	switch length := len([]rune(answer)); {
+	case length >= twoTypoLength:
+		return maxAllowedTypo
+	case length >= oneTypoLength:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
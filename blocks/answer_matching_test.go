@@ -0,0 +1,37 @@
+package blocks_test
+
+import (
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesAnswer(t *testing.T) {
+	tests := []struct {
+		name   string
+		guess  string
+		answer string
+		fuzzy  bool
+		want   bool
+	}{
+		{"Exact", "secret", "secret", false, true},
+		{"Case and whitespace", "  SeCrEt ", "secret", false, true},
+		{"Wrong", "wrong", "secret", false, false},
+		{"Typo without fuzzy", "secrte", "secret", false, false},
+		{"Punctuation without fuzzy", "Ada Lovelace.", "Ada Lovelace", false, false},
+		{"Punctuation with fuzzy", "ada-lovelace.", "Ada Lovelace", true, true},
+		{"One typo with fuzzy", "secrt", "secret", true, true},
+		{"Two typos in a short answer", "scrt", "secret", true, false},
+		{"Two typos in a long answer", "Welington Harbor", "Wellington Harbour", true, true},
+		{"Short answers must be exact", "cat", "car", true, false},
+		{"Macron counts as one typo", "Kerer", "Kererū", true, true},
+		{"Completely different", "elephant", "Ada Lovelace", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, blocks.MatchesAnswer(tt.guess, tt.answer, tt.fuzzy))
+		})
+	}
+}
@@ -20,6 +20,7 @@ const (
 	ContextCheckpoint      BlockContext = "checkpoint"       // Verify a player is at a location
 	ContextStart           BlockContext = "start"            // Start pages - introductions, rules, set team name
 	ContextFinish          BlockContext = "finish"           // Finish/end pages
+	ContextLibrary         BlockContext = "library"          // Saved in a user's block library
 )
 
 // FormValueTrue is the string value "true" used in form checkbox comparisons.
@@ -54,6 +55,10 @@ type Block interface {
 	GetPoints() int
 	GetIconSVG() string
 	GetData() json.RawMessage
+	GetVisibility() Visibility
+	SetVisibility(v Visibility)
+	GetLibraryID() string
+	SetLibraryID(id string)
 
 	// Data Operations
 	ParseData() error
@@ -73,8 +78,22 @@ type BaseBlock struct {
 	Data       json.RawMessage `json:"-"`
 	Order      int             `json:"-"`
 	Points     int             `json:"-"`
+	Visibility Visibility      `json:"visibility,omitzero"`
+	LibraryID  string          `json:"-"` // Library block this is a linked copy of
 }
 
+// GetVisibility returns the conditions for showing the block to a team.
+func (b *BaseBlock) GetVisibility() Visibility { return b.Visibility }
+
+// SetVisibility replaces the conditions for showing the block to a team.
+func (b *BaseBlock) SetVisibility(v Visibility) { b.Visibility = v }
+
+// GetLibraryID returns the library block this block is a linked copy of.
+func (b *BaseBlock) GetLibraryID() string { return b.LibraryID }
+
+// SetLibraryID links the block to a library block, or unlinks it if empty.
+func (b *BaseBlock) SetLibraryID(id string) { b.LibraryID = id }
+
 //nolint:gochecknoglobals // Central block registry pattern requires package-level state
 var (
 	blockRegistry   = make(map[string]*RegisteredBlock)
@@ -116,18 +135,26 @@ func init() {
 	)
 	registerBlock(&YoutubeBlock{}, []BlockContext{ContextLocationContent, ContextFinish, ContextStart})
 	registerBlock(&HeaderBlock{}, []BlockContext{ContextLocationContent, ContextStart, ContextFinish})
+	registerBlock(
+		&MapBlock{},
+		[]BlockContext{ContextLocationContent, ContextLocationClues, ContextFinish, ContextStart},
+	)
 	registerBlock(&RandomClueBlock{}, []BlockContext{ContextLocationClues})
 
 	// Interactive blocks
 	registerBlock(&BrokerBlock{}, []BlockContext{ContextLocationContent, ContextLocationClues})
 	registerBlock(&ChecklistBlock{}, []BlockContext{ContextLocationContent, ContextStart})
 	registerBlock(&ClueBlock{}, []BlockContext{ContextLocationContent, ContextLocationClues})
+	registerBlock(&ClozeBlock{}, []BlockContext{ContextLocationContent, ContextCheckpoint})
 	registerBlock(&PasswordBlock{}, []BlockContext{ContextLocationContent, ContextCheckpoint})
+	registerBlock(&NumericBlock{}, []BlockContext{ContextLocationContent, ContextCheckpoint})
 	registerBlock(&PhotoBlock{}, []BlockContext{ContextLocationContent, ContextFinish})
 	registerBlock(&PincodeBlock{}, []BlockContext{ContextLocationContent, ContextCheckpoint})
+	registerBlock(&PollBlock{}, []BlockContext{ContextLocationContent})
 	registerBlock(&QuizBlock{}, []BlockContext{ContextLocationContent, ContextCheckpoint})
 	registerBlock(&RatingBlock{}, []BlockContext{ContextLocationContent, ContextFinish})
 	registerBlock(&SortingBlock{}, []BlockContext{ContextLocationContent, ContextCheckpoint})
+	registerBlock(&MatchingBlock{}, []BlockContext{ContextLocationContent, ContextCheckpoint})
 
 	// Task specific blocks
 	registerBlock(&TaskBlock{}, []BlockContext{ContextTask})
@@ -198,6 +225,12 @@ func CreateFromBaseBlock(baseBlock BaseBlock) (Block, error) {
 		return NewImageBlock(baseBlock), nil
 	case "sorting":
 		return NewSortingBlock(baseBlock), nil
+	case "matching":
+		return NewMatchingBlock(baseBlock), nil
+	case "numeric":
+		return NewNumericBlock(baseBlock), nil
+	case "cloze":
+		return NewClozeBlock(baseBlock), nil
 	case "quiz_block":
 		return NewQuizBlock(baseBlock), nil
 	case "clue":
@@ -222,6 +255,10 @@ func CreateFromBaseBlock(baseBlock BaseBlock) (Block, error) {
 		return NewTaskBlock(baseBlock), nil
 	case "rating":
 		return NewRatingBlock(baseBlock), nil
+	case "poll":
+		return NewPollBlock(baseBlock), nil
+	case "map":
+		return NewMapBlock(baseBlock), nil
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrBlockTypeNotFound, baseBlock.Type)
 	}
@@ -282,6 +319,27 @@ func NewSortingBlock(base BaseBlock) *SortingBlock {
 	}
 }
 
+func NewClozeBlock(base BaseBlock) *ClozeBlock {
+	return &ClozeBlock{
+		BaseBlock:     base,
+		ScoringScheme: AllOrNothing,
+	}
+}
+
+func NewNumericBlock(base BaseBlock) *NumericBlock {
+	return &NumericBlock{
+		BaseBlock:     base,
+		ToleranceType: ToleranceAbsolute,
+	}
+}
+
+func NewMatchingBlock(base BaseBlock) *MatchingBlock {
+	return &MatchingBlock{
+		BaseBlock:     base,
+		ScoringScheme: AllOrNothing,
+	}
+}
+
 func NewClueBlock(base BaseBlock) *ClueBlock {
 	return &ClueBlock{
 		BaseBlock: base,
@@ -350,3 +408,17 @@ func NewRatingBlock(base BaseBlock) *RatingBlock {
 		MaxRating: 5, // Default max rating
 	}
 }
+
+func NewPollBlock(base BaseBlock) *PollBlock {
+	return &PollBlock{
+		BaseBlock: base,
+	}
+}
+
+func NewMapBlock(base BaseBlock) *MapBlock {
+	return &MapBlock{
+		BaseBlock: base,
+		Zoom:      defaultMapZoom,
+		Features:  MapFeatureCollection{Type: "FeatureCollection", Features: []MapFeature{}},
+	}
+}
@@ -0,0 +1,236 @@
+package blocks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ClozeBlock is a fill-in-the-blanks block. Authors write markdown with
+// [[answer]] markers, separating alternative answers with a pipe, e.g.
+// "The capital is [[Wellington|Te Whanganui-a-Tara]]." Markers are replaced
+// by inputs before the content reaches the player, so answers stay on the
+// server.
+type ClozeBlock struct {
+	BaseBlock
+	Content       string   `json:"content"`
+	Fuzzy         bool     `json:"fuzzy"`
+	WordBank      bool     `json:"word_bank"`
+	Distractors   []string `json:"distractors"`
+	ScoringScheme string   `json:"scoring_scheme"`
+}
+
+// ClozePlayerData stores player progress.
+type ClozePlayerData struct {
+	Answers   []string `json:"answers"`    // The most recent answer for each blank
+	Correct   []bool   `json:"correct"`    // Whether each blank was answered correctly
+	Attempts  int      `json:"attempts"`   // Number of attempts made so far
+	IsCorrect bool     `json:"is_correct"` // Whether every blank is correct
+}
+
+var clozeMarker = regexp.MustCompile(`\[\[([^\[\]]+)\]\]`)
+
+// GetName returns the block type name.
+func (b *ClozeBlock) GetName() string { return "Fill in the Blanks" }
+
+func (b *ClozeBlock) GetDescription() string {
+	return "Players fill the gaps in a passage of text."
+}
+
+func (b *ClozeBlock) GetIconSVG() string {
+	return `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-text-cursor-input"><path d="M5 4h1a3 3 0 0 1 3 3 3 3 0 0 1 3-3h1"/><path d="M13 20h-1a3 3 0 0 1-3-3 3 3 0 0 1-3 3H5"/><path d="M5 16H4a2 2 0 0 1-2-2v-4a2 2 0 0 1 2-2h1"/><path d="M13 8h7a2 2 0 0 1 2 2v4a2 2 0 0 1-2 2h-7"/><path d="M9 7v10"/></svg>`
+}
+
+func (b *ClozeBlock) GetType() string { return "cloze" }
+
+func (b *ClozeBlock) GetID() string { return b.ID }
+
+func (b *ClozeBlock) GetLocationID() string { return b.LocationID }
+
+func (b *ClozeBlock) GetOrder() int { return b.Order }
+
+func (b *ClozeBlock) GetPoints() int { return b.Points }
+
+func (b *ClozeBlock) GetData() json.RawMessage {
+	data, _ := json.Marshal(b)
+	return data
+}
+
+// ParseData parses the block data from JSON.
+func (b *ClozeBlock) ParseData() error {
+	return json.Unmarshal(b.Data, b)
+}
+
+func (b *ClozeBlock) UpdateBlockData(input map[string][]string) error {
+	// Parse points
+	pointsInput, ok := input["points"]
+	if ok && len(pointsInput[0]) > 0 {
+		points, err := strconv.Atoi(pointsInput[0])
+		if err != nil {
+			return errors.New("points must be an integer")
+		}
+		b.Points = points
+	} else {
+		b.Points = 0
+	}
+
+	if content, exists := input["content"]; exists && len(content) > 0 {
+		b.Content = content[0]
+	}
+
+	// Parse scoring scheme
+	if scheme, exists := input["scoring_scheme"]; exists && len(scheme) > 0 {
+		b.ScoringScheme = scheme[0]
+	} else {
+		b.ScoringScheme = AllOrNothing
+	}
+
+	b.Fuzzy = false
+	if fuzzy, exists := input["fuzzy"]; exists && len(fuzzy) > 0 {
+		b.Fuzzy = fuzzy[0] == "on"
+	}
+
+	b.WordBank = false
+	if wordBank, exists := input["word_bank"]; exists && len(wordBank) > 0 {
+		b.WordBank = wordBank[0] == "on"
+	}
+
+	b.Distractors = []string{}
+	if distractors, exists := input["distractors"]; exists && len(distractors) > 0 {
+		for word := range strings.SplitSeq(distractors[0], ",") {
+			if word = strings.TrimSpace(word); word != "" {
+				b.Distractors = append(b.Distractors, word)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RequiresValidation returns whether this block requires player input validation.
+func (b *ClozeBlock) RequiresValidation() bool { return true }
+
+func (b *ClozeBlock) ValidatePlayerInput(state PlayerState, input map[string][]string) (PlayerState, error) {
+	playerData, err := b.ParsePlayerData(state.GetPlayerData())
+	if err != nil {
+		return state, err
+	}
+
+	// If the player already has a correct solution in RetryUntilCorrect mode, don't process further
+	if b.ScoringScheme == RetryUntilCorrect && playerData.IsCorrect {
+		return state, nil
+	}
+
+	answers, exists := input["blank"]
+	if !exists || len(answers) == 0 {
+		return state, errors.New("answers are required")
+	}
+
+	blanks := b.Blanks()
+	playerData.Answers = make([]string, len(blanks))
+	playerData.Correct = make([]bool, len(blanks))
+	correct := 0
+	for i, accepted := range blanks {
+		guess := valueAt(answers, i)
+		playerData.Answers[i] = guess
+		for _, answer := range accepted {
+			if MatchesAnswer(guess, answer, b.Fuzzy) {
+				playerData.Correct[i] = true
+				correct++
+				break
+			}
+		}
+	}
+	playerData.Attempts++
+	playerData.IsCorrect = correct == len(blanks)
+
+	newPlayerData, err := json.Marshal(playerData)
+	if err != nil {
+		return state, fmt.Errorf("failed to save player data: %w", err)
+	}
+	state.SetPlayerData(newPlayerData)
+
+	switch b.ScoringScheme {
+	case RetryUntilCorrect:
+		state.SetComplete(playerData.IsCorrect)
+		if playerData.IsCorrect {
+			state.SetPointsAwarded(b.Points)
+		} else {
+			state.SetPointsAwarded(0)
+		}
+	case CorrectItemCorrectPlace:
+		state.SetComplete(true)
+		if len(blanks) > 0 {
+			state.SetPointsAwarded(b.Points * correct / len(blanks))
+		}
+	default:
+		state.SetComplete(true)
+		if playerData.IsCorrect {
+			state.SetPointsAwarded(b.Points)
+		} else {
+			state.SetPointsAwarded(0)
+		}
+	}
+
+	return state, nil
+}
+
+// ParsePlayerData parses the stored player data, if any.
+func (b *ClozeBlock) ParsePlayerData(raw json.RawMessage) (ClozePlayerData, error) {
+	var playerData ClozePlayerData
+	if raw != nil {
+		if err := json.Unmarshal(raw, &playerData); err != nil {
+			return playerData, fmt.Errorf("failed to parse player data: %w", err)
+		}
+	}
+	return playerData, nil
+}
+
+// Blanks returns the accepted answers for each blank, in order.
+func (b *ClozeBlock) Blanks() [][]string {
+	matches := clozeMarker.FindAllStringSubmatch(b.Content, -1)
+	blanks := make([][]string, 0, len(matches))
+	for _, match := range matches {
+		accepted := []string{}
+		for answer := range strings.SplitSeq(match[1], "|") {
+			if answer = strings.TrimSpace(answer); answer != "" {
+				accepted = append(accepted, answer)
+			}
+		}
+		blanks = append(blanks, accepted)
+	}
+	return blanks
+}
+
+// ClozePlaceholder is the token that stands in for blank i in MaskedContent.
+// It is plain text so it survives markdown rendering untouched.
+func ClozePlaceholder(i int) string {
+	return fmt.Sprintf("clozeblank%dend", i)
+}
+
+// MaskedContent returns the content with each blank replaced by its
+// placeholder, so it can be shown to players without revealing answers.
+func (b *ClozeBlock) MaskedContent() string {
+	i := 0
+	return clozeMarker.ReplaceAllStringFunc(b.Content, func(string) string {
+		placeholder := ClozePlaceholder(i)
+		i++
+		return placeholder
+	})
+}
+
+// WordBankWords returns the first accepted answer for each blank plus any
+// distractors, shuffled consistently for each player.
+func (b *ClozeBlock) WordBankWords(playerID string) []string {
+	words := []string{}
+	for _, accepted := range b.Blanks() {
+		if len(accepted) > 0 {
+			words = append(words, accepted[0])
+		}
+	}
+	words = append(words, b.Distractors...)
+	return deterministicShuffle(words, b.ID+playerID)
+}
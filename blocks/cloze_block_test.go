@@ -0,0 +1,131 @@
+package blocks_test
+
+import (
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClozeBlock(scheme string) *blocks.ClozeBlock {
+	return &blocks.ClozeBlock{
+		BaseBlock: blocks.BaseBlock{
+			ID:     "block-id",
+			Type:   "cloze",
+			Points: 20,
+		},
+		Content:       "The capital of New Zealand is [[Wellington | Te Whanganui-a-Tara]] and its largest city is [[Auckland]].",
+		ScoringScheme: scheme,
+	}
+}
+
+func TestClozeBlock_GetterMethods(t *testing.T) {
+	block := newTestClozeBlock(blocks.AllOrNothing)
+
+	assert.Equal(t, "Fill in the Blanks", block.GetName())
+	assert.Equal(t, "cloze", block.GetType())
+	assert.Equal(t, "block-id", block.GetID())
+	assert.Equal(t, 20, block.GetPoints())
+	assert.True(t, block.RequiresValidation())
+}
+
+func TestClozeBlock_Blanks(t *testing.T) {
+	block := newTestClozeBlock(blocks.AllOrNothing)
+
+	assert.Equal(t, [][]string{{"Wellington", "Te Whanganui-a-Tara"}, {"Auckland"}}, block.Blanks())
+
+	masked := block.MaskedContent()
+	assert.NotContains(t, masked, "Wellington")
+	assert.NotContains(t, masked, "Auckland")
+	assert.Contains(t, masked, blocks.ClozePlaceholder(0))
+	assert.Contains(t, masked, blocks.ClozePlaceholder(1))
+}
+
+func TestClozeBlock_UpdateBlockData(t *testing.T) {
+	block := blocks.NewClozeBlock(blocks.BaseBlock{ID: "block-id"})
+
+	err := block.UpdateBlockData(map[string][]string{
+		"points":         {"10"},
+		"content":        {"Kia [[ora]]"},
+		"scoring_scheme": {blocks.CorrectItemCorrectPlace},
+		"fuzzy":          {"on"},
+		"word_bank":      {"on"},
+		"distractors":    {"kaha, , pai "},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, block.Points)
+	assert.Equal(t, "Kia [[ora]]", block.Content)
+	assert.Equal(t, blocks.CorrectItemCorrectPlace, block.ScoringScheme)
+	assert.True(t, block.Fuzzy)
+	assert.True(t, block.WordBank)
+	assert.Equal(t, []string{"kaha", "pai"}, block.Distractors)
+
+	// Unchecked options are turned off
+	err = block.UpdateBlockData(map[string][]string{"content": {"Kia [[ora]]"}})
+	require.NoError(t, err)
+	assert.False(t, block.Fuzzy)
+	assert.False(t, block.WordBank)
+	assert.Empty(t, block.Distractors)
+	assert.Equal(t, blocks.AllOrNothing, block.ScoringScheme)
+
+	err = block.UpdateBlockData(map[string][]string{"points": {"many"}})
+	require.Error(t, err)
+}
+
+func TestClozeBlock_ValidatePlayerInput(t *testing.T) {
+	correct := map[string][]string{"blank": {"te whanganui-a-tara", "AUCKLAND"}}
+	oneWrong := map[string][]string{"blank": {"Wellington", "Christchurch"}}
+	typo := map[string][]string{"blank": {"Welington", "Auckland"}}
+
+	tests := []struct {
+		name         string
+		scheme       string
+		fuzzy        bool
+		input        map[string][]string
+		wantComplete bool
+		wantPoints   int
+	}{
+		{"All or nothing correct", blocks.AllOrNothing, false, correct, true, 20},
+		{"All or nothing incorrect", blocks.AllOrNothing, false, oneWrong, true, 0},
+		{"Per blank partial", blocks.CorrectItemCorrectPlace, false, oneWrong, true, 10},
+		{"Retry incorrect", blocks.RetryUntilCorrect, false, oneWrong, false, 0},
+		{"Retry correct", blocks.RetryUntilCorrect, false, correct, true, 20},
+		{"Typo without fuzzy", blocks.AllOrNothing, false, typo, true, 0},
+		{"Typo with fuzzy", blocks.AllOrNothing, true, typo, true, 20},
+		{"Missing blanks are wrong", blocks.CorrectItemCorrectPlace, false, map[string][]string{"blank": {"Wellington"}}, true, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := newTestClozeBlock(tt.scheme)
+			block.Fuzzy = tt.fuzzy
+			state := &blocks.MockPlayerState{BlockID: "block-id", PlayerID: "player-id"}
+
+			newState, err := block.ValidatePlayerInput(state, tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantComplete, newState.IsComplete())
+			assert.Equal(t, tt.wantPoints, newState.GetPointsAwarded())
+
+			playerData, err := block.ParsePlayerData(newState.GetPlayerData())
+			require.NoError(t, err)
+			assert.Equal(t, 1, playerData.Attempts)
+			assert.Len(t, playerData.Correct, 2)
+		})
+	}
+
+	t.Run("Missing input", func(t *testing.T) {
+		block := newTestClozeBlock(blocks.AllOrNothing)
+		_, err := block.ValidatePlayerInput(&blocks.MockPlayerState{}, map[string][]string{})
+		require.Error(t, err)
+	})
+}
+
+func TestClozeBlock_WordBankWords(t *testing.T) {
+	block := newTestClozeBlock(blocks.AllOrNothing)
+	block.Distractors = []string{"Christchurch"}
+
+	words := block.WordBankWords("player-1")
+	assert.ElementsMatch(t, []string{"Wellington", "Auckland", "Christchurch"}, words)
+	assert.Equal(t, words, block.WordBankWords("player-1"))
+}
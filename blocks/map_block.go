@@ -0,0 +1,301 @@
+package blocks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MapBlock embeds an interactive map with author-defined GeoJSON features.
+type MapBlock struct {
+	BaseBlock
+	Caption   string               `json:"caption"`
+	CenterLat float64              `json:"center_lat"`
+	CenterLng float64              `json:"center_lng"`
+	Zoom      float64              `json:"zoom"`
+	Features  MapFeatureCollection `json:"features"`
+}
+
+// MapFeatureCollection is a GeoJSON FeatureCollection.
+type MapFeatureCollection struct {
+	Type     string       `json:"type"`
+	Features []MapFeature `json:"features"`
+}
+
+// MapFeature is a single GeoJSON Feature.
+type MapFeature struct {
+	Type       string         `json:"type"`
+	Geometry   MapGeometry    `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// MapGeometry is a GeoJSON geometry. Coordinates are kept raw and
+// validated against the shape required by the geometry type.
+type MapGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// Map defaults and limits.
+const (
+	defaultMapZoom = 15
+	minMapZoom     = 0
+	maxMapZoom     = 22
+	maxMapFeatures = 500
+)
+
+// Basic Attributes Getters
+
+func (b *MapBlock) GetID() string         { return b.ID }
+func (b *MapBlock) GetType() string       { return "map" }
+func (b *MapBlock) GetLocationID() string { return b.LocationID }
+func (b *MapBlock) GetName() string       { return "Map" }
+func (b *MapBlock) GetDescription() string {
+	return "Show a map with your own pins, routes, and areas."
+}
+func (b *MapBlock) GetOrder() int  { return b.Order }
+func (b *MapBlock) GetPoints() int { return b.Points }
+func (b *MapBlock) GetIconSVG() string {
+	return `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-map"><path d="M14.106 5.553a2 2 0 0 0 1.788 0l3.659-1.83A1 1 0 0 1 21 4.619v12.764a1 1 0 0 1-.553.894l-4.553 2.277a2 2 0 0 1-1.788 0l-4.212-2.106a2 2 0 0 0-1.788 0l-3.659 1.83A1 1 0 0 1 3 19.381V6.618a1 1 0 0 1 .553-.894l4.553-2.277a2 2 0 0 1 1.788 0z"/><path d="M15 5.764v15"/><path d="M9 3.236v15"/></svg>`
+}
+func (b *MapBlock) GetData() json.RawMessage {
+	data, _ := json.Marshal(b)
+	return data
+}
+
+// Data Operations
+
+func (b *MapBlock) ParseData() error {
+	// Zoom 0 shows the whole world, so only a missing zoom gets the default
+	b.Zoom = defaultMapZoom
+	if err := json.Unmarshal(b.Data, b); err != nil {
+		return err
+	}
+	if b.Features.Type == "" {
+		b.Features.Type = "FeatureCollection"
+	}
+	return nil
+}
+
+// UpdateBlockData expects values with the following keys, and leaves any
+// that are missing unchanged:
+// - caption (optional markdown)
+// - center_lat, center_lng (optional, decimal degrees)
+// - zoom (optional, 0-22)
+// - geojson (optional FeatureCollection, Feature, or bare geometry).
+func (b *MapBlock) UpdateBlockData(input map[string][]string) error {
+	if caption, exists := input["caption"]; exists && len(caption) > 0 {
+		b.Caption = caption[0]
+	}
+
+	// Only fields in the input are changed, so a partial update keeps the
+	// rest of the view. A blank centre fits the map to its features.
+	if values, exists := input["center_lat"]; exists {
+		lat, err := parseFloatValue(values, 0)
+		if err != nil {
+			return errors.New("latitude must be a number")
+		}
+		if lat < -90 || lat > 90 {
+			return errors.New("latitude must be between -90 and 90")
+		}
+		b.CenterLat = lat
+	}
+	if values, exists := input["center_lng"]; exists {
+		lng, err := parseFloatValue(values, 0)
+		if err != nil {
+			return errors.New("longitude must be a number")
+		}
+		if lng < -180 || lng > 180 {
+			return errors.New("longitude must be between -180 and 180")
+		}
+		b.CenterLng = lng
+	}
+	if values, exists := input["zoom"]; exists {
+		zoom, err := parseFloatValue(values, defaultMapZoom)
+		if err != nil {
+			return errors.New("zoom must be a number")
+		}
+		if zoom < minMapZoom || zoom > maxMapZoom {
+			return fmt.Errorf("zoom must be between %d and %d", minMapZoom, maxMapZoom)
+		}
+		b.Zoom = zoom
+	}
+
+	if raw, exists := input["geojson"]; exists && len(raw) > 0 {
+		features, parseErr := ParseGeoJSON(raw[0])
+		if parseErr != nil {
+			return parseErr
+		}
+		b.Features = features
+	}
+
+	return nil
+}
+
+// Validation and Points Calculation
+
+func (b *MapBlock) RequiresValidation() bool { return false }
+
+func (b *MapBlock) ValidatePlayerInput(state PlayerState, _ map[string][]string) (PlayerState, error) {
+	// No validation required for MapBlock; mark as complete
+	state.SetComplete(true)
+	return state, nil
+}
+
+// FeaturesJSON returns the features as a GeoJSON string for the map renderer.
+func (b *MapBlock) FeaturesJSON() string {
+	features := b.Features
+	if features.Type == "" {
+		features.Type = "FeatureCollection"
+	}
+	if features.Features == nil {
+		features.Features = []MapFeature{}
+	}
+	data, err := json.Marshal(features)
+	if err != nil {
+		return `{"type":"FeatureCollection","features":[]}`
+	}
+	return string(data)
+}
+
+// HasCenter reports whether the author has set a map centre.
+func (b *MapBlock) HasCenter() bool {
+	return b.CenterLat != 0 || b.CenterLng != 0
+}
+
+// ParseGeoJSON parses a FeatureCollection, a single Feature, or a bare
+// geometry into a validated FeatureCollection.
+func ParseGeoJSON(raw string) (MapFeatureCollection, error) {
+	collection := MapFeatureCollection{Type: "FeatureCollection", Features: []MapFeature{}}
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return collection, nil
+	}
+
+	var probe struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(raw), &probe); err != nil {
+		return collection, errors.New("GeoJSON is not valid JSON")
+	}
+
+	switch probe.Type {
+	case "FeatureCollection":
+		if err := json.Unmarshal([]byte(raw), &collection); err != nil {
+			return collection, fmt.Errorf("parsing feature collection: %w", err)
+		}
+	case "Feature":
+		var feature MapFeature
+		if err := json.Unmarshal([]byte(raw), &feature); err != nil {
+			return collection, fmt.Errorf("parsing feature: %w", err)
+		}
+		collection.Features = append(collection.Features, feature)
+	default:
+		var geometry MapGeometry
+		if err := json.Unmarshal([]byte(raw), &geometry); err != nil {
+			return collection, fmt.Errorf("parsing geometry: %w", err)
+		}
+		collection.Features = append(collection.Features, MapFeature{Type: "Feature", Geometry: geometry})
+	}
+
+	if len(collection.Features) > maxMapFeatures {
+		return collection, fmt.Errorf("maps are limited to %d features", maxMapFeatures)
+	}
+
+	for i := range collection.Features {
+		feature := &collection.Features[i]
+		if feature.Type == "" {
+			feature.Type = "Feature"
+		}
+		if feature.Type != "Feature" {
+			return collection, fmt.Errorf("feature %d: unexpected type %q", i+1, feature.Type)
+		}
+		if err := validateGeometry(feature.Geometry); err != nil {
+			return collection, fmt.Errorf("feature %d: %w", i+1, err)
+		}
+	}
+	return collection, nil
+}
+
+// validateGeometry checks that coordinates match the geometry type
+// and fall within valid longitude/latitude ranges.
+func validateGeometry(geometry MapGeometry) error {
+	switch geometry.Type {
+	case "Point":
+		var coords []float64
+		if err := json.Unmarshal(geometry.Coordinates, &coords); err != nil {
+			return errors.New("point coordinates must be [lng, lat]")
+		}
+		return validatePosition(coords)
+	case "LineString", "MultiPoint":
+		var coords [][]float64
+		if err := json.Unmarshal(geometry.Coordinates, &coords); err != nil {
+			return fmt.Errorf("%s coordinates must be a list of positions", geometry.Type)
+		}
+		if geometry.Type == "LineString" && len(coords) < 2 {
+			return errors.New("a line needs at least two positions")
+		}
+		return validatePositions(coords)
+	case "Polygon", "MultiLineString":
+		var coords [][][]float64
+		if err := json.Unmarshal(geometry.Coordinates, &coords); err != nil {
+			return fmt.Errorf("%s coordinates must be a list of rings", geometry.Type)
+		}
+		for _, ring := range coords {
+			if err := validatePositions(ring); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "MultiPolygon":
+		var coords [][][][]float64
+		if err := json.Unmarshal(geometry.Coordinates, &coords); err != nil {
+			return errors.New("MultiPolygon coordinates must be a list of polygons")
+		}
+		for _, polygon := range coords {
+			for _, ring := range polygon {
+				if err := validatePositions(ring); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case "":
+		return errors.New("geometry is missing a type")
+	default:
+		return fmt.Errorf("unsupported geometry type %q", geometry.Type)
+	}
+}
+
+func validatePositions(positions [][]float64) error {
+	for _, position := range positions {
+		if err := validatePosition(position); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePosition(position []float64) error {
+	if len(position) < 2 {
+		return errors.New("positions must be [lng, lat]")
+	}
+	if position[0] < -180 || position[0] > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+	if position[1] < -90 || position[1] > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	return nil
+}
+
+// parseFloatValue parses the first value, or returns blank when there is
+// none.
+func parseFloatValue(values []string, blank float64) (float64, error) {
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return blank, nil
+	}
+	return strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+}
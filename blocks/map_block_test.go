@@ -0,0 +1,211 @@
+package blocks_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapBlock_Getters(t *testing.T) {
+	block := blocks.MapBlock{
+		BaseBlock: blocks.BaseBlock{
+			ID:         "test-id",
+			LocationID: "location-123",
+			Order:      1,
+			Points:     5,
+		},
+	}
+
+	assert.Equal(t, "Map", block.GetName())
+	assert.Equal(t, "map", block.GetType())
+	assert.Equal(t, "test-id", block.GetID())
+	assert.Equal(t, "location-123", block.GetLocationID())
+	assert.Equal(t, 1, block.GetOrder())
+	assert.Equal(t, 5, block.GetPoints())
+	assert.False(t, block.RequiresValidation())
+}
+
+func TestMapBlock_ParseData(t *testing.T) {
+	data := `{"caption":"Route","center_lat":-45.86,"center_lng":170.51,"features":{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[170.51,-45.86]},"properties":{"name":"Start"}}]}}`
+	block := blocks.MapBlock{
+		BaseBlock: blocks.BaseBlock{
+			Data: json.RawMessage(data),
+		},
+	}
+
+	err := block.ParseData()
+	require.NoError(t, err)
+	assert.Equal(t, "Route", block.Caption)
+	assert.InDelta(t, -45.86, block.CenterLat, 0.0001)
+	assert.InDelta(t, 170.51, block.CenterLng, 0.0001)
+	assert.InDelta(t, 15, block.Zoom, 0.0001, "zoom should default when missing")
+	require.Len(t, block.Features.Features, 1)
+	assert.Equal(t, "Start", block.Features.Features[0].Properties["name"])
+	assert.True(t, block.HasCenter())
+}
+
+func TestMapBlock_UpdateBlockData(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string][]string
+		wantErr  bool
+		features int
+	}{
+		{
+			name: "Feature collection",
+			input: map[string][]string{
+				"caption":    {"Follow the path"},
+				"center_lat": {"-45.8"},
+				"center_lng": {"170.5"},
+				"zoom":       {"12.5"},
+				"geojson":    {`{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"LineString","coordinates":[[170.5,-45.8],[170.6,-45.9]]},"properties":{"color":"#ff0000"}}]}`},
+			},
+			features: 1,
+		},
+		{
+			name:     "Single feature is wrapped",
+			input:    map[string][]string{"geojson": {`{"type":"Feature","geometry":{"type":"Point","coordinates":[170.5,-45.8]}}`}},
+			features: 1,
+		},
+		{
+			name:     "Bare geometry is wrapped",
+			input:    map[string][]string{"geojson": {`{"type":"Polygon","coordinates":[[[170.5,-45.8],[170.6,-45.8],[170.6,-45.9],[170.5,-45.8]]]}`}},
+			features: 1,
+		},
+		{
+			name:     "Blank GeoJSON clears features",
+			input:    map[string][]string{"geojson": {""}},
+			features: 0,
+		},
+		{
+			name:    "Invalid JSON",
+			input:   map[string][]string{"geojson": {`{"type":`}},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported geometry",
+			input:   map[string][]string{"geojson": {`{"type":"Circle","coordinates":[0,0]}`}},
+			wantErr: true,
+		},
+		{
+			name:    "Point out of range",
+			input:   map[string][]string{"geojson": {`{"type":"Point","coordinates":[190,0]}`}},
+			wantErr: true,
+		},
+		{
+			name:    "Line with one position",
+			input:   map[string][]string{"geojson": {`{"type":"LineString","coordinates":[[170.5,-45.8]]}`}},
+			wantErr: true,
+		},
+		{
+			name:    "Latitude out of range",
+			input:   map[string][]string{"center_lat": {"91"}},
+			wantErr: true,
+		},
+		{
+			name:    "Zoom out of range",
+			input:   map[string][]string{"zoom": {"30"}},
+			wantErr: true,
+		},
+		{
+			name:    "Zoom not a number",
+			input:   map[string][]string{"zoom": {"close"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := blocks.NewMapBlock(blocks.BaseBlock{})
+			err := block.UpdateBlockData(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, block.Features.Features, tt.features)
+			for _, feature := range block.Features.Features {
+				assert.Equal(t, "Feature", feature.Type)
+			}
+		})
+	}
+}
+
+func TestMapBlock_UpdateBlockData_View(t *testing.T) {
+	block := blocks.NewMapBlock(blocks.BaseBlock{})
+	err := block.UpdateBlockData(map[string][]string{
+		"center_lat": {"-45.8"},
+		"center_lng": {"170.5"},
+		"zoom":       {"12"},
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, -45.8, block.CenterLat, 0.0001)
+	assert.InDelta(t, 170.5, block.CenterLng, 0.0001)
+	assert.InDelta(t, 12, block.Zoom, 0.0001)
+
+	// Blank centre fits the map to its features instead
+	err = block.UpdateBlockData(map[string][]string{
+		"center_lat": {""},
+		"center_lng": {""},
+		"zoom":       {""},
+	})
+	require.NoError(t, err)
+	assert.False(t, block.HasCenter())
+	assert.InDelta(t, 15, block.Zoom, 0.0001)
+}
+
+func TestMapBlock_UpdateBlockData_Partial(t *testing.T) {
+	block := blocks.NewMapBlock(blocks.BaseBlock{})
+	block.CenterLat = -45.8
+	block.CenterLng = 170.5
+	block.Zoom = 12
+
+	// Fields missing from the input are left alone
+	err := block.UpdateBlockData(map[string][]string{"caption": {"Route"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Route", block.Caption)
+	assert.InDelta(t, -45.8, block.CenterLat, 0.0001)
+	assert.InDelta(t, 170.5, block.CenterLng, 0.0001)
+	assert.InDelta(t, 12, block.Zoom, 0.0001)
+
+	// Zoom 0 shows the whole world
+	err = block.UpdateBlockData(map[string][]string{"zoom": {"0"}})
+	require.NoError(t, err)
+	assert.Zero(t, block.Zoom)
+	assert.InDelta(t, -45.8, block.CenterLat, 0.0001)
+
+	// and survives being saved and loaded
+	saved := blocks.MapBlock{BaseBlock: blocks.BaseBlock{Data: block.GetData()}}
+	require.NoError(t, saved.ParseData())
+	assert.Zero(t, saved.Zoom)
+}
+
+func TestMapBlock_FeaturesJSON(t *testing.T) {
+	block := blocks.MapBlock{}
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, block.FeaturesJSON())
+
+	collection, err := blocks.ParseGeoJSON(`{"type":"Point","coordinates":[170.5,-45.8]}`)
+	require.NoError(t, err)
+	block.Features = collection
+	assert.JSONEq(t,
+		`{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[170.5,-45.8]},"properties":null}]}`,
+		block.FeaturesJSON(),
+	)
+}
+
+func TestMapBlock_ValidatePlayerInput(t *testing.T) {
+	block := blocks.MapBlock{
+		BaseBlock: blocks.BaseBlock{
+			Points: 5,
+		},
+	}
+
+	state := &blocks.MockPlayerState{}
+	newState, err := block.ValidatePlayerInput(state, map[string][]string{})
+	require.NoError(t, err)
+	assert.True(t, newState.IsComplete())
+	assert.Equal(t, 0, newState.GetPointsAwarded())
+}
@@ -0,0 +1,281 @@
+package blocks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MatchingBlock is a quiz-type block that requires players to match each
+// item on the left with its partner on the right.
+type MatchingBlock struct {
+	BaseBlock
+	Content       string         `json:"content"`
+	Pairs         []MatchingPair `json:"pairs"`
+	ScoringScheme string         `json:"scoring_scheme"`
+}
+
+// MatchingPair is a left item and its correct right-hand partner.
+// Either side may have text, an image, or both. Players see ID against the
+// left item and RightID against the right, so the page doesn't give away
+// which belong together.
+type MatchingPair struct {
+	ID         string `json:"id"`
+	RightID    string `json:"right_id"`
+	Left       string `json:"left"`
+	LeftImage  string `json:"left_image"`
+	Right      string `json:"right"`
+	RightImage string `json:"right_image"`
+}
+
+// MatchingPlayerData stores player progress.
+type MatchingPlayerData struct {
+	Matches   map[string]string `json:"matches"`    // Left pair ID to the right ID of the chosen item
+	Attempts  int               `json:"attempts"`   // Number of attempts made so far
+	IsCorrect bool              `json:"is_correct"` // Whether every pair is matched correctly
+}
+
+// GetName returns the block type name.
+func (b *MatchingBlock) GetName() string { return "Matching" }
+
+func (b *MatchingBlock) GetDescription() string {
+	return "Match each item with its pair."
+}
+
+func (b *MatchingBlock) GetIconSVG() string {
+	return `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-arrow-left-right"><path d="M8 3 4 7l4 4"/><path d="M4 7h16"/><path d="m16 21 4-4-4-4"/><path d="M20 17H4"/></svg>`
+}
+
+func (b *MatchingBlock) GetType() string { return "matching" }
+
+func (b *MatchingBlock) GetID() string { return b.ID }
+
+func (b *MatchingBlock) GetLocationID() string { return b.LocationID }
+
+func (b *MatchingBlock) GetOrder() int { return b.Order }
+
+func (b *MatchingBlock) GetPoints() int { return b.Points }
+
+func (b *MatchingBlock) GetData() json.RawMessage {
+	data, _ := json.Marshal(b)
+	return data
+}
+
+// ParseData parses the block data from JSON.
+func (b *MatchingBlock) ParseData() error {
+	if err := json.Unmarshal(b.Data, b); err != nil {
+		return err
+	}
+	// Pairs saved before right-hand items had their own IDs keep working
+	// until the block is next saved
+	for i := range b.Pairs {
+		if b.Pairs[i].RightID == "" {
+			b.Pairs[i].RightID = b.Pairs[i].ID
+		}
+	}
+	return nil
+}
+
+// UpdateBlockData expects values with the following keys:
+// - points, content, scoring_scheme
+// - matching-ids, matching-right-ids, matching-left, matching-left-images,
+// matching-right, matching-right-images (one value per pair, in order).
+func (b *MatchingBlock) UpdateBlockData(input map[string][]string) error {
+	// Parse points
+	pointsInput, ok := input["points"]
+	if ok && len(pointsInput[0]) > 0 {
+		points, err := strconv.Atoi(pointsInput[0])
+		if err != nil {
+			return errors.New("points must be an integer")
+		}
+		b.Points = points
+	} else {
+		b.Points = 0
+	}
+
+	// Update content
+	if content, exists := input["content"]; exists && len(content) > 0 {
+		b.Content = content[0]
+	}
+
+	// Parse scoring scheme
+	if scheme, exists := input["scoring_scheme"]; exists && len(scheme) > 0 {
+		b.ScoringScheme = scheme[0]
+	} else {
+		b.ScoringScheme = AllOrNothing
+	}
+
+	ids := input["matching-ids"]
+	rightIDs := input["matching-right-ids"]
+	lefts := input["matching-left"]
+	leftImages := input["matching-left-images"]
+	rights := input["matching-right"]
+	rightImages := input["matching-right-images"]
+
+	count := max(len(lefts), len(rights))
+	updatedPairs := make([]MatchingPair, 0, count)
+	for i := range count {
+		pair := MatchingPair{
+			Left:       strings.TrimSpace(valueAt(lefts, i)),
+			LeftImage:  strings.TrimSpace(valueAt(leftImages, i)),
+			Right:      strings.TrimSpace(valueAt(rights, i)),
+			RightImage: strings.TrimSpace(valueAt(rightImages, i)),
+		}
+		if pair.Left == "" && pair.LeftImage == "" && pair.Right == "" && pair.RightImage == "" {
+			continue
+		}
+
+		pair.ID = valueAt(ids, i)
+		if pair.ID == "" {
+			id, err := uuid.NewRandom()
+			if err != nil {
+				return fmt.Errorf("failed to generate UUID: %w", err)
+			}
+			pair.ID = id.String()
+		}
+		pair.RightID = valueAt(rightIDs, i)
+		if pair.RightID == "" || pair.RightID == pair.ID {
+			id, err := uuid.NewRandom()
+			if err != nil {
+				return fmt.Errorf("failed to generate UUID: %w", err)
+			}
+			pair.RightID = id.String()
+		}
+
+		updatedPairs = append(updatedPairs, pair)
+	}
+	b.Pairs = updatedPairs
+	return nil
+}
+
+// valueAt returns the value at index i, or an empty string if out of range.
+func valueAt(values []string, i int) string {
+	if i < len(values) {
+		return values[i]
+	}
+	return ""
+}
+
+// RequiresValidation returns whether this block requires player input validation.
+func (b *MatchingBlock) RequiresValidation() bool { return true }
+
+func (b *MatchingBlock) ValidatePlayerInput(state PlayerState, input map[string][]string) (PlayerState, error) {
+	playerData, err := b.ParsePlayerData(state.GetPlayerData())
+	if err != nil {
+		return state, err
+	}
+
+	// If the player already has a correct solution in RetryUntilCorrect mode, don't process further
+	if b.ScoringScheme == RetryUntilCorrect && playerData.IsCorrect {
+		return state, nil
+	}
+
+	matches, err := b.getMatches(input)
+	if err != nil {
+		return state, err
+	}
+
+	playerData.Matches = matches
+	playerData.Attempts++
+	correct := b.CountCorrect(matches)
+	playerData.IsCorrect = correct == len(b.Pairs)
+
+	newPlayerData, err := json.Marshal(playerData)
+	if err != nil {
+		return state, fmt.Errorf("failed to save player data: %w", err)
+	}
+	state.SetPlayerData(newPlayerData)
+
+	switch b.ScoringScheme {
+	case RetryUntilCorrect:
+		state.SetComplete(playerData.IsCorrect)
+		if playerData.IsCorrect {
+			state.SetPointsAwarded(b.Points)
+		} else {
+			state.SetPointsAwarded(0)
+		}
+	case CorrectItemCorrectPlace:
+		state.SetComplete(true)
+		state.SetPointsAwarded(b.partialPoints(correct))
+	default:
+		state.SetComplete(true)
+		if playerData.IsCorrect {
+			state.SetPointsAwarded(b.Points)
+		} else {
+			state.SetPointsAwarded(0)
+		}
+	}
+
+	return state, nil
+}
+
+// ParsePlayerData parses the stored player data, if any.
+func (b *MatchingBlock) ParsePlayerData(raw json.RawMessage) (MatchingPlayerData, error) {
+	var playerData MatchingPlayerData
+	if raw != nil {
+		if err := json.Unmarshal(raw, &playerData); err != nil {
+			return playerData, fmt.Errorf("failed to parse player data: %w", err)
+		}
+	}
+	if playerData.Matches == nil {
+		playerData.Matches = make(map[string]string)
+	}
+	return playerData, nil
+}
+
+// getMatches pairs each submitted left item with the right item chosen for it.
+func (b *MatchingBlock) getMatches(input map[string][]string) (map[string]string, error) {
+	lefts, exists := input["matching-left"]
+	if !exists || len(lefts) == 0 {
+		return nil, errors.New("matches are required")
+	}
+	rights := input["matching-right"]
+
+	matches := make(map[string]string, len(lefts))
+	for i, left := range lefts {
+		if right := valueAt(rights, i); right != "" {
+			matches[left] = right
+		}
+	}
+	return matches, nil
+}
+
+// CountCorrect returns the number of pairs matched correctly.
+func (b *MatchingBlock) CountCorrect(matches map[string]string) int {
+	correct := 0
+	for _, pair := range b.Pairs {
+		if matches[pair.ID] == pair.RightID {
+			correct++
+		}
+	}
+	return correct
+}
+
+// partialPoints awards points in proportion to the number of correct pairs.
+func (b *MatchingBlock) partialPoints(correct int) int {
+	if len(b.Pairs) == 0 || correct == 0 {
+		return 0
+	}
+	return int(float64(b.Points) * float64(correct) / float64(len(b.Pairs)))
+}
+
+// ShuffledRight returns the right-hand items in the order shown to a player.
+// The shuffle is deterministic so a team always sees the same order.
+func (b *MatchingBlock) ShuffledRight(playerID string) []MatchingPair {
+	ids := make([]string, len(b.Pairs))
+	pairs := make(map[string]MatchingPair, len(b.Pairs))
+	for i, pair := range b.Pairs {
+		ids[i] = pair.ID
+		pairs[pair.ID] = pair
+	}
+
+	shuffled := make([]MatchingPair, 0, len(b.Pairs))
+	for _, id := range deterministicShuffle(ids, b.ID+playerID) {
+		shuffled = append(shuffled, pairs[id])
+	}
+	return shuffled
+}
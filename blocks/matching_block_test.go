@@ -0,0 +1,191 @@
+package blocks_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMatchingBlock(scheme string) *blocks.MatchingBlock {
+	return &blocks.MatchingBlock{
+		BaseBlock: blocks.BaseBlock{
+			ID:     "block-id",
+			Type:   "matching",
+			Points: 90,
+		},
+		ScoringScheme: scheme,
+		Pairs: []blocks.MatchingPair{
+			{ID: "p1", RightID: "r1", Left: "Kererū", Right: "Wood pigeon"},
+			{ID: "p2", RightID: "r2", Left: "Pīwakawaka", Right: "Fantail"},
+			{ID: "p3", RightID: "r3", Left: "Kōtare", RightImage: "https://example.com/kingfisher.jpg"},
+		},
+	}
+}
+
+func TestMatchingBlock_GetterMethods(t *testing.T) {
+	block := newTestMatchingBlock(blocks.AllOrNothing)
+
+	assert.Equal(t, "Matching", block.GetName())
+	assert.Equal(t, "Match each item with its pair.", block.GetDescription())
+	assert.Contains(t, block.GetIconSVG(), `<svg xmlns="http://www.w3.org/2000/svg"`)
+	assert.Equal(t, "matching", block.GetType())
+	assert.Equal(t, "block-id", block.GetID())
+	assert.Equal(t, 90, block.GetPoints())
+	assert.True(t, block.RequiresValidation())
+
+	var unmarshaled blocks.MatchingBlock
+	err := json.Unmarshal(block.GetData(), &unmarshaled)
+	require.NoError(t, err)
+	assert.Len(t, unmarshaled.Pairs, 3)
+	assert.Equal(t, "https://example.com/kingfisher.jpg", unmarshaled.Pairs[2].RightImage)
+}
+
+func TestMatchingBlock_UpdateBlockData(t *testing.T) {
+	block := blocks.NewMatchingBlock(blocks.BaseBlock{ID: "block-id"})
+
+	input := map[string][]string{
+		"content":               {"Match the birds"},
+		"points":                {"30"},
+		"scoring_scheme":        {blocks.RetryUntilCorrect},
+		"matching-ids":          {"p1", "", ""},
+		"matching-right-ids":    {"r1", "", ""},
+		"matching-left":         {"Kererū", "Tūī", ""},
+		"matching-left-images":  {"", "", ""},
+		"matching-right":        {"Wood pigeon", "", ""},
+		"matching-right-images": {"", "/static/uploads/tui.jpg", ""},
+	}
+
+	err := block.UpdateBlockData(input)
+	require.NoError(t, err)
+	assert.Equal(t, "Match the birds", block.Content)
+	assert.Equal(t, 30, block.Points)
+	assert.Equal(t, blocks.RetryUntilCorrect, block.ScoringScheme)
+	require.Len(t, block.Pairs, 2, "empty rows should be dropped")
+	assert.Equal(t, "p1", block.Pairs[0].ID)
+	assert.Equal(t, "r1", block.Pairs[0].RightID)
+	assert.NotEmpty(t, block.Pairs[1].ID, "new pairs should be given an ID")
+	assert.NotEmpty(t, block.Pairs[1].RightID, "new pairs should be given a right ID")
+	assert.NotEqual(t, block.Pairs[1].ID, block.Pairs[1].RightID)
+	assert.Equal(t, "/static/uploads/tui.jpg", block.Pairs[1].RightImage)
+
+	// Invalid points
+	err = block.UpdateBlockData(map[string][]string{"points": {"lots"}})
+	require.Error(t, err)
+
+	// Missing scoring scheme defaults to all or nothing
+	err = block.UpdateBlockData(map[string][]string{"points": {""}})
+	require.NoError(t, err)
+	assert.Equal(t, blocks.AllOrNothing, block.ScoringScheme)
+	assert.Equal(t, 0, block.Points)
+}
+
+func TestMatchingBlock_ValidatePlayerInput(t *testing.T) {
+	correct := map[string][]string{
+		"matching-left":  {"p1", "p2", "p3"},
+		"matching-right": {"r1", "r2", "r3"},
+	}
+	oneWrong := map[string][]string{
+		"matching-left":  {"p1", "p2", "p3"},
+		"matching-right": {"r1", "r3", "r2"},
+	}
+
+	tests := []struct {
+		name         string
+		scheme       string
+		input        map[string][]string
+		wantComplete bool
+		wantPoints   int
+	}{
+		{"All or nothing correct", blocks.AllOrNothing, correct, true, 90},
+		{"All or nothing incorrect", blocks.AllOrNothing, oneWrong, true, 0},
+		{"Per pair correct", blocks.CorrectItemCorrectPlace, correct, true, 90},
+		{"Per pair partial", blocks.CorrectItemCorrectPlace, oneWrong, true, 30},
+		{"Retry correct", blocks.RetryUntilCorrect, correct, true, 90},
+		{"Retry incorrect", blocks.RetryUntilCorrect, oneWrong, false, 0},
+		{"Unanswered pairs are wrong", blocks.CorrectItemCorrectPlace, map[string][]string{
+			"matching-left":  {"p1", "p2", "p3"},
+			"matching-right": {"r1", "", ""},
+		}, true, 30},
+		{"Left IDs are not answers", blocks.AllOrNothing, map[string][]string{
+			"matching-left":  {"p1", "p2", "p3"},
+			"matching-right": {"p1", "p2", "p3"},
+		}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := newTestMatchingBlock(tt.scheme)
+			state := &blocks.MockPlayerState{BlockID: "block-id", PlayerID: "player-id"}
+
+			newState, err := block.ValidatePlayerInput(state, tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantComplete, newState.IsComplete())
+			assert.Equal(t, tt.wantPoints, newState.GetPointsAwarded())
+
+			playerData, err := block.ParsePlayerData(newState.GetPlayerData())
+			require.NoError(t, err)
+			assert.Equal(t, 1, playerData.Attempts)
+		})
+	}
+
+	t.Run("Missing input", func(t *testing.T) {
+		block := newTestMatchingBlock(blocks.AllOrNothing)
+		state := &blocks.MockPlayerState{BlockID: "block-id", PlayerID: "player-id"}
+		_, err := block.ValidatePlayerInput(state, map[string][]string{})
+		require.Error(t, err)
+	})
+
+	t.Run("Retry keeps counting attempts until correct", func(t *testing.T) {
+		block := newTestMatchingBlock(blocks.RetryUntilCorrect)
+		var state blocks.PlayerState = &blocks.MockPlayerState{BlockID: "block-id", PlayerID: "player-id"}
+
+		state, err := block.ValidatePlayerInput(state, oneWrong)
+		require.NoError(t, err)
+		state, err = block.ValidatePlayerInput(state, correct)
+		require.NoError(t, err)
+		assert.True(t, state.IsComplete())
+
+		// Further submissions are ignored once correct
+		state, err = block.ValidatePlayerInput(state, oneWrong)
+		require.NoError(t, err)
+		assert.True(t, state.IsComplete())
+		assert.Equal(t, 90, state.GetPointsAwarded())
+
+		playerData, err := block.ParsePlayerData(state.GetPlayerData())
+		require.NoError(t, err)
+		assert.Equal(t, 2, playerData.Attempts)
+		assert.True(t, playerData.IsCorrect)
+	})
+}
+
+func TestMatchingBlock_ParseData(t *testing.T) {
+	block := blocks.MatchingBlock{BaseBlock: blocks.BaseBlock{
+		Data: json.RawMessage(`{"pairs":[{"id":"p1","right_id":"r1"},{"id":"p2"}]}`),
+	}}
+	require.NoError(t, block.ParseData())
+	assert.Equal(t, "r1", block.Pairs[0].RightID)
+	assert.Equal(t, "p2", block.Pairs[1].RightID, "older pairs should still be scored")
+
+	// Saving the block gives older pairs their own right ID
+	err := block.UpdateBlockData(map[string][]string{
+		"matching-ids":       {"p1", "p2"},
+		"matching-right-ids": {"r1", "p2"},
+		"matching-left":      {"Kererū", "Tūī"},
+		"matching-right":     {"Wood pigeon", "Parson bird"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "r1", block.Pairs[0].RightID)
+	assert.NotEqual(t, "p2", block.Pairs[1].RightID)
+}
+
+func TestMatchingBlock_ShuffledRight(t *testing.T) {
+	block := newTestMatchingBlock(blocks.AllOrNothing)
+
+	first := block.ShuffledRight("player-1")
+	second := block.ShuffledRight("player-1")
+	assert.Equal(t, first, second, "the same player should always see the same order")
+	assert.ElementsMatch(t, block.Pairs, first)
+}
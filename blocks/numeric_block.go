@@ -0,0 +1,248 @@
+package blocks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NumericBlock asks players for a number, such as an estimate or a
+// measurement, and accepts answers within a tolerance of the exact value.
+type NumericBlock struct {
+	BaseBlock
+	Prompt        string  `json:"prompt"`
+	Answer        float64 `json:"answer"`
+	Tolerance     float64 `json:"tolerance"`
+	ToleranceType string  `json:"tolerance_type"`
+	Units         string  `json:"units"`
+	Graded        bool    `json:"graded"`
+}
+
+// Tolerance types.
+const (
+	ToleranceAbsolute = "absolute"
+	TolerancePercent  = "percent"
+)
+
+type numericBlockData struct {
+	Attempts int       `json:"attempts"`
+	Guesses  []float64 `json:"guesses"`
+}
+
+// Basic Attributes Getters
+
+func (b *NumericBlock) GetID() string         { return b.ID }
+func (b *NumericBlock) GetType() string       { return "numeric" }
+func (b *NumericBlock) GetLocationID() string { return b.LocationID }
+func (b *NumericBlock) GetName() string       { return "Number" }
+func (b *NumericBlock) GetDescription() string {
+	return "Players enter a number, with points for getting close."
+}
+func (b *NumericBlock) GetOrder() int  { return b.Order }
+func (b *NumericBlock) GetPoints() int { return b.Points }
+func (b *NumericBlock) GetIconSVG() string {
+	return `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-ruler"><path d="M21.3 15.3a2.4 2.4 0 0 1 0 3.4l-2.6 2.6a2.4 2.4 0 0 1-3.4 0L2.7 8.7a2.41 2.41 0 0 1 0-3.4l2.6-2.6a2.41 2.41 0 0 1 3.4 0Z"/><path d="m14.5 12.5 2-2"/><path d="m11.5 9.5 2-2"/><path d="m8.5 6.5 2-2"/><path d="m17.5 15.5 2-2"/></svg>`
+}
+func (b *NumericBlock) GetData() json.RawMessage {
+	data, _ := json.Marshal(b)
+	return data
+}
+
+// Data Operations
+
+func (b *NumericBlock) ParseData() error {
+	if err := json.Unmarshal(b.Data, b); err != nil {
+		return err
+	}
+	if b.ToleranceType == "" {
+		b.ToleranceType = ToleranceAbsolute
+	}
+	return nil
+}
+
+func (b *NumericBlock) UpdateBlockData(input map[string][]string) error {
+	// Points
+	if input["points"] != nil && input["points"][0] != "" {
+		points, err := strconv.Atoi(input["points"][0])
+		if err != nil {
+			return errors.New("points must be an integer")
+		}
+		b.Points = points
+	}
+
+	if prompt, exists := input["prompt"]; exists && len(prompt) > 0 {
+		b.Prompt = prompt[0]
+	}
+
+	if answer, exists := input["answer"]; exists && len(answer) > 0 && strings.TrimSpace(answer[0]) != "" {
+		value, err := ParseNumber(answer[0], "")
+		if err != nil {
+			return errors.New("answer must be a number")
+		}
+		b.Answer = value
+	}
+
+	b.Tolerance = 0
+	if tolerance, exists := input["tolerance"]; exists && len(tolerance) > 0 && strings.TrimSpace(tolerance[0]) != "" {
+		value, err := ParseNumber(tolerance[0], "")
+		if err != nil {
+			return errors.New("tolerance must be a number")
+		}
+		if value < 0 {
+			return errors.New("tolerance cannot be negative")
+		}
+		b.Tolerance = value
+	}
+
+	b.ToleranceType = ToleranceAbsolute
+	if toleranceType, exists := input["tolerance_type"]; exists && len(toleranceType) > 0 {
+		switch toleranceType[0] {
+		case ToleranceAbsolute, TolerancePercent:
+			b.ToleranceType = toleranceType[0]
+		default:
+			return errors.New("tolerance type must be absolute or percent")
+		}
+	}
+
+	if units, exists := input["units"]; exists && len(units) > 0 {
+		b.Units = strings.TrimSpace(units[0])
+	}
+
+	b.Graded = false
+	if graded, exists := input["graded"]; exists && len(graded) > 0 {
+		b.Graded = graded[0] == "on"
+	}
+
+	return nil
+}
+
+// Validation and Points Calculation
+
+func (b *NumericBlock) RequiresValidation() bool { return true }
+
+func (b *NumericBlock) ValidatePlayerInput(state PlayerState, input map[string][]string) (PlayerState, error) {
+	if input["answer"] == nil {
+		return state, errors.New("answer is a required field")
+	}
+
+	guess, err := ParseNumber(input["answer"][0], b.Units)
+	if err != nil {
+		return state, errors.New("answer must be a number")
+	}
+
+	newPlayerData := numericBlockData{}
+	if state.GetPlayerData() != nil {
+		if unmarshalErr := json.Unmarshal(state.GetPlayerData(), &newPlayerData); unmarshalErr != nil {
+			return state, fmt.Errorf("parse player data: %w", unmarshalErr)
+		}
+	}
+
+	// Increment the number of attempts and save guesses
+	newPlayerData.Attempts++
+	newPlayerData.Guesses = append(newPlayerData.Guesses, guess)
+
+	playerData, err := json.Marshal(newPlayerData)
+	if err != nil {
+		return state, errors.New("error saving player data")
+	}
+	state.SetPlayerData(playerData)
+
+	// Graded blocks take a single guess and award points for closeness
+	if b.Graded {
+		state.SetComplete(true)
+		state.SetPointsAwarded(b.GradedPoints(guess))
+		return state, nil
+	}
+
+	if !b.IsWithinTolerance(guess) {
+		return state, nil
+	}
+
+	state.SetComplete(true)
+	state.SetPointsAwarded(b.Points)
+	return state, nil
+}
+
+// AllowedError returns the absolute distance from the answer that is
+// accepted, converting percentage tolerances against the answer.
+func (b *NumericBlock) AllowedError() float64 {
+	if b.ToleranceType == TolerancePercent {
+		return math.Abs(b.Answer) * b.Tolerance / 100
+	}
+	return b.Tolerance
+}
+
+// IsWithinTolerance reports whether a guess is close enough to be correct.
+func (b *NumericBlock) IsWithinTolerance(guess float64) bool {
+	// Allow for floating point noise on exact answers such as 0.1 + 0.2
+	const epsilon = 1e-9
+	return math.Abs(guess-b.Answer) <= b.AllowedError()+epsilon
+}
+
+// GradedPoints scales points linearly from full marks for an exact answer
+// down to zero at the edge of the tolerance.
+func (b *NumericBlock) GradedPoints(guess float64) int {
+	if !b.IsWithinTolerance(guess) {
+		return 0
+	}
+	allowed := b.AllowedError()
+	if allowed == 0 {
+		return b.Points
+	}
+	closeness := 1 - math.Abs(guess-b.Answer)/allowed
+	return int(math.Round(float64(b.Points) * max(closeness, 0)))
+}
+
+// Guesses returns every guess a player has made.
+func (b *NumericBlock) Guesses(state PlayerState) []float64 {
+	var playerData numericBlockData
+	if state.GetPlayerData() == nil {
+		return nil
+	}
+	if err := json.Unmarshal(state.GetPlayerData(), &playerData); err != nil {
+		return nil
+	}
+	return playerData.Guesses
+}
+
+// FormatNumber formats a number without trailing zeros.
+func FormatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// thousandsSeparated matches a number whose commas group its whole part
+// into threes, such as 1,250 or 12,345.6.
+var thousandsSeparated = regexp.MustCompile(`^[+-]?\d{1,3}(,\d{3})+(\.\d*)?$`)
+
+// ParseNumber parses a number typed by a person. It ignores surrounding
+// whitespace, thousands separators, and a trailing unit if one is given.
+// A comma that doesn't separate thousands is read as a decimal point, as
+// in 3,5, and numbers that could be read either way are rejected.
+func ParseNumber(raw string, units string) (float64, error) {
+	value := strings.TrimSpace(raw)
+	if units != "" && len(value) > len(units) && strings.EqualFold(value[len(value)-len(units):], units) {
+		value = strings.TrimSpace(value[:len(value)-len(units)])
+	}
+	value = strings.ReplaceAll(value, " ", "")
+	switch {
+	case !strings.Contains(value, ","):
+	case thousandsSeparated.MatchString(value):
+		value = strings.ReplaceAll(value, ",", "")
+	case strings.Count(value, ",") == 1 && !strings.Contains(value, "."):
+		value = strings.Replace(value, ",", ".", 1)
+	default:
+		return 0, errors.New("number has misplaced commas")
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+		return 0, errors.New("number is out of range")
+	}
+	return parsed, nil
+}
@@ -0,0 +1,217 @@
+package blocks_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumericBlock_Getters(t *testing.T) {
+	block := blocks.NumericBlock{
+		BaseBlock: blocks.BaseBlock{
+			ID:         "test-id",
+			LocationID: "location-123",
+			Order:      1,
+			Points:     5,
+		},
+	}
+
+	assert.Equal(t, "Number", block.GetName())
+	assert.Equal(t, "numeric", block.GetType())
+	assert.Equal(t, "test-id", block.GetID())
+	assert.Equal(t, "location-123", block.GetLocationID())
+	assert.Equal(t, 1, block.GetOrder())
+	assert.Equal(t, 5, block.GetPoints())
+	assert.True(t, block.RequiresValidation())
+}
+
+func TestNumericBlock_ParseData(t *testing.T) {
+	block := blocks.NumericBlock{
+		BaseBlock: blocks.BaseBlock{
+			Data: json.RawMessage(`{"prompt":"How tall?","answer":42.5,"tolerance":10,"tolerance_type":"percent","units":"m","graded":true}`),
+		},
+	}
+
+	err := block.ParseData()
+	require.NoError(t, err)
+	assert.Equal(t, "How tall?", block.Prompt)
+	assert.InDelta(t, 42.5, block.Answer, 0.0001)
+	assert.InDelta(t, 10, block.Tolerance, 0.0001)
+	assert.Equal(t, blocks.TolerancePercent, block.ToleranceType)
+	assert.Equal(t, "m", block.Units)
+	assert.True(t, block.Graded)
+}
+
+func TestNumericBlock_UpdateBlockData(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "Valid input",
+			input: map[string][]string{
+				"points":         {"10"},
+				"prompt":         {"Estimate the height"},
+				"answer":         {"1,250.5"},
+				"tolerance":      {"5"},
+				"tolerance_type": {"percent"},
+				"units":          {" m "},
+				"graded":         {"on"},
+			},
+		},
+		{
+			name:    "Invalid points",
+			input:   map[string][]string{"points": {"ten"}},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid answer",
+			input:   map[string][]string{"answer": {"tall"}},
+			wantErr: true,
+		},
+		{
+			name:    "Negative tolerance",
+			input:   map[string][]string{"tolerance": {"-1"}},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown tolerance type",
+			input:   map[string][]string{"tolerance_type": {"relative"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := blocks.NewNumericBlock(blocks.BaseBlock{})
+			err := block.UpdateBlockData(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 10, block.Points)
+			assert.Equal(t, "Estimate the height", block.Prompt)
+			assert.InDelta(t, 1250.5, block.Answer, 0.0001)
+			assert.InDelta(t, 5, block.Tolerance, 0.0001)
+			assert.Equal(t, blocks.TolerancePercent, block.ToleranceType)
+			assert.Equal(t, "m", block.Units)
+			assert.True(t, block.Graded)
+		})
+	}
+}
+
+func TestNumericBlock_ValidatePlayerInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		toleranceType string
+		tolerance     float64
+		graded        bool
+		guess         string
+		wantComplete  bool
+		wantPoints    int
+	}{
+		{"Exact answer", blocks.ToleranceAbsolute, 0, false, "120", true, 10},
+		{"Exact answer with units", blocks.ToleranceAbsolute, 0, false, "120 M", true, 10},
+		{"Wrong answer without tolerance", blocks.ToleranceAbsolute, 0, false, "121", false, 0},
+		{"Within absolute tolerance", blocks.ToleranceAbsolute, 5, false, "124.5", true, 10},
+		{"Outside absolute tolerance", blocks.ToleranceAbsolute, 5, false, "125.5", false, 0},
+		{"Within percent tolerance", blocks.TolerancePercent, 10, false, "108", true, 10},
+		{"Outside percent tolerance", blocks.TolerancePercent, 10, false, "107", false, 0},
+		{"Graded exact", blocks.ToleranceAbsolute, 20, true, "120", true, 10},
+		{"Graded halfway", blocks.ToleranceAbsolute, 20, true, "130", true, 5},
+		{"Graded outside tolerance", blocks.ToleranceAbsolute, 20, true, "150", true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := blocks.NumericBlock{
+				BaseBlock:     blocks.BaseBlock{Points: 10},
+				Answer:        120,
+				Tolerance:     tt.tolerance,
+				ToleranceType: tt.toleranceType,
+				Units:         "m",
+				Graded:        tt.graded,
+			}
+			state := &blocks.MockPlayerState{}
+
+			newState, err := block.ValidatePlayerInput(state, map[string][]string{"answer": {tt.guess}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantComplete, newState.IsComplete())
+			assert.Equal(t, tt.wantPoints, newState.GetPointsAwarded())
+		})
+	}
+}
+
+func TestNumericBlock_ValidatePlayerInput_RecordsGuesses(t *testing.T) {
+	block := blocks.NumericBlock{
+		BaseBlock: blocks.BaseBlock{Points: 10},
+		Answer:    3.5,
+	}
+	var state blocks.PlayerState = &blocks.MockPlayerState{}
+
+	state, err := block.ValidatePlayerInput(state, map[string][]string{"answer": {"3"}})
+	require.NoError(t, err)
+	state, err = block.ValidatePlayerInput(state, map[string][]string{"answer": {"3.5"}})
+	require.NoError(t, err)
+	assert.True(t, state.IsComplete())
+
+	var playerData struct {
+		Attempts int       `json:"attempts"`
+		Guesses  []float64 `json:"guesses"`
+	}
+	require.NoError(t, json.Unmarshal(state.GetPlayerData(), &playerData))
+	assert.Equal(t, 2, playerData.Attempts)
+	assert.Equal(t, []float64{3, 3.5}, playerData.Guesses)
+	assert.Equal(t, []float64{3, 3.5}, block.Guesses(state))
+
+	// Non-numeric guesses are rejected without being recorded
+	_, err = block.ValidatePlayerInput(state, map[string][]string{"answer": {"three"}})
+	require.Error(t, err)
+	_, err = block.ValidatePlayerInput(state, map[string][]string{})
+	require.Error(t, err)
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		raw     string
+		units   string
+		want    float64
+		wantErr bool
+	}{
+		{"42", "", 42, false},
+		{" 1,000.5 ", "", 1000.5, false},
+		{"1,250", "", 1250, false},
+		{"12,345,678", "", 12345678, false},
+		{"3,5", "", 3.5, false},
+		{"-0,25", "", -0.25, false},
+		{"1 000,5", "", 1000.5, false},
+		{"3,5 kg", "kg", 3.5, false},
+		{"1,2,3", "", 0, true},
+		{"1.000,5", "", 0, true},
+		{"12,34.5", "", 0, true},
+		{"-3", "", -3, false},
+		{"12kg", "kg", 12, false},
+		{"12 KG", "kg", 12, false},
+		{"kg", "kg", 0, true},
+		{"twelve", "", 0, true},
+		{"NaN", "", 0, true},
+		{"Inf", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := blocks.ParseNumber(tt.raw, tt.units)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 )
 
 type PasswordBlock struct {
@@ -64,9 +63,7 @@ func (b *PasswordBlock) UpdateBlockData(input map[string][]string) error {
 	}
 	b.Prompt = input["prompt"][0]
 	b.Answer = input["answer"][0]
-	if input["fuzzy"] != nil {
-		b.Fuzzy = input["fuzzy"][0] == "on"
-	}
+	b.Fuzzy = input["fuzzy"] != nil && input["fuzzy"][0] == "on"
 	if input["unlocked_content"] != nil {
 		b.UnlockedContent = input["unlocked_content"][0]
 	}
@@ -93,10 +90,7 @@ func (b *PasswordBlock) ValidatePlayerInput(state PlayerState, input map[string]
 	newPlayerData.Attempts++
 	newPlayerData.Guesses = append(newPlayerData.Guesses, input["answer"][0])
 
-	guess := input["answer"][0]
-	guess = strings.TrimSpace(strings.ToLower(guess))
-
-	if guess != strings.TrimSpace(strings.ToLower(b.Answer)) {
+	if !MatchesAnswer(input["answer"][0], b.Answer, b.Fuzzy) {
 		// Incorrect answer, save player data and return an error
 		playerData, err := json.Marshal(newPlayerData)
 		if err != nil {
@@ -0,0 +1,175 @@
+package blocks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// PollBlock asks every team the same question. Once a team has voted it can
+// see how all teams in the instance voted.
+type PollBlock struct {
+	BaseBlock
+	Prompt  string       `json:"prompt"`
+	Options []PollOption `json:"options"`
+}
+
+// PollOption is a single choice in a poll.
+type PollOption struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// PollPlayerData stores a team's vote. It only holds the chosen option so
+// identical votes serialise identically and can be counted in the database.
+type PollPlayerData struct {
+	Option string `json:"option"`
+}
+
+// PollResult is the number of votes for a single option.
+type PollResult struct {
+	Option  PollOption
+	Votes   int
+	Percent int
+}
+
+// PollResults is the distribution of votes across every option.
+type PollResults struct {
+	Options []PollResult
+	Total   int
+}
+
+// Basic Attributes Getters
+
+func (b *PollBlock) GetID() string         { return b.ID }
+func (b *PollBlock) GetType() string       { return "poll" }
+func (b *PollBlock) GetLocationID() string { return b.LocationID }
+func (b *PollBlock) GetName() string       { return "Poll" }
+func (b *PollBlock) GetDescription() string {
+	return "Teams vote on a question and see how everyone else voted."
+}
+func (b *PollBlock) GetOrder() int  { return b.Order }
+func (b *PollBlock) GetPoints() int { return b.Points }
+func (b *PollBlock) GetIconSVG() string {
+	return `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="lucide lucide-vote"><path d="m9 12 2 2 4-4"/><path d="M5 7c0-1.1.9-2 2-2h10a2 2 0 0 1 2 2v12H5V7Z"/><path d="M22 19H2"/></svg>`
+}
+func (b *PollBlock) GetAdminData() any {
+	return &b
+}
+func (b *PollBlock) GetData() json.RawMessage {
+	data, _ := json.Marshal(b)
+	return data
+}
+
+// Data Operations
+
+func (b *PollBlock) ParseData() error {
+	return json.Unmarshal(b.Data, b)
+}
+
+func (b *PollBlock) UpdateBlockData(input map[string][]string) error {
+	// Points
+	if input["points"] != nil {
+		points, err := strconv.Atoi(input["points"][0])
+		if err != nil {
+			return errors.New("points must be an integer")
+		}
+		b.Points = points
+	}
+
+	// Prompt
+	if input["prompt"] == nil {
+		return errors.New("prompt is required")
+	}
+	b.Prompt = input["prompt"][0]
+
+	// Options. IDs are kept so existing votes still count after an edit.
+	ids := input["poll-ids"]
+	texts := input["poll-options"]
+	options := make([]PollOption, 0, len(texts))
+	for i, text := range texts {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		option := PollOption{ID: valueAt(ids, i), Text: text}
+		if option.ID == "" {
+			id, err := uuid.NewRandom()
+			if err != nil {
+				return fmt.Errorf("failed to generate UUID: %w", err)
+			}
+			option.ID = id.String()
+		}
+		options = append(options, option)
+	}
+	b.Options = options
+
+	return nil
+}
+
+// Validation and Points Calculation
+
+func (b *PollBlock) RequiresValidation() bool { return true }
+
+func (b *PollBlock) ValidatePlayerInput(state PlayerState, input map[string][]string) (PlayerState, error) {
+	if input["option"] == nil || input["option"][0] == "" {
+		return state, errors.New("option is required")
+	}
+
+	if _, ok := b.Option(input["option"][0]); !ok {
+		return state, errors.New("option is not part of this poll")
+	}
+
+	playerData, err := json.Marshal(PollPlayerData{Option: input["option"][0]})
+	if err != nil {
+		return state, errors.New("error saving player data")
+	}
+
+	state.SetPlayerData(playerData)
+	state.SetComplete(true)
+	state.SetPointsAwarded(b.Points)
+	return state, nil
+}
+
+// ParsePlayerData parses the stored player data, if any.
+func (b *PollBlock) ParsePlayerData(raw json.RawMessage) (PollPlayerData, error) {
+	var playerData PollPlayerData
+	if raw != nil {
+		if err := json.Unmarshal(raw, &playerData); err != nil {
+			return playerData, fmt.Errorf("failed to parse player data: %w", err)
+		}
+	}
+	return playerData, nil
+}
+
+// Option returns the option with the given ID.
+func (b *PollBlock) Option(id string) (PollOption, bool) {
+	for _, option := range b.Options {
+		if option.ID == id {
+			return option, true
+		}
+	}
+	return PollOption{}, false
+}
+
+// Results converts vote counts keyed by option ID into a distribution over
+// the current options. Votes for options that have since been removed are
+// ignored.
+func (b *PollBlock) Results(votes map[string]int) PollResults {
+	results := PollResults{Options: make([]PollResult, 0, len(b.Options))}
+	for _, option := range b.Options {
+		results.Options = append(results.Options, PollResult{Option: option, Votes: votes[option.ID]})
+		results.Total += votes[option.ID]
+	}
+	if results.Total > 0 {
+		for i := range results.Options {
+			results.Options[i].Percent = results.Options[i].Votes * 100 / results.Total
+		}
+	}
+	return results
+}
@@ -0,0 +1,142 @@
+package blocks_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPollBlock() blocks.PollBlock {
+	return blocks.PollBlock{
+		BaseBlock: blocks.BaseBlock{
+			ID:         "poll-id",
+			LocationID: "location-123",
+			Order:      1,
+			Points:     5,
+		},
+		Prompt: "Best snack?",
+		Options: []blocks.PollOption{
+			{ID: "a", Text: "Chips"},
+			{ID: "b", Text: "Fruit"},
+			{ID: "c", Text: "Lollies"},
+		},
+	}
+}
+
+func TestPollBlock_Getters(t *testing.T) {
+	block := newTestPollBlock()
+
+	assert.Equal(t, "Poll", block.GetName())
+	assert.Equal(t, "poll", block.GetType())
+	assert.Equal(t, "poll-id", block.GetID())
+	assert.Equal(t, "location-123", block.GetLocationID())
+	assert.Equal(t, 1, block.GetOrder())
+	assert.Equal(t, 5, block.GetPoints())
+	assert.NotEmpty(t, block.GetDescription())
+	assert.NotEmpty(t, block.GetIconSVG())
+}
+
+func TestPollBlock_ParseData(t *testing.T) {
+	original := newTestPollBlock()
+	block := blocks.PollBlock{BaseBlock: blocks.BaseBlock{Data: original.GetData()}}
+
+	require.NoError(t, block.ParseData())
+	assert.Equal(t, original.Prompt, block.Prompt)
+	assert.Equal(t, original.Options, block.Options)
+}
+
+func TestPollBlock_UpdateBlockData(t *testing.T) {
+	block := newTestPollBlock()
+
+	err := block.UpdateBlockData(map[string][]string{
+		"points":       {"10"},
+		"prompt":       {"Favourite colour?"},
+		"poll-ids":     {"a", "", ""},
+		"poll-options": {" Red ", "", "Blue"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 10, block.Points)
+	assert.Equal(t, "Favourite colour?", block.Prompt)
+	require.Len(t, block.Options, 2)
+	assert.Equal(t, blocks.PollOption{ID: "a", Text: "Red"}, block.Options[0])
+	assert.Equal(t, "Blue", block.Options[1].Text)
+	assert.NotEmpty(t, block.Options[1].ID, "new options should be given an ID")
+}
+
+func TestPollBlock_UpdateBlockData_Errors(t *testing.T) {
+	block := newTestPollBlock()
+
+	err := block.UpdateBlockData(map[string][]string{"points": {"ten"}, "prompt": {"?"}})
+	require.Error(t, err)
+
+	err = block.UpdateBlockData(map[string][]string{})
+	require.Error(t, err)
+}
+
+func TestPollBlock_ValidatePlayerInput(t *testing.T) {
+	block := newTestPollBlock()
+
+	tests := []struct {
+		name    string
+		input   map[string][]string
+		wantErr bool
+	}{
+		{name: "valid option", input: map[string][]string{"option": {"b"}}},
+		{name: "missing option", input: map[string][]string{}, wantErr: true},
+		{name: "empty option", input: map[string][]string{"option": {""}}, wantErr: true},
+		{name: "unknown option", input: map[string][]string{"option": {"z"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &blocks.MockPlayerState{BlockID: block.ID, PlayerID: "team"}
+			newState, err := block.ValidatePlayerInput(state, tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.False(t, newState.IsComplete())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, newState.IsComplete())
+			assert.Equal(t, 5, newState.GetPointsAwarded())
+			assert.JSONEq(t, `{"option":"b"}`, string(newState.GetPlayerData()))
+		})
+	}
+}
+
+func TestPollBlock_ParsePlayerData(t *testing.T) {
+	block := newTestPollBlock()
+
+	data, err := block.ParsePlayerData(nil)
+	require.NoError(t, err)
+	assert.Empty(t, data.Option)
+
+	data, err = block.ParsePlayerData(json.RawMessage(`{"option":"c"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "c", data.Option)
+
+	_, err = block.ParsePlayerData(json.RawMessage(`{`))
+	require.Error(t, err)
+}
+
+func TestPollBlock_Results(t *testing.T) {
+	block := newTestPollBlock()
+
+	results := block.Results(map[string]int{"a": 3, "c": 1, "removed": 4})
+	assert.Equal(t, 4, results.Total, "votes for removed options should be ignored")
+	require.Len(t, results.Options, 3)
+	assert.Equal(t, 3, results.Options[0].Votes)
+	assert.Equal(t, 75, results.Options[0].Percent)
+	assert.Equal(t, 0, results.Options[1].Votes)
+	assert.Equal(t, 0, results.Options[1].Percent)
+	assert.Equal(t, 25, results.Options[2].Percent)
+
+	empty := block.Results(nil)
+	assert.Equal(t, 0, empty.Total)
+	assert.Len(t, empty.Options, 3)
+}
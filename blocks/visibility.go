@@ -0,0 +1,146 @@
+package blocks
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Visibility holds the conditions a team must meet before a block is shown.
+// Every condition that is set must be met. A zero Visibility always shows
+// the block.
+type Visibility struct {
+	AfterBlockID string   `json:"after_block_id,omitempty"` // Shown once this block is complete
+	GroupID      string   `json:"group_id,omitempty"`       // Only shown to teams currently in this group
+	TeamCodes    []string `json:"team_codes,omitempty"`     // Only shown to these teams
+	AfterMinutes int      `json:"after_minutes,omitempty"`  // Shown this long after checking in
+	MinPoints    int      `json:"min_points,omitempty"`     // Shown once the team has this many points
+}
+
+// TeamProgress is the team state that visibility conditions are checked
+// against.
+type TeamProgress struct {
+	TeamCode  string
+	GroupID   string // The group the team is currently in
+	Points    int
+	ArrivedAt time.Time
+	Now       time.Time
+	Completed map[string]bool // Completed block IDs
+}
+
+// NewTeamProgress builds the progress for a team at a location from its
+// block states.
+func NewTeamProgress(
+	teamCode string,
+	groupID string,
+	points int,
+	arrivedAt time.Time,
+	states map[string]PlayerState,
+) TeamProgress {
+	completed := make(map[string]bool, len(states))
+	for blockID, state := range states {
+		if state != nil && state.IsComplete() {
+			completed[blockID] = true
+		}
+	}
+	return TeamProgress{
+		TeamCode:  teamCode,
+		GroupID:   groupID,
+		Points:    points,
+		ArrivedAt: arrivedAt,
+		Now:       time.Now(),
+		Completed: completed,
+	}
+}
+
+// IsSet reports whether any condition has been set.
+func (v Visibility) IsSet() bool {
+	return v.AfterBlockID != "" || v.GroupID != "" || len(v.TeamCodes) > 0 || v.AfterMinutes > 0 || v.MinPoints > 0
+}
+
+// IsVisible reports whether the team meets every condition.
+func (v Visibility) IsVisible(p TeamProgress) bool {
+	if v.Excludes(p) {
+		return false
+	}
+	if v.AfterBlockID != "" && !p.Completed[v.AfterBlockID] {
+		return false
+	}
+	if v.AfterMinutes > 0 && p.Now.Before(v.VisibleFrom(p)) {
+		return false
+	}
+	return true
+}
+
+// Excludes reports whether the block is hidden by a condition the team
+// will not meet just by working through the location: the group, the team
+// list and the points threshold. Blocks waiting on another block or a
+// timer are not excluded.
+func (v Visibility) Excludes(p TeamProgress) bool {
+	if v.GroupID != "" && v.GroupID != p.GroupID {
+		return true
+	}
+	if len(v.TeamCodes) > 0 && !slices.Contains(v.TeamCodes, p.TeamCode) {
+		return true
+	}
+	return v.MinPoints > 0 && p.Points < v.MinPoints
+}
+
+// VisibleFrom returns when the time condition is met for the team.
+func (v Visibility) VisibleFrom(p TeamProgress) time.Time {
+	return p.ArrivedAt.Add(time.Duration(v.AfterMinutes) * time.Minute)
+}
+
+// CanSee reports whether the team can see the block.
+func (p TeamProgress) CanSee(block Block) bool {
+	return block.GetVisibility().IsVisible(p)
+}
+
+// VisibleBlocks returns the blocks the team can currently see, in order.
+func (p TeamProgress) VisibleBlocks(all Blocks) Blocks {
+	visible := make(Blocks, 0, len(all))
+	for _, block := range all {
+		if p.CanSee(block) {
+			visible = append(visible, block)
+		}
+	}
+	return visible
+}
+
+// ParseVisibility reads visibility conditions from form input with the keys
+// after_block_id, group_id, team_codes, after_minutes, and min_points.
+func ParseVisibility(input map[string][]string) (Visibility, error) {
+	var v Visibility
+	if afterBlock := input["after_block_id"]; len(afterBlock) > 0 {
+		v.AfterBlockID = afterBlock[0]
+	}
+	if group := input["group_id"]; len(group) > 0 {
+		v.GroupID = strings.TrimSpace(group[0])
+	}
+
+	for _, code := range input["team_codes"] {
+		if code = strings.TrimSpace(code); code != "" && !slices.Contains(v.TeamCodes, code) {
+			v.TeamCodes = append(v.TeamCodes, code)
+		}
+	}
+
+	if minutes := input["after_minutes"]; len(minutes) > 0 && minutes[0] != "" {
+		parsed, err := strconv.Atoi(minutes[0])
+		if err != nil || parsed < 0 {
+			return v, errors.New("minutes must be a positive whole number")
+		}
+		v.AfterMinutes = parsed
+	}
+
+	if points := input["min_points"]; len(points) > 0 && points[0] != "" {
+		parsed, err := strconv.Atoi(points[0])
+		if err != nil || parsed < 0 {
+			return v, errors.New("points must be a positive whole number")
+		}
+		v.MinPoints = parsed
+	}
+
+	return v, nil
+}
@@ -0,0 +1,137 @@
+package blocks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisibility_IsVisible(t *testing.T) {
+	arrived := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	progress := blocks.TeamProgress{
+		TeamCode:  "ABC",
+		GroupID:   "second",
+		Points:    50,
+		ArrivedAt: arrived,
+		Now:       arrived.Add(10 * time.Minute),
+		Completed: map[string]bool{"done": true},
+	}
+
+	tests := []struct {
+		name       string
+		visibility blocks.Visibility
+		visible    bool
+		excluded   bool
+	}{
+		{name: "no conditions", visibility: blocks.Visibility{}, visible: true},
+		{name: "after completed block", visibility: blocks.Visibility{AfterBlockID: "done"}, visible: true},
+		{name: "after incomplete block", visibility: blocks.Visibility{AfterBlockID: "todo"}, visible: false},
+		{name: "in group", visibility: blocks.Visibility{GroupID: "second"}, visible: true},
+		{
+			name:       "in another group",
+			visibility: blocks.Visibility{GroupID: "first"},
+			visible:    false,
+			excluded:   true,
+		},
+		{name: "team listed", visibility: blocks.Visibility{TeamCodes: []string{"XYZ", "ABC"}}, visible: true},
+		{
+			name:       "team not listed",
+			visibility: blocks.Visibility{TeamCodes: []string{"XYZ"}},
+			visible:    false,
+			excluded:   true,
+		},
+		{name: "timer elapsed", visibility: blocks.Visibility{AfterMinutes: 10}, visible: true},
+		{name: "timer running", visibility: blocks.Visibility{AfterMinutes: 11}, visible: false},
+		{name: "enough points", visibility: blocks.Visibility{MinPoints: 50}, visible: true},
+		{
+			name:       "not enough points",
+			visibility: blocks.Visibility{MinPoints: 51},
+			visible:    false,
+			excluded:   true,
+		},
+		{
+			name:       "every condition must be met",
+			visibility: blocks.Visibility{AfterBlockID: "done", MinPoints: 10, AfterMinutes: 20},
+			visible:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.visible, tt.visibility.IsVisible(progress))
+			assert.Equal(t, tt.excluded, tt.visibility.Excludes(progress))
+		})
+	}
+}
+
+func TestVisibility_IsSet(t *testing.T) {
+	assert.False(t, blocks.Visibility{}.IsSet())
+	assert.True(t, blocks.Visibility{AfterBlockID: "x"}.IsSet())
+	assert.True(t, blocks.Visibility{GroupID: "x"}.IsSet())
+	assert.True(t, blocks.Visibility{TeamCodes: []string{"x"}}.IsSet())
+	assert.True(t, blocks.Visibility{AfterMinutes: 1}.IsSet())
+	assert.True(t, blocks.Visibility{MinPoints: 1}.IsSet())
+}
+
+func TestNewTeamProgress(t *testing.T) {
+	states := map[string]blocks.PlayerState{
+		"complete":   &blocks.MockPlayerState{BlockID: "complete", IsCompleteVal: true},
+		"incomplete": &blocks.MockPlayerState{BlockID: "incomplete"},
+		"missing":    nil,
+	}
+
+	progress := blocks.NewTeamProgress("ABC", "group", 5, time.Now(), states)
+	assert.Equal(t, map[string]bool{"complete": true}, progress.Completed)
+	assert.Equal(t, "ABC", progress.TeamCode)
+	assert.Equal(t, "group", progress.GroupID)
+	assert.Equal(t, 5, progress.Points)
+}
+
+func TestTeamProgress_VisibleBlocks(t *testing.T) {
+	shown := blocks.NewMarkdownBlock(blocks.BaseBlock{ID: "shown"})
+	hidden := blocks.NewMarkdownBlock(blocks.BaseBlock{ID: "hidden"})
+	hidden.SetVisibility(blocks.Visibility{AfterBlockID: "shown"})
+
+	progress := blocks.TeamProgress{Completed: map[string]bool{}}
+	visible := progress.VisibleBlocks(blocks.Blocks{shown, hidden})
+	require.Len(t, visible, 1)
+	assert.Equal(t, "shown", visible[0].GetID())
+}
+
+func TestVisibility_RoundTrip(t *testing.T) {
+	block := blocks.NewMarkdownBlock(blocks.BaseBlock{ID: "block"})
+	block.Content = "Hello"
+	assert.NotContains(t, string(block.GetData()), "visibility", "unset conditions should not be stored")
+
+	block.SetVisibility(blocks.Visibility{AfterBlockID: "other", MinPoints: 3})
+	parsed := blocks.NewMarkdownBlock(blocks.BaseBlock{Data: block.GetData()})
+	require.NoError(t, parsed.ParseData())
+	assert.Equal(t, "Hello", parsed.Content)
+	assert.Equal(t, blocks.Visibility{AfterBlockID: "other", MinPoints: 3}, parsed.GetVisibility())
+}
+
+func TestParseVisibility(t *testing.T) {
+	v, err := blocks.ParseVisibility(map[string][]string{
+		"after_block_id": {"block"},
+		"group_id":       {" group "},
+		"team_codes":     {"ABC", " ABC ", "", "XYZ"},
+		"after_minutes":  {"15"},
+		"min_points":     {""},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, blocks.Visibility{
+		AfterBlockID: "block",
+		GroupID:      "group",
+		TeamCodes:    []string{"ABC", "XYZ"},
+		AfterMinutes: 15,
+	}, v)
+
+	_, err = blocks.ParseVisibility(map[string][]string{"after_minutes": {"soon"}})
+	require.Error(t, err)
+
+	_, err = blocks.ParseVisibility(map[string][]string{"min_points": {"-1"}})
+	require.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -14,6 +15,8 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/nathanhollows/Rapua/v6/db"
 	admin "github.com/nathanhollows/Rapua/v6/internal/handlers/admin"
+	api "github.com/nathanhollows/Rapua/v6/internal/handlers/api"
+	lti "github.com/nathanhollows/Rapua/v6/internal/handlers/lti"
 	players "github.com/nathanhollows/Rapua/v6/internal/handlers/players"
 	public "github.com/nathanhollows/Rapua/v6/internal/handlers/public"
 	"github.com/nathanhollows/Rapua/v6/internal/migrations"
@@ -33,6 +36,8 @@ import (
 const (
 	version    = "v6.14.1"
 	uploadsDir = "static/uploads/"
+	// ltiHTTPTimeout bounds requests to LMS platforms
+	ltiHTTPTimeout = 10 * time.Second
 )
 
 func main() {
@@ -383,22 +388,34 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 	initialiseFolders(logger)
 
 	// Initialize repositories
+	adminSessionRepo := repositories.NewAdminSessionRepository(dbc)
+	apiKeyRepo := repositories.NewAPIKeyRepository(dbc)
 	blockStateRepo := repositories.NewBlockStateRepository(dbc)
 	blockRepo := repositories.NewBlockRepository(dbc, blockStateRepo)
+	blockRevisionRepo := repositories.NewBlockRevisionRepository(dbc)
 	checkInRepo := repositories.NewCheckInRepository(dbc)
 	creditRepo := repositories.NewCreditRepository(dbc)
 	creditPurchaseRepo := repositories.NewCreditPurchaseRepository(dbc)
 	facilitatorRepo := repositories.NewFacilitatorTokenRepo(dbc)
 	instanceRepo := repositories.NewInstanceRepository(dbc)
 	instanceSettingsRepo := repositories.NewInstanceSettingsRepository(dbc)
+	ltiRepo := repositories.NewLTIRepository(dbc)
 	locationRepo := repositories.NewLocationRepository(dbc)
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(dbc)
 	markerRepo := repositories.NewMarkerRepository(dbc)
 	notificationRepo := repositories.NewNotificationRepository(dbc)
+	organisationRepo := repositories.NewOrganisationRepository(dbc)
+	pushRepo := repositories.NewPushRepository(dbc)
+	recoveryCodeRepo := repositories.NewRecoveryCodeRepository(dbc)
+	scheduledNotificationRepo := repositories.NewScheduledNotificationRepository(dbc)
 	shareLinkRepo := repositories.NewShareLinkRepository(dbc)
 	teamRepo := repositories.NewTeamRepository(dbc)
+	teamMessageRepo := repositories.NewTeamMessageRepository(dbc)
 	teamStartLogRepo := repositories.NewTeamStartLogRepository(dbc)
+	trashRepo := repositories.NewTrashRepository(dbc)
 	userRepo := repositories.NewUserRepository(dbc)
 	uploadRepo := repositories.NewUploadRepository(dbc)
+	webhookRepo := repositories.NewWebhookRepository(dbc)
 
 	// Initialize transactor for services
 	transactor := db.NewTransactor(dbc)
@@ -412,9 +429,12 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		instanceRepo,
 		locationRepo,
 		markerRepo,
+		organisationRepo,
 	)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, userRepo)
+	webhookService := services.NewWebhookService(webhookRepo, services.NewWebhookHTTPClient(), logger)
 	locationStatsService := services.NewLocationStatsService(locationRepo)
-	gameScheduleService := services.NewGameScheduleService(instanceRepo)
+	gameScheduleService := services.NewGameScheduleService(instanceRepo, webhookService)
 	quickstartService := services.NewQuickstartService(instanceRepo)
 	markerService := services.NewMarkerService(markerRepo)
 	uploadService := services.NewUploadService(uploadRepo, localStorage)
@@ -423,6 +443,7 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		transactor,
 		blockRepo,
 		blockStateRepo,
+		blockRevisionRepo,
 		checkInRepo,
 		instanceRepo,
 		instanceSettingsRepo,
@@ -434,6 +455,14 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		creditPurchaseRepo,
 		teamStartLogRepo,
 		uploadRepo,
+		trashRepo,
+		organisationRepo,
+		apiKeyRepo,
+		webhookRepo,
+		ltiRepo,
+		recoveryCodeRepo,
+		adminSessionRepo,
+		loginAttemptRepo,
 		dbc,
 		uploadsDir,
 		logger,
@@ -445,16 +474,34 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		locationRepo,
 		blockRepo,
 	)
-	facilitatorService := services.NewFacilitatorService(facilitatorRepo)
 	assetGenerator := services.NewAssetGenerator()
-	identityService := services.NewAuthService(userRepo)
+	adminSessionService := services.NewAdminSessionService(adminSessionRepo, loginAttemptRepo, userRepo)
+	identityService := services.NewAuthService(userRepo, adminSessionService)
 	blockService := services.NewBlockService(blockRepo, blockStateRepo)
+	blockLibraryService := services.NewBlockLibraryService(
+		transactor,
+		repositories.NewBlockLibraryRepository(dbc),
+		blockRepo,
+	)
+	blockRevisionService := services.NewBlockRevisionService(blockRevisionRepo, blockRepo)
+	trashService := services.NewTrashService(transactor, trashRepo, instanceRepo, locationRepo)
+	markerLibraryService := services.NewMarkerLibraryService(transactor, markerRepo)
+	organisationService := services.NewOrganisationService(
+		transactor,
+		organisationRepo,
+		instanceRepo,
+		userRepo,
+		creditRepo,
+		teamStartLogRepo,
+	)
+	twoFactorService := services.NewTwoFactorService(userRepo, recoveryCodeRepo, organisationRepo)
 	emailService := services.NewEmailService()
 	instanceSettingsService := services.NewInstanceSettingsService(instanceSettingsRepo)
 	locationService := services.NewLocationService(locationRepo, markerRepo, blockRepo, markerService)
 
 	// Set the relation loader so gameStructureService can load location relations
 	gameStructureService.SetRelationLoader(locationService)
+	locationImportService := services.NewLocationImportService(locationService, gameStructureService, deleteService)
 
 	navigationService := services.NewNavigationService(locationRepo, teamRepo, gameStructureService, blockService)
 	checkInService := services.NewCheckInService(
@@ -464,8 +511,31 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		locationStatsService,
 		navigationService,
 		blockService,
+		webhookService,
+	)
+	messageService := services.NewMessageService(teamMessageRepo, notificationRepo)
+	facilitatorService := services.NewFacilitatorService(
+		facilitatorRepo,
+		instanceRepo,
+		locationRepo,
+		teamRepo,
+		blockService,
+		navigationService,
+		checkInService,
+		messageService,
+	)
+	// Push endpoints come from players' browsers, so use the client that
+	// refuses private addresses
+	pushService := services.NewPushService(pushRepo, services.NewWebhookHTTPClient(), logger)
+	pushService.Start()
+	notificationService := services.NewNotificationService(
+		notificationRepo,
+		scheduledNotificationRepo,
+		instanceRepo,
+		teamRepo,
+		pushService,
+		logger,
 	)
-	notificationService := services.NewNotificationService(notificationRepo, teamRepo)
 	userService := services.NewUserService(userRepo, instanceRepo)
 	monthlyCreditTopupJob := services.NewMonthlyCreditTopupService(transactor, creditRepo, logger)
 	staleCreditCleanupService := services.NewStalePurchaseCleanupService(transactor, logger)
@@ -481,6 +551,7 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		creditService,
 		creditPurchaseRepo,
 		userRepo,
+		organisationRepo,
 		logger,
 	)
 	teamService := services.NewTeamService(
@@ -490,8 +561,18 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		creditService,
 		blockStateRepo,
 		locationRepo,
+		webhookService,
 	)
 	leaderBoardService := services.NewLeaderBoardService(teamRepo)
+	// LMS platforms are registered by hosts, so they may be on a private
+	// network such as a campus LMS
+	ltiService := services.NewLTIService(
+		ltiRepo,
+		teamService,
+		navigationService,
+		&http.Client{Timeout: ltiHTTPTimeout},
+		logger,
+	)
 	instanceService := services.NewInstanceService(
 		instanceRepo, instanceSettingsRepo, blockRepo,
 	)
@@ -523,6 +604,31 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		},
 		scheduler.NextDaily,
 	)
+	jobs.AddJob(
+		"Trash Purge",
+		deleteService.PurgeExpiredTrash,
+		scheduler.NextDaily,
+	)
+	jobs.AddJob(
+		"Webhook Deliveries",
+		webhookService.ProcessDeliveries,
+		scheduler.NextMinute,
+	)
+	jobs.AddJob(
+		"Webhook Delivery Log Purge",
+		webhookService.PurgeDeliveries,
+		scheduler.NextDaily,
+	)
+	jobs.AddJob(
+		"Scheduled Notifications",
+		notificationService.SendScheduledNotifications,
+		scheduler.NextMinute,
+	)
+	jobs.AddJob(
+		"LTI Grade Sync",
+		ltiService.SyncGrades,
+		scheduler.NextMinute,
+	)
 	jobs.Start()
 
 	// Initialize magic token service for CLI-generated login links
@@ -542,6 +648,8 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		&templateService,
 		userService,
 		magicTokenService,
+		twoFactorService,
+		adminSessionService,
 	)
 
 	playerHandler := players.NewPlayerHandler(
@@ -551,8 +659,10 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		instanceService,
 		locationService,
 		markerService,
+		messageService,
 		navigationService,
 		notificationService,
+		pushService,
 		teamService,
 		uploadService,
 	)
@@ -560,9 +670,12 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 	adminHandler := admin.NewAdminHandler(
 		logger,
 		accessService,
+		apiKeyService,
 		assetGenerator,
 		identityService,
 		blockService,
+		blockLibraryService,
+		blockRevisionService,
 		creditService,
 		creditPurchaseRepo,
 		deleteService,
@@ -573,19 +686,41 @@ func runApp(logger *slog.Logger, dbc *bun.DB) { //nolint:funlen // Main setup fu
 		instanceService,
 		instanceSettingsService,
 		locationService,
+		locationImportService,
 		markerService,
+		markerLibraryService,
+		messageService,
 		navigationService,
 		notificationService,
+		organisationService,
 		teamService,
 		templateService,
+		trashService,
 		uploadService,
 		userService,
 		quickstartService,
 		leaderBoardService,
 		stripeService,
+		webhookService,
+		ltiService,
+		twoFactorService,
+		adminSessionService,
 	)
 
-	server.Start(logger, publicHandler, playerHandler, adminHandler, jobs)
+	apiHandler := api.NewHandler(
+		logger,
+		accessService,
+		apiKeyService,
+		instanceService,
+		leaderBoardService,
+		locationService,
+		notificationService,
+		teamService,
+	)
+
+	ltiHandler := lti.NewHandler(logger, ltiService)
+
+	server.Start(logger, publicHandler, playerHandler, adminHandler, apiHandler, ltiHandler, jobs)
 }
 
 func initialiseFolders(logger *slog.Logger) {
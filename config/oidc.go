@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// oidcProviderID limits provider IDs to what can appear in an environment
+// variable name and a URL path.
+var oidcProviderID = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// OIDCProvider is an OpenID Connect identity provider that users can log in
+// with, such as Azure AD, Keycloak, or Okta.
+type OIDCProvider struct {
+	// ID names the provider in its environment variables
+	ID string
+	// Name is shown on the login button
+	Name         string
+	ClientID     string
+	ClientSecret string
+	// DiscoveryURL is the provider's .well-known/openid-configuration URL
+	DiscoveryURL string
+	Scopes       []string
+	// TrustEmail treats every email the provider sends as verified, for
+	// providers that manage their users' addresses but omit email_verified
+	TrustEmail bool
+}
+
+// OIDCProviders returns the providers listed in OIDC_PROVIDERS. Each ID in
+// the comma separated list is configured by OIDC_<ID>_NAME,
+// OIDC_<ID>_CLIENT_ID, OIDC_<ID>_CLIENT_SECRET, OIDC_<ID>_DISCOVERY_URL,
+// and optionally OIDC_<ID>_SCOPES and OIDC_<ID>_TRUST_EMAIL. Providers
+// with an invalid ID or missing settings are skipped and returned in
+// invalid.
+func OIDCProviders() (providers []OIDCProvider, invalid []string) {
+	for _, id := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		id = strings.ToLower(strings.TrimSpace(id))
+		if id == "" {
+			continue
+		}
+		if !oidcProviderID.MatchString(id) {
+			invalid = append(invalid, id)
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(id, "-", "_")) + "_"
+		provider := OIDCProvider{
+			ID:           id,
+			Name:         strings.TrimSpace(os.Getenv(prefix + "NAME")),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			DiscoveryURL: os.Getenv(prefix + "DISCOVERY_URL"),
+			Scopes:       strings.Fields(strings.ReplaceAll(os.Getenv(prefix+"SCOPES"), ",", " ")),
+			TrustEmail:   os.Getenv(prefix+"TRUST_EMAIL") == "true" || os.Getenv(prefix+"TRUST_EMAIL") == "1",
+		}
+		if provider.ClientID == "" || provider.ClientSecret == "" || provider.DiscoveryURL == "" {
+			invalid = append(invalid, id)
+			continue
+		}
+		if provider.Name == "" {
+			provider.Name = id
+		}
+		if len(provider.Scopes) == 0 {
+			provider.Scopes = []string{"openid", "email", "profile"}
+		}
+		providers = append(providers, provider)
+	}
+	return providers, invalid
+}
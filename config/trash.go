@@ -0,0 +1,25 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// DefaultTrashRetentionDays is how long deleted games, locations and teams
+// are kept before they are purged.
+const DefaultTrashRetentionDays = 30
+
+// TrashRetentionDays returns how many days items stay in the trash.
+// Falls back to DefaultTrashRetentionDays if not set or invalid.
+func TrashRetentionDays() int {
+	daysStr := os.Getenv("TRASH_RETENTION_DAYS")
+	if daysStr == "" {
+		return DefaultTrashRetentionDays
+	}
+
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days <= 0 {
+		return DefaultTrashRetentionDays
+	}
+	return days
+}
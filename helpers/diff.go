@@ -0,0 +1,81 @@
+package helpers
+
+import "strings"
+
+// DiffOp describes how a line changed between two versions of a text.
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffAdded
+	DiffRemoved
+)
+
+// DiffLine is a single line of a line-by-line diff.
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffLines compares two texts line by line using the longest common
+// subsequence. Removed lines come before the lines that replaced them.
+func DiffLines(before, after string) []DiffLine {
+	a := splitLines(before)
+	b := splitLines(after)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := make([]DiffLine, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, DiffLine{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, DiffLine{Op: DiffRemoved, Text: a[i]})
+			i++
+		default:
+			diff = append(diff, DiffLine{Op: DiffAdded, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, DiffLine{Op: DiffRemoved, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, DiffLine{Op: DiffAdded, Text: b[j]})
+	}
+	return diff
+}
+
+// DiffChanged reports whether a diff contains any added or removed lines.
+func DiffChanged(diff []DiffLine) bool {
+	for _, line := range diff {
+		if line.Op != DiffEqual {
+			return true
+		}
+	}
+	return false
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
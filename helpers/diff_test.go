@@ -0,0 +1,75 @@
+package helpers_test
+
+import (
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []helpers.DiffLine
+	}{
+		{
+			name:   "Unchanged",
+			before: "one\ntwo",
+			after:  "one\ntwo",
+			want: []helpers.DiffLine{
+				{Op: helpers.DiffEqual, Text: "one"},
+				{Op: helpers.DiffEqual, Text: "two"},
+			},
+		},
+		{
+			name:   "Changed line",
+			before: "one\ntwo\nthree",
+			after:  "one\n2\nthree",
+			want: []helpers.DiffLine{
+				{Op: helpers.DiffEqual, Text: "one"},
+				{Op: helpers.DiffRemoved, Text: "two"},
+				{Op: helpers.DiffAdded, Text: "2"},
+				{Op: helpers.DiffEqual, Text: "three"},
+			},
+		},
+		{
+			name:   "Added at end",
+			before: "one",
+			after:  "one\ntwo\n",
+			want: []helpers.DiffLine{
+				{Op: helpers.DiffEqual, Text: "one"},
+				{Op: helpers.DiffAdded, Text: "two"},
+			},
+		},
+		{
+			name:   "Removed at start",
+			before: "zero\none",
+			after:  "one",
+			want: []helpers.DiffLine{
+				{Op: helpers.DiffRemoved, Text: "zero"},
+				{Op: helpers.DiffEqual, Text: "one"},
+			},
+		},
+		{
+			name:   "From empty",
+			before: "",
+			after:  "one",
+			want: []helpers.DiffLine{
+				{Op: helpers.DiffAdded, Text: "one"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, helpers.DiffLines(tt.before, tt.after))
+		})
+	}
+}
+
+func TestDiffChanged(t *testing.T) {
+	assert.False(t, helpers.DiffChanged(helpers.DiffLines("a\nb", "a\nb")))
+	assert.True(t, helpers.DiffChanged(helpers.DiffLines("a\nb", "a\nc")))
+}
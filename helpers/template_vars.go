@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// TemplateVars maps variable names, such as team.name, to their values.
+type TemplateVars map[string]string
+
+// TemplateVarNames lists every variable authors may use in block content.
+var TemplateVarNames = []string{
+	"team.name",
+	"team.code",
+	"team.points",
+	"location.name",
+	"instance.name",
+	"instance.end_time",
+	"locations.visited",
+	"locations.remaining",
+}
+
+// templateVarPattern matches {{name}}, allowing spaces inside the braces.
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.]+)\s*\}\}`)
+
+// ExpandTemplateVars replaces known variables in plain text s with their
+// values. Unknown variables, and known variables without a value, are left as
+// written.
+func ExpandTemplateVars(s string, vars TemplateVars) string {
+	return expandTemplateVars(s, vars, func(v string) string { return v })
+}
+
+// ExpandTemplateVarsMarkdown replaces known variables in markdown s with their
+// values. Values are escaped so a team name cannot inject markdown or HTML.
+func ExpandTemplateVarsMarkdown(s string, vars TemplateVars) string {
+	return expandTemplateVars(s, vars, escapeMarkdown)
+}
+
+func expandTemplateVars(s string, vars TemplateVars, escape func(string) string) string {
+	if len(vars) == 0 || !strings.Contains(s, "{{") {
+		return s
+	}
+	return templateVarPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := templateVarPattern.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok || !slices.Contains(TemplateVarNames, name) {
+			return match
+		}
+		return escape(value)
+	})
+}
+
+// UnknownTemplateVars returns the variables in s that are not supported, in
+// the order they first appear.
+func UnknownTemplateVars(s string) []string {
+	var unknown []string
+	for _, match := range templateVarPattern.FindAllStringSubmatch(s, -1) {
+		name := match[1]
+		if !slices.Contains(TemplateVarNames, name) && !slices.Contains(unknown, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// escapeMarkdown backslash-escapes ASCII punctuation so the value renders as
+// plain text.
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < 128 && strings.ContainsRune("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
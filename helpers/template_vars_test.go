@@ -0,0 +1,64 @@
+package helpers_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/helpers"
+)
+
+func TestExpandTemplateVars(t *testing.T) {
+	vars := helpers.TemplateVars{
+		"team.name":     "Kiwis",
+		"team.points":   "42",
+		"location.name": "Library",
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "No variables", input: "Hello world", want: "Hello world"},
+		{name: "Known variable", input: "Hello {{team.name}}!", want: "Hello Kiwis!"},
+		{name: "Spaces inside braces", input: "{{ team.points }} points", want: "42 points"},
+		{name: "Several variables", input: "{{team.name}} at {{location.name}}", want: "Kiwis at Library"},
+		{name: "Unknown variable", input: "Hello {{team.colour}}", want: "Hello {{team.colour}}"},
+		{name: "Known variable without value", input: "Ends {{instance.end_time}}", want: "Ends {{instance.end_time}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := helpers.ExpandTemplateVars(tt.input, vars); got != tt.want {
+				t.Errorf("ExpandTemplateVars() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandTemplateVarsMarkdown(t *testing.T) {
+	vars := helpers.TemplateVars{"team.name": "<script>alert(1)</script> [link](javascript:alert(1))"}
+
+	md := helpers.ExpandTemplateVarsMarkdown("Hello **{{team.name}}**", vars)
+	html, err := helpers.MarkdownToHTML(md, nil)
+	if err != nil {
+		t.Fatalf("MarkdownToHTML() error = %v", err)
+	}
+	if strings.Contains(string(html), "<script") || strings.Contains(string(html), "<a ") {
+		t.Errorf("team name was rendered as markup: %s", html)
+	}
+	if !strings.Contains(string(html), "[link]") {
+		t.Errorf("team name was not rendered as text: %s", html)
+	}
+}
+
+func TestUnknownTemplateVars(t *testing.T) {
+	got := helpers.UnknownTemplateVars("{{team.name}} {{ team.colour }} {{oops}} {{team.colour}}")
+	want := []string{"team.colour", "oops"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("UnknownTemplateVars() = %v, want %v", got, want)
+	}
+
+	if got := helpers.UnknownTemplateVars("{{team.name}} and {{locations.remaining}}"); len(got) != 0 {
+		t.Errorf("UnknownTemplateVars() = %v, want none", got)
+	}
+}
@@ -11,6 +11,8 @@ const (
 	TeamKey    ContextKey = "team"
 	PreviewKey ContextKey = "preview"
 	StatusKey  ContextKey = "status"
+	VarsKey    ContextKey = "template_vars"
+	APIKeyKey  ContextKey = "api_key"
 )
 
 // UserStatus represents the current status of the application.
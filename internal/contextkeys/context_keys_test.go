@@ -96,6 +96,7 @@ func TestContextKeyUniqueness(t *testing.T) {
 		contextkeys.TeamKey,
 		contextkeys.PreviewKey,
 		contextkeys.StatusKey,
+		contextkeys.APIKeyKey,
 	}
 
 	// Check for duplicates
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/blocks"
 	"github.com/nathanhollows/Rapua/v6/internal/services"
 	templates "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
 	"github.com/nathanhollows/Rapua/v6/models"
@@ -94,8 +95,27 @@ func (h *Handler) ActivityTeamsOverview(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = templates.ActivityTeamsTable(user.CurrentInstance.Settings, len(user.CurrentInstance.Locations), leaderboardData, sortField, sortOrder).
-		Render(r.Context(), w)
+	unread, err := h.messageService.UnreadByTeam(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.handleError(
+			w,
+			r,
+			"ActivityTeamsOverview: counting unread messages",
+			"Error counting unread messages",
+			"Could not load data",
+			err,
+		)
+		return
+	}
+
+	err = templates.ActivityTeamsTable(
+		user.CurrentInstance.Settings,
+		len(user.CurrentInstance.Locations),
+		leaderboardData,
+		unread,
+		sortField,
+		sortOrder,
+	).Render(r.Context(), w)
 	if err != nil {
 		h.logger.Error("ActivityTeamsOverview: rendering template", "error", err)
 	}
@@ -153,6 +173,46 @@ func (h *Handler) ActivityLocations(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ActivityPolls returns the results of every poll in the current instance.
+func (h *Handler) ActivityPolls(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	var polls []templates.PollSummary
+	for _, location := range user.CurrentInstance.Locations {
+		contentBlocks, err := h.blockService.FindByOwnerIDAndContext(
+			r.Context(),
+			location.ID,
+			blocks.ContextLocationContent,
+		)
+		if err != nil {
+			h.logger.Error("ActivityPolls: finding blocks", "error", err, "location", location.ID)
+			return
+		}
+
+		for _, block := range contentBlocks {
+			poll, ok := block.(*blocks.PollBlock)
+			if !ok {
+				continue
+			}
+			results, err := h.blockService.GetPollResults(r.Context(), poll)
+			if err != nil {
+				h.logger.Error("ActivityPolls: getting poll results", "error", err, "block", poll.ID)
+				return
+			}
+			polls = append(polls, templates.PollSummary{
+				Location: location,
+				Poll:     *poll,
+				Results:  results,
+			})
+		}
+	}
+
+	err := templates.ActivityPolls(polls).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("ActivityPolls: rendering template", "error", err)
+	}
+}
+
 // TeamActivity displays the activity tracker page.
 // It accepts HTMX requests to update the team activity.
 func (h *Handler) TeamActivity(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,214 @@
+package admin
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	admin "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	templates "github.com/nathanhollows/Rapua/v6/internal/templates/blocks"
+)
+
+// BlockLibrary shows the user's saved blocks.
+// GET /admin/library.
+func (h *Handler) BlockLibrary(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	library, err := h.blockLibraryService.List(r.Context(), user.ID)
+	if err != nil {
+		h.handleError(w, r, "BlockLibrary: listing blocks", "Could not load block library", "error", err)
+		return
+	}
+
+	c := admin.BlockLibrary(user.CurrentInstance.Settings, library)
+	err = admin.Layout(c, *user, "Block Library", "Block Library").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("BlockLibrary: rendering template", "error", err)
+	}
+}
+
+// BlockLibrarySave saves a copy of a block to the user's library. The title
+// comes from the htmx prompt.
+// POST /admin/library.
+func (h *Handler) BlockLibrarySave(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	blockID := r.FormValue("block_id")
+	access, err := h.accessService.CanAdminAccessBlock(r.Context(), user.ID, blockID)
+	if err != nil || !access {
+		h.handleError(
+			w,
+			r,
+			"BlockLibrarySave: checking access",
+			"Could not save block. Access denied",
+			"error",
+			err,
+			"blockID",
+			blockID,
+		)
+		return
+	}
+
+	block, err := h.blockService.GetByBlockID(r.Context(), blockID)
+	if err != nil {
+		h.handleError(w, r, "BlockLibrarySave: getting block", "Could not save block", "error", err)
+		return
+	}
+
+	_, err = h.blockLibraryService.Save(r.Context(), user.ID, block, r.Header.Get("Hx-Prompt"))
+	if err != nil {
+		h.handleError(w, r, "BlockLibrarySave: saving block", "Could not save block", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "Saved to your block library")
+}
+
+// BlockLibraryRename renames a library block.
+// PUT /admin/library/{id}.
+func (h *Handler) BlockLibraryRename(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.blockLibraryService.Rename(r.Context(), user.ID, chi.URLParam(r, "id"), r.FormValue("title"))
+	if err != nil {
+		h.handleError(w, r, "BlockLibraryRename: renaming block", "Could not rename block", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "Block renamed")
+}
+
+// BlockLibraryDelete removes a block from the library. Linked copies are kept.
+// DELETE /admin/library/{id}.
+func (h *Handler) BlockLibraryDelete(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.blockLibraryService.Delete(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.handleError(w, r, "BlockLibraryDelete: deleting block", "Could not delete block", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "Block removed from your library")
+}
+
+// BlockLibraryPicker lists the library blocks that can be added to an owner.
+// GET /admin/library/picker?owner={uuid}&context={context}&target={selector}.
+func (h *Handler) BlockLibraryPicker(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	ownerID := r.URL.Query().Get("owner")
+	blockContext := blocks.BlockContext(r.URL.Query().Get("context"))
+	access, err := h.accessService.CanAdminAccessBlockOwner(r.Context(), user.ID, ownerID, blockContext)
+	if err != nil || !access {
+		h.logger.Error("BlockLibraryPicker: checking access", "error", err, "owner", ownerID)
+		return
+	}
+
+	library, err := h.blockLibraryService.ListForContext(r.Context(), user.ID, blockContext)
+	if err != nil {
+		h.logger.Error("BlockLibraryPicker: listing blocks", "error", err)
+		return
+	}
+
+	err = admin.BlockLibraryPicker(library, ownerID, blockContext, r.URL.Query().Get("target")).
+		Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("BlockLibraryPicker: rendering template", "error", err)
+	}
+}
+
+// BlockLibraryInsert adds a copy of a library block to an owner.
+// POST /admin/library/{id}/insert.
+func (h *Handler) BlockLibraryInsert(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	ownerID := r.FormValue("owner")
+	blockContext := blocks.BlockContext(r.FormValue("context"))
+	validContexts := []blocks.BlockContext{
+		blocks.ContextLocationContent,
+		blocks.ContextLocationClues,
+		blocks.ContextCheckpoint,
+		blocks.ContextStart,
+		blocks.ContextFinish,
+		blocks.ContextTask,
+	}
+	if !slices.Contains(validContexts, blockContext) {
+		h.handleError(w, r, "BlockLibraryInsert: invalid context", "Invalid context parameter", "context", blockContext)
+		return
+	}
+
+	access, err := h.accessService.CanAdminAccessBlockOwner(r.Context(), user.ID, ownerID, blockContext)
+	if err != nil || !access {
+		h.handleError(
+			w,
+			r,
+			"BlockLibraryInsert: checking access",
+			"Could not add block. Access denied",
+			"error",
+			err,
+			"owner",
+			ownerID,
+		)
+		return
+	}
+
+	block, err := h.blockLibraryService.Insert(
+		r.Context(),
+		user.ID,
+		chi.URLParam(r, "id"),
+		ownerID,
+		blockContext,
+		r.FormValue("linked") == "true",
+	)
+	if err != nil {
+		h.handleError(w, r, "BlockLibraryInsert: inserting block", "Could not add block", "error", err)
+		return
+	}
+
+	err = templates.RenderAdminBlock(user.CurrentInstance.Settings, block, blockContext, true).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("BlockLibraryInsert: rendering template", "error", err)
+	}
+}
+
+// BlockUnlink stops a block from following its library block.
+// POST /admin/blocks/{id}/unlink.
+func (h *Handler) BlockUnlink(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	blockID := chi.URLParam(r, "id")
+	access, err := h.accessService.CanAdminAccessBlock(r.Context(), user.ID, blockID)
+	if err != nil || !access {
+		h.handleError(
+			w,
+			r,
+			"BlockUnlink: checking access",
+			"Could not unlink block. Access denied",
+			"error",
+			err,
+			"blockID",
+			blockID,
+		)
+		return
+	}
+
+	block, err := h.blockService.GetByBlockID(r.Context(), blockID)
+	if err != nil {
+		h.handleError(w, r, "BlockUnlink: getting block", "Could not unlink block", "error", err)
+		return
+	}
+
+	block, err = h.blockLibraryService.Unlink(r.Context(), block)
+	if err != nil {
+		h.handleError(w, r, "BlockUnlink: unlinking block", "Could not unlink block", "error", err)
+		return
+	}
+
+	err = templates.LibraryLinkBadge(block, true).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("BlockUnlink: rendering template", "error", err)
+	}
+	h.handleSuccess(w, r, "Block unlinked from the library")
+}
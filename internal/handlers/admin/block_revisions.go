@@ -0,0 +1,116 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	admin "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	templates "github.com/nathanhollows/Rapua/v6/internal/templates/blocks"
+)
+
+// BlockRevisions lists the saved versions of a block.
+// GET /admin/blocks/{id}/revisions.
+func (h *Handler) BlockRevisions(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	blockID := chi.URLParam(r, "id")
+	access, err := h.accessService.CanAdminAccessBlock(r.Context(), user.ID, blockID)
+	if err != nil || !access {
+		h.handleError(
+			w,
+			r,
+			"BlockRevisions: checking access",
+			"Could not load history",
+			"error",
+			err,
+			"blockID",
+			blockID,
+		)
+		return
+	}
+
+	revisions, err := h.blockRevisionService.List(r.Context(), blockID)
+	if err != nil {
+		h.handleError(w, r, "BlockRevisions: listing revisions", "Could not load history", "error", err)
+		return
+	}
+
+	err = admin.BlockRevisions(revisions).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("BlockRevisions: rendering template", "error", err)
+	}
+}
+
+// BlockDeletedList lists the blocks deleted from an owner that can be
+// restored.
+// GET /admin/blocks/deleted?owner={uuid}&context={context}&target={selector}.
+func (h *Handler) BlockDeletedList(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	ownerID := r.URL.Query().Get("owner")
+	blockContext := blocks.BlockContext(r.URL.Query().Get("context"))
+	access, err := h.accessService.CanAdminAccessBlockOwner(r.Context(), user.ID, ownerID, blockContext)
+	if err != nil || !access {
+		h.logger.Error("BlockDeletedList: checking access", "error", err, "owner", ownerID)
+		return
+	}
+
+	deleted, err := h.blockRevisionService.ListDeleted(r.Context(), ownerID, blockContext)
+	if err != nil {
+		h.logger.Error("BlockDeletedList: listing deleted blocks", "error", err)
+		return
+	}
+
+	err = admin.DeletedBlocks(deleted, r.URL.Query().Get("target")).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("BlockDeletedList: rendering template", "error", err)
+	}
+}
+
+// BlockRevisionRestore rolls a block back to a revision, or restores a
+// deleted block.
+// POST /admin/blocks/revisions/{id}/restore.
+func (h *Handler) BlockRevisionRestore(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	revision, err := h.blockRevisionService.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.handleError(w, r, "BlockRevisionRestore: getting revision", "Could not restore block", "error", err)
+		return
+	}
+
+	access, err := h.accessService.CanAdminAccessBlockOwner(r.Context(), user.ID, revision.OwnerID, revision.Context)
+	if err != nil || !access {
+		h.handleError(
+			w,
+			r,
+			"BlockRevisionRestore: checking access",
+			"Could not restore block. Access denied",
+			"error",
+			err,
+			"revisionID",
+			revision.ID,
+		)
+		return
+	}
+
+	block, err := h.blockRevisionService.Restore(r.Context(), user.ID, revision.ID)
+	if err != nil {
+		h.handleError(w, r, "BlockRevisionRestore: restoring block", "Could not restore block", "error", err)
+		return
+	}
+
+	// Restoring a library block updates its linked copies too
+	_, err = h.blockLibraryService.SyncLinked(r.Context(), user.ID, block)
+	if err != nil {
+		h.logger.Error("BlockRevisionRestore: updating linked blocks", "error", err, "blockID", block.GetID())
+	}
+
+	err = templates.RenderAdminBlock(user.CurrentInstance.Settings, block, revision.Context, true).
+		Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("BlockRevisionRestore: rendering template", "error", err)
+	}
+	h.handleSuccess(w, r, "Block restored")
+}
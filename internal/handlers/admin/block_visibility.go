@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"maps"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	templates "github.com/nathanhollows/Rapua/v6/internal/templates/blocks"
+	"github.com/nathanhollows/Rapua/v6/navigation"
+)
+
+// BlockVisibilityGet shows the form to edit when a block is shown to teams.
+// GET /admin/blocks/{id}/visibility.
+func (h *Handler) BlockVisibilityGet(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	blockID := chi.URLParam(r, "id")
+	access, err := h.accessService.CanAdminAccessBlock(r.Context(), user.ID, blockID)
+	if err != nil || !access {
+		h.handleError(
+			w,
+			r,
+			"BlockVisibilityGet: checking access",
+			"Could not load block",
+			"error",
+			err,
+			"blockID",
+			blockID,
+		)
+		return
+	}
+
+	block, err := h.blockService.GetByBlockID(r.Context(), blockID)
+	if err != nil {
+		h.handleError(w, r, "BlockVisibilityGet: getting block", "Could not load block", "error", err)
+		return
+	}
+
+	siblings, err := h.blockService.FindByOwnerIDAndContext(
+		r.Context(),
+		block.GetLocationID(),
+		blocks.ContextLocationContent,
+	)
+	if err != nil {
+		h.handleError(w, r, "BlockVisibilityGet: finding blocks", "Could not load block", "error", err)
+		return
+	}
+
+	err = templates.VisibilityForm(user.CurrentInstance, block, siblings).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("BlockVisibilityGet: rendering template", "error", err)
+	}
+}
+
+// BlockVisibilityUpdate saves when a block is shown to teams.
+// PUT /admin/blocks/{id}/visibility.
+func (h *Handler) BlockVisibilityUpdate(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	blockID := chi.URLParam(r, "id")
+	access, err := h.accessService.CanAdminAccessBlock(r.Context(), user.ID, blockID)
+	if err != nil || !access {
+		h.handleError(
+			w,
+			r,
+			"BlockVisibilityUpdate: checking access",
+			"Could not update block",
+			"error",
+			err,
+			"blockID",
+			blockID,
+		)
+		return
+	}
+
+	block, err := h.blockService.GetByBlockID(r.Context(), blockID)
+	if err != nil {
+		h.handleError(w, r, "BlockVisibilityUpdate: getting block", "Could not update block", "error", err)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		h.handleError(w, r, "BlockVisibilityUpdate: parsing form", "Could not update block", "error", err)
+		return
+	}
+
+	data := make(map[string][]string)
+	maps.Copy(data, r.PostForm)
+
+	visibility, err := blocks.ParseVisibility(data)
+	if err != nil {
+		h.handleError(w, r, "BlockVisibilityUpdate: parsing visibility", err.Error(), "error", err)
+		return
+	}
+
+	// The block it depends on must be on the same page
+	if visibility.AfterBlockID != "" {
+		siblings, findErr := h.blockService.FindByOwnerIDAndContext(
+			r.Context(),
+			block.GetLocationID(),
+			blocks.ContextLocationContent,
+		)
+		if findErr != nil {
+			h.handleError(w, r, "BlockVisibilityUpdate: finding blocks", "Could not update block", "error", findErr)
+			return
+		}
+		found := false
+		for _, sibling := range siblings {
+			found = found || sibling.GetID() == visibility.AfterBlockID
+		}
+		if !found {
+			h.handleError(
+				w,
+				r,
+				"BlockVisibilityUpdate: unknown block",
+				"Choose a block from this location",
+				"blockID",
+				visibility.AfterBlockID,
+			)
+			return
+		}
+	}
+
+	// The group must be one of the game's
+	if visibility.GroupID != "" &&
+		navigation.FindGroupByID(&user.CurrentInstance.GameStructure, visibility.GroupID) == nil {
+		h.handleError(
+			w,
+			r,
+			"BlockVisibilityUpdate: unknown group",
+			"Choose a group from this game",
+			"groupID",
+			visibility.GroupID,
+		)
+		return
+	}
+
+	_, err = h.blockService.UpdateVisibility(r.Context(), block, visibility)
+	if err != nil {
+		h.handleError(w, r, "BlockVisibilityUpdate: updating block", "Could not update block", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "Visibility updated")
+}
@@ -123,7 +123,7 @@ func (h *Handler) BlockCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = templates.RenderAdminBlock(user.CurrentInstance.Settings, block, true).Render(r.Context(), w)
+	err = templates.RenderAdminBlock(user.CurrentInstance.Settings, block, blockContext, true).Render(r.Context(), w)
 	if err != nil {
 		h.logger.Error("BlockCreate: rendering template", "error", err)
 	}
@@ -165,7 +165,7 @@ func (h *Handler) BlockNewWithOwnerAndContextPost(w http.ResponseWriter, r *http
 		return
 	}
 
-	err = templates.RenderAdminBlock(user.CurrentInstance.Settings, block, true).Render(r.Context(), w)
+	err = templates.RenderAdminBlock(user.CurrentInstance.Settings, block, blockContext, true).Render(r.Context(), w)
 	if err != nil {
 		h.logger.Error("BlockNewWithOwnerAndContextPost: rendering template", "error", err)
 	}
@@ -265,13 +265,54 @@ func (h *Handler) BlockUpdate(w http.ResponseWriter, r *http.Request) {
 	data := make(map[string][]string)
 	maps.Copy(data, r.PostForm)
 
-	_, err = h.blockService.UpdateBlock(r.Context(), block, data)
+	// Keep the content from before the first tracked edit
+	err = h.blockRevisionService.RecordOriginal(r.Context(), blockID)
+	if err != nil {
+		h.handleError(w, r, "BlockUpdate: saving original revision", "Could not update block", "error", err)
+		return
+	}
+
+	wasLinked := block.GetLibraryID() != ""
+	block, err = h.blockService.UpdateBlock(r.Context(), block, data)
 	if err != nil {
 		h.handleError(w, r, "BlockUpdate: updating block", "Could not update block", "error", err)
 		return
 	}
 
-	h.handleSuccess(w, r, "Block updated")
+	err = h.blockRevisionService.RecordEdit(r.Context(), user.ID, blockID)
+	if err != nil {
+		h.logger.Error("BlockUpdate: saving revision", "error", err, "blockID", blockID)
+	}
+
+	// Library blocks push their changes to linked copies
+	synced, err := h.blockLibraryService.SyncLinked(r.Context(), user.ID, block)
+	if err != nil {
+		h.handleError(w, r, "BlockUpdate: updating linked blocks", "Could not update linked copies", "error", err)
+		return
+	}
+
+	// Refresh the unknown variable warning in the editor
+	if _, ok := templates.TemplateVarsText(block); ok {
+		err = templates.TemplateVarsWarning(block, true).Render(r.Context(), w)
+		if err != nil {
+			h.logger.Error("BlockUpdate: rendering variables warning", "error", err)
+		}
+	}
+
+	switch {
+	case wasLinked:
+		err = templates.LibraryLinkBadge(block, true).Render(r.Context(), w)
+		if err != nil {
+			h.logger.Error("BlockUpdate: rendering library badge", "error", err)
+		}
+		h.handleSuccess(w, r, "Block updated and unlinked from the library")
+	case synced == 1:
+		h.handleSuccess(w, r, "Block updated. 1 linked copy updated")
+	case synced > 1:
+		h.handleSuccess(w, r, fmt.Sprintf("Block updated. %d linked copies updated", synced))
+	default:
+		h.handleSuccess(w, r, "Block updated")
+	}
 }
 
 // BlockDelete deletes a block by ID.
@@ -308,7 +349,7 @@ func (h *Handler) BlockDelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.deleteService.DeleteBlock(r.Context(), block.GetID())
+	err = h.deleteService.DeleteBlock(r.Context(), user.ID, block.GetID())
 	if err != nil {
 		h.handleError(w, r, "BlockDeleteRESTful: deleting block", "Could not delete block", "error", err)
 		return
@@ -17,6 +17,11 @@ import (
 // StripeService provides Stripe-related operations.
 type StripeService interface {
 	CreateCheckoutSession(ctx context.Context, userID string, credits int) (*stripe.CheckoutSession, error)
+	CreateOrganisationCheckoutSession(
+		ctx context.Context,
+		userID, organisationID string,
+		credits int,
+	) (*stripe.CheckoutSession, error)
 	ProcessWebhook(ctx context.Context, payload []byte, signature string) error
 }
 
@@ -49,9 +54,19 @@ func (h *Handler) CreateCheckoutSession(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Create Stripe checkout session
-	session, err := h.stripeService.CreateCheckoutSession(r.Context(), user.ID, credits)
+	// Create Stripe checkout session, for an organisation's pool if one is given
+	var session *stripe.CheckoutSession
+	if organisationID := r.FormValue("organisation_id"); organisationID != "" {
+		session, err = h.stripeService.CreateOrganisationCheckoutSession(r.Context(), user.ID, organisationID, credits)
+	} else {
+		session, err = h.stripeService.CreateCheckoutSession(r.Context(), user.ID, credits)
+	}
 	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			h.handleError(w, r, "CreateCheckoutSession: not an owner",
+				"Only owners can buy credits for an organisation", err)
+			return
+		}
 		if errors.Is(err, services.ErrStripeNotConfigured) {
 			h.handleError(w, r, "CreateCheckoutSession: Stripe not configured",
 				"Credit purchases are not currently available", err)
@@ -1,12 +1,15 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/nathanhollows/Rapua/v6/helpers"
 	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
 	templates "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
 	public "github.com/nathanhollows/Rapua/v6/internal/templates/public"
 	"github.com/nathanhollows/Rapua/v6/models"
@@ -20,7 +23,15 @@ const (
 
 // FacilitatorShowModal renders the modal for creating a facilitator token.
 func (h *Handler) FacilitatorShowModal(w http.ResponseWriter, r *http.Request) {
-	err := templates.FacilitatorLinkModal().Render(r.Context(), w)
+	user := h.UserFromContext(r.Context())
+
+	locations, err := h.locationService.FindByInstance(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.handleError(w, r, "fetching locations", "Error fetching locations", "error", err)
+		return
+	}
+
+	err = templates.FacilitatorLinkModal(locations).Render(r.Context(), w)
 	if err != nil {
 		h.handleError(w, r, "rendering template", "Error rendering template", "error", err)
 	}
@@ -50,12 +61,26 @@ func (h *Handler) FacilitatorCreateTokenLink(w http.ResponseWriter, r *http.Requ
 		duration = hoursPerDay * time.Hour
 	}
 
+	// Only keep locations from the current game
+	gameLocations, err := h.locationService.FindByInstance(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.handleError(w, r, "fetching locations", "Error fetching locations", "error", err)
+		return
+	}
 	var locations []string
-	if r.Form.Get("locations") != "" {
-		locations = append(locations, r.Form.Get("locations"))
+	for _, location := range gameLocations {
+		if slices.Contains(r.Form["locations"], location.ID) {
+			locations = append(locations, location.ID)
+		}
 	}
 
-	token, err := h.facilitatorService.CreateFacilitatorToken(r.Context(), user.CurrentInstanceID, locations, duration)
+	token, err := h.facilitatorService.CreateFacilitatorToken(
+		r.Context(),
+		user.CurrentInstanceID,
+		r.Form.Get("label"),
+		locations,
+		duration,
+	)
 	if err != nil {
 		h.handleError(w, r, "creating facilitator token", "Error creating facilitator token")
 		return
@@ -69,6 +94,47 @@ func (h *Handler) FacilitatorCreateTokenLink(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// FacilitatorLinks lists the current game's facilitator links so they can
+// be revoked.
+// GET /admin/facilitator.
+func (h *Handler) FacilitatorLinks(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	tokens, err := h.facilitatorService.List(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.handleError(w, r, "FacilitatorLinks: listing links", "Could not load facilitator links", "error", err)
+		return
+	}
+	locations, err := h.locationService.FindByInstance(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.handleError(w, r, "FacilitatorLinks: fetching locations", "Could not load facilitator links", "error", err)
+		return
+	}
+
+	c := templates.FacilitatorLinks(tokens, locations)
+	err = templates.Layout(c, *user, "Activity", "Facilitator links").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("FacilitatorLinks: rendering template", "error", err)
+	}
+}
+
+// FacilitatorLinkRevoke revokes a facilitator link. Anyone using it is
+// logged out on their next request.
+// DELETE /admin/facilitator/links/{id}.
+func (h *Handler) FacilitatorLinkRevoke(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.facilitatorService.Revoke(r.Context(), user.CurrentInstanceID, chi.URLParam(r, "id"))
+	if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "FacilitatorLinkRevoke: revoking link", "Could not revoke the link",
+			"error", err, "instance_id", user.CurrentInstanceID)
+		return
+	}
+
+	h.handleSuccess(w, r, "Link revoked")
+}
+
 const facilitatorSessionCookie = "rapua_facilitator"
 
 // FacilitatorLogin accepts a token and creates a session cookie.
@@ -120,47 +186,195 @@ func (h *Handler) FacilitatorDashboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Fetch locations
-	locations, err := h.locationService.FindByInstance(r.Context(), facToken.InstanceID)
+	stations, err := h.facilitatorService.Stations(r.Context(), facToken)
 	if err != nil {
-		h.handleError(w, r, "fetching locations", "Error fetching locations", "error", err)
+		h.handleError(w, r, "fetching facilitator stations", "Error fetching locations", "error", err)
+		return
+	}
+
+	authed := contextkeys.GetUserStatus(r.Context()).IsAdminLoggedIn
+	c := templates.FacilitatorDashboard(stations)
+	err = public.AuthLayout(c, "Facilitator Dashboard", authed).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("Activity: rendering template", "error", err)
+	}
+}
+
+// FacilitatorStations renders the facilitator's locations so the dashboard
+// can refresh them in place.
+func (h *Handler) FacilitatorStations(w http.ResponseWriter, r *http.Request) {
+	facToken, ok := h.facilitatorToken(w, r)
+	if !ok {
 		return
 	}
+	h.renderFacilitatorStations(w, r, facToken)
+}
 
-	filteredLocations := h.filterLocationsForToken(locations, facToken)
+// FacilitatorCheckIn checks a team in at one of the facilitator's locations.
+func (h *Handler) FacilitatorCheckIn(w http.ResponseWriter, r *http.Request) {
+	facToken, ok := h.facilitatorToken(w, r)
+	if !ok {
+		return
+	}
 
-	// Team activity overview
-	overview, err := h.teamService.GetTeamActivityOverview(r.Context(), facToken.InstanceID, filteredLocations)
+	teamCode := chi.URLParam(r, "teamCode")
+	err := h.facilitatorService.CheckIn(r.Context(), facToken, chi.URLParam(r, "locationID"), teamCode)
 	if err != nil {
-		h.handleError(w, r, "fetching team activity overview", "Error fetching team activity overview", "error", err)
+		h.facilitatorActionError(w, r, "checking team in", "Error checking team in", err)
 		return
 	}
 
-	authed := contextkeys.GetUserStatus(r.Context()).IsAdminLoggedIn
-	c := templates.FacilitatorDashboard(locations, overview)
-	err = public.AuthLayout(c, "Facilitator Dashboard", authed).Render(r.Context(), w)
+	h.renderFacilitatorStations(w, r, facToken)
+	h.handleSuccess(w, r, "Checked in "+teamCode)
+}
+
+// FacilitatorCheckOut checks a team out of one of the facilitator's locations.
+func (h *Handler) FacilitatorCheckOut(w http.ResponseWriter, r *http.Request) {
+	facToken, ok := h.facilitatorToken(w, r)
+	if !ok {
+		return
+	}
+
+	teamCode := chi.URLParam(r, "teamCode")
+	err := h.facilitatorService.CheckOut(r.Context(), facToken, chi.URLParam(r, "locationID"), teamCode)
 	if err != nil {
-		h.logger.Error("Activity: rendering template", "error", err)
+		h.facilitatorActionError(w, r, "checking team out", "Error checking team out", err)
+		return
+	}
+
+	h.renderFacilitatorStations(w, r, facToken)
+	h.handleSuccess(w, r, "Checked out "+teamCode)
+}
+
+// FacilitatorApproveBlock completes a task for a team at one of the
+// facilitator's locations.
+func (h *Handler) FacilitatorApproveBlock(w http.ResponseWriter, r *http.Request) {
+	facToken, ok := h.facilitatorToken(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.facilitatorService.ApproveBlock(
+		r.Context(),
+		facToken,
+		chi.URLParam(r, "locationID"),
+		chi.URLParam(r, "teamCode"),
+		chi.URLParam(r, "blockID"),
+	)
+	if err != nil {
+		h.facilitatorActionError(w, r, "approving block", "Error approving task", err)
+		return
+	}
+
+	h.renderFacilitatorStations(w, r, facToken)
+	h.handleSuccess(w, r, "Task approved")
+}
+
+// FacilitatorMessages shows a facilitator a team's conversation with the
+// organisers.
+func (h *Handler) FacilitatorMessages(w http.ResponseWriter, r *http.Request) {
+	facToken, ok := h.facilitatorToken(w, r)
+	if !ok {
+		return
+	}
+
+	team, messages, err := h.facilitatorService.Messages(r.Context(), facToken, chi.URLParam(r, "teamCode"))
+	if err != nil {
+		h.facilitatorActionError(w, r, "fetching messages", "Error loading messages", err)
+		return
+	}
+
+	err = templates.FacilitatorMessages(*team, messages).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("rendering facilitator messages", "error", err)
+	}
+}
+
+// FacilitatorMessageReply sends a facilitator's reply to a team.
+func (h *Handler) FacilitatorMessageReply(w http.ResponseWriter, r *http.Request) {
+	facToken, ok := h.facilitatorToken(w, r)
+	if !ok {
+		return
+	}
+
+	teamCode := chi.URLParam(r, "teamCode")
+	err := h.facilitatorService.Reply(r.Context(), facToken, teamCode, r.FormValue("content"))
+	if err != nil {
+		h.facilitatorActionError(w, r, "sending message", "Error sending message", err)
+		return
+	}
+
+	_, messages, err := h.facilitatorService.Messages(r.Context(), facToken, teamCode)
+	if err != nil {
+		h.facilitatorActionError(w, r, "fetching messages", "Error loading messages", err)
+		return
+	}
+
+	err = templates.TeamMessagesList(messages).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("rendering facilitator messages", "error", err)
 	}
 }
 
-// filterLocationsForToken filters locations based on the facilitator token permissions.
-func (h *Handler) filterLocationsForToken(
-	locations []models.Location,
-	token *models.FacilitatorToken,
-) []models.Location {
-	if len(token.Locations) == 0 {
-		return locations
-	}
-
-	var filtered []models.Location
-	for _, allowedID := range token.Locations {
-		for _, loc := range locations {
-			if loc.ID == allowedID {
-				filtered = append(filtered, loc)
-				break
-			}
+// facilitatorToken returns the facilitator's token from their session cookie.
+// It writes an error and returns false if they no longer have a valid one.
+func (h *Handler) facilitatorToken(w http.ResponseWriter, r *http.Request) (*models.FacilitatorToken, bool) {
+	token, err := r.Cookie(facilitatorSessionCookie)
+	if err == nil {
+		var facToken *models.FacilitatorToken
+		facToken, err = h.facilitatorService.ValidateToken(r.Context(), token.Value)
+		if err == nil {
+			return facToken, true
 		}
 	}
-	return filtered
+	w.Header().Set("Hx-Reswap", "none")
+	h.handleError(
+		w,
+		r,
+		"facilitator session expired",
+		"Your session has expired. Please ask for another login link.",
+		"error", err,
+	)
+	return nil, false
+}
+
+// facilitatorActionError explains why a facilitator's action didn't work.
+func (h *Handler) facilitatorActionError(w http.ResponseWriter, r *http.Request, logMsg, flashMsg string, err error) {
+	w.Header().Set("Hx-Reswap", "none")
+	switch {
+	case errors.Is(err, services.ErrPermissionDenied):
+		flashMsg = "Your link doesn't include that location or team"
+	case errors.Is(err, services.ErrLocationNotFound), errors.Is(err, services.ErrTeamNotFound):
+		flashMsg = "That team or location could not be found. Try refreshing the page."
+	case errors.Is(err, services.ErrAlreadyCheckedIn):
+		flashMsg = "That team is already checked in somewhere"
+	case errors.Is(err, services.ErrUnfinishedCheckIn):
+		flashMsg = "Approve the team's remaining tasks before checking them out"
+	case errors.Is(err, services.ErrUnecessaryCheckOut), errors.Is(err, services.ErrCheckOutAtWrongLocation):
+		flashMsg = "That team is not checked in here"
+	case errors.Is(err, services.ErrMessageEmpty):
+		flashMsg = "Write a message to send"
+	case errors.Is(err, services.ErrMessageTooLong):
+		flashMsg = "That message is too long"
+	}
+	h.handleError(w, r, logMsg, flashMsg, "error", err)
+}
+
+// renderFacilitatorStations renders the token's locations.
+func (h *Handler) renderFacilitatorStations(
+	w http.ResponseWriter,
+	r *http.Request,
+	facToken *models.FacilitatorToken,
+) {
+	stations, err := h.facilitatorService.Stations(r.Context(), facToken)
+	if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "fetching facilitator stations", "Error fetching locations", "error", err)
+		return
+	}
+
+	err = templates.FacilitatorStations(stations).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("rendering facilitator stations", "error", err)
+	}
 }
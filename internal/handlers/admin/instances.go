@@ -29,7 +29,13 @@ func (h *Handler) Instances(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c := templates.Instances(user.Instances, user.CurrentInstance, gameTemplates)
+	shared, err := h.instanceService.FindSharedWithUser(r.Context(), user.ID)
+	if err != nil {
+		h.handleError(w, r, "Instances: finding shared games", "Error finding shared games", "error", err)
+		return
+	}
+
+	c := templates.Instances(user.Instances, shared, user.CurrentInstance, gameTemplates)
 	err = templates.Layout(c, *user, "Games and Templates", "Games and Templates").Render(r.Context(), w)
 	if err != nil {
 		h.handleError(
@@ -344,6 +350,13 @@ func (h *Handler) InstancesNameEditPost(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	access, err := h.accessService.CanAdminEditInstance(r.Context(), user.ID, instance.ID)
+	if err != nil || !access {
+		h.handleError(w, r, "InstancesNameEditPost: checking access", "You can't rename this game", "error", err)
+		_ = templates.InstanceName(*instance, instance.ID == user.CurrentInstanceID).Render(r.Context(), w)
+		return
+	}
+
 	if err := r.ParseForm(); err != nil {
 		h.handleError(
 			w,
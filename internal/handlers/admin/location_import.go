@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	templates "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// maxImportSize limits the size of location import files.
+const maxImportSize = 5 << 20 // 5MB
+
+// LocationImport shows the form to import locations from a file.
+// GET /admin/locations/import.
+func (h *Handler) LocationImport(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	c := templates.LocationImport(
+		user.CurrentInstance.Settings,
+		user.CurrentInstance.GameStructure,
+		r.URL.Query().Get("groupId"),
+	)
+	err := templates.Layout(c, *user, "Locations", "Import Locations").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("LocationImport: rendering template", "error", err)
+	}
+}
+
+// LocationImportPreview shows what an import file would create without
+// saving anything.
+// POST /admin/locations/import/preview.
+func (h *Handler) LocationImportPreview(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	rows, ok := h.readLocationImport(w, r, user, "LocationImportPreview")
+	if !ok {
+		return
+	}
+
+	err := templates.LocationImportPreview(user.CurrentInstance.Settings, rows).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("LocationImportPreview: rendering template", "error", err)
+	}
+}
+
+// LocationImportPost creates the locations in an import file.
+// POST /admin/locations/import.
+func (h *Handler) LocationImportPost(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	rows, ok := h.readLocationImport(w, r, user, "LocationImportPost")
+	if !ok {
+		return
+	}
+
+	_, err := h.locationImportService.Import(r.Context(), user.CurrentInstanceID, rows)
+	if err != nil {
+		h.handleError(
+			w,
+			r,
+			"LocationImportPost: importing locations",
+			"Import failed and no locations were created. Please try again",
+			"error",
+			err,
+			"instance_id",
+			user.CurrentInstanceID,
+		)
+		return
+	}
+
+	h.redirect(w, r, "/admin/locations")
+}
+
+// readLocationImport parses the uploaded file and previews its rows. It
+// reports any problem to the user and returns false if the file cannot be
+// used.
+func (h *Handler) readLocationImport(
+	w http.ResponseWriter,
+	r *http.Request,
+	user *models.User,
+	caller string,
+) ([]services.LocationImportRow, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	err := r.ParseMultipartForm(maxImportSize)
+	if err != nil {
+		h.handleError(w, r, caller+": parsing form", "File too large", "error", err)
+		return nil, false
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		h.handleError(w, r, caller+": getting file", "Choose a file to import", "error", err)
+		return nil, false
+	}
+	defer file.Close()
+
+	points := 0
+	if user.CurrentInstance.Settings.EnablePoints && r.FormValue("points") != "" {
+		points, err = strconv.Atoi(r.FormValue("points"))
+		if err != nil {
+			h.handleError(w, r, caller+": converting points", "Points must be a whole number", "error", err)
+			return nil, false
+		}
+	}
+
+	rows, err := h.locationImportService.Preview(
+		&user.CurrentInstance,
+		fileHeader.Filename,
+		file,
+		r.FormValue("groupId"),
+		points,
+	)
+	switch {
+	case errors.Is(err, services.ErrImportFormatUnsupported):
+		h.handleError(w, r, caller+": unsupported file", "Upload a .csv, .gpx or .kml file", "error", err)
+		return nil, false
+	case errors.Is(err, services.ErrImportEmpty):
+		h.handleError(w, r, caller+": empty file", "The file does not contain any locations", "error", err)
+		return nil, false
+	case errors.Is(err, services.ErrImportTooLarge):
+		h.handleError(
+			w,
+			r,
+			caller+": too many rows",
+			"Files can contain up to "+strconv.Itoa(services.MaxLocationImportRows)+" locations",
+			"error",
+			err,
+		)
+		return nil, false
+	case err != nil:
+		h.handleError(
+			w,
+			r,
+			caller+": reading file",
+			"Could not read the file. Please check it is valid",
+			"error",
+			err,
+			"instance_id",
+			user.CurrentInstanceID,
+		)
+		return nil, false
+	}
+	return rows, true
+}
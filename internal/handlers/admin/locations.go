@@ -91,6 +91,13 @@ func (h *Handler) LocationNew(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	library, err := h.markerLibraryService.List(r.Context(), user.ID, services.MarkerLibraryFilter{})
+	if err != nil {
+		h.handleError(w, r, "LocationNew: getting marker library", "Error getting markers", "error", err)
+		return
+	}
+	libraryMarkers, duplicatable := splitLibraryMarkers(library, duplicatable, user.CurrentInstanceID)
+
 	targetGroupID := r.URL.Query().Get("groupId")
 	afterLocationID := r.URL.Query().Get("afterLocationId")
 	beforeLocationID := r.URL.Query().Get("beforeLocationId")
@@ -107,6 +114,7 @@ func (h *Handler) LocationNew(w http.ResponseWriter, r *http.Request) {
 		Settings:         user.CurrentInstance.Settings,
 		Neighbouring:     user.CurrentInstance.Locations,
 		Duplicatable:     duplicatable,
+		Library:          libraryMarkers,
 		TargetGroupID:    targetGroupID,
 		AfterLocationID:  afterLocationID,
 		BeforeLocationID: beforeLocationID,
@@ -120,6 +128,38 @@ func (h *Handler) LocationNew(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// splitLibraryMarkers returns the library markers not yet used in the
+// instance, and the other shareable markers that are not in the library.
+func splitLibraryMarkers(
+	library []services.LibraryMarker,
+	shareable []models.Marker,
+	instanceID string,
+) ([]models.Marker, []models.Marker) {
+	inLibrary := make(map[string]bool, len(library))
+	markers := make([]models.Marker, 0, len(library))
+	for _, item := range library {
+		inLibrary[item.Marker.Code] = true
+		used := false
+		for _, location := range item.Marker.Locations {
+			if location.InstanceID == instanceID {
+				used = true
+				break
+			}
+		}
+		if !used {
+			markers = append(markers, item.Marker)
+		}
+	}
+
+	others := make([]models.Marker, 0, len(shareable))
+	for _, marker := range shareable {
+		if !inLibrary[marker.Code] {
+			others = append(others, marker)
+		}
+	}
+	return markers, others
+}
+
 // LocationNewPost handles creating a new location.
 func (h *Handler) LocationNewPost(w http.ResponseWriter, r *http.Request) {
 	user := h.UserFromContext(r.Context())
@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	templates "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// LTI shows the LMS platforms that can launch the current game.
+// GET /admin/lti.
+func (h *Handler) LTI(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	platforms, err := h.ltiService.ListPlatforms(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.handleError(w, r, "LTI: listing platforms", "Could not load LMS settings", "error", err)
+		return
+	}
+
+	c := templates.LTI(h.ltiService.ToolConfig(), platforms)
+	err = templates.Layout(c, *user, "Experience", "LMS").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("LTI: rendering template", "error", err)
+	}
+}
+
+// LTIPlatformCreate registers an LMS to launch the current game.
+// POST /admin/lti.
+func (h *Handler) LTIPlatformCreate(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := r.ParseForm()
+	if err != nil {
+		h.handleError(w, r, "LTIPlatformCreate: parse form", "Failed to parse form data", "error", err)
+	} else {
+		scoreMaximum, _ := strconv.Atoi(r.FormValue("score_maximum"))
+		_, err = h.ltiService.CreatePlatform(r.Context(), user.CurrentInstanceID, services.LTIPlatformInput{
+			Name:         r.FormValue("name"),
+			Issuer:       r.FormValue("issuer"),
+			ClientID:     r.FormValue("client_id"),
+			DeploymentID: r.FormValue("deployment_id"),
+			AuthLoginURL: r.FormValue("auth_login_url"),
+			AuthTokenURL: r.FormValue("auth_token_url"),
+			KeySetURL:    r.FormValue("key_set_url"),
+			GradeMode:    models.LTIGradeMode(r.FormValue("grade_mode")),
+			ScoreMaximum: scoreMaximum,
+		})
+		if err != nil {
+			h.handleError(
+				w,
+				r,
+				"LTIPlatformCreate: creating platform",
+				"Could not add LMS: "+err.Error(),
+				"error",
+				err,
+				"instance_id",
+				user.CurrentInstanceID,
+			)
+		} else {
+			h.handleSuccess(w, r, "LMS added")
+		}
+	}
+
+	// The list is the swap target, so it is rendered even after an error
+	platforms, err := h.ltiService.ListPlatforms(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.logger.Error("LTIPlatformCreate: listing platforms", "error", err, "instance_id", user.CurrentInstanceID)
+	}
+	err = templates.LTIPlatformList(platforms).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("LTIPlatformCreate: rendering template", "error", err)
+	}
+}
+
+// LTIPlatformGrading changes what a platform's gradebook receives.
+// PUT /admin/lti/{id}.
+func (h *Handler) LTIPlatformGrading(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	if err := r.ParseForm(); err != nil {
+		h.handleError(w, r, "LTIPlatformGrading: parse form", "Failed to parse form data", "error", err)
+		return
+	}
+
+	scoreMaximum, _ := strconv.Atoi(r.FormValue("score_maximum"))
+	_, err := h.ltiService.UpdateGrading(
+		r.Context(),
+		user.CurrentInstanceID,
+		chi.URLParam(r, "id"),
+		models.LTIGradeMode(r.FormValue("grade_mode")),
+		scoreMaximum,
+	)
+	if err != nil {
+		h.handleError(w, r, "LTIPlatformGrading: updating platform", "Could not save grading: "+err.Error(),
+			"error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "Grading saved")
+}
+
+// LTIPlatformDelete removes an LMS registration.
+// DELETE /admin/lti/{id}.
+func (h *Handler) LTIPlatformDelete(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.ltiService.DeletePlatform(r.Context(), user.CurrentInstanceID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.handleError(w, r, "LTIPlatformDelete: deleting platform", "Could not remove LMS", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "LMS removed")
+}
@@ -0,0 +1,244 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	templates "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// MarkerLibrary shows the places in the user's marker library.
+// GET /admin/markers.
+func (h *Handler) MarkerLibrary(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	markers, err := h.markerLibraryService.List(r.Context(), user.ID, markerLibraryFilter(r))
+	if err != nil {
+		h.handleError(w, r, "MarkerLibrary: listing markers", "Could not load marker library", "error", err)
+		return
+	}
+
+	c := templates.MarkerLibrary(markers)
+	err = templates.Layout(c, *user, "Marker Library", "Marker Library").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("MarkerLibrary: rendering template", "error", err)
+	}
+}
+
+// MarkerLibrarySearch filters the marker library by name and distance.
+// GET /admin/markers/search.
+func (h *Handler) MarkerLibrarySearch(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	markers, err := h.markerLibraryService.List(r.Context(), user.ID, markerLibraryFilter(r))
+	if err != nil {
+		h.handleError(w, r, "MarkerLibrarySearch: listing markers", "Could not search marker library", "error", err)
+		return
+	}
+
+	err = templates.MarkerLibraryList(markers).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("MarkerLibrarySearch: rendering template", "error", err)
+	}
+}
+
+// MarkerLibraryNew shows the form to add a place to the marker library.
+// GET /admin/markers/new.
+func (h *Handler) MarkerLibraryNew(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	c := templates.MarkerLibraryForm(nil)
+	err := templates.Layout(c, *user, "Marker Library", "New Marker").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("MarkerLibraryNew: rendering template", "error", err)
+	}
+}
+
+// MarkerLibraryCreate adds a place to the marker library.
+// POST /admin/markers.
+func (h *Handler) MarkerLibraryCreate(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	details, ok := h.readMarkerDetails(w, r, "MarkerLibraryCreate")
+	if !ok {
+		return
+	}
+
+	_, err := h.markerLibraryService.Create(r.Context(), user.ID, details)
+	if err != nil {
+		h.handleError(w, r, "MarkerLibraryCreate: creating marker", "Could not save marker", "error", err)
+		return
+	}
+
+	h.redirect(w, r, "/admin/markers")
+}
+
+// MarkerLibraryEdit shows the form to edit a library marker.
+// GET /admin/markers/{code}.
+func (h *Handler) MarkerLibraryEdit(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	marker, err := h.markerLibraryService.Get(r.Context(), user.ID, chi.URLParam(r, "code"))
+	if err != nil {
+		h.logger.Error("MarkerLibraryEdit: getting marker", "error", err, "code", chi.URLParam(r, "code"))
+		h.redirect(w, r, "/admin/markers")
+		return
+	}
+
+	c := templates.MarkerLibraryForm(marker)
+	err = templates.Layout(c, *user, "Marker Library", marker.Name).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("MarkerLibraryEdit: rendering template", "error", err)
+	}
+}
+
+// MarkerLibraryUpdate saves changes to a library marker.
+// POST /admin/markers/{code}.
+func (h *Handler) MarkerLibraryUpdate(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	details, ok := h.readMarkerDetails(w, r, "MarkerLibraryUpdate")
+	if !ok {
+		return
+	}
+
+	_, err := h.markerLibraryService.Update(r.Context(), user.ID, chi.URLParam(r, "code"), details)
+	if err != nil {
+		h.handleError(w, r, "MarkerLibraryUpdate: updating marker", "Could not save marker", "error", err)
+		return
+	}
+
+	h.redirect(w, r, "/admin/markers")
+}
+
+// MarkerLibraryRemove takes a marker out of the marker library.
+// DELETE /admin/markers/{code}.
+func (h *Handler) MarkerLibraryRemove(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.markerLibraryService.Remove(r.Context(), user.ID, chi.URLParam(r, "code"))
+	if err != nil {
+		h.handleError(w, r, "MarkerLibraryRemove: removing marker", "Could not remove marker", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "Removed from your marker library")
+}
+
+// MarkerLibraryAdd saves a location's marker to the marker library.
+// POST /admin/markers/add.
+func (h *Handler) MarkerLibraryAdd(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	locationID := r.FormValue("location_id")
+	access, err := h.accessService.CanAdminAccessLocation(r.Context(), user.ID, locationID)
+	if err != nil || !access {
+		h.handleError(
+			w,
+			r,
+			"MarkerLibraryAdd: checking access",
+			"Could not save marker. Access denied",
+			"error",
+			err,
+			"location_id",
+			locationID,
+		)
+		return
+	}
+
+	location, err := h.locationService.GetByID(r.Context(), locationID)
+	if err != nil {
+		h.handleError(w, r, "MarkerLibraryAdd: getting location", "Could not save marker", "error", err)
+		return
+	}
+
+	_, err = h.markerLibraryService.Add(r.Context(), user.ID, location.MarkerID)
+	if errors.Is(err, services.ErrPermissionDenied) {
+		h.handleError(
+			w,
+			r,
+			"MarkerLibraryAdd: marker in another library",
+			"This marker is already in someone else's library",
+			"error",
+			err,
+		)
+		return
+	} else if err != nil {
+		h.handleError(w, r, "MarkerLibraryAdd: adding marker", "Could not save marker", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "Saved to your marker library")
+}
+
+// readMarkerDetails reads the marker form, uploading the photo if one was
+// chosen. It reports any problem to the user and returns false if the form
+// cannot be used.
+func (h *Handler) readMarkerDetails(
+	w http.ResponseWriter,
+	r *http.Request,
+	caller string,
+) (services.MarkerLibraryDetails, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	err := r.ParseMultipartForm(maxUploadSize)
+	if err != nil {
+		h.handleError(w, r, caller+": parsing form", "File too large", "error", err)
+		return services.MarkerLibraryDetails{}, false
+	}
+
+	details := services.MarkerLibraryDetails{
+		Name:     r.FormValue("name"),
+		Notes:    r.FormValue("notes"),
+		ImageURL: r.FormValue("image_url"),
+	}
+	if r.FormValue("latitude") != "" || r.FormValue("longitude") != "" {
+		details.Lat, err = strconv.ParseFloat(r.FormValue("latitude"), 64)
+		if err == nil {
+			details.Lng, err = strconv.ParseFloat(r.FormValue("longitude"), 64)
+		}
+		if err != nil {
+			h.handleError(w, r, caller+": parsing coordinates", "Choose a place on the map", "error", err)
+			return services.MarkerLibraryDetails{}, false
+		}
+	}
+
+	file, fileHeader, err := r.FormFile("photo")
+	if errors.Is(err, http.ErrMissingFile) {
+		return details, true
+	} else if err != nil {
+		h.handleError(w, r, caller+": getting photo", "Could not upload photo", "error", err)
+		return services.MarkerLibraryDetails{}, false
+	}
+	defer file.Close()
+
+	// Library photos belong to the user rather than to any one game
+	upload, err := h.uploadService.UploadFile(r.Context(), file, fileHeader, services.UploadMetadata{})
+	if err != nil {
+		h.handleError(w, r, caller+": uploading photo", "Could not upload photo", "error", err)
+		return services.MarkerLibraryDetails{}, false
+	}
+	if upload.Type != models.MediaTypeImage {
+		h.handleError(w, r, caller+": photo is not an image", "Photos must be images")
+		return services.MarkerLibraryDetails{}, false
+	}
+	details.ImageURL = upload.OriginalURL
+	return details, true
+}
+
+// markerLibraryFilter reads the search and proximity parameters.
+func markerLibraryFilter(r *http.Request) services.MarkerLibraryFilter {
+	filter := services.MarkerLibraryFilter{
+		Query: strings.TrimSpace(r.URL.Query().Get("q")),
+	}
+	lat, latErr := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	lng, lngErr := strconv.ParseFloat(r.URL.Query().Get("lng"), 64)
+	if latErr == nil && lngErr == nil {
+		filter.Near, filter.Lat, filter.Lng = true, lat, lng
+	}
+	return filter
+}
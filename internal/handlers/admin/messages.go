@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	templates "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// TeamMessages renders a team's conversation with the organisers and marks
+// the team's messages as read.
+func (h *Handler) TeamMessages(w http.ResponseWriter, r *http.Request) {
+	team, ok := h.messageTeam(w, r)
+	if !ok {
+		return
+	}
+
+	h.markMessagesRead(r, team)
+	h.renderTeamMessages(w, r, team)
+}
+
+// markMessagesRead marks a team's messages as read by the organisers, unless
+// the user can only view the game. Viewers don't answer messages, so they
+// mustn't clear the unread flags that those who do rely on.
+func (h *Handler) markMessagesRead(r *http.Request, team *models.Team) {
+	user := h.UserFromContext(r.Context())
+	role, err := h.accessService.InstanceRole(r.Context(), user.ID, team.InstanceID)
+	if err != nil {
+		h.logger.Error("markMessagesRead: finding role", "error", err, "team_code", team.Code)
+		return
+	}
+	if !role.CanFacilitate() {
+		return
+	}
+
+	err = h.messageService.MarkReadByOrganisers(r.Context(), team)
+	if err != nil {
+		h.logger.Error("markMessagesRead: marking messages read", "error", err, "team_code", team.Code)
+	}
+}
+
+// TeamMessageReply sends a reply to a team.
+func (h *Handler) TeamMessageReply(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+	team, ok := h.messageTeam(w, r)
+	if !ok {
+		return
+	}
+
+	_, err := h.messageService.Reply(r.Context(), team, user.Name, r.FormValue("content"))
+	if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		switch {
+		case errors.Is(err, services.ErrMessageEmpty):
+			h.handleError(w, r, "TeamMessageReply: empty message", "Write a message to send", "error", err)
+		case errors.Is(err, services.ErrMessageTooLong):
+			h.handleError(w, r, "TeamMessageReply: message too long", "That message is too long", "error", err)
+		default:
+			h.handleError(w, r, "TeamMessageReply: sending reply", "Error sending message", "error", err)
+		}
+		return
+	}
+	h.renderTeamMessages(w, r, team)
+}
+
+// messageTeam finds the team named in the URL, if it belongs to the
+// current instance.
+func (h *Handler) messageTeam(w http.ResponseWriter, r *http.Request) (*models.Team, bool) {
+	user := h.UserFromContext(r.Context())
+	teamCode := chi.URLParam(r, "teamCode")
+
+	team, err := h.teamService.GetTeamByCode(r.Context(), teamCode)
+	if err != nil || team == nil || team.InstanceID != user.CurrentInstanceID {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "messageTeam: finding team", "Team not found", "error", err, "team_code", teamCode)
+		return nil, false
+	}
+	return team, true
+}
+
+func (h *Handler) renderTeamMessages(w http.ResponseWriter, r *http.Request, team *models.Team) {
+	messages, err := h.messageService.Thread(r.Context(), team)
+	if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "renderTeamMessages: finding messages", "Error loading messages", "error", err)
+		return
+	}
+
+	err = templates.TeamMessagesList(messages).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("renderTeamMessages: rendering template", "error", err)
+	}
+}
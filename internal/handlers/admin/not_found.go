@@ -31,3 +31,19 @@ func (h *Handler) NotFound(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// Forbidden stops a change the user's role in the current game doesn't allow.
+func (h *Handler) Forbidden(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Hx-Request") == htmxHeaderTrue {
+		h.handleError(
+			w,
+			r,
+			"Forbidden: role does not allow request",
+			"Your role doesn't allow changes to this game",
+			"path",
+			r.URL.Path,
+		)
+		return
+	}
+	http.Error(w, "Forbidden", http.StatusForbidden)
+}
@@ -1,9 +1,16 @@
 package admin
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
 	admin "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	"github.com/nathanhollows/Rapua/v6/models"
 )
 
 // NotifyAllPost sends a notification to all teams.
@@ -114,3 +121,143 @@ func (h *Handler) NotifyTeamPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// Announcements lists the current game's scheduled and sent announcements.
+// GET /admin/notify.
+func (h *Handler) Announcements(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	notifications, err := h.notificationService.ScheduledNotifications(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.handleError(w, r, "Announcements: listing notifications", "Could not load announcements", "error", err)
+		return
+	}
+
+	c := admin.Announcements(user.CurrentInstance, notifications)
+	err = admin.Layout(c, *user, "Activity", "Announcements").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("Announcements: rendering template", "error", err)
+	}
+}
+
+// AnnouncementSchedule sends or schedules an announcement to some or all
+// teams.
+// POST /admin/notify.
+func (h *Handler) AnnouncementSchedule(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	notification, err := parseAnnouncement(r)
+	if err == nil {
+		err = h.notificationService.ScheduleNotification(r.Context(), &user.CurrentInstance, notification)
+	}
+	switch {
+	case err == nil && notification.Pending():
+		h.handleSuccess(w, r, "Announcement scheduled")
+	case err == nil && notification.Skipped != "":
+		h.handleError(w, r, "AnnouncementSchedule: game has ended", "Not sent: "+notification.Skipped)
+	case err == nil && notification.Recipients == 1:
+		h.handleSuccess(w, r, "Announcement sent to 1 team")
+	case err == nil:
+		h.handleSuccess(w, r, fmt.Sprintf("Announcement sent to %d teams", notification.Recipients))
+	case errors.Is(err, services.ErrNotificationEmpty),
+		errors.Is(err, services.ErrNotificationTooLong),
+		errors.Is(err, services.ErrInvalidNotificationTarget),
+		errors.Is(err, services.ErrInvalidNotificationTiming):
+		h.handleError(w, r, "AnnouncementSchedule: invalid announcement", "Could not send: "+err.Error(), "error", err)
+	default:
+		h.handleError(
+			w,
+			r,
+			"AnnouncementSchedule: scheduling announcement",
+			"Error sending announcement",
+			"error",
+			err,
+			"instance_id",
+			user.CurrentInstanceID,
+		)
+	}
+
+	// The list is the swap target, so it is rendered even after an error
+	h.renderAnnouncementList(w, r, user)
+}
+
+// AnnouncementCancel deletes an announcement that hasn't been sent.
+// DELETE /admin/notify/{id}.
+func (h *Handler) AnnouncementCancel(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.notificationService.CancelScheduledNotification(r.Context(), user.CurrentInstanceID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.handleError(
+			w,
+			r,
+			"AnnouncementCancel: cancelling announcement",
+			"Could not cancel announcement",
+			"error",
+			err,
+			"instance_id",
+			user.CurrentInstanceID,
+		)
+	} else {
+		h.handleSuccess(w, r, "Announcement cancelled")
+	}
+	h.renderAnnouncementList(w, r, user)
+}
+
+func (h *Handler) renderAnnouncementList(w http.ResponseWriter, r *http.Request, user *models.User) {
+	notifications, err := h.notificationService.ScheduledNotifications(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.logger.Error(
+			"renderAnnouncementList: listing notifications",
+			"error", err,
+			"instance_id", user.CurrentInstanceID,
+		)
+	}
+	err = admin.AnnouncementList(user.CurrentInstance, notifications).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("renderAnnouncementList: rendering template", "error", err)
+	}
+}
+
+// parseAnnouncement reads an announcement from the form. Announcements sent
+// now are scheduled for the current time so they are kept with the rest.
+func parseAnnouncement(r *http.Request) (*models.ScheduledNotification, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, fmt.Errorf("parsing form: %w", err)
+	}
+
+	notification := &models.ScheduledNotification{
+		Content: r.FormValue("content"),
+		Target:  models.NotificationTarget(r.FormValue("target")),
+	}
+
+	switch notification.Target {
+	case models.TargetTeam:
+		notification.TargetID = r.FormValue("team_code")
+	case models.TargetGroup:
+		notification.TargetID = r.FormValue("group_id")
+	case models.TargetNotVisited:
+		notification.TargetID = r.FormValue("location_id")
+	}
+
+	switch timing := r.FormValue("timing"); timing {
+	case "now":
+		notification.Timing = models.NotifyAt
+		notification.SendAt = time.Now().UTC()
+	case string(models.NotifyAt):
+		notification.Timing = models.NotifyAt
+		// The browser converts the local time to UTC
+		notification.SendAt, err = time.Parse(time.RFC3339, r.FormValue("send_at"))
+		if err != nil {
+			return nil, services.ErrInvalidNotificationTiming
+		}
+	default:
+		notification.Timing = models.NotificationTiming(timing)
+		notification.OffsetMinutes, err = strconv.Atoi(r.FormValue("offset_minutes"))
+		if err != nil {
+			return nil, services.ErrInvalidNotificationTiming
+		}
+	}
+	return notification, nil
+}
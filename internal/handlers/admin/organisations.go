@@ -0,0 +1,369 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	templates "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// Organisations lists the organisations the user belongs to.
+// GET /admin/organisations.
+func (h *Handler) Organisations(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	memberships, err := h.organisationService.Memberships(r.Context(), user.ID)
+	if err != nil {
+		h.handleError(w, r, "Organisations: listing memberships", "Could not load organisations", "error", err)
+		return
+	}
+
+	c := templates.Organisations(memberships)
+	err = templates.Layout(c, *user, "Organisations", "Organisations").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("Organisations: rendering template", "error", err)
+	}
+}
+
+// OrganisationCreate starts a new organisation owned by the user.
+// POST /admin/organisations.
+func (h *Handler) OrganisationCreate(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	org, err := h.organisationService.Create(r.Context(), user.ID, r.FormValue("name"))
+	if err != nil {
+		h.handleError(w, r, "OrganisationCreate: creating organisation", "Could not create organisation", "error", err)
+		return
+	}
+
+	h.redirect(w, r, "/admin/organisations/"+org.ID)
+}
+
+// Organisation shows an organisation's members and shared games.
+// GET /admin/organisations/{id}.
+func (h *Handler) Organisation(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	org, role, err := h.organisationService.Get(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.logger.Error("Organisation: getting organisation", "error", err, "id", chi.URLParam(r, "id"))
+		h.redirect(w, r, "/admin/organisations")
+		return
+	}
+
+	unshared, err := h.unsharedInstances(r, user.ID)
+	if err != nil {
+		h.handleError(w, r, "Organisation: finding games", "Could not load your games", "error", err)
+		return
+	}
+
+	c := templates.Organisation(*org, role, user.ID, unshared)
+	err = templates.Layout(c, *user, "Organisations", org.Name).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("Organisation: rendering template", "error", err)
+	}
+}
+
+// OrganisationRename changes an organisation's name.
+// PUT /admin/organisations/{id}.
+func (h *Handler) OrganisationRename(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.organisationService.Rename(r.Context(), user.ID, chi.URLParam(r, "id"), r.FormValue("name"))
+	if errors.Is(err, services.ErrPermissionDenied) {
+		h.handleError(w, r, "OrganisationRename: not an owner", "Only owners can rename an organisation")
+		return
+	} else if err != nil {
+		h.handleError(w, r, "OrganisationRename: renaming organisation", "Could not rename organisation", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "Organisation renamed")
+}
+
+// OrganisationDelete removes an organisation. Shared games stay with the
+// members who created them.
+// DELETE /admin/organisations/{id}.
+func (h *Handler) OrganisationDelete(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.organisationService.Delete(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if errors.Is(err, services.ErrPermissionDenied) {
+		h.handleError(w, r, "OrganisationDelete: not an owner", "Only owners can delete an organisation")
+		return
+	} else if err != nil {
+		h.handleError(w, r, "OrganisationDelete: deleting organisation", "Could not delete organisation", "error", err)
+		return
+	}
+
+	h.redirect(w, r, "/admin/organisations")
+}
+
+// OrganisationMemberAdd gives an existing user a role in the organisation.
+// POST /admin/organisations/{id}/members.
+func (h *Handler) OrganisationMemberAdd(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	orgID := chi.URLParam(r, "id")
+	role := models.OrganisationRole(r.FormValue("role"))
+	_, err := h.organisationService.AddMember(r.Context(), user.ID, orgID, r.FormValue("email"), role)
+	switch {
+	case errors.Is(err, services.ErrNoAccountForEmail):
+		h.handleError(
+			w,
+			r,
+			"OrganisationMemberAdd: no account",
+			"No one has signed up with that email yet. Ask them to create an account first",
+		)
+	case errors.Is(err, services.ErrAlreadyMember):
+		h.handleError(w, r, "OrganisationMemberAdd: already a member", "They are already a member")
+	case errors.Is(err, services.ErrPermissionDenied):
+		h.handleError(w, r, "OrganisationMemberAdd: not an owner", "Only owners can add members")
+	case err != nil:
+		h.handleError(w, r, "OrganisationMemberAdd: adding member", "Could not add member", "error", err)
+	default:
+		h.handleSuccess(w, r, "Member added")
+	}
+
+	h.renderOrganisation(w, r, user.ID, orgID)
+}
+
+// OrganisationMemberRole changes a member's role.
+// PUT /admin/organisations/{id}/members/{userID}.
+func (h *Handler) OrganisationMemberRole(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	orgID := chi.URLParam(r, "id")
+	role := models.OrganisationRole(r.FormValue("role"))
+	err := h.organisationService.SetRole(r.Context(), user.ID, orgID, chi.URLParam(r, "userID"), role)
+	switch {
+	case errors.Is(err, services.ErrLastOwner):
+		h.handleError(w, r, "OrganisationMemberRole: last owner", "An organisation needs at least one owner")
+	case errors.Is(err, services.ErrPermissionDenied):
+		h.handleError(w, r, "OrganisationMemberRole: not an owner", "Only owners can change roles")
+	case err != nil:
+		h.handleError(w, r, "OrganisationMemberRole: setting role", "Could not change role", "error", err)
+	default:
+		h.handleSuccess(w, r, "Role changed")
+	}
+
+	h.renderOrganisation(w, r, user.ID, orgID)
+}
+
+// OrganisationMemberRemove takes a member out of the organisation, or lets a
+// member leave.
+// DELETE /admin/organisations/{id}/members/{userID}.
+func (h *Handler) OrganisationMemberRemove(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	orgID := chi.URLParam(r, "id")
+	memberID := chi.URLParam(r, "userID")
+	err := h.organisationService.RemoveMember(r.Context(), user.ID, orgID, memberID)
+	switch {
+	case errors.Is(err, services.ErrLastOwner):
+		h.handleError(
+			w,
+			r,
+			"OrganisationMemberRemove: last owner",
+			"An organisation needs at least one owner. Make someone else an owner first",
+		)
+	case errors.Is(err, services.ErrPermissionDenied):
+		h.handleError(w, r, "OrganisationMemberRemove: not an owner", "Only owners can remove members")
+	case err != nil:
+		h.handleError(w, r, "OrganisationMemberRemove: removing member", "Could not remove member", "error", err)
+	case memberID == user.ID:
+		h.redirect(w, r, "/admin/organisations")
+		return
+	default:
+		h.handleSuccess(w, r, "Member removed")
+	}
+
+	h.renderOrganisation(w, r, user.ID, orgID)
+}
+
+// OrganisationCredits shows an organisation's credit pool and each member's
+// usage for a month.
+// GET /admin/organisations/{id}/credits.
+func (h *Handler) OrganisationCredits(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	month := time.Now()
+	if r.URL.Query().Get("month") != "" {
+		parsed, err := time.Parse("2006-01", r.URL.Query().Get("month"))
+		if err != nil {
+			h.handleError(w, r, "OrganisationCredits: parsing month", "Invalid month", "error", err)
+			return
+		}
+		month = parsed
+	}
+
+	billing, err := h.organisationService.Billing(r.Context(), user.ID, chi.URLParam(r, "id"), month)
+	if err != nil {
+		h.logger.Error("OrganisationCredits: getting billing", "error", err, "id", chi.URLParam(r, "id"))
+		h.redirect(w, r, "/admin/organisations")
+		return
+	}
+
+	// Changing the month only replaces the usage report
+	if r.Header.Get("Hx-Request") == htmxHeaderTrue && r.Header.Get("Hx-Boosted") != htmxHeaderTrue {
+		err = templates.OrganisationUsage(*billing).Render(r.Context(), w)
+		if err != nil {
+			h.logger.Error("OrganisationCredits: rendering usage", "error", err)
+		}
+		return
+	}
+
+	c := templates.OrganisationCredits(*billing, r.URL.Query().Get("session_id") != "")
+	err = templates.Layout(c, *user, "Organisations", billing.Organisation.Name+" credits").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("OrganisationCredits: rendering template", "error", err)
+	}
+}
+
+// OrganisationCreditLimit sets how many credits a member may draw from the
+// pool each month.
+// PUT /admin/organisations/{id}/members/{userID}/limit.
+func (h *Handler) OrganisationCreditLimit(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil || limit < 0 {
+		h.handleError(
+			w,
+			r,
+			"OrganisationCreditLimit: parsing limit",
+			"Limit must be 0 or more",
+			"limit",
+			r.FormValue("limit"),
+		)
+		return
+	}
+
+	err = h.organisationService.SetCreditLimit(
+		r.Context(),
+		user.ID,
+		chi.URLParam(r, "id"),
+		chi.URLParam(r, "userID"),
+		limit,
+	)
+	if errors.Is(err, services.ErrPermissionDenied) {
+		h.handleError(w, r, "OrganisationCreditLimit: not an owner", "Only owners can set credit limits")
+		return
+	} else if err != nil {
+		h.handleError(w, r, "OrganisationCreditLimit: setting limit", "Could not set limit", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "Limit saved")
+}
+
+// OrganisationTwoFactor sets whether members must use two-factor
+// authentication.
+// PUT /admin/organisations/{id}/two-factor.
+func (h *Handler) OrganisationTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	orgID := chi.URLParam(r, "id")
+	require := r.FormValue("require") == "on"
+	err := h.organisationService.SetRequireTwoFactor(r.Context(), user.ID, orgID, require)
+	switch {
+	case errors.Is(err, services.ErrPermissionDenied):
+		h.handleError(w, r, "OrganisationTwoFactor: not an owner", "Only owners can require two-factor authentication")
+	case errors.Is(err, services.ErrTwoFactorNotEnabled):
+		h.handleError(
+			w,
+			r,
+			"OrganisationTwoFactor: owner has no two-factor",
+			"Turn on two-factor authentication for your own account first",
+		)
+	case err != nil:
+		h.handleError(w, r, "OrganisationTwoFactor: saving", "Could not save the requirement", "error", err)
+	case require:
+		h.handleSuccess(w, r, "Members now need two-factor authentication")
+	default:
+		h.handleSuccess(w, r, "Two-factor authentication is now optional")
+	}
+
+	h.renderOrganisation(w, r, user.ID, orgID)
+}
+
+// OrganisationShare shares one of the user's games or templates with the
+// organisation.
+// POST /admin/organisations/{id}/instances.
+func (h *Handler) OrganisationShare(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	orgID := chi.URLParam(r, "id")
+	err := h.organisationService.ShareInstance(r.Context(), user.ID, orgID, r.FormValue("instance_id"))
+	if errors.Is(err, services.ErrPermissionDenied) {
+		h.handleError(w, r, "OrganisationShare: permission denied", "You can only share your own games as an editor or owner")
+	} else if err != nil {
+		h.handleError(w, r, "OrganisationShare: sharing instance", "Could not share game", "error", err)
+	} else {
+		h.handleSuccess(w, r, "Shared with the organisation")
+	}
+
+	h.renderOrganisation(w, r, user.ID, orgID)
+}
+
+// OrganisationUnshare stops sharing a game or template with the organisation.
+// DELETE /admin/organisations/{id}/instances/{instanceID}.
+func (h *Handler) OrganisationUnshare(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.organisationService.UnshareInstance(r.Context(), user.ID, chi.URLParam(r, "instanceID"))
+	if errors.Is(err, services.ErrPermissionDenied) {
+		h.handleError(w, r, "OrganisationUnshare: permission denied", "Only owners can stop sharing other members' games")
+	} else if err != nil {
+		h.handleError(w, r, "OrganisationUnshare: unsharing instance", "Could not stop sharing", "error", err)
+	} else {
+		h.handleSuccess(w, r, "No longer shared")
+	}
+
+	h.renderOrganisation(w, r, user.ID, chi.URLParam(r, "id"))
+}
+
+// renderOrganisation re-renders an organisation's members and games after
+// they change.
+func (h *Handler) renderOrganisation(w http.ResponseWriter, r *http.Request, userID, orgID string) {
+	org, role, err := h.organisationService.Get(r.Context(), userID, orgID)
+	if err != nil {
+		h.logger.Error("getting organisation", "error", err)
+		return
+	}
+	unshared, err := h.unsharedInstances(r, userID)
+	if err != nil {
+		h.logger.Error("finding games", "error", err)
+		return
+	}
+	err = templates.OrganisationDetails(*org, role, userID, unshared).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("rendering organisation", "error", err)
+	}
+}
+
+// unsharedInstances returns the user's games and templates that are not
+// shared with any organisation.
+func (h *Handler) unsharedInstances(r *http.Request, userID string) ([]models.Instance, error) {
+	games, err := h.instanceService.FindByUserID(r.Context(), userID)
+	if err != nil {
+		return nil, err
+	}
+	gameTemplates, err := h.templateService.Find(r.Context(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var unshared []models.Instance
+	for _, instance := range append(games, gameTemplates...) {
+		if instance.OrganisationID == "" {
+			unshared = append(unshared, instance)
+		}
+	}
+	return unshared, nil
+}
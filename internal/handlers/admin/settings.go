@@ -2,11 +2,15 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi"
 	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/internal/sessions"
 	templates "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	"github.com/nathanhollows/Rapua/v6/models"
 )
 
 const (
@@ -77,8 +81,28 @@ func (h *Handler) SettingsAppearance(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) SettingsSecurity(w http.ResponseWriter, r *http.Request) {
 	user := h.UserFromContext(r.Context())
 
-	c := templates.Settings(templates.SettingsSecurity(*user))
-	err := templates.Layout(c, *user, "Settings", "Security").Render(r.Context(), w)
+	twoFactor, err := h.twoFactorService.Status(r.Context(), user)
+	if err != nil {
+		h.logger.Error("SettingsSecurity: getting two-factor status", "error", err, "user_id", user.ID)
+	}
+
+	adminSessions, err := h.adminSessionService.List(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("SettingsSecurity: listing sessions", "error", err, "user_id", user.ID)
+	}
+	attempts, err := h.adminSessionService.RecentAttempts(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("SettingsSecurity: listing login attempts", "error", err, "user_id", user.ID)
+	}
+
+	c := templates.Settings(templates.SettingsSecurity(
+		*user,
+		twoFactor,
+		adminSessions,
+		sessions.AdminSessionID(r),
+		attempts,
+	))
+	err = templates.Layout(c, *user, "Settings", "Security").Render(r.Context(), w)
 	if err != nil {
 		h.logger.Error("rendering account page", "error", err.Error())
 	}
@@ -133,6 +157,159 @@ func (h *Handler) SettingsSecurityPost(w http.ResponseWriter, r *http.Request) {
 	h.handleSuccess(w, r, "Security settings updated!")
 }
 
+// SettingsTwoFactorBegin starts setting up two-factor authentication by
+// showing a QR code for the user's authenticator app.
+// POST /admin/settings/security/two-factor.
+func (h *Handler) SettingsTwoFactorBegin(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	enrolment, err := h.twoFactorService.BeginEnrolment(r.Context(), user)
+	if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "SettingsTwoFactorBegin: starting enrolment", "Could not set up two-factor authentication",
+			"error", err, "user_id", user.ID)
+		return
+	}
+
+	err = templates.TwoFactorEnrol(*enrolment).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("SettingsTwoFactorBegin: rendering template", "error", err)
+	}
+}
+
+// SettingsTwoFactorConfirm turns on two-factor authentication once the user
+// enters a code from their app, and shows their recovery codes.
+// POST /admin/settings/security/two-factor/confirm.
+func (h *Handler) SettingsTwoFactorConfirm(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	codes, err := h.twoFactorService.ConfirmEnrolment(r.Context(), user, r.FormValue("code"))
+	if errors.Is(err, services.ErrInvalidTwoFactorCode) {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "SettingsTwoFactorConfirm: invalid code", "That code didn't match. Try the next one")
+		return
+	} else if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r,
+			"SettingsTwoFactorConfirm: confirming enrolment",
+			"Could not turn on two-factor authentication",
+			"error", err, "user_id", user.ID)
+		return
+	}
+
+	h.handleSuccess(w, r, "Two-factor authentication is on")
+	err = templates.TwoFactorRecoveryCodes(codes).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("SettingsTwoFactorConfirm: rendering template", "error", err)
+	}
+}
+
+// SettingsTwoFactorRecoveryCodes replaces the user's recovery codes.
+// POST /admin/settings/security/two-factor/recovery-codes.
+func (h *Handler) SettingsTwoFactorRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(r.Context(), user, r.FormValue("code"))
+	if errors.Is(err, services.ErrInvalidTwoFactorCode) {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "SettingsTwoFactorRecoveryCodes: invalid code", "Invalid code")
+		return
+	} else if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "SettingsTwoFactorRecoveryCodes: regenerating codes", "Could not create recovery codes",
+			"error", err, "user_id", user.ID)
+		return
+	}
+
+	h.handleSuccess(w, r, "New recovery codes created")
+	err = templates.TwoFactorRecoveryCodes(codes).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("SettingsTwoFactorRecoveryCodes: rendering template", "error", err)
+	}
+}
+
+// SettingsTwoFactorDisable turns off two-factor authentication.
+// POST /admin/settings/security/two-factor/disable.
+func (h *Handler) SettingsTwoFactorDisable(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.twoFactorService.Disable(r.Context(), user, r.FormValue("code"))
+	switch {
+	case errors.Is(err, services.ErrTwoFactorRequired):
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "SettingsTwoFactorDisable: required",
+			"An organisation you belong to requires two-factor authentication")
+		return
+	case errors.Is(err, services.ErrInvalidTwoFactorCode):
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "SettingsTwoFactorDisable: invalid code", "Invalid code")
+		return
+	case err != nil:
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "SettingsTwoFactorDisable: disabling", "Could not turn off two-factor authentication",
+			"error", err, "user_id", user.ID)
+		return
+	}
+
+	h.handleSuccess(w, r, "Two-factor authentication is off")
+	err = templates.TwoFactorSettings(services.TwoFactorStatus{}).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("SettingsTwoFactorDisable: rendering template", "error", err)
+	}
+}
+
+// SettingsSessionRevoke logs out one of the user's other sessions.
+// DELETE /admin/settings/security/sessions/{id}.
+func (h *Handler) SettingsSessionRevoke(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	sessionID := chi.URLParam(r, "id")
+	if sessionID == sessions.AdminSessionID(r) {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "SettingsSessionRevoke: current session", "Use Sign out to end this session")
+		return
+	}
+
+	err := h.adminSessionService.Revoke(r.Context(), user.ID, sessionID)
+	if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "SettingsSessionRevoke: revoking session", "Could not log out the session",
+			"error", err, "user_id", user.ID)
+		return
+	}
+
+	h.handleSuccess(w, r, "Session logged out")
+}
+
+// SettingsSessionsRevokeOthers logs out every session except the current one.
+// DELETE /admin/settings/security/sessions.
+func (h *Handler) SettingsSessionsRevokeOthers(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	currentID := sessions.AdminSessionID(r)
+	count, err := h.adminSessionService.RevokeOthers(r.Context(), user.ID, currentID)
+	if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "SettingsSessionsRevokeOthers: revoking sessions", "Could not log out other sessions",
+			"error", err, "user_id", user.ID)
+		return
+	}
+
+	adminSessions, err := h.adminSessionService.List(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("SettingsSessionsRevokeOthers: listing sessions", "error", err, "user_id", user.ID)
+	}
+	noun := "sessions"
+	if count == 1 {
+		noun = "session"
+	}
+	h.handleSuccess(w, r, fmt.Sprintf("Logged out of %d other %s", count, noun))
+	err = templates.AdminSessions(adminSessions, currentID).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("SettingsSessionsRevokeOthers: rendering template", "error", err)
+	}
+}
+
 // DeleteAccount handles account deletion.
 func (h *Handler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
 	user := h.UserFromContext(r.Context())
@@ -245,3 +422,77 @@ func (h *Handler) SettingsCreditUsageChart(w http.ResponseWriter, r *http.Reques
 		return
 	}
 }
+
+// SettingsAPIKeys displays the user's REST API keys.
+// GET /admin/settings/api-keys.
+func (h *Handler) SettingsAPIKeys(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	keys, err := h.apiKeyService.List(r.Context(), user.ID)
+	if err != nil {
+		h.handleError(w, r, "SettingsAPIKeys: listing keys", "Could not load API keys", "error", err)
+		return
+	}
+
+	c := templates.Settings(templates.SettingsAPIKeys(keys))
+	err = templates.Layout(c, *user, "Settings", "API Keys").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("SettingsAPIKeys: rendering template", "error", err)
+	}
+}
+
+// SettingsAPIKeyCreate creates an API key and shows it once.
+// POST /admin/settings/api-keys.
+func (h *Handler) SettingsAPIKeyCreate(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	secret := ""
+	err := r.ParseForm()
+	if err != nil {
+		h.handleError(w, r, "SettingsAPIKeyCreate: parse form", "Failed to parse form data", "error", err)
+	} else {
+		scopes := make([]models.APIScope, 0, len(r.Form["scopes"]))
+		for _, scope := range r.Form["scopes"] {
+			scopes = append(scopes, models.APIScope(scope))
+		}
+		_, secret, err = h.apiKeyService.Create(r.Context(), user.ID, r.FormValue("name"), scopes)
+		if err != nil {
+			h.handleError(
+				w,
+				r,
+				"SettingsAPIKeyCreate: creating key",
+				"Could not create API key: "+err.Error(),
+				"error",
+				err,
+				"user_id",
+				user.ID,
+			)
+		} else {
+			h.handleSuccess(w, r, "API key created")
+		}
+	}
+
+	// The list is the swap target, so it is rendered even after an error
+	keys, err := h.apiKeyService.List(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("SettingsAPIKeyCreate: listing keys", "error", err, "user_id", user.ID)
+	}
+	err = templates.APIKeys(keys, secret).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("SettingsAPIKeyCreate: rendering template", "error", err)
+	}
+}
+
+// SettingsAPIKeyRevoke deletes an API key.
+// DELETE /admin/settings/api-keys/{id}.
+func (h *Handler) SettingsAPIKeyRevoke(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.apiKeyService.Revoke(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.handleError(w, r, "SettingsAPIKeyRevoke: revoking key", "Could not revoke API key", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "API key revoked")
+}
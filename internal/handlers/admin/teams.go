@@ -131,6 +131,21 @@ func (h *Handler) TeamOverview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Opening the team marks their messages as read
+	h.markMessagesRead(r, team)
+	messages, err := h.messageService.Thread(r.Context(), team)
+	if err != nil {
+		h.handleError(
+			w,
+			r,
+			"TeamOverview: getting messages",
+			"Error getting messages",
+			"Could not load data",
+			err,
+		)
+		return
+	}
+
 	// Get uploads for this team
 	uploads, err := h.uploadService.Search(r.Context(), map[string]string{
 		"team_code": team.Code,
@@ -154,6 +169,7 @@ func (h *Handler) TeamOverview(w http.ResponseWriter, r *http.Request) {
 		Instance:       user.CurrentInstance,
 		Team:           *team,
 		Notifications:  notifications,
+		Messages:       messages,
 		NextLocations:  locations,
 		Uploads:        uploads,
 		TotalLocations: len(user.CurrentInstance.Locations),
@@ -124,6 +124,12 @@ func (h *Handler) TemplatesLaunch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	access, err := h.accessService.CanAdminAccessInstance(r.Context(), user.ID, id)
+	if err != nil || !access {
+		h.handleError(w, r, "TemplatesLaunch: checking access", "You can't use this template", "error", err)
+		return
+	}
+
 	// Regenerate refers to location codes
 	regen := r.Form.Has("regenerate")
 
@@ -255,7 +261,7 @@ func (h *Handler) TemplatesDelete(w http.ResponseWriter, r *http.Request) {
 			user.CurrentInstanceID,
 		)
 	} else {
-		err = templates.Toast(*flash.NewSuccess("Template deleted")).Render(r.Context(), w)
+		err = templates.Toast(*flash.NewSuccess("Template moved to trash")).Render(r.Context(), w)
 		if err != nil {
 			h.logger.Error("InstanceDelete: rendering template", "Error", err)
 		}
@@ -321,6 +327,13 @@ func (h *Handler) TemplatesNameEditPost(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	access, err := h.accessService.CanAdminEditInstance(r.Context(), user.ID, template.ID)
+	if err != nil || !access {
+		h.handleError(w, r, "TemplateNameEditPost: checking access", "You can't rename this template", "error", err)
+		_ = templates.TemplateName(*template).Render(r.Context(), w)
+		return
+	}
+
 	if err := r.ParseForm(); err != nil {
 		h.handleError(
 			w,
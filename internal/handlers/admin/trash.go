@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	admin "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// Trash shows the user's deleted games, locations and teams.
+// GET /admin/trash.
+func (h *Handler) Trash(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	items, err := h.trashService.List(r.Context(), user.ID)
+	if err != nil {
+		h.handleError(w, r, "Trash: listing trash", "Could not load the trash", "error", err)
+		return
+	}
+
+	c := admin.Trash(items)
+	err = admin.Layout(c, *user, "Trash", "Trash").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("Trash: rendering template", "error", err)
+	}
+}
+
+// TrashRestore puts a trashed item back.
+// POST /admin/trash/{id}/restore.
+func (h *Handler) TrashRestore(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	item, err := h.trashService.Restore(r.Context(), user.ID, chi.URLParam(r, "id"))
+	switch {
+	case errors.Is(err, services.ErrGameInTrash):
+		h.handleError(
+			w,
+			r,
+			"TrashRestore: game is in the trash",
+			"Restore the game first, then restore this",
+			"error",
+			err,
+		)
+	case err != nil:
+		h.handleError(w, r, "TrashRestore: restoring item", "Could not restore item", "error", err)
+	case item.Type == models.TrashInstance:
+		h.handleSuccess(w, r, item.Name+" restored. Find it in your games")
+	default:
+		h.handleSuccess(w, r, item.Name+" restored")
+	}
+
+	h.renderTrashItems(w, r, user.ID)
+}
+
+// TrashPurge deletes a trashed item forever.
+// DELETE /admin/trash/{id}.
+func (h *Handler) TrashPurge(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.deleteService.PurgeTrash(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.handleError(w, r, "TrashPurge: purging item", "Could not delete item", "error", err)
+	} else {
+		h.handleSuccess(w, r, "Deleted forever")
+	}
+
+	h.renderTrashItems(w, r, user.ID)
+}
+
+// renderTrashItems re-renders the trash list after it changes.
+func (h *Handler) renderTrashItems(w http.ResponseWriter, r *http.Request, userID string) {
+	items, err := h.trashService.List(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("listing trash", "error", err)
+		return
+	}
+
+	err = admin.TrashItems(items).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("rendering trash", "error", err)
+	}
+}
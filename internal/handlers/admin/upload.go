@@ -41,10 +41,16 @@ func (h *Handler) UploadMedia(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if r.Form.Get("context") == "image_block" {
-		if renderErr := templates.ImageAdminUpload(*media).Render(r.Context(), w); renderErr != nil {
-			h.handleError(w, r, "UploadMedia", "Failed to render template", "error", renderErr)
-		}
+	var renderErr error
+	switch r.Form.Get("context") {
+	case "image_block":
+		renderErr = templates.ImageAdminUpload(*media).Render(r.Context(), w)
+	case "matching_block":
+		renderErr = templates.MatchingAdminUpload(*media, r.Form.Get("target"), r.Form.Get("target_name")).
+			Render(r.Context(), w)
+	}
+	if renderErr != nil {
+		h.handleError(w, r, "UploadMedia", "Failed to render template", "error", renderErr)
 	}
 
 	h.handleSuccess(w, r, "File uploaded")
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"mime/multipart"
 	"net/http"
@@ -18,9 +19,18 @@ import (
 // htmxHeaderTrue is the string value "true" used in HTMX header comparisons.
 const htmxHeaderTrue = "true"
 
+type APIKeyService interface {
+	// Create generates a key, returning it in full only this once
+	Create(ctx context.Context, userID, name string, scopes []models.APIScope) (*models.APIKey, string, error)
+	List(ctx context.Context, userID string) ([]models.APIKey, error)
+	Revoke(ctx context.Context, userID, keyID string) error
+}
+
 type AccessService interface {
+	InstanceRole(ctx context.Context, userID, instanceID string) (models.OrganisationRole, error)
 	CanAdminAccessBlock(ctx context.Context, userID, blockID string) (bool, error)
 	CanAdminAccessInstance(ctx context.Context, userID, instanceID string) (bool, error)
+	CanAdminEditInstance(ctx context.Context, userID, instanceID string) (bool, error)
 	CanAdminAccessLocation(ctx context.Context, userID, locationID string) (bool, error)
 	CanAdminAccessMarker(ctx context.Context, userID, markerID string) (bool, error)
 	CanAdminAccessBlockOwner(
@@ -83,7 +93,59 @@ type BlockService interface {
 	// CheckValidationRequiredForLocation checks if any blocks in a location require validation
 	CheckValidationRequiredForLocation(ctx context.Context, locationID string) (bool, error)
 	// CheckValidationRequiredForCheckIn checks if any blocks still require validation for a check-in
-	CheckValidationRequiredForCheckIn(ctx context.Context, locationID, teamCode string) (bool, error)
+	CheckValidationRequiredForCheckIn(ctx context.Context, locationID string, team models.Team) (bool, error)
+	// UpdateVisibility replaces the visibility conditions for a block
+	UpdateVisibility(ctx context.Context, block blocks.Block, visibility blocks.Visibility) (blocks.Block, error)
+
+	// GetPollResults returns the distribution of votes for a poll
+	GetPollResults(ctx context.Context, poll *blocks.PollBlock) (blocks.PollResults, error)
+}
+
+type BlockLibraryService interface {
+	// List returns the user's library blocks
+	List(ctx context.Context, userID string) ([]services.LibraryBlock, error)
+	// ListForContext returns the user's library blocks that can be used in a context
+	ListForContext(
+		ctx context.Context,
+		userID string,
+		blockContext blocks.BlockContext,
+	) ([]services.LibraryBlock, error)
+	// Save copies a block into the user's library
+	Save(ctx context.Context, userID string, block blocks.Block, title string) (services.LibraryBlock, error)
+	// Rename changes the title of a library block
+	Rename(ctx context.Context, userID, itemID, title string) error
+	// Insert adds a copy of a library block, optionally linked, to the owner
+	Insert(
+		ctx context.Context,
+		userID, itemID, ownerID string,
+		blockContext blocks.BlockContext,
+		linked bool,
+	) (blocks.Block, error)
+	// SyncLinked copies a library block's content to its linked copies
+	SyncLinked(ctx context.Context, userID string, item blocks.Block) (int, error)
+	// Unlink stops a block from following its library block
+	Unlink(ctx context.Context, block blocks.Block) (blocks.Block, error)
+	// Delete removes a library block, keeping its copies
+	Delete(ctx context.Context, userID, itemID string) error
+}
+
+type BlockRevisionService interface {
+	// RecordOriginal saves a block's content before its first tracked change
+	RecordOriginal(ctx context.Context, blockID string) error
+	// RecordEdit saves a block's content after a user edits it
+	RecordEdit(ctx context.Context, userID, blockID string) error
+	// List returns a block's revisions, newest first
+	List(ctx context.Context, blockID string) ([]services.BlockRevision, error)
+	// Get returns a single revision
+	Get(ctx context.Context, revisionID string) (*models.BlockRevision, error)
+	// ListDeleted returns the blocks deleted from an owner that can be restored
+	ListDeleted(
+		ctx context.Context,
+		ownerID string,
+		blockContext blocks.BlockContext,
+	) ([]models.BlockRevision, error)
+	// Restore rolls a block back to a revision, or restores a deleted block
+	Restore(ctx context.Context, userID, revisionID string) (blocks.Block, error)
 }
 
 type CreditService interface {
@@ -98,12 +160,13 @@ type CreditService interface {
 }
 
 type DeleteService interface {
-	DeleteBlock(ctx context.Context, blockID string) error
+	DeleteBlock(ctx context.Context, userID, blockID string) error
 	DeleteInstance(ctx context.Context, userID, instanceID string) error
 	DeleteLocation(ctx context.Context, locationID string) error
 	ResetTeams(ctx context.Context, instanceID string, teamCodes []string) error
 	DeleteTeams(ctx context.Context, instanceID string, teamIDs []string) error
 	DeleteUser(ctx context.Context, userID string) error
+	PurgeTrash(ctx context.Context, userID, itemID string) error
 }
 
 type DuplicationService interface {
@@ -142,11 +205,28 @@ type FacilitatorService interface {
 	CreateFacilitatorToken(
 		ctx context.Context,
 		instanceID string,
+		label string,
 		locations []string,
 		duration time.Duration,
 	) (string, error)
 	ValidateToken(ctx context.Context, token string) (*models.FacilitatorToken, error)
 	CleanupExpiredTokens(ctx context.Context) error
+	// List returns the instance's facilitator links that have not expired
+	List(ctx context.Context, instanceID string) ([]models.FacilitatorToken, error)
+	// Revoke deletes a facilitator link, logging out anyone using it
+	Revoke(ctx context.Context, instanceID, id string) error
+	// Stations returns the token's locations with the teams at and due at each
+	Stations(ctx context.Context, token *models.FacilitatorToken) ([]services.FacilitatorStation, error)
+	CheckIn(ctx context.Context, token *models.FacilitatorToken, locationID, teamCode string) error
+	CheckOut(ctx context.Context, token *models.FacilitatorToken, locationID, teamCode string) error
+	ApproveBlock(ctx context.Context, token *models.FacilitatorToken, locationID, teamCode, blockID string) error
+	// Messages returns a team's conversation and marks the team's messages read
+	Messages(
+		ctx context.Context,
+		token *models.FacilitatorToken,
+		teamCode string,
+	) (*models.Team, []models.TeamMessage, error)
+	Reply(ctx context.Context, token *models.FacilitatorToken, teamCode, content string) error
 }
 
 type GameScheduleService interface {
@@ -163,6 +243,8 @@ type InstanceService interface {
 
 	// FindByUserID returns all instances for the given user
 	FindByUserID(ctx context.Context, userID string) ([]models.Instance, error)
+	// FindSharedWithUser returns the instances shared with the given user
+	FindSharedWithUser(ctx context.Context, userID string) ([]models.Instance, error)
 	// FindInstanceIDsForUser returns the IDs of all instances for the given user
 	FindInstanceIDsForUser(ctx context.Context, userID string) ([]string, error)
 
@@ -181,6 +263,19 @@ type InstanceSettingsService interface {
 	GetInstanceSettings(ctx context.Context, instanceID string) (*models.InstanceSettings, error)
 }
 
+type LocationImportService interface {
+	// Preview reads an import file and reports what would be created
+	Preview(
+		instance *models.Instance,
+		filename string,
+		file io.Reader,
+		groupID string,
+		defaultPoints int,
+	) ([]services.LocationImportRow, error)
+	// Import creates the locations from a preview
+	Import(ctx context.Context, instanceID string, rows []services.LocationImportRow) (int, error)
+}
+
 type MarkerService interface {
 	// CreateMarker creates a new marker
 	CreateMarker(ctx context.Context, name string, lat, lng float64) (models.Marker, error)
@@ -189,6 +284,31 @@ type MarkerService interface {
 	FindMarkersNotInInstance(ctx context.Context, instanceID string, otherInstances []string) ([]models.Marker, error)
 }
 
+type MarkerLibraryService interface {
+	// List returns the user's library markers that match the filter
+	List(ctx context.Context, ownerID string, filter services.MarkerLibraryFilter) ([]services.LibraryMarker, error)
+	// Get returns a marker from the user's library
+	Get(ctx context.Context, ownerID, code string) (*models.Marker, error)
+	// Create adds a new place to the user's library
+	Create(ctx context.Context, ownerID string, details services.MarkerLibraryDetails) (*models.Marker, error)
+	// Update changes a library marker
+	Update(ctx context.Context, ownerID, code string, details services.MarkerLibraryDetails) (*models.Marker, error)
+	// Add saves an existing marker to the user's library
+	Add(ctx context.Context, ownerID, code string) (*models.Marker, error)
+	// Remove takes a marker out of the user's library
+	Remove(ctx context.Context, ownerID, code string) error
+}
+
+type MessageService interface {
+	// Reply sends a message to a team from an admin or facilitator
+	Reply(ctx context.Context, team *models.Team, author, content string) (*models.TeamMessage, error)
+	// Thread returns a team's messages, oldest first
+	Thread(ctx context.Context, team *models.Team) ([]models.TeamMessage, error)
+	MarkReadByOrganisers(ctx context.Context, team *models.Team) error
+	// UnreadByTeam counts each team's messages no organiser has read yet
+	UnreadByTeam(ctx context.Context, instanceID string) (map[string]int, error)
+}
+
 type NavigationService interface {
 	GetNextLocations(ctx context.Context, team *models.Team) ([]models.Location, error)
 	GetPlayerNavigationView(ctx context.Context, team *models.Team) (*services.PlayerNavigationView, error)
@@ -198,12 +318,81 @@ type NotificationService interface {
 	SendNotification(ctx context.Context, teamCode string, content string) (models.Notification, error)
 	SendNotificationToAllTeams(ctx context.Context, instanceID string, content string) error
 	GetNotifications(ctx context.Context, teamCode string) ([]models.Notification, error)
+	// ScheduleNotification saves an announcement, sending it now if it is due
+	ScheduleNotification(
+		ctx context.Context,
+		instance *models.Instance,
+		notification *models.ScheduledNotification,
+	) error
+	// ScheduledNotifications returns the pending and sent announcements
+	ScheduledNotifications(ctx context.Context, instanceID string) ([]models.ScheduledNotification, error)
+	// CancelScheduledNotification deletes an announcement that hasn't been sent
+	CancelScheduledNotification(ctx context.Context, instanceID, id string) error
+}
+
+type OrganisationService interface {
+	// Memberships returns the organisations the user belongs to
+	Memberships(ctx context.Context, userID string) ([]models.OrganisationMember, error)
+	// Create starts a new organisation owned by the user
+	Create(ctx context.Context, userID, name string) (*models.Organisation, error)
+	// Get returns an organisation and the user's role in it
+	Get(ctx context.Context, userID, organisationID string) (*models.Organisation, models.OrganisationRole, error)
+	// Rename changes an organisation's name
+	Rename(ctx context.Context, userID, organisationID, name string) error
+	// Delete removes an organisation
+	Delete(ctx context.Context, userID, organisationID string) error
+	// AddMember gives the user with the email address a role
+	AddMember(
+		ctx context.Context,
+		userID, organisationID, email string,
+		role models.OrganisationRole,
+	) (*models.OrganisationMember, error)
+	// SetRole changes a member's role
+	SetRole(ctx context.Context, userID, organisationID, memberID string, role models.OrganisationRole) error
+	// RemoveMember takes a member out of an organisation
+	RemoveMember(ctx context.Context, userID, organisationID, memberID string) error
+	// ShareInstance shares a game or template with an organisation
+	ShareInstance(ctx context.Context, userID, organisationID, instanceID string) error
+	// UnshareInstance stops sharing a game or template
+	UnshareInstance(ctx context.Context, userID, instanceID string) error
+	// Billing returns the credit pool and each member's usage for a month
+	Billing(ctx context.Context, userID, organisationID string, month time.Time) (*services.OrganisationBilling, error)
+	// SetCreditLimit caps a member's monthly draw from the credit pool
+	SetCreditLimit(ctx context.Context, userID, organisationID, memberID string, limit int) error
+	// SetRequireTwoFactor sets whether members must use two-factor authentication
+	SetRequireTwoFactor(ctx context.Context, userID, organisationID string, require bool) error
+}
+
+type AdminSessionService interface {
+	// List returns the user's active sessions, most recently used first
+	List(ctx context.Context, userID string) ([]models.AdminSession, error)
+	// Revoke ends one of the user's sessions
+	Revoke(ctx context.Context, userID, sessionID string) error
+	// RevokeOthers ends every session except the current one
+	RevokeOthers(ctx context.Context, userID, currentID string) (int, error)
+	// RecentAttempts returns the latest attempts to log in to the account
+	RecentAttempts(ctx context.Context, userID string) ([]models.LoginAttempt, error)
 }
 
 type QuickstartService interface {
 	DismissQuickstart(ctx context.Context, instanceID string) error
 }
 
+type TwoFactorService interface {
+	// Status returns whether the user has or needs two-factor authentication
+	Status(ctx context.Context, user *models.User) (services.TwoFactorStatus, error)
+	// Required reports whether one of the user's organisations requires it
+	Required(ctx context.Context, userID string) (bool, error)
+	// BeginEnrolment gives the user a secret for their authenticator app
+	BeginEnrolment(ctx context.Context, user *models.User) (*services.TwoFactorEnrolment, error)
+	// ConfirmEnrolment turns on two-factor and returns recovery codes
+	ConfirmEnrolment(ctx context.Context, user *models.User, code string) ([]string, error)
+	// RegenerateRecoveryCodes replaces the user's recovery codes
+	RegenerateRecoveryCodes(ctx context.Context, user *models.User, code string) ([]string, error)
+	// Disable turns off two-factor authentication
+	Disable(ctx context.Context, user *models.User, code string) error
+}
+
 type TeamService interface {
 	// AddTeams adds teams to the database
 	AddTeams(ctx context.Context, instanceID string, count int) ([]models.Team, error)
@@ -236,6 +425,13 @@ type TeamService interface {
 	) []services.GroupedCheckIns
 }
 
+type TrashService interface {
+	// List returns the user's trash, most recently deleted first
+	List(ctx context.Context, userID string) ([]models.TrashItem, error)
+	// Restore puts a trashed game, location or team back
+	Restore(ctx context.Context, userID, itemID string) (*models.TrashItem, error)
+}
+
 type UploadService interface {
 	UploadFile(
 		ctx context.Context,
@@ -259,6 +455,29 @@ type UserService interface {
 	SwitchInstance(ctx context.Context, user *models.User, instanceID string) error
 }
 
+type WebhookService interface {
+	Create(ctx context.Context, instanceID, url string, events []models.WebhookEvent) (*models.Webhook, error)
+	List(ctx context.Context, instanceID string) ([]models.Webhook, error)
+	SetEnabled(ctx context.Context, instanceID, id string, enabled bool) (*models.Webhook, error)
+	Delete(ctx context.Context, instanceID, id string) error
+	// Deliveries returns the most recent deliveries, newest first
+	Deliveries(ctx context.Context, instanceID string, limit int) ([]models.WebhookDelivery, error)
+}
+
+type LTIService interface {
+	CreatePlatform(ctx context.Context, instanceID string, in services.LTIPlatformInput) (*models.LTIPlatform, error)
+	ListPlatforms(ctx context.Context, instanceID string) ([]models.LTIPlatform, error)
+	UpdateGrading(
+		ctx context.Context,
+		instanceID, id string,
+		mode models.LTIGradeMode,
+		scoreMaximum int,
+	) (*models.LTIPlatform, error)
+	DeletePlatform(ctx context.Context, instanceID, id string) error
+	// ToolConfig returns the URLs an LMS needs to register Rapua
+	ToolConfig() services.LTIToolConfig
+}
+
 type LeaderBoardService interface {
 	// GetLeaderBoardData returns sorted and ranked leaderboard data
 	GetLeaderBoardData(
@@ -275,9 +494,12 @@ type LeaderBoardService interface {
 type Handler struct {
 	logger                  *slog.Logger
 	accessService           AccessService
+	apiKeyService           APIKeyService
 	assetGenerator          services.AssetGenerator
 	identityService         IdentityService
 	blockService            BlockService
+	blockLibraryService     BlockLibraryService
+	blockRevisionService    BlockRevisionService
 	creditService           CreditService
 	creditPurchaseRepo      CreditPurchaseRepository
 	deleteService           DeleteService
@@ -288,24 +510,36 @@ type Handler struct {
 	instanceService         InstanceService
 	instanceSettingsService InstanceSettingsService
 	locationService         services.LocationService
+	locationImportService   LocationImportService
 	markerService           MarkerService
+	markerLibraryService    MarkerLibraryService
+	messageService          MessageService
 	navigationService       NavigationService
 	notificationService     NotificationService
+	organisationService     OrganisationService
 	teamService             TeamService
 	templateService         services.TemplateService
+	trashService            TrashService
 	uploadService           UploadService
 	userService             UserService
 	quickstartService       QuickstartService
 	leaderBoardService      LeaderBoardService
 	stripeService           StripeService
+	webhookService          WebhookService
+	ltiService              LTIService
+	twoFactorService        TwoFactorService
+	adminSessionService     AdminSessionService
 }
 
 func NewAdminHandler(
 	logger *slog.Logger,
 	accessService AccessService,
+	apiKeyService APIKeyService,
 	assetGenerator services.AssetGenerator,
 	identityService IdentityService,
 	blockService BlockService,
+	blockLibraryService BlockLibraryService,
+	blockRevisionService BlockRevisionService,
 	creditService CreditService,
 	creditPurchaseRepo CreditPurchaseRepository,
 	deleteService DeleteService,
@@ -316,23 +550,35 @@ func NewAdminHandler(
 	instanceService InstanceService,
 	instanceSettingsService InstanceSettingsService,
 	locationService services.LocationService,
+	locationImportService LocationImportService,
 	markerService MarkerService,
+	markerLibraryService MarkerLibraryService,
+	messageService MessageService,
 	navigationService NavigationService,
 	notificationService NotificationService,
+	organisationService OrganisationService,
 	teamService TeamService,
 	templateService services.TemplateService,
+	trashService TrashService,
 	uploadService UploadService,
 	userService UserService,
 	quickstartService QuickstartService,
 	leaderBoardService LeaderBoardService,
 	stripeService StripeService,
+	webhookService WebhookService,
+	ltiService LTIService,
+	twoFactorService TwoFactorService,
+	adminSessionService AdminSessionService,
 ) *Handler {
 	return &Handler{
 		logger:                  logger,
 		accessService:           accessService,
+		apiKeyService:           apiKeyService,
 		assetGenerator:          assetGenerator,
 		identityService:         identityService,
 		blockService:            blockService,
+		blockLibraryService:     blockLibraryService,
+		blockRevisionService:    blockRevisionService,
 		creditService:           creditService,
 		creditPurchaseRepo:      creditPurchaseRepo,
 		deleteService:           deleteService,
@@ -343,16 +589,25 @@ func NewAdminHandler(
 		instanceService:         instanceService,
 		instanceSettingsService: instanceSettingsService,
 		locationService:         locationService,
+		locationImportService:   locationImportService,
 		markerService:           markerService,
+		markerLibraryService:    markerLibraryService,
+		messageService:          messageService,
 		navigationService:       navigationService,
 		notificationService:     notificationService,
+		organisationService:     organisationService,
 		teamService:             teamService,
 		templateService:         templateService,
+		trashService:            trashService,
 		uploadService:           uploadService,
 		userService:             userService,
 		quickstartService:       quickstartService,
 		leaderBoardService:      leaderBoardService,
 		stripeService:           stripeService,
+		webhookService:          webhookService,
+		ltiService:              ltiService,
+		twoFactorService:        twoFactorService,
+		adminSessionService:     adminSessionService,
 	}
 }
 
@@ -361,6 +616,16 @@ func (h *Handler) GetIdentityService() IdentityService {
 	return h.identityService
 }
 
+// GetTwoFactorService returns the TwoFactorService used by the handler.
+func (h *Handler) GetTwoFactorService() TwoFactorService {
+	return h.twoFactorService
+}
+
+// GetAccessService returns the AccessService used by the handler.
+func (h *Handler) GetAccessService() AccessService {
+	return h.accessService
+}
+
 // UserFromContext retrieves the user from the context.
 // User will always be in the context because of the middleware.
 func (h *Handler) UserFromContext(ctx context.Context) *models.User {
@@ -0,0 +1,136 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	templates "github.com/nathanhollows/Rapua/v6/internal/templates/admin"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// webhookDeliveryLimit is how many deliveries the log shows.
+const webhookDeliveryLimit = 50
+
+// Webhooks shows the current game's webhooks and their delivery log.
+// GET /admin/webhooks.
+func (h *Handler) Webhooks(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	webhooks, err := h.webhookService.List(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.handleError(w, r, "Webhooks: listing webhooks", "Could not load webhooks", "error", err)
+		return
+	}
+	deliveries, err := h.webhookService.Deliveries(r.Context(), user.CurrentInstanceID, webhookDeliveryLimit)
+	if err != nil {
+		h.handleError(w, r, "Webhooks: listing deliveries", "Could not load webhooks", "error", err)
+		return
+	}
+
+	c := templates.Webhooks(webhooks, deliveries)
+	err = templates.Layout(c, *user, "Experience", "Webhooks").Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("Webhooks: rendering template", "error", err)
+	}
+}
+
+// WebhookCreate adds a webhook to the current game.
+// POST /admin/webhooks.
+func (h *Handler) WebhookCreate(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := r.ParseForm()
+	if err != nil {
+		h.handleError(w, r, "WebhookCreate: parse form", "Failed to parse form data", "error", err)
+	} else {
+		events := make([]models.WebhookEvent, 0, len(r.Form["events"]))
+		for _, event := range r.Form["events"] {
+			events = append(events, models.WebhookEvent(event))
+		}
+		_, err = h.webhookService.Create(r.Context(), user.CurrentInstanceID, r.FormValue("url"), events)
+		if err != nil {
+			h.handleError(
+				w,
+				r,
+				"WebhookCreate: creating webhook",
+				"Could not add webhook: "+err.Error(),
+				"error",
+				err,
+				"instance_id",
+				user.CurrentInstanceID,
+			)
+		} else {
+			h.handleSuccess(w, r, "Webhook added")
+		}
+	}
+
+	// The list is the swap target, so it is rendered even after an error
+	webhooks, err := h.webhookService.List(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.logger.Error("WebhookCreate: listing webhooks", "error", err, "instance_id", user.CurrentInstanceID)
+	}
+	err = templates.WebhookList(webhooks).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("WebhookCreate: rendering template", "error", err)
+	}
+}
+
+// WebhookToggle turns a webhook on or off.
+// PUT /admin/webhooks/{id}.
+func (h *Handler) WebhookToggle(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	if err := r.ParseForm(); err != nil {
+		h.handleError(w, r, "WebhookToggle: parse form", "Failed to parse form data", "error", err)
+		return
+	}
+
+	enabled := r.FormValue("enabled") == "on"
+	_, err := h.webhookService.SetEnabled(r.Context(), user.CurrentInstanceID, chi.URLParam(r, "id"), enabled)
+	if err != nil {
+		h.handleError(w, r, "WebhookToggle: updating webhook", "Could not update webhook", "error", err)
+		return
+	}
+
+	if enabled {
+		h.handleSuccess(w, r, "Webhook enabled")
+	} else {
+		h.handleSuccess(w, r, "Webhook disabled")
+	}
+}
+
+// WebhookDelete removes a webhook and its delivery log.
+// DELETE /admin/webhooks/{id}.
+func (h *Handler) WebhookDelete(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	err := h.webhookService.Delete(r.Context(), user.CurrentInstanceID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.handleError(w, r, "WebhookDelete: deleting webhook", "Could not delete webhook", "error", err)
+		return
+	}
+
+	h.handleSuccess(w, r, "Webhook deleted")
+}
+
+// WebhookDeliveries refreshes the delivery log.
+// GET /admin/webhooks/deliveries.
+func (h *Handler) WebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	user := h.UserFromContext(r.Context())
+
+	webhooks, err := h.webhookService.List(r.Context(), user.CurrentInstanceID)
+	if err != nil {
+		h.handleError(w, r, "WebhookDeliveries: listing webhooks", "Could not load deliveries", "error", err)
+		return
+	}
+	deliveries, err := h.webhookService.Deliveries(r.Context(), user.CurrentInstanceID, webhookDeliveryLimit)
+	if err != nil {
+		h.handleError(w, r, "WebhookDeliveries: listing deliveries", "Could not load deliveries", "error", err)
+		return
+	}
+
+	err = templates.WebhookDeliveries(webhooks, deliveries).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("WebhookDeliveries: rendering template", "error", err)
+	}
+}
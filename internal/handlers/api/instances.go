@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// ListInstances returns the user's games and the games shared with them.
+func (h *Handler) ListInstances(w http.ResponseWriter, r *http.Request) {
+	user := h.userFromContext(r.Context())
+
+	owned, err := h.instanceService.FindByUserID(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("API ListInstances: finding instances", "error", err, "user_id", user.ID)
+		h.writeError(w, http.StatusInternalServerError, "could not list instances")
+		return
+	}
+	shared, err := h.instanceService.FindSharedWithUser(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("API ListInstances: finding shared instances", "error", err, "user_id", user.ID)
+		h.writeError(w, http.StatusInternalServerError, "could not list instances")
+		return
+	}
+
+	data := make([]instanceResponse, 0, len(owned)+len(shared))
+	for _, instance := range owned {
+		data = append(data, newInstanceResponse(instance, models.RoleOwner))
+	}
+	for _, instance := range shared {
+		role, err := h.accessService.InstanceRole(r.Context(), user.ID, instance.ID)
+		if err != nil {
+			h.logger.Error("API ListInstances: checking role", "error", err, "instance_id", instance.ID)
+			h.writeError(w, http.StatusInternalServerError, "could not list instances")
+			return
+		}
+		data = append(data, newInstanceResponse(instance, role))
+	}
+
+	h.writeJSON(w, http.StatusOK, listResponse[instanceResponse]{Data: data})
+}
+
+// GetInstance returns one game.
+func (h *Handler) GetInstance(w http.ResponseWriter, r *http.Request) {
+	instanceID := chi.URLParam(r, "instanceID")
+	role, ok := h.instanceRole(w, r, instanceID, nil)
+	if !ok {
+		return
+	}
+
+	instance, err := h.instanceService.GetByID(r.Context(), instanceID)
+	if err != nil {
+		h.logger.Error("API GetInstance: finding instance", "error", err, "instance_id", instanceID)
+		h.writeError(w, http.StatusInternalServerError, "could not load instance")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, newInstanceResponse(*instance, role))
+}
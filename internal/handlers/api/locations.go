@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+type createLocationRequest struct {
+	Name   string  `json:"name"`
+	Lat    float64 `json:"lat"`
+	Lng    float64 `json:"lng"`
+	Points int     `json:"points"`
+}
+
+// ListLocations returns a game's locations.
+func (h *Handler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	instanceID := chi.URLParam(r, "instanceID")
+	if _, ok := h.instanceRole(w, r, instanceID, nil); !ok {
+		return
+	}
+
+	locations, err := h.locationService.FindByInstance(r.Context(), instanceID)
+	if err != nil {
+		h.logger.Error("API ListLocations: finding locations", "error", err, "instance_id", instanceID)
+		h.writeError(w, http.StatusInternalServerError, "could not list locations")
+		return
+	}
+
+	data := make([]locationResponse, 0, len(locations))
+	for _, location := range locations {
+		data = append(data, newLocationResponse(location))
+	}
+	h.writeJSON(w, http.StatusOK, listResponse[locationResponse]{Data: data})
+}
+
+// CreateLocation adds a location to a game. Only editors may change a
+// game's content.
+func (h *Handler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	instanceID := chi.URLParam(r, "instanceID")
+	if _, ok := h.instanceRole(w, r, instanceID, models.OrganisationRole.CanEdit); !ok {
+		return
+	}
+
+	var req createLocationRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	location, err := h.locationService.CreateLocation(
+		r.Context(),
+		instanceID,
+		strings.TrimSpace(req.Name),
+		req.Lat,
+		req.Lng,
+		req.Points,
+	)
+	if err != nil {
+		// The service only fails validation before it writes anything
+		h.logger.Warn("API CreateLocation: creating location", "error", err, "instance_id", instanceID)
+		h.writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	// Load the marker so its coordinates are included
+	err = h.locationService.LoadRelations(r.Context(), &location)
+	if err != nil {
+		h.logger.Error("API CreateLocation: loading marker", "error", err, "location_id", location.ID)
+		h.writeError(w, http.StatusInternalServerError, "location created but could not be loaded")
+		return
+	}
+	h.writeJSON(w, http.StatusCreated, newLocationResponse(location))
+}
+
+// GetLocation returns one location.
+func (h *Handler) GetLocation(w http.ResponseWriter, r *http.Request) {
+	location, err := h.locationService.GetByID(r.Context(), chi.URLParam(r, "locationID"))
+	if err != nil {
+		h.writeError(w, http.StatusNotFound, errNotFound.Error())
+		return
+	}
+	if _, ok := h.instanceRole(w, r, location.InstanceID, nil); !ok {
+		return
+	}
+
+	err = h.locationService.LoadRelations(r.Context(), location)
+	if err != nil {
+		h.logger.Error("API GetLocation: loading marker", "error", err, "location_id", location.ID)
+		h.writeError(w, http.StatusInternalServerError, "could not load location")
+		return
+	}
+	h.writeJSON(w, http.StatusOK, newLocationResponse(*location))
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+type sendNotificationRequest struct {
+	Content string `json:"content"`
+	// Leave empty to notify every team that has started
+	TeamCode string `json:"team_code"`
+}
+
+// SendNotification sends a message to one team or to every team playing.
+func (h *Handler) SendNotification(w http.ResponseWriter, r *http.Request) {
+	instanceID := chi.URLParam(r, "instanceID")
+	if _, ok := h.instanceRole(w, r, instanceID, models.OrganisationRole.CanFacilitate); !ok {
+		return
+	}
+
+	var req sendNotificationRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	req.Content = strings.TrimSpace(req.Content)
+	if req.Content == "" {
+		h.writeError(w, http.StatusUnprocessableEntity, "content cannot be empty")
+		return
+	}
+
+	if req.TeamCode == "" {
+		err := h.notificationService.SendNotificationToAllTeams(r.Context(), instanceID, req.Content)
+		if err != nil {
+			h.logger.Warn("API SendNotification: notifying all teams", "error", err, "instance_id", instanceID)
+			h.writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	team, err := h.teamService.GetTeamByCode(r.Context(), req.TeamCode)
+	if err != nil || team == nil || team.InstanceID != instanceID {
+		h.writeError(w, http.StatusUnprocessableEntity, "team not found in this instance")
+		return
+	}
+	_, err = h.notificationService.SendNotification(r.Context(), team.Code, req.Content)
+	if err != nil {
+		h.logger.Error("API SendNotification: notifying team", "error", err, "team_code", team.Code)
+		h.writeError(w, http.StatusInternalServerError, "could not send notification")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// The API returns these types rather than the models so that changes to the
+// database don't change what integrations receive.
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type listResponse[T any] struct {
+	Data []T `json:"data"`
+}
+
+type instanceResponse struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Status         string     `json:"status"`
+	Role           string     `json:"role"`
+	OrganisationID string     `json:"organisation_id,omitempty"`
+	StartTime      *time.Time `json:"start_time"`
+	EndTime        *time.Time `json:"end_time"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func newInstanceResponse(instance models.Instance, role models.OrganisationRole) instanceResponse {
+	res := instanceResponse{
+		ID:             instance.ID,
+		Name:           instance.Name,
+		Status:         instance.GetStatus().String(),
+		Role:           string(role),
+		OrganisationID: instance.OrganisationID,
+		CreatedAt:      instance.CreatedAt,
+	}
+	if !instance.StartTime.IsZero() {
+		res.StartTime = &instance.StartTime.Time
+	}
+	if !instance.EndTime.IsZero() {
+		res.EndTime = &instance.EndTime.Time
+	}
+	return res
+}
+
+type locationResponse struct {
+	ID         string  `json:"id"`
+	InstanceID string  `json:"instance_id"`
+	Name       string  `json:"name"`
+	Code       string  `json:"code"`
+	Lat        float64 `json:"lat"`
+	Lng        float64 `json:"lng"`
+	Points     int     `json:"points"`
+	Order      int     `json:"order"`
+}
+
+func newLocationResponse(location models.Location) locationResponse {
+	return locationResponse{
+		ID:         location.ID,
+		InstanceID: location.InstanceID,
+		Name:       location.Name,
+		Code:       location.MarkerID,
+		Lat:        location.Marker.Lat,
+		Lng:        location.Marker.Lng,
+		Points:     location.Points,
+		Order:      location.Order,
+	}
+}
+
+type teamResponse struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	InstanceID string `json:"instance_id"`
+	HasStarted bool   `json:"has_started"`
+	Points     int    `json:"points"`
+}
+
+func newTeamResponse(team models.Team) teamResponse {
+	return teamResponse{
+		Code:       team.Code,
+		Name:       team.Name,
+		InstanceID: team.InstanceID,
+		HasStarted: team.HasStarted,
+		Points:     team.Points,
+	}
+}
+
+type checkInResponse struct {
+	TeamCode        string     `json:"team_code"`
+	LocationID      string     `json:"location_id"`
+	TimeIn          time.Time  `json:"time_in"`
+	TimeOut         *time.Time `json:"time_out"`
+	Points          int        `json:"points"`
+	BlocksCompleted bool       `json:"blocks_completed"`
+}
+
+func newCheckInResponse(checkIn models.CheckIn) checkInResponse {
+	res := checkInResponse{
+		TeamCode:        checkIn.TeamID,
+		LocationID:      checkIn.LocationID,
+		TimeIn:          checkIn.TimeIn,
+		Points:          checkIn.Points,
+		BlocksCompleted: checkIn.BlocksCompleted,
+	}
+	if !checkIn.TimeOut.IsZero() {
+		res.TimeOut = &checkIn.TimeOut
+	}
+	return res
+}
+
+type leaderboardResponse struct {
+	Rank         int        `json:"rank"`
+	TeamCode     string     `json:"team_code"`
+	TeamName     string     `json:"team_name"`
+	Points       int        `json:"points"`
+	Progress     int        `json:"progress"`
+	Status       string     `json:"status"`
+	CheckInCount int        `json:"check_in_count"`
+	LastSeen     *time.Time `json:"last_seen"`
+}
+
+func newLeaderboardResponse(row services.LeaderBoardTeamData) leaderboardResponse {
+	res := leaderboardResponse{
+		Rank:         row.Rank,
+		TeamCode:     row.Code,
+		TeamName:     row.Name,
+		Points:       row.Points,
+		Progress:     row.Progress,
+		Status:       string(row.Status),
+		CheckInCount: row.CheckInCount,
+	}
+	if !row.LastSeen.IsZero() {
+		res.LastSeen = &row.LastSeen
+	}
+	return res
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+)
+
+// ListCheckIns returns every check-in in a game.
+func (h *Handler) ListCheckIns(w http.ResponseWriter, r *http.Request) {
+	instanceID := chi.URLParam(r, "instanceID")
+	if _, ok := h.instanceRole(w, r, instanceID, nil); !ok {
+		return
+	}
+
+	teams, err := h.teamService.FindAllWithScans(r.Context(), instanceID)
+	if err != nil {
+		h.logger.Error("API ListCheckIns: finding teams", "error", err, "instance_id", instanceID)
+		h.writeError(w, http.StatusInternalServerError, "could not list check-ins")
+		return
+	}
+
+	data := []checkInResponse{}
+	for _, team := range teams {
+		for _, checkIn := range team.CheckIns {
+			data = append(data, newCheckInResponse(checkIn))
+		}
+	}
+	h.writeJSON(w, http.StatusOK, listResponse[checkInResponse]{Data: data})
+}
+
+// Leaderboard returns the ranked teams that have started playing. It takes
+// the same ranking, sort and order query parameters as the activity page.
+func (h *Handler) Leaderboard(w http.ResponseWriter, r *http.Request) {
+	instanceID := chi.URLParam(r, "instanceID")
+	if _, ok := h.instanceRole(w, r, instanceID, nil); !ok {
+		return
+	}
+
+	teams, err := h.teamService.FindAllWithScans(r.Context(), instanceID)
+	if err != nil {
+		h.logger.Error("API Leaderboard: finding teams", "error", err, "instance_id", instanceID)
+		h.writeError(w, http.StatusInternalServerError, "could not load leaderboard")
+		return
+	}
+	locations, err := h.locationService.FindByInstance(r.Context(), instanceID)
+	if err != nil {
+		h.logger.Error("API Leaderboard: finding locations", "error", err, "instance_id", instanceID)
+		h.writeError(w, http.StatusInternalServerError, "could not load leaderboard")
+		return
+	}
+
+	sortField := r.URL.Query().Get("sort")
+	if sortField == "" {
+		sortField = "rank"
+	}
+	sortOrder := r.URL.Query().Get("order")
+	if sortOrder == "" {
+		sortOrder = "asc"
+	}
+
+	rows, err := h.leaderBoardService.GetLeaderBoardData(
+		r.Context(),
+		teams,
+		len(locations),
+		r.URL.Query().Get("ranking"),
+		sortField,
+		sortOrder,
+	)
+	if err != nil {
+		h.logger.Error("API Leaderboard: ranking teams", "error", err, "instance_id", instanceID)
+		h.writeError(w, http.StatusInternalServerError, "could not load leaderboard")
+		return
+	}
+
+	data := make([]leaderboardResponse, 0, len(rows))
+	for _, row := range rows {
+		data = append(data, newLeaderboardResponse(row))
+	}
+	h.writeJSON(w, http.StatusOK, listResponse[leaderboardResponse]{Data: data})
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// maxTeamsPerRequest stops a single request from creating an unbounded
+// number of teams.
+const maxTeamsPerRequest = 500
+
+type createTeamsRequest struct {
+	Count int `json:"count"`
+}
+
+// ListTeams returns a game's teams.
+func (h *Handler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	instanceID := chi.URLParam(r, "instanceID")
+	if _, ok := h.instanceRole(w, r, instanceID, nil); !ok {
+		return
+	}
+
+	teams, err := h.teamService.FindAll(r.Context(), instanceID)
+	if err != nil {
+		h.logger.Error("API ListTeams: finding teams", "error", err, "instance_id", instanceID)
+		h.writeError(w, http.StatusInternalServerError, "could not list teams")
+		return
+	}
+
+	data := make([]teamResponse, 0, len(teams))
+	for _, team := range teams {
+		data = append(data, newTeamResponse(team))
+	}
+	h.writeJSON(w, http.StatusOK, listResponse[teamResponse]{Data: data})
+}
+
+// CreateTeams adds teams to a game. Facilitators may add teams, as they can
+// from the admin pages.
+func (h *Handler) CreateTeams(w http.ResponseWriter, r *http.Request) {
+	instanceID := chi.URLParam(r, "instanceID")
+	if _, ok := h.instanceRole(w, r, instanceID, models.OrganisationRole.CanFacilitate); !ok {
+		return
+	}
+
+	var req createTeamsRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if req.Count < 1 || req.Count > maxTeamsPerRequest {
+		h.writeError(w, http.StatusUnprocessableEntity, "count must be between 1 and 500")
+		return
+	}
+
+	teams, err := h.teamService.AddTeams(r.Context(), instanceID, req.Count)
+	if err != nil {
+		h.logger.Error("API CreateTeams: adding teams", "error", err, "instance_id", instanceID)
+		h.writeError(w, http.StatusInternalServerError, "could not add teams")
+		return
+	}
+
+	data := make([]teamResponse, 0, len(teams))
+	for _, team := range teams {
+		data = append(data, newTeamResponse(team))
+	}
+	h.writeJSON(w, http.StatusCreated, listResponse[teamResponse]{Data: data})
+}
+
+// GetTeam returns one team by its code.
+func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	team, err := h.teamService.GetTeamByCode(r.Context(), chi.URLParam(r, "code"))
+	if err != nil || team == nil {
+		h.writeError(w, http.StatusNotFound, errNotFound.Error())
+		return
+	}
+	if _, ok := h.instanceRole(w, r, team.InstanceID, nil); !ok {
+		return
+	}
+	h.writeJSON(w, http.StatusOK, newTeamResponse(*team))
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// maxBodyBytes limits the size of JSON request bodies.
+const maxBodyBytes = 64 << 10
+
+type AccessService interface {
+	// InstanceRole returns the user's role in a game, or "" without access
+	InstanceRole(ctx context.Context, userID, instanceID string) (models.OrganisationRole, error)
+}
+
+type APIKeyService interface {
+	Authenticate(ctx context.Context, secret string) (*models.APIKey, *models.User, error)
+}
+
+type InstanceService interface {
+	FindByUserID(ctx context.Context, userID string) ([]models.Instance, error)
+	FindSharedWithUser(ctx context.Context, userID string) ([]models.Instance, error)
+	GetByID(ctx context.Context, id string) (*models.Instance, error)
+}
+
+type LeaderBoardService interface {
+	GetLeaderBoardData(
+		ctx context.Context,
+		teams []models.Team,
+		locationCount int,
+		rankingScheme string,
+		sortField string,
+		sortOrder string,
+	) ([]services.LeaderBoardTeamData, error)
+}
+
+type LocationService interface {
+	CreateLocation(ctx context.Context, instanceID, name string, lat, lng float64, points int) (models.Location, error)
+	GetByID(ctx context.Context, locationID string) (*models.Location, error)
+	FindByInstance(ctx context.Context, instanceID string) ([]models.Location, error)
+	LoadRelations(ctx context.Context, location *models.Location) error
+}
+
+type NotificationService interface {
+	SendNotification(ctx context.Context, teamCode string, content string) (models.Notification, error)
+	SendNotificationToAllTeams(ctx context.Context, instanceID string, content string) error
+}
+
+type TeamService interface {
+	AddTeams(ctx context.Context, instanceID string, count int) ([]models.Team, error)
+	FindAll(ctx context.Context, instanceID string) ([]models.Team, error)
+	FindAllWithScans(ctx context.Context, instanceID string) ([]models.Team, error)
+	GetTeamByCode(ctx context.Context, code string) (*models.Team, error)
+}
+
+// Handler serves the versioned JSON API. Requests are authenticated by API
+// key before they reach it, and every game is checked against the key
+// owner's role just as the admin pages do.
+type Handler struct {
+	logger              *slog.Logger
+	accessService       AccessService
+	apiKeyService       APIKeyService
+	instanceService     InstanceService
+	leaderBoardService  LeaderBoardService
+	locationService     LocationService
+	notificationService NotificationService
+	teamService         TeamService
+}
+
+func NewHandler(
+	logger *slog.Logger,
+	accessService AccessService,
+	apiKeyService APIKeyService,
+	instanceService InstanceService,
+	leaderBoardService LeaderBoardService,
+	locationService LocationService,
+	notificationService NotificationService,
+	teamService TeamService,
+) *Handler {
+	return &Handler{
+		logger:              logger,
+		accessService:       accessService,
+		apiKeyService:       apiKeyService,
+		instanceService:     instanceService,
+		leaderBoardService:  leaderBoardService,
+		locationService:     locationService,
+		notificationService: notificationService,
+		teamService:         teamService,
+	}
+}
+
+func (h *Handler) GetAPIKeyService() APIKeyService {
+	return h.apiKeyService
+}
+
+// errNotFound is returned for games the key's owner cannot see, so that
+// callers cannot tell them apart from games that don't exist.
+var errNotFound = errors.New("not found")
+
+func (h *Handler) userFromContext(ctx context.Context) *models.User {
+	user, ok := ctx.Value(contextkeys.UserKey).(*models.User)
+	if !ok {
+		return nil
+	}
+	return user
+}
+
+// instanceRole returns the user's role in a game. It writes the error
+// response and returns ok=false if the user has no access, or if allowed
+// rejects the role.
+func (h *Handler) instanceRole(
+	w http.ResponseWriter,
+	r *http.Request,
+	instanceID string,
+	allowed func(models.OrganisationRole) bool,
+) (models.OrganisationRole, bool) {
+	user := h.userFromContext(r.Context())
+	if user == nil {
+		h.writeError(w, http.StatusUnauthorized, "missing API key")
+		return "", false
+	}
+
+	role, err := h.accessService.InstanceRole(r.Context(), user.ID, instanceID)
+	if err != nil {
+		h.logger.Error("API: checking instance role", "error", err, "instance_id", instanceID)
+		h.writeError(w, http.StatusInternalServerError, "could not check access")
+		return "", false
+	}
+	if role == "" {
+		h.writeError(w, http.StatusNotFound, errNotFound.Error())
+		return "", false
+	}
+	if allowed != nil && !allowed(role) {
+		h.writeError(w, http.StatusForbidden, "your role in this game does not allow this")
+		return "", false
+	}
+	return role, true
+}
+
+// decode reads a JSON request body into v.
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if errors.Is(err, io.EOF) {
+		h.writeError(w, http.StatusBadRequest, "request body is empty")
+		return false
+	}
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		h.logger.Error("API: writing response", "error", err)
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, status int, message string) {
+	h.writeJSON(w, status, errorResponse{Error: message})
+}
+
+// NotFound answers unknown API routes in JSON rather than with the HTML 404.
+func (h *Handler) NotFound(w http.ResponseWriter, _ *http.Request) {
+	h.writeError(w, http.StatusNotFound, errNotFound.Error())
+}
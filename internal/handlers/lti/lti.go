@@ -0,0 +1,166 @@
+package lti
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/internal/sessions"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+const (
+	// ltiSession holds the state and nonce between login and launch
+	ltiSession = "lti"
+	// ltiSessionAge is how long a user has to sign in at the platform
+	ltiSessionAge = 600
+)
+
+type LTIService interface {
+	KeySet(ctx context.Context) ([]byte, error)
+	Launch(ctx context.Context, idToken, nonce string) (*models.Team, error)
+	Login(ctx context.Context, req services.LTILoginRequest) (*services.LTILoginRedirect, error)
+}
+
+// Handler lets an LMS launch games as an LTI 1.3 tool. The platform's
+// requests are signed, so these routes sit outside CSRF protection.
+type Handler struct {
+	logger     *slog.Logger
+	ltiService LTIService
+}
+
+func NewHandler(logger *slog.Logger, ltiService LTIService) *Handler {
+	return &Handler{
+		logger:     logger,
+		ltiService: ltiService,
+	}
+}
+
+// Login starts a launch. The platform sends the browser here, and Rapua
+// sends it back to the platform to authenticate the user.
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid login request", http.StatusBadRequest)
+		return
+	}
+
+	redirect, err := h.ltiService.Login(r.Context(), services.LTILoginRequest{
+		Issuer:         r.Form.Get("iss"),
+		LoginHint:      r.Form.Get("login_hint"),
+		TargetLinkURI:  r.Form.Get("target_link_uri"),
+		LTIMessageHint: r.Form.Get("lti_message_hint"),
+		ClientID:       r.Form.Get("client_id"),
+		DeploymentID:   r.Form.Get("lti_deployment_id"),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrLTIPlatformNotFound) || errors.Is(err, services.ErrInvalidLTILaunch) {
+			http.Error(w, "This LMS is not registered with Rapua. Ask the game's host to check its LMS settings.",
+				http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("LTI login", "error", err, "issuer", r.Form.Get("iss"))
+		http.Error(w, "Something went wrong starting the game", http.StatusInternalServerError)
+		return
+	}
+
+	session, err := sessions.Get(r, ltiSession)
+	if err != nil {
+		h.logger.Error("LTI login: getting session", "error", err)
+	}
+	session.Values["state"] = redirect.State
+	session.Values["nonce"] = redirect.Nonce
+	// The launch is a cross-site POST from the platform, so the cookie must
+	// be sent with it
+	session.Options.Path = "/lti"
+	session.Options.MaxAge = ltiSessionAge
+	session.Options.HttpOnly = true
+	session.Options.Secure = true
+	session.Options.SameSite = http.SameSiteNoneMode
+	if err = session.Save(r, w); err != nil {
+		h.logger.Error("LTI login: saving session", "error", err)
+		http.Error(w, "Something went wrong starting the game", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, redirect.URL, http.StatusFound)
+}
+
+// Launch signs the LMS user in as their team and sends them to the game.
+func (h *Handler) Launch(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid launch", http.StatusBadRequest)
+		return
+	}
+	if errMsg := r.Form.Get("error"); errMsg != "" {
+		h.logger.Warn("LTI launch: platform returned an error", "error", errMsg,
+			"description", r.Form.Get("error_description"))
+		http.Error(w, "The LMS could not sign you in. Please try again.", http.StatusBadRequest)
+		return
+	}
+
+	session, err := sessions.Get(r, ltiSession)
+	if err != nil {
+		h.logger.Warn("LTI launch: getting session", "error", err)
+	}
+	state, _ := session.Values["state"].(string)
+	nonce, _ := session.Values["nonce"].(string)
+	if state == "" || state != r.Form.Get("state") {
+		http.Error(w, "This launch has expired. Please open the game from your course again.", http.StatusBadRequest)
+		return
+	}
+
+	// A state and nonce may only be used once
+	session.Options.MaxAge = -1
+	if err = session.Save(r, w); err != nil {
+		h.logger.Error("LTI launch: clearing session", "error", err)
+	}
+
+	team, err := h.ltiService.Launch(r.Context(), r.Form.Get("id_token"), nonce)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInsufficientCredits):
+			http.Error(w, "Unable to start game. The host has been notified.", http.StatusPaymentRequired)
+		case errors.Is(err, services.ErrLTIPlatformNotFound), errors.Is(err, services.ErrInvalidLTILaunch):
+			h.logger.Warn("LTI launch: rejected", "error", err)
+			http.Error(w, "This launch could not be verified. Please open the game from your course again.",
+				http.StatusUnauthorized)
+		default:
+			h.logger.Error("LTI launch", "error", err)
+			http.Error(w, "Something went wrong starting the game", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// An unreadable player cookie is replaced with a fresh session
+	player, err := sessions.GetPlayer(r)
+	if err != nil {
+		h.logger.Warn("LTI launch: getting player session", "error", err)
+	}
+	player.Values["team"] = team.Code
+	player.Options.Path = "/"
+	player.Options.HttpOnly = true
+	player.Options.SameSite = http.SameSiteLaxMode
+	player.Options.Secure = true
+	if err = player.Save(r, w); err != nil {
+		h.logger.Error("LTI launch: saving player session", "error", err)
+		http.Error(w, "Something went wrong starting the game", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/next", http.StatusSeeOther)
+}
+
+// KeySet publishes the public key platforms use to check Rapua's requests.
+func (h *Handler) KeySet(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.ltiService.KeySet(r.Context())
+	if err != nil {
+		h.logger.Error("LTI key set", "error", err)
+		http.Error(w, "Key set unavailable", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	_, _ = w.Write(keys)
+}
@@ -179,6 +179,47 @@ func (h *PlayerHandler) GetStartGameButtonBlock(w http.ResponseWriter, r *http.R
 	}
 }
 
+// GetPollResults returns the live results for a poll the team has voted on.
+func (h *PlayerHandler) GetPollResults(w http.ResponseWriter, r *http.Request) {
+	blockID := chi.URLParam(r, "id")
+
+	team, err := h.getTeamFromContext(r.Context())
+	if err != nil {
+		h.handleError(w, r, "getting team from context", "Unable to load poll results. Please try refreshing the page.")
+		return
+	}
+
+	// Results are only shown to teams that have voted. A team can only have
+	// a state for blocks in its own instance.
+	block, state, err := h.blockService.GetBlockWithStateByBlockIDAndTeamCode(r.Context(), blockID, team.Code)
+	if err != nil {
+		h.handleError(w, r, fmt.Errorf("getting block %s: %w", blockID, err).Error(), "This block could not be found.")
+		return
+	}
+
+	pollBlock, ok := block.(*blocks.PollBlock)
+	if !ok || !state.IsComplete() {
+		h.handleError(w, r, "poll results requested before voting", "Vote to see the results.")
+		return
+	}
+
+	results, err := h.blockService.GetPollResults(r.Context(), pollBlock)
+	if err != nil {
+		h.handleError(w, r, fmt.Errorf("getting poll results: %w", err).Error(), "Unable to load poll results.")
+		return
+	}
+
+	vote, _ := pollBlock.ParsePlayerData(state.GetPlayerData())
+	err = templates.PollResults(results, vote.Option).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error(
+			"failed to render poll results template",
+			"error", err.Error(),
+			"block_id", blockID,
+		)
+	}
+}
+
 // ValidateBlock runs input validation on the block.
 func (h *PlayerHandler) ValidateBlock(w http.ResponseWriter, r *http.Request) {
 	team, err := h.getTeamFromContext(r.Context())
@@ -213,7 +254,17 @@ func (h *PlayerHandler) ValidateBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = templates.RenderPlayerUpdate(team.Instance.Settings, block, state).Render(r.Context(), w)
+	// Let hidden blocks on the page check whether they can now be shown
+	if state.IsComplete() {
+		w.Header().Set("HX-Trigger", "block-completed")
+	}
+
+	ctx := r.Context()
+	if _, ok := templates.TemplateVarsText(block); ok {
+		ctx = h.blockTemplateVars(ctx, team, block)
+	}
+
+	err = templates.RenderPlayerUpdate(team.Instance.Settings, block, state).Render(ctx, w)
 	if err != nil {
 		h.handleError(w, r, fmt.Errorf("validateBlock: rendering template: %w", err).Error(), "Something went wrong!")
 		return
@@ -56,13 +56,19 @@ func (h *PlayerHandler) Complete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Check ins are needed for the location counts in template variables
+	err = h.teamService.LoadRelation(r.Context(), team, "CheckIns")
+	if err != nil {
+		h.logger.Error("loading check ins for 'complete' page", "error", err.Error())
+	}
+
 	// If the user is in preview mode, only render the template, not the full layout.
 	template := templates.Complete(*team, pageBlocks, blockStates)
 	if r.Context().Value(contextkeys.PreviewKey) == nil {
 		template = templates.Layout(template, "Complete", team.Messages)
 	}
 
-	err = template.Render(r.Context(), w)
+	err = template.Render(withTemplateVars(r.Context(), team, nil), w)
 	if err != nil {
 		h.logger.Error("rendering 'complete' page", "error", err.Error())
 	}
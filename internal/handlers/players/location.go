@@ -92,6 +92,11 @@ func (h *PlayerHandler) CheckInView(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	groupID, err := h.navigationService.CurrentGroupID(r.Context(), team)
+	if err != nil {
+		h.logger.Error("CheckInView: finding current group", "error", err)
+	}
+	progress := blocks.NewTeamProgress(team.Code, groupID, team.Points, team.CheckIns[index].TimeIn, blockStates)
 	data := templates.CheckInViewData{
 		Settings:  team.Instance.Settings,
 		Scan:      team.CheckIns[index],
@@ -99,10 +104,12 @@ func (h *PlayerHandler) CheckInView(w http.ResponseWriter, r *http.Request) {
 		States:    blockStates,
 		View:      view,
 		TaskBlock: taskBlock,
+		Progress:  &progress,
 	}
 
+	ctx := withTemplateVars(r.Context(), team, &team.CheckIns[index].Location)
 	c := templates.CheckInView(data)
-	err = templates.Layout(c, team.CheckIns[index].Location.Name, team.Messages).Render(r.Context(), w)
+	err = templates.Layout(c, team.CheckIns[index].Location.Name, team.Messages).Render(ctx, w)
 	if err != nil {
 		h.logger.Error("rendering checkin view", "error", err.Error())
 	}
@@ -168,8 +175,78 @@ func (h *PlayerHandler) checkInPreview(w http.ResponseWriter, r *http.Request) {
 		TaskBlock: nil, // Preview mode doesn't have task context
 	}
 
-	err = templates.CheckInView(data).Render(r.Context(), w)
+	err = templates.CheckInView(data).Render(withTemplateVars(r.Context(), team, &location), w)
 	if err != nil {
 		h.logger.Error("LocationPreview: rendering template", "error", err)
 	}
 }
+
+// RevealBlock renders a hidden block once the team can see it. If the block
+// is still hidden, nothing is returned so the placeholder stays in place.
+func (h *PlayerHandler) RevealBlock(w http.ResponseWriter, r *http.Request) {
+	blockID := chi.URLParam(r, "id")
+
+	team, err := h.getTeamFromContext(r.Context())
+	if err != nil {
+		h.handleError(w, r, "RevealBlock: getting team", "Something went wrong!", "error", err)
+		return
+	}
+
+	err = h.teamService.LoadRelation(r.Context(), team, "CheckIns")
+	if err != nil {
+		h.handleError(w, r, "RevealBlock: loading check ins", "Something went wrong!", "error", err)
+		return
+	}
+
+	block, err := h.blockService.GetByBlockID(r.Context(), blockID)
+	if err != nil {
+		h.handleError(w, r, "RevealBlock: getting block", "Something went wrong!", "error", err, "block", blockID)
+		return
+	}
+
+	// The team must be checked in at the block's location
+	index := -1
+	for i, checkIn := range team.CheckIns {
+		if checkIn.LocationID == block.GetLocationID() {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	contentBlocks, blockStates, err := h.blockService.FindByOwnerIDAndTeamCodeWithStateAndContext(
+		r.Context(),
+		block.GetLocationID(),
+		team.Code,
+		blocks.ContextLocationContent,
+	)
+	if err != nil {
+		h.handleError(w, r, "RevealBlock: getting blocks", "Something went wrong!", "error", err, "block", blockID)
+		return
+	}
+
+	groupID, err := h.navigationService.CurrentGroupID(r.Context(), team)
+	if err != nil {
+		h.logger.Error("RevealBlock: finding current group", "error", err)
+	}
+	progress := blocks.NewTeamProgress(team.Code, groupID, team.Points, team.CheckIns[index].TimeIn, blockStates)
+	for _, contentBlock := range contentBlocks {
+		if contentBlock.GetID() != blockID {
+			continue
+		}
+		if !progress.CanSee(contentBlock) {
+			break
+		}
+		ctx := withTemplateVars(r.Context(), team, &team.CheckIns[index].Location)
+		err = templates.CheckInBlock(team.Instance.Settings, contentBlock, blockStates[blockID]).Render(ctx, w)
+		if err != nil {
+			h.logger.Error("RevealBlock: rendering template", "error", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
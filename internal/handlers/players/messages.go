@@ -0,0 +1,89 @@
+package players
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	templates "github.com/nathanhollows/Rapua/v6/internal/templates/players"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// Messages shows the team's conversation with the organisers.
+func (h *PlayerHandler) Messages(w http.ResponseWriter, r *http.Request) {
+	team, err := h.getTeamFromContext(r.Context())
+	if err != nil {
+		h.redirect(w, r, "/play")
+		return
+	}
+
+	messages, err := h.readMessages(r, team)
+	if err != nil {
+		h.handleError(w, r, "Messages: loading messages", "Error loading messages", "error", err)
+		return
+	}
+
+	c := templates.TeamMessages(*team, messages)
+	err = templates.Layout(c, "Messages", nil).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("Messages: rendering template", "error", err)
+	}
+}
+
+// MessagesThread returns the team's messages, so new replies show up.
+func (h *PlayerHandler) MessagesThread(w http.ResponseWriter, r *http.Request) {
+	team, err := h.getTeamFromContext(r.Context())
+	if err != nil {
+		h.redirect(w, r, "/play")
+		return
+	}
+	h.renderMessages(w, r, team)
+}
+
+// MessagesPost sends a message from the team to the organisers.
+func (h *PlayerHandler) MessagesPost(w http.ResponseWriter, r *http.Request) {
+	team, err := h.getTeamFromContext(r.Context())
+	if err != nil {
+		h.redirect(w, r, "/play")
+		return
+	}
+
+	_, err = h.messageService.SendFromTeam(r.Context(), team, r.FormValue("content"))
+	if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		switch {
+		case errors.Is(err, services.ErrMessageEmpty):
+			h.handleError(w, r, "MessagesPost: empty message", "Write a message to send", "error", err)
+		case errors.Is(err, services.ErrMessageTooLong):
+			h.handleError(w, r, "MessagesPost: message too long", "That message is too long", "error", err)
+		default:
+			h.handleError(w, r, "MessagesPost: sending message", "Error sending message", "error", err)
+		}
+		return
+	}
+	h.renderMessages(w, r, team)
+}
+
+func (h *PlayerHandler) renderMessages(w http.ResponseWriter, r *http.Request, team *models.Team) {
+	messages, err := h.readMessages(r, team)
+	if err != nil {
+		w.Header().Set("Hx-Reswap", "none")
+		h.handleError(w, r, "renderMessages: loading messages", "Error loading messages", "error", err)
+		return
+	}
+
+	err = templates.TeamMessagesList(messages).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("renderMessages: rendering template", "error", err)
+	}
+}
+
+// readMessages returns the team's messages, marking the organisers' replies
+// as read now the team has seen them.
+func (h *PlayerHandler) readMessages(r *http.Request, team *models.Team) ([]models.TeamMessage, error) {
+	err := h.messageService.MarkReadByTeam(r.Context(), team)
+	if err != nil {
+		return nil, err
+	}
+	return h.messageService.Thread(r.Context(), team)
+}
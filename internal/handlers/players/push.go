@@ -0,0 +1,86 @@
+package players
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+)
+
+// maxPushSubscriptionSize is the largest subscription a browser should send.
+const maxPushSubscriptionSize = 8 << 10
+
+// pushSubscription is a browser's PushSubscription.toJSON().
+type pushSubscription struct {
+	Endpoint string                        `json:"endpoint"`
+	Keys     services.PushSubscriptionKeys `json:"keys"`
+}
+
+// PushKey returns the key the browser subscribes to alerts with.
+func (h *PlayerHandler) PushKey(w http.ResponseWriter, r *http.Request) {
+	key, err := h.pushService.PublicKey(r.Context())
+	if err != nil {
+		h.logger.Error("PushKey: loading key", "error", err)
+		http.Error(w, "Alerts are unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(map[string]string{"key": key})
+	if err != nil {
+		h.logger.Error("PushKey: encoding response", "error", err)
+	}
+}
+
+// PushSubscribe turns alerts on for the player's device.
+func (h *PlayerHandler) PushSubscribe(w http.ResponseWriter, r *http.Request) {
+	team, err := h.getTeamFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Join a game to turn on alerts", http.StatusUnauthorized)
+		return
+	}
+
+	var subscription pushSubscription
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPushSubscriptionSize)).Decode(&subscription)
+	if err != nil {
+		http.Error(w, "Invalid subscription", http.StatusBadRequest)
+		return
+	}
+
+	err = h.pushService.Subscribe(r.Context(), team, subscription.Endpoint, subscription.Keys)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPushSubscription) {
+			http.Error(w, "Invalid subscription", http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("PushSubscribe: saving subscription", "error", err, "team", team.Code)
+		http.Error(w, "Error turning on alerts", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PushUnsubscribe turns alerts off for the player's device.
+func (h *PlayerHandler) PushUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	team, err := h.getTeamFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Join a game to turn off alerts", http.StatusUnauthorized)
+		return
+	}
+
+	var subscription pushSubscription
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPushSubscriptionSize)).Decode(&subscription)
+	if err != nil || subscription.Endpoint == "" {
+		http.Error(w, "Invalid subscription", http.StatusBadRequest)
+		return
+	}
+
+	err = h.pushService.Unsubscribe(r.Context(), team, subscription.Endpoint)
+	if err != nil {
+		h.logger.Error("PushUnsubscribe: deleting subscription", "error", err, "team", team.Code)
+		http.Error(w, "Error turning off alerts", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
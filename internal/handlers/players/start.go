@@ -49,7 +49,7 @@ func (h *PlayerHandler) Start(w http.ResponseWriter, r *http.Request) {
 		template = templates.Layout(template, "Start", team.Messages)
 	}
 
-	err = template.Render(r.Context(), w)
+	err = template.Render(withTemplateVars(r.Context(), team, nil), w)
 	if err != nil {
 		h.logger.Error("rendering start", "error", err.Error())
 	}
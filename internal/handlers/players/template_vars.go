@@ -0,0 +1,73 @@
+package players
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/helpers"
+	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// withTemplateVars adds the team's template variables to the context so
+// blocks can personalise their content. The location may be nil on pages
+// that are not tied to a location.
+func withTemplateVars(ctx context.Context, team *models.Team, location *models.Location) context.Context {
+	return context.WithValue(ctx, contextkeys.VarsKey, teamTemplateVars(team, location))
+}
+
+// teamTemplateVars builds the template variables for a team. Check ins and
+// the instance's locations should be loaded for the location counts.
+func teamTemplateVars(team *models.Team, location *models.Location) helpers.TemplateVars {
+	visited := make(map[string]bool, len(team.CheckIns))
+	for _, checkIn := range team.CheckIns {
+		visited[checkIn.LocationID] = true
+	}
+	remaining := 0
+	for _, loc := range team.Instance.Locations {
+		if !visited[loc.ID] {
+			remaining++
+		}
+	}
+
+	name := team.Name
+	if name == "" {
+		name = team.Code
+	}
+
+	endTime := ""
+	if !team.Instance.EndTime.Time.IsZero() {
+		endTime = team.Instance.EndTime.Time.Local().Format("02 Jan 03:04 PM")
+	}
+
+	vars := helpers.TemplateVars{
+		"team.name":           name,
+		"team.code":           team.Code,
+		"team.points":         strconv.Itoa(team.Points),
+		"instance.name":       team.Instance.Name,
+		"instance.end_time":   endTime,
+		"locations.visited":   strconv.Itoa(len(visited)),
+		"locations.remaining": strconv.Itoa(remaining),
+	}
+	if location != nil {
+		vars["location.name"] = location.Name
+	}
+	return vars
+}
+
+// blockTemplateVars adds the team's template variables for the location the
+// block belongs to. Variables are left out if check ins cannot be loaded.
+func (h *PlayerHandler) blockTemplateVars(ctx context.Context, team *models.Team, block blocks.Block) context.Context {
+	err := h.teamService.LoadRelation(ctx, team, "CheckIns")
+	if err != nil {
+		h.logger.Error("loading check ins for template variables", "error", err, "team", team.Code)
+		return ctx
+	}
+	for _, checkIn := range team.CheckIns {
+		if checkIn.LocationID == block.GetLocationID() {
+			return withTemplateVars(ctx, team, &checkIn.Location)
+		}
+	}
+	return withTemplateVars(ctx, team, nil)
+}
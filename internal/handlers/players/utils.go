@@ -35,6 +35,13 @@ type BlockService interface {
 		ownerID, teamCode string,
 		blockContext blocks.BlockContext,
 	) ([]blocks.Block, map[string]blocks.PlayerState, error)
+	// GetBlockWithStateByBlockIDAndTeamCode fetches a block and its state for the given team
+	GetBlockWithStateByBlockIDAndTeamCode(
+		ctx context.Context,
+		blockID, teamCode string,
+	) (blocks.Block, blocks.PlayerState, error)
+	// GetPollResults returns the distribution of votes for a poll
+	GetPollResults(ctx context.Context, poll *blocks.PollBlock) (blocks.PollResults, error)
 }
 
 type CheckInService interface {
@@ -58,6 +65,8 @@ type MarkerService interface {
 
 type NavigationService interface {
 	// IsValidLocation(ctx context.Context, team *models.Team, markerID string) (bool, error)
+	// CurrentGroupID returns the group the team is currently in
+	CurrentGroupID(ctx context.Context, team *models.Team) (string, error)
 	GetNextLocations(ctx context.Context, team *models.Team) ([]models.Location, error)
 	GetPlayerNavigationView(ctx context.Context, team *models.Team) (*services.PlayerNavigationView, error)
 	GetPreviewNavigationView(
@@ -73,11 +82,28 @@ type LocationService interface {
 	LoadBlocks(ctx context.Context, location *models.Location) error
 }
 
+type MessageService interface {
+	// SendFromTeam sends a message from a team to the organisers
+	SendFromTeam(ctx context.Context, team *models.Team, content string) (*models.TeamMessage, error)
+	// Thread returns a team's messages, oldest first
+	Thread(ctx context.Context, team *models.Team) ([]models.TeamMessage, error)
+	MarkReadByTeam(ctx context.Context, team *models.Team) error
+}
+
 type NotificationService interface {
 	GetNotifications(ctx context.Context, teamCode string) ([]models.Notification, error)
 	DismissNotification(ctx context.Context, notificationID string) error
 }
 
+type PushService interface {
+	// PublicKey returns the key browsers need to subscribe to alerts
+	PublicKey(ctx context.Context) (string, error)
+	// Subscribe saves a device's subscription to the team's alerts
+	Subscribe(ctx context.Context, team *models.Team, endpoint string, keys services.PushSubscriptionKeys) error
+	// Unsubscribe stops sending the team's alerts to a device
+	Unsubscribe(ctx context.Context, team *models.Team, endpoint string) error
+}
+
 type TeamService interface {
 	// GetTeamByCode returns a team by code
 	GetTeamByCode(ctx context.Context, code string) (*models.Team, error)
@@ -109,8 +135,10 @@ type PlayerHandler struct {
 	instanceService     InstanceService
 	locationService     LocationService
 	markerService       MarkerService
+	messageService      MessageService
 	navigationService   NavigationService
 	notificationService NotificationService
+	pushService         PushService
 	teamService         TeamService
 	uploadService       UploadService
 }
@@ -122,8 +150,10 @@ func NewPlayerHandler(
 	instanceService InstanceService,
 	locationService LocationService,
 	markerService MarkerService,
+	messageService MessageService,
 	navigationService NavigationService,
 	notificationService NotificationService,
+	pushService PushService,
 	teamService TeamService,
 	uploadService UploadService,
 ) *PlayerHandler {
@@ -134,8 +164,10 @@ func NewPlayerHandler(
 		instanceService:     instanceService,
 		locationService:     locationService,
 		markerService:       markerService,
+		messageService:      messageService,
 		navigationService:   navigationService,
 		notificationService: notificationService,
+		pushService:         pushService,
 		teamService:         teamService,
 		uploadService:       uploadService,
 	}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/markbates/goth/gothic"
@@ -17,6 +18,10 @@ import (
 	"github.com/nathanhollows/Rapua/v6/models"
 )
 
+// maxTwoFactorAttempts is how many wrong codes a user may enter before they
+// must enter their password again.
+const maxTwoFactorAttempts = 5
+
 // Login is the handler for the admin login page.
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	authed := contextkeys.GetUserStatus(r.Context()).IsAdminLoggedIn
@@ -26,7 +31,7 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c := templates.Login(h.identityService.AllowGoogleLogin())
+	c := templates.Login(h.identityService.AllowGoogleLogin(), h.identityService.OIDCProviders())
 	err := templates.AuthLayout(c, "Login", false).Render(r.Context(), w)
 
 	if err != nil {
@@ -43,6 +48,14 @@ func (h *Handler) LoginPost(w http.ResponseWriter, r *http.Request) {
 	}
 	email := r.Form.Get("email")
 	password := r.Form.Get("password")
+	method := string(models.LoginMethodPassword)
+
+	// Refuse to check the password while the address is locked out
+	err = h.adminSessionService.CheckLockout(r.Context(), email)
+	if err != nil {
+		h.loginRefused(w, r, email, method, err)
+		return
+	}
 
 	// Try to authenticate the user
 	user, err := h.identityService.AuthenticateUser(r.Context(), email, password)
@@ -50,6 +63,12 @@ func (h *Handler) LoginPost(w http.ResponseWriter, r *http.Request) {
 		if !errors.Is(err, sql.ErrNoRows) {
 			h.logger.Error("authenticating user", "err", err)
 		}
+		err = h.adminSessionService.RecordFailure(
+			r.Context(), email, method, models.LoginFailureInvalidPassword, loginClient(r),
+		)
+		if err != nil {
+			h.logger.Error("LoginPost: recording failed login", "err", err)
+		}
 		w.WriteHeader(http.StatusUnauthorized)
 		c := templates.LoginError("Invalid email or password.")
 		err = c.Render(r.Context(), w)
@@ -59,9 +78,21 @@ func (h *Handler) LoginPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session, err := sessions.NewFromUser(r, *user)
+	// Users with two-factor authentication enter a code before the admin
+	// session is issued
+	if user.TOTPEnabled {
+		err = h.startTwoFactor(w, r, *user, method)
+		if err != nil {
+			h.handleError(w, r, "LoginPost: starting two-factor", "Error logging in", "error", err)
+			return
+		}
+		w.Header().Add("Hx-Redirect", "/login/two-factor")
+		return
+	}
+
+	err = h.logIn(w, r, user, method)
 	if err != nil {
-		h.logger.Error("creating session", "err", err)
+		h.logger.Error("LoginPost: logging in", "err", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		c := templates.LoginError("An error occurred while trying to log in. Please try again.")
 		err = c.Render(r.Context(), w)
@@ -70,9 +101,149 @@ func (h *Handler) LoginPost(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	w.Header().Add("Hx-Redirect", "/admin")
+}
+
+// loginRefused shows why a login was refused before its credentials were
+// checked. Attempts during a lockout are recorded but don't extend it.
+func (h *Handler) loginRefused(w http.ResponseWriter, r *http.Request, email, method string, err error) {
+	status := http.StatusInternalServerError
+	message := "An error occurred while trying to log in. Please try again."
+	if errors.Is(err, services.ErrLoginLocked) {
+		status = http.StatusTooManyRequests
+		message = fmt.Sprintf(
+			"Too many failed attempts. Try again in %d minutes.",
+			int(services.LoginLockoutWindow.Minutes()),
+		)
+		err = h.adminSessionService.RecordFailure(r.Context(), email, method, models.LoginFailureLocked, loginClient(r))
+		if err != nil {
+			h.logger.Error("recording locked out login", "err", err)
+		}
+	} else {
+		h.logger.Error("checking login lockout", "err", err)
+	}
+
+	w.WriteHeader(status)
+	err = templates.LoginError(message).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("rendering login error", "err", err)
+	}
+}
+
+// logIn issues the admin session to a user who has proved who they are,
+// recording it so it can be revoked later.
+func (h *Handler) logIn(w http.ResponseWriter, r *http.Request, user *models.User, method string) error {
+	record, err := h.adminSessionService.Start(r.Context(), user, method, loginClient(r))
+	if err != nil {
+		return fmt.Errorf("starting session: %w", err)
+	}
+	session, err := sessions.NewFromUser(r, *user, record.ID)
+	if err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
 	err = session.Save(r, w)
 	if err != nil {
-		h.handleError(w, r, "LoginPost: saving session", "Error logging in", "error", err)
+		return fmt.Errorf("saving session: %w", err)
+	}
+	return nil
+}
+
+// startTwoFactor remembers a user who has entered their password until they
+// enter their two-factor code.
+func (h *Handler) startTwoFactor(w http.ResponseWriter, r *http.Request, user models.User, method string) error {
+	session, err := sessions.NewTwoFactor(r, user, method)
+	if err != nil {
+		return fmt.Errorf("creating two-factor session: %w", err)
+	}
+	return session.Save(r, w)
+}
+
+// TwoFactor asks for a code from the user's authenticator app.
+// GET /login/two-factor.
+func (h *Handler) TwoFactor(w http.ResponseWriter, r *http.Request) {
+	session, err := sessions.GetTwoFactor(r)
+	if err != nil || session.Values["user_id"] == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	err = templates.AuthLayout(templates.TwoFactor(), "Two-factor authentication", false).Render(r.Context(), w)
+	if err != nil {
+		h.logger.Error("rendering two-factor page", "err", err)
+	}
+}
+
+// TwoFactorPost checks the user's code and logs them in.
+// POST /login/two-factor.
+func (h *Handler) TwoFactorPost(w http.ResponseWriter, r *http.Request) {
+	pending, err := sessions.GetTwoFactor(r)
+	if err != nil {
+		w.Header().Add("Hx-Redirect", "/login")
+		return
+	}
+	userID, _ := pending.Values["user_id"].(string)
+	method, _ := pending.Values["method"].(string)
+	expires, _ := pending.Values["expires"].(int64)
+	attempts, _ := pending.Values["attempts"].(int)
+	if userID == "" || time.Now().Unix() > expires || attempts >= maxTwoFactorAttempts {
+		// Start again from the password
+		pending.Options.MaxAge = -1
+		_ = pending.Save(r, w)
+		w.Header().Add("Hx-Redirect", "/login")
+		return
+	}
+
+	user, err := h.userService.GetUserByID(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, r, "TwoFactorPost: finding user", "Error logging in", "error", err, "userID", userID)
+		return
+	}
+
+	// Failed codes count towards the same lockout as failed passwords
+	twoFactorMethod := string(models.LoginMethodTwoFactor)
+	err = h.adminSessionService.CheckLockout(r.Context(), user.Email)
+	if err != nil {
+		pending.Options.MaxAge = -1
+		_ = pending.Save(r, w)
+		h.loginRefused(w, r, user.Email, twoFactorMethod, err)
+		return
+	}
+
+	err = h.twoFactorService.Verify(r.Context(), user, r.FormValue("code"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "An error occurred while checking your code. Please try again."
+		if errors.Is(err, services.ErrInvalidTwoFactorCode) {
+			status = http.StatusUnauthorized
+			message = "Invalid code."
+			pending.Values["attempts"] = attempts + 1
+			_ = pending.Save(r, w)
+			err = h.adminSessionService.RecordFailure(
+				r.Context(), user.Email, twoFactorMethod, models.LoginFailureInvalidCode, loginClient(r),
+			)
+			if err != nil {
+				h.logger.Error("TwoFactorPost: recording failed code", "err", err, "userID", userID)
+			}
+		} else {
+			h.logger.Error("TwoFactorPost: verifying code", "err", err, "userID", userID)
+		}
+		w.WriteHeader(status)
+		err = templates.LoginError(message).Render(r.Context(), w)
+		if err != nil {
+			h.logger.Error("TwoFactorPost: rendering template", "err", err)
+		}
+		return
+	}
+
+	pending.Options.MaxAge = -1
+	err = pending.Save(r, w)
+	if err != nil {
+		h.handleError(w, r, "TwoFactorPost: clearing two-factor session", "Error logging in", "error", err)
+		return
+	}
+	err = h.logIn(w, r, user, method)
+	if err != nil {
+		h.handleError(w, r, "TwoFactorPost: logging in", "Error logging in", "error", err)
 		return
 	}
 	w.Header().Add("Hx-Redirect", "/admin")
@@ -87,6 +258,17 @@ func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, helpers.URL("/login"), http.StatusSeeOther)
 		return
 	}
+
+	// End the server-side session too, so the cookie can't be replayed
+	userID, _ := session.Values["user_id"].(string)
+	sessionID, _ := session.Values["session_id"].(string)
+	if userID != "" && sessionID != "" {
+		err = h.adminSessionService.Revoke(r.Context(), userID, sessionID)
+		if err != nil && !errors.Is(err, services.ErrSessionNotFound) {
+			h.logger.Error("Logout: revoking session", "err", err, "userID", userID)
+		}
+	}
+
 	session.Options.MaxAge = -1
 	err = session.Save(r, w)
 	if err != nil {
@@ -105,7 +287,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c := templates.Register(h.identityService.AllowGoogleLogin())
+	c := templates.Register(h.identityService.AllowGoogleLogin(), h.identityService.OIDCProviders())
 	err := templates.AuthLayout(c, "Register", false).Render(r.Context(), w)
 
 	if err != nil {
@@ -213,7 +395,7 @@ func (h *Handler) ForgotPasswordPost(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Auth redirects the user to the Google OAuth page.
+// Auth redirects the user to the identity provider's login page.
 func (h *Handler) Auth(w http.ResponseWriter, r *http.Request) {
 	// Include the provider to the query string
 	// since Chi doesn't do this automatically
@@ -230,7 +412,7 @@ func (h *Handler) Auth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// AuthCallback handles the callback from Google OAuth.
+// AuthCallback handles the callback from the identity provider.
 func (h *Handler) AuthCallback(w http.ResponseWriter, r *http.Request) {
 	// Include the provider to the query string
 	// since Chi doesn't do this automatically
@@ -238,6 +420,19 @@ func (h *Handler) AuthCallback(w http.ResponseWriter, r *http.Request) {
 	r.URL.RawQuery = fmt.Sprintf("%s&provider=%s", r.URL.RawQuery, provider)
 
 	user, err := h.identityService.CompleteUserAuth(w, r)
+	if errors.Is(err, services.ErrOAuthEmailNotVerified) || errors.Is(err, services.ErrOAuthEmailMissing) {
+		h.logger.Warn("completing auth", "error", err, "provider", provider)
+		w.WriteHeader(http.StatusForbidden)
+		c := templates.OAuthError(
+			"We couldn't confirm your email address with that provider. Log in with your password, " +
+				"or ask your identity provider's administrator to share verified email addresses.",
+		)
+		err = templates.AuthLayout(c, "Login", false).Render(r.Context(), w)
+		if err != nil {
+			h.logger.Error("AuthCallback: rendering template", "error", err)
+		}
+		return
+	}
 	if err != nil {
 		h.logger.Error("completing auth", "error", err)
 		http.Redirect(w, r, "/login", http.StatusSeeOther)
@@ -250,31 +445,42 @@ func (h *Handler) AuthCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session, err := sessions.NewFromUser(r, *user)
+	if user.TOTPEnabled {
+		err = h.startTwoFactor(w, r, *user, provider)
+		if err != nil {
+			h.handleError(w, r, "AuthCallback: starting two-factor", "Error authenticating user", "error", err)
+			return
+		}
+		h.refreshTo(w, r, "/login/two-factor")
+		return
+	}
+
+	err = h.logIn(w, r, user, provider)
 	if err != nil {
-		h.logger.Error("creating session", "err", err)
+		h.logger.Error("AuthCallback: logging in", "err", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		c := templates.LoginError("An error occurred while trying to log in. Please try again.")
 		err = c.Render(r.Context(), w)
 		if err != nil {
 			h.handleError(w, r, "AuthCallback: rendering template", "Error authenticating user", "error", err)
-			return
 		}
-	}
-
-	err = session.Save(r, w)
-	if err != nil {
-		h.handleError(w, r, "AuthCallback: saving session", "Error authenticating user", "error")
 		return
 	}
 
-	_, err = w.Write([]byte(`
+	h.refreshTo(w, r, "/admin")
+}
+
+// refreshTo sends the browser on from an identity provider's callback. A meta
+// refresh is used rather than a redirect so the next request is same-site and
+// carries the session cookie.
+func (h *Handler) refreshTo(w http.ResponseWriter, r *http.Request, path string) {
+	_, err := fmt.Fprintf(w, `
 <!DOCTYPE html>
 <html>
-<head><meta http-equiv="refresh" content="0; url='/admin'"></head>
+<head><meta http-equiv="refresh" content="0; url='%s'"></head>
 <body></body>
 </html>
-		`))
+		`, path)
 	if err != nil {
 		h.handleError(w, r, "AuthCallback: writing response", "Error authenticating user", "error", err)
 	}
@@ -417,7 +623,8 @@ func (h *Handler) ResendEmailVerification(w http.ResponseWriter, r *http.Request
 }
 
 // MagicLogin handles magic login link authentication.
-// It validates the token, creates a session, and redirects to /admin.
+// It validates the token, creates a session, and redirects to /admin, or
+// asks for a two-factor code first if the user has it turned on.
 func (h *Handler) MagicLogin(w http.ResponseWriter, r *http.Request) {
 	token := chi.URLParam(r, "token")
 	if token == "" {
@@ -442,17 +649,23 @@ func (h *Handler) MagicLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create session
-	session, err := sessions.NewFromUser(r, *user)
-	if err != nil {
-		h.logger.Error("magic login: creating session", "err", err)
-		http.Error(w, "An error occurred while logging in", http.StatusInternalServerError)
+	// A magic link stands in for the password only, so users with
+	// two-factor authentication still enter a code
+	if user.TOTPEnabled {
+		err = h.startTwoFactor(w, r, *user, string(models.LoginMethodMagicLink))
+		if err != nil {
+			h.logger.Error("magic login: starting two-factor", "err", err)
+			http.Error(w, "An error occurred while logging in", http.StatusInternalServerError)
+			return
+		}
+		http.Redirect(w, r, "/login/two-factor", http.StatusSeeOther)
 		return
 	}
 
-	err = session.Save(r, w)
+	// Create session
+	err = h.logIn(w, r, user, string(models.LoginMethodMagicLink))
 	if err != nil {
-		h.logger.Error("magic login: saving session", "err", err)
+		h.logger.Error("magic login: logging in", "err", err)
 		http.Error(w, "An error occurred while logging in", http.StatusInternalServerError)
 		return
 	}
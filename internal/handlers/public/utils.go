@@ -3,10 +3,14 @@ package public
 import (
 	"context"
 	"log/slog"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/markbates/goth"
 	"github.com/nathanhollows/Rapua/v6/internal/flash"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/internal/sessions"
 	templates "github.com/nathanhollows/Rapua/v6/internal/templates/public"
 	"github.com/nathanhollows/Rapua/v6/models"
 )
@@ -33,6 +37,7 @@ type IdentityService interface {
 
 	// OAuth operations
 	AllowGoogleLogin() bool
+	OIDCProviders() []sessions.OIDCProvider
 	OAuthLogin(ctx context.Context, provider string, user goth.User) (*models.User, error)
 	CheckUserRegisteredWithOAuth(ctx context.Context, provider, userID string) (*models.User, error)
 	CreateUserWithOAuth(ctx context.Context, user goth.User) (*models.User, error)
@@ -52,15 +57,36 @@ type MagicTokenService interface {
 	ValidateToken(token string) (userID string, err error)
 }
 
+type TwoFactorService interface {
+	// Verify checks a code from the user's authenticator app or a recovery code
+	Verify(ctx context.Context, user *models.User, code string) error
+}
+
+type AdminSessionService interface {
+	// Start records a successful login and creates the session for it
+	Start(
+		ctx context.Context,
+		user *models.User,
+		method string,
+		client services.LoginClient,
+	) (*models.AdminSession, error)
+	Revoke(ctx context.Context, userID, sessionID string) error
+	// CheckLockout returns services.ErrLoginLocked after repeated failures
+	CheckLockout(ctx context.Context, email string) error
+	RecordFailure(ctx context.Context, email, method, reason string, client services.LoginClient) error
+}
+
 // Handler handles public-facing HTTP requests.
 type Handler struct {
-	logger            *slog.Logger
-	identityService   IdentityService
-	deleteService     DeleteService
-	emailService      EmailService
-	templateService   TemplateService
-	userService       UserService
-	magicTokenService MagicTokenService
+	logger              *slog.Logger
+	identityService     IdentityService
+	deleteService       DeleteService
+	emailService        EmailService
+	templateService     TemplateService
+	userService         UserService
+	magicTokenService   MagicTokenService
+	twoFactorService    TwoFactorService
+	adminSessionService AdminSessionService
 }
 
 // NewHandler creates a new public handler.
@@ -72,15 +98,19 @@ func NewHandler(
 	templateService TemplateService,
 	userService UserService,
 	magicTokenService MagicTokenService,
+	twoFactorService TwoFactorService,
+	adminSessionService AdminSessionService,
 ) *Handler {
 	return &Handler{
-		logger:            logger,
-		identityService:   identityService,
-		deleteService:     deleteService,
-		emailService:      emailService,
-		templateService:   templateService,
-		userService:       userService,
-		magicTokenService: magicTokenService,
+		logger:              logger,
+		identityService:     identityService,
+		deleteService:       deleteService,
+		emailService:        emailService,
+		templateService:     templateService,
+		userService:         userService,
+		magicTokenService:   magicTokenService,
+		twoFactorService:    twoFactorService,
+		adminSessionService: adminSessionService,
 	}
 }
 
@@ -108,6 +138,24 @@ func (h *Handler) redirect(w http.ResponseWriter, r *http.Request, path string)
 	http.Redirect(w, r, path, http.StatusFound)
 }
 
+// loginClient describes the device making a login request. Rapua runs
+// behind a reverse proxy, so the forwarded address is preferred.
+func loginClient(r *http.Request) services.LoginClient {
+	ip, _, _ := strings.Cut(r.Header.Get("X-Forwarded-For"), ",")
+	ip = strings.TrimSpace(ip)
+	if ip == "" {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ip = host
+	}
+	return services.LoginClient{
+		IPAddress: ip,
+		UserAgent: r.UserAgent(),
+	}
+}
+
 // GetIdentityService returns the identity service for use in middleware.
 func (h *Handler) GetIdentityService() IdentityService {
 	return h.identityService
@@ -0,0 +1,18 @@
+package static
+
+import (
+	"net/http"
+	"path/filepath"
+)
+
+// ServeServiceWorker serves the service worker that shows push alerts. It
+// isn't cached, so browsers pick up changes to it straight away.
+func ServeServiceWorker(baseDir string) http.HandlerFunc {
+	path := filepath.Join(baseDir, "static", "js", "sw.js")
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Service-Worker-Allowed", "/")
+		http.ServeFile(w, r, path)
+	}
+}
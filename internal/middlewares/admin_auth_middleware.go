@@ -53,6 +53,13 @@ func AdminCheckInstanceMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// Users made to set up two-factor authentication may not have a game
+		// yet, and can't open one until they do
+		if twoFactorSetupRoutes.MatchString(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		if user.CurrentInstanceID == "" {
 			// flash.Message{
 			// 	Title:   "Error",
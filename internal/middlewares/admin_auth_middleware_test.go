@@ -145,6 +145,15 @@ func TestAdminCheckInstanceMiddleware(t *testing.T) {
 			expectedStatusCode: http.StatusOK,
 			expectedLocation:   "",
 		},
+		{
+			name: "User without Instance Setting Up Two-Factor",
+			path: "/admin/settings/security",
+			user: &models.User{
+				CurrentInstanceID: "",
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedLocation:   "",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// InstanceRoleGetter finds the role a user has in a game.
+type InstanceRoleGetter interface {
+	InstanceRole(ctx context.Context, userID, instanceID string) (models.OrganisationRole, error)
+}
+
+var (
+	// personalRoutes belong to the user rather than the current game, so the
+	// handlers check access to anything they change.
+	personalRoutes = regexp.MustCompile(
+		`^/admin/(instances|templates|organisations|settings|markers|library|trash|credits|markdown)(/|$)`,
+	)
+	// facilitatorRoutes run a live game without changing its content.
+	facilitatorRoutes = regexp.MustCompile(`^/admin/(teams|notify|schedule|facilitator)(/|$)`)
+	// editorRoutes show secrets, so only editors may even look at them.
+	editorRoutes = regexp.MustCompile(`^/admin/webhooks(/|$)`)
+)
+
+// AdminRoleMiddleware limits what a user may do in the current game to what
+// their role allows. Viewers can only look, facilitators can also run the
+// game, and editors and owners can change anything. Requests that are not
+// allowed are passed to deny.
+func AdminRoleMiddleware(roles InstanceRoleGetter, deny http.HandlerFunc, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(contextkeys.UserKey).(*models.User)
+		if !ok || user == nil {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+
+		// Users own the games they create, so only shared games need a lookup
+		if personalRoutes.MatchString(r.URL.Path) ||
+			user.CurrentInstanceID == "" ||
+			user.CurrentInstance.UserID == user.ID {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		role, err := roles.InstanceRole(r.Context(), user.ID, user.CurrentInstanceID)
+		if err != nil || role == "" {
+			// The game is no longer shared with the user
+			http.Redirect(w, r, "/admin/instances", http.StatusSeeOther)
+			return
+		}
+
+		allowed := role.CanEdit() || (isReadOnly(r) && !editorRoutes.MatchString(r.URL.Path))
+		if facilitatorRoutes.MatchString(r.URL.Path) {
+			allowed = allowed || role.CanFacilitate()
+		}
+		if !allowed {
+			deny(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isReadOnly reports whether a request only looks at a game. Starting and
+// stopping a game are GET requests, so they never count.
+func isReadOnly(r *http.Request) bool {
+	if strings.HasPrefix(r.URL.Path, "/admin/schedule") {
+		return false
+	}
+	return r.Method == http.MethodGet || r.Method == http.MethodHead
+}
@@ -0,0 +1,169 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// MockRoleGetter is a mock implementation of InstanceRoleGetter.
+type MockRoleGetter struct {
+	role models.OrganisationRole
+}
+
+func (m *MockRoleGetter) InstanceRole(_ context.Context, _, _ string) (models.OrganisationRole, error) {
+	return m.role, nil
+}
+
+func TestAdminRoleMiddleware(t *testing.T) {
+	owner := &models.User{
+		ID:                "owner",
+		CurrentInstanceID: "game",
+		CurrentInstance:   models.Instance{ID: "game", UserID: "owner"},
+	}
+	member := &models.User{
+		ID:                "member",
+		CurrentInstanceID: "game",
+		CurrentInstance:   models.Instance{ID: "game", UserID: "owner"},
+	}
+
+	testCases := []struct {
+		name               string
+		method             string
+		path               string
+		user               *models.User
+		role               models.OrganisationRole
+		expectedStatusCode int
+		expectedLocation   string
+	}{
+		{
+			name:               "Creator can change their game",
+			method:             http.MethodPost,
+			path:               "/admin/locations/new",
+			user:               owner,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Editor can change a shared game",
+			method:             http.MethodPost,
+			path:               "/admin/locations/new",
+			user:               member,
+			role:               models.RoleEditor,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Viewer can look at a shared game",
+			method:             http.MethodGet,
+			path:               "/admin/locations",
+			user:               member,
+			role:               models.RoleViewer,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Viewer cannot change a shared game",
+			method:             http.MethodPost,
+			path:               "/admin/locations/new",
+			user:               member,
+			role:               models.RoleViewer,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Viewer cannot add teams",
+			method:             http.MethodPost,
+			path:               "/admin/teams/add",
+			user:               member,
+			role:               models.RoleViewer,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Facilitator can add teams",
+			method:             http.MethodPost,
+			path:               "/admin/teams/add",
+			user:               member,
+			role:               models.RoleFacilitator,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Facilitator cannot change content",
+			method:             http.MethodPost,
+			path:               "/admin/locations/new",
+			user:               member,
+			role:               models.RoleFacilitator,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Viewer cannot start a game",
+			method:             http.MethodGet,
+			path:               "/admin/schedule/start",
+			user:               member,
+			role:               models.RoleViewer,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Viewer cannot see webhook secrets",
+			method:             http.MethodGet,
+			path:               "/admin/webhooks",
+			user:               member,
+			role:               models.RoleViewer,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Editor can see webhooks",
+			method:             http.MethodGet,
+			path:               "/admin/webhooks",
+			user:               member,
+			role:               models.RoleEditor,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Personal pages are not limited",
+			method:             http.MethodPost,
+			path:               "/admin/markers",
+			user:               member,
+			role:               models.RoleViewer,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Game no longer shared",
+			method:             http.MethodGet,
+			path:               "/admin/locations",
+			user:               member,
+			expectedStatusCode: http.StatusSeeOther,
+			expectedLocation:   "/admin/instances",
+		},
+	}
+
+	deny := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			ctx := context.WithValue(req.Context(), contextkeys.UserKey, tc.user)
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler := AdminRoleMiddleware(&MockRoleGetter{role: tc.role}, deny, dummyHandler())
+			handler.ServeHTTP(w, req)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			if result.StatusCode != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, result.StatusCode)
+			}
+
+			if tc.expectedLocation != "" {
+				location := result.Header.Get("Location")
+				if location != tc.expectedLocation {
+					t.Errorf("Expected redirect to %s, got %s", tc.expectedLocation, location)
+				}
+			}
+		})
+	}
+}
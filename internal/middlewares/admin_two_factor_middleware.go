@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// TwoFactorRequirement reports whether a user must use two-factor
+// authentication.
+type TwoFactorRequirement interface {
+	Required(ctx context.Context, userID string) (bool, error)
+}
+
+// twoFactorSetupRoutes stay open to users who still need to set up
+// two-factor authentication.
+var twoFactorSetupRoutes = regexp.MustCompile(`^/admin/settings/security(/|$)`)
+
+// AdminTwoFactorMiddleware sends users who belong to an organisation that
+// requires two-factor authentication, but haven't set it up, to their
+// security settings until they do.
+func AdminTwoFactorMiddleware(requirement TwoFactorRequirement, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(contextkeys.UserKey).(*models.User)
+		if !ok || user == nil {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+
+		if user.TOTPEnabled || twoFactorSetupRoutes.MatchString(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Fail closed so an outage can't skip the requirement
+		required, err := requirement.Required(r.Context(), user.ID)
+		if err != nil || required {
+			if r.Header.Get("Hx-Request") == "true" {
+				w.Header().Set("Hx-Redirect", "/admin/settings/security")
+				return
+			}
+			http.Redirect(w, r, "/admin/settings/security", http.StatusSeeOther)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,125 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// MockTwoFactorRequirement is a mock implementation of TwoFactorRequirement.
+type MockTwoFactorRequirement struct {
+	required bool
+	err      error
+}
+
+func (m *MockTwoFactorRequirement) Required(_ context.Context, _ string) (bool, error) {
+	return m.required, m.err
+}
+
+func TestAdminTwoFactorMiddleware(t *testing.T) {
+	enrolled := &models.User{ID: "enrolled", TOTPEnabled: true}
+	notEnrolled := &models.User{ID: "not-enrolled"}
+
+	testCases := []struct {
+		name               string
+		path               string
+		htmx               bool
+		user               *models.User
+		requirement        MockTwoFactorRequirement
+		expectedStatusCode int
+		expectedLocation   string
+	}{
+		{
+			name:               "Optional and not set up",
+			path:               "/admin/locations",
+			user:               notEnrolled,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Required and set up",
+			path:               "/admin/locations",
+			user:               enrolled,
+			requirement:        MockTwoFactorRequirement{required: true},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Required and not set up",
+			path:               "/admin/locations",
+			user:               notEnrolled,
+			requirement:        MockTwoFactorRequirement{required: true},
+			expectedStatusCode: http.StatusSeeOther,
+			expectedLocation:   "/admin/settings/security",
+		},
+		{
+			name:               "Required and not set up with htmx",
+			path:               "/admin/locations",
+			htmx:               true,
+			user:               notEnrolled,
+			requirement:        MockTwoFactorRequirement{required: true},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Security settings stay open for set up",
+			path:               "/admin/settings/security/two-factor/confirm",
+			user:               notEnrolled,
+			requirement:        MockTwoFactorRequirement{required: true},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Fails closed",
+			path:               "/admin/locations",
+			user:               notEnrolled,
+			requirement:        MockTwoFactorRequirement{err: errors.New("database is down")},
+			expectedStatusCode: http.StatusSeeOther,
+			expectedLocation:   "/admin/settings/security",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.htmx {
+				req.Header.Set("Hx-Request", "true")
+			}
+			ctx := context.WithValue(req.Context(), contextkeys.UserKey, tc.user)
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			reached := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				reached = true
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := AdminTwoFactorMiddleware(&tc.requirement, next)
+			handler.ServeHTTP(w, req)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			if result.StatusCode != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, result.StatusCode)
+			}
+
+			if tc.expectedLocation != "" {
+				location := result.Header.Get("Location")
+				if location != tc.expectedLocation {
+					t.Errorf("Expected redirect to %s, got %s", tc.expectedLocation, location)
+				}
+			}
+
+			if tc.htmx {
+				if reached {
+					t.Error("Expected the request to stop at the middleware")
+				}
+				if got := result.Header.Get("Hx-Redirect"); got != "/admin/settings/security" {
+					t.Errorf("Expected Hx-Redirect to /admin/settings/security, got %s", got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// MockAPIKeyAuthenticator is a mock implementation of APIKeyAuthenticator.
+type MockAPIKeyAuthenticator struct {
+	secret string
+	key    *models.APIKey
+}
+
+func (m *MockAPIKeyAuthenticator) Authenticate(
+	_ context.Context,
+	secret string,
+) (*models.APIKey, *models.User, error) {
+	if secret != m.secret {
+		return nil, nil, errors.New("invalid api key")
+	}
+	return m.key, &models.User{ID: m.key.UserID}, nil
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	key := &models.APIKey{
+		ID:     "key",
+		UserID: "user",
+		Scopes: models.StrArray{string(models.ScopeTeamsRead)},
+	}
+
+	testCases := []struct {
+		name               string
+		authorization      string
+		scope              models.APIScope
+		expectedStatusCode int
+	}{
+		{
+			name:               "No header",
+			scope:              models.ScopeTeamsRead,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Not a bearer token",
+			authorization:      "Basic cmFwdWE6cmFwdWE=",
+			scope:              models.ScopeTeamsRead,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Unknown key",
+			authorization:      "Bearer rapua_wrong",
+			scope:              models.ScopeTeamsRead,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Key with scope",
+			authorization:      "Bearer rapua_secret",
+			scope:              models.ScopeTeamsRead,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Key without scope",
+			authorization:      "Bearer rapua_secret",
+			scope:              models.ScopeTeamsWrite,
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	authenticator := &MockAPIKeyAuthenticator{secret: "rapua_secret", key: key}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/instances", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			var user *models.User
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user, _ = r.Context().Value(contextkeys.UserKey).(*models.User)
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := APIKeyMiddleware(authenticator, APIScopeMiddleware(tc.scope, next))
+			handler.ServeHTTP(w, req)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			if result.StatusCode != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, result.StatusCode)
+			}
+			if result.StatusCode == http.StatusUnauthorized && result.Header.Get("WWW-Authenticate") == "" {
+				t.Error("Expected a WWW-Authenticate header")
+			}
+			if result.StatusCode == http.StatusOK && (user == nil || user.ID != key.UserID) {
+				t.Error("Expected the key's owner in the context")
+			}
+		})
+	}
+}
@@ -106,6 +106,7 @@ func init() { //nolint:gocognit,gochecknoinits // Migration init is required
 		var instances []models.Instance
 		err = db.NewSelect().
 			Model(&instances).
+			Column("id", "name").
 			Scan(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to fetch instances: %w", err)
@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018090000_Block struct {
+	bun.BaseModel `bun:"table:blocks"`
+
+	ID        string `bun:"id,pk,notnull"`
+	Title     string `bun:"title,type:varchar(255)"`
+	LibraryID string `bun:"library_id,type:varchar(36),nullzero"`
+}
+
+func init() {
+	// Adds a title for blocks saved to a user's library, and a link from
+	// copies back to the library block they follow
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewAddColumn().
+			Model((*m20261018090000_Block)(nil)).
+			ColumnExpr("title varchar(255) NOT NULL DEFAULT ''").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("add title column: %w", err)
+		}
+
+		_, err = db.NewAddColumn().
+			Model((*m20261018090000_Block)(nil)).
+			ColumnExpr("library_id varchar(36)").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("add library_id column: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018090000_Block)(nil)).
+			Index("idx_blocks_library_id").
+			Column("library_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create library_id index: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropIndex().
+			Model((*m20261018090000_Block)(nil)).
+			Index("idx_blocks_library_id").
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop library_id index: %w", err)
+		}
+
+		// Library blocks have no owner outside the library
+		_, err = db.NewDelete().
+			Model((*m20261018090000_Block)(nil)).
+			Where("context = ?", "library").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete library blocks: %w", err)
+		}
+
+		_, err = db.NewDropColumn().Model((*m20261018090000_Block)(nil)).Column("library_id").Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop library_id column: %w", err)
+		}
+		_, err = db.NewDropColumn().Model((*m20261018090000_Block)(nil)).Column("title").Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop title column: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018100000_BlockRevision struct {
+	bun.BaseModel `bun:"table:block_revisions"`
+
+	ID        string          `bun:"id,pk,type:varchar(36)"`
+	CreatedAt time.Time       `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	BlockID   string          `bun:"block_id,notnull,type:varchar(36)"`
+	OwnerID   string          `bun:"owner_id,notnull,type:varchar(36)"`
+	Context   string          `bun:"context,type:string"`
+	Type      string          `bun:"type,type:varchar(50)"`
+	Data      json.RawMessage `bun:"data,type:jsonb"`
+	Ordering  int             `bun:"ordering,type:int"`
+	Points    int             `bun:"points,type:int"`
+	UserID    string          `bun:"user_id,type:varchar(36),nullzero"`
+	Action    string          `bun:"action,type:varchar(20),notnull"`
+}
+
+func init() {
+	// Keeps a history of block content so edits can be rolled back and
+	// deleted blocks restored
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewCreateTable().
+			Model((*m20261018100000_BlockRevision)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create block_revisions table: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018100000_BlockRevision)(nil)).
+			Index("idx_block_revisions_block_id").
+			Column("block_id", "created_at").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create block_id index: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018100000_BlockRevision)(nil)).
+			Index("idx_block_revisions_owner_id").
+			Column("owner_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create owner_id index: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropTable().
+			Model((*m20261018100000_BlockRevision)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop block_revisions table: %w", err)
+		}
+		return nil
+	})
+}
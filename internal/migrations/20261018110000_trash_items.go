@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018110000_TrashItem struct {
+	bun.BaseModel `bun:"table:trash_items"`
+
+	ID         string          `bun:"id,pk,type:varchar(36)"`
+	CreatedAt  time.Time       `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	PurgeAt    time.Time       `bun:"purge_at,notnull"`
+	UserID     string          `bun:"user_id,notnull,type:varchar(36)"`
+	InstanceID string          `bun:"instance_id,notnull,type:varchar(36)"`
+	Type       string          `bun:"type,type:varchar(20),notnull"`
+	EntityID   string          `bun:"entity_id,notnull,type:varchar(36)"`
+	Name       string          `bun:"name,type:varchar(255)"`
+	Data       json.RawMessage `bun:"data,type:jsonb"`
+}
+
+func init() {
+	// Deleted games, locations and teams are kept in the trash until they
+	// are purged
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewCreateTable().
+			Model((*m20261018110000_TrashItem)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create trash_items table: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018110000_TrashItem)(nil)).
+			Index("idx_trash_items_user_id").
+			Column("user_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create user_id index: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018110000_TrashItem)(nil)).
+			Index("idx_trash_items_purge_at").
+			Column("purge_at").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create purge_at index: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropTable().
+			Model((*m20261018110000_TrashItem)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop trash_items table: %w", err)
+		}
+		return nil
+	})
+}
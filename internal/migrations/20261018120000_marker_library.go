@@ -0,0 +1,87 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018120000_Marker struct {
+	bun.BaseModel `bun:"table:markers"`
+
+	Code     string `bun:"code,unique,pk"`
+	OwnerID  string `bun:"owner_id,type:varchar(36)"`
+	Notes    string `bun:"notes,type:text"`
+	ImageURL string `bun:"image_url,type:varchar(512)"`
+}
+
+func init() {
+	// Markers saved to a marker library are kept when no location uses them,
+	// so the same place and QR code can be reused across games
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewAddColumn().
+			Model((*m20261018120000_Marker)(nil)).
+			ColumnExpr("owner_id varchar(36) NOT NULL DEFAULT ''").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("add owner_id column: %w", err)
+		}
+
+		_, err = db.NewAddColumn().
+			Model((*m20261018120000_Marker)(nil)).
+			ColumnExpr("notes text NOT NULL DEFAULT ''").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("add notes column: %w", err)
+		}
+
+		_, err = db.NewAddColumn().
+			Model((*m20261018120000_Marker)(nil)).
+			ColumnExpr("image_url varchar(512) NOT NULL DEFAULT ''").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("add image_url column: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018120000_Marker)(nil)).
+			Index("idx_markers_owner_id").
+			Column("owner_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create owner_id index: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropIndex().
+			Model((*m20261018120000_Marker)(nil)).
+			Index("idx_markers_owner_id").
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop owner_id index: %w", err)
+		}
+
+		// Library markers no longer used by a location have nothing to keep them
+		_, err = db.NewDelete().
+			Model((*m20261018120000_Marker)(nil)).
+			Where("owner_id != ''").
+			Where("code NOT IN (SELECT marker_id FROM locations)").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete unused library markers: %w", err)
+		}
+
+		for _, column := range []string{"image_url", "notes", "owner_id"} {
+			_, err = db.NewDropColumn().Model((*m20261018120000_Marker)(nil)).Column(column).Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("drop %s column: %w", column, err)
+			}
+		}
+
+		return nil
+	})
+}
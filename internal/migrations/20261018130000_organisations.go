@@ -0,0 +1,120 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018130000_Organisation struct {
+	bun.BaseModel `bun:"table:organisations"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	ID        string    `bun:"id,pk,type:varchar(36)"`
+	Name      string    `bun:"name,type:varchar(255),notnull"`
+}
+
+type m20261018130000_OrganisationMember struct {
+	bun.BaseModel `bun:"table:organisation_members"`
+
+	CreatedAt      time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	OrganisationID string    `bun:"organisation_id,pk,type:varchar(36)"`
+	UserID         string    `bun:"user_id,pk,type:varchar(36)"`
+	Role           string    `bun:"role,type:varchar(20),notnull"`
+}
+
+type m20261018130000_Instance struct {
+	bun.BaseModel `bun:"table:instances"`
+
+	ID             string `bun:"id,pk,type:varchar(36)"`
+	OrganisationID string `bun:"organisation_id,type:varchar(36)"`
+}
+
+func init() {
+	// Organisations let several users share games and templates, with a role
+	// deciding what each member may do
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewCreateTable().
+			Model((*m20261018130000_Organisation)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create organisations table: %w", err)
+		}
+
+		_, err = db.NewCreateTable().
+			Model((*m20261018130000_OrganisationMember)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create organisation_members table: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018130000_OrganisationMember)(nil)).
+			Index("idx_organisation_members_user_id").
+			Column("user_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create user_id index: %w", err)
+		}
+
+		_, err = db.NewAddColumn().
+			Model((*m20261018130000_Instance)(nil)).
+			ColumnExpr("organisation_id varchar(36) NOT NULL DEFAULT ''").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("add organisation_id column: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018130000_Instance)(nil)).
+			Index("idx_instances_organisation_id").
+			Column("organisation_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create organisation_id index: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropIndex().
+			Model((*m20261018130000_Instance)(nil)).
+			Index("idx_instances_organisation_id").
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop organisation_id index: %w", err)
+		}
+
+		_, err = db.NewDropColumn().
+			Model((*m20261018130000_Instance)(nil)).
+			Column("organisation_id").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop organisation_id column: %w", err)
+		}
+
+		_, err = db.NewDropTable().
+			Model((*m20261018130000_OrganisationMember)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop organisation_members table: %w", err)
+		}
+
+		_, err = db.NewDropTable().
+			Model((*m20261018130000_Organisation)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop organisations table: %w", err)
+		}
+		return nil
+	})
+}
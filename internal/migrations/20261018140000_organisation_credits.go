@@ -0,0 +1,113 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018140000_Organisation struct {
+	bun.BaseModel `bun:"table:organisations"`
+
+	ID string `bun:"id,pk,type:varchar(36)"`
+}
+
+type m20261018140000_OrganisationMember struct {
+	bun.BaseModel `bun:"table:organisation_members"`
+
+	OrganisationID string `bun:"organisation_id,pk,type:varchar(36)"`
+	UserID         string `bun:"user_id,pk,type:varchar(36)"`
+}
+
+type m20261018140000_CreditPurchase struct {
+	bun.BaseModel `bun:"table:credit_purchases"`
+
+	ID string `bun:"id,pk,type:varchar(36)"`
+}
+
+type m20261018140000_CreditAdjustment struct {
+	bun.BaseModel `bun:"table:credit_adjustments"`
+
+	ID string `bun:"id,pk,type:varchar(36)"`
+}
+
+type m20261018140000_TeamStartLog struct {
+	bun.BaseModel `bun:"table:team_start_logs"`
+
+	ID string `bun:"id,pk,type:varchar(36)"`
+}
+
+func init() {
+	// Organisations hold a credit pool that members' shared games draw from,
+	// with an optional monthly limit for each member
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		columns := []struct {
+			model any
+			expr  string
+		}{
+			{(*m20261018140000_Organisation)(nil), "free_credits int NOT NULL DEFAULT 0"},
+			{(*m20261018140000_Organisation)(nil), "paid_credits int NOT NULL DEFAULT 0"},
+			{(*m20261018140000_Organisation)(nil), "monthly_credit_limit int NOT NULL DEFAULT 0"},
+			{(*m20261018140000_Organisation)(nil), "stripe_customer_id varchar(255)"},
+			{(*m20261018140000_OrganisationMember)(nil), "credit_limit int NOT NULL DEFAULT 0"},
+			{(*m20261018140000_CreditPurchase)(nil), "organisation_id varchar(36) NOT NULL DEFAULT ''"},
+			{(*m20261018140000_CreditAdjustment)(nil), "organisation_id varchar(36) NOT NULL DEFAULT ''"},
+			{(*m20261018140000_TeamStartLog)(nil), "organisation_id varchar(36) NOT NULL DEFAULT ''"},
+		}
+		for _, column := range columns {
+			_, err := db.NewAddColumn().
+				Model(column.model).
+				ColumnExpr(column.expr).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("add column %q: %w", column.expr, err)
+			}
+		}
+
+		_, err := db.NewCreateIndex().
+			Model((*m20261018140000_TeamStartLog)(nil)).
+			Index("idx_team_start_logs_organisation_id").
+			Column("organisation_id", "created_at").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create organisation_id index: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropIndex().
+			Model((*m20261018140000_TeamStartLog)(nil)).
+			Index("idx_team_start_logs_organisation_id").
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop organisation_id index: %w", err)
+		}
+
+		columns := []struct {
+			model any
+			name  string
+		}{
+			{(*m20261018140000_TeamStartLog)(nil), "organisation_id"},
+			{(*m20261018140000_CreditAdjustment)(nil), "organisation_id"},
+			{(*m20261018140000_CreditPurchase)(nil), "organisation_id"},
+			{(*m20261018140000_OrganisationMember)(nil), "credit_limit"},
+			{(*m20261018140000_Organisation)(nil), "stripe_customer_id"},
+			{(*m20261018140000_Organisation)(nil), "monthly_credit_limit"},
+			{(*m20261018140000_Organisation)(nil), "paid_credits"},
+			{(*m20261018140000_Organisation)(nil), "free_credits"},
+		}
+		for _, column := range columns {
+			_, err = db.NewDropColumn().
+				Model(column.model).
+				Column(column.name).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("drop column %s: %w", column.name, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018150000_APIKey struct {
+	bun.BaseModel `bun:"table:api_keys"`
+
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt  time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	ID         string    `bun:"id,pk,type:varchar(36)"`
+	UserID     string    `bun:"user_id,type:varchar(36),notnull"`
+	Name       string    `bun:"name,type:varchar(255),notnull"`
+	Prefix     string    `bun:"prefix,type:varchar(16),notnull"`
+	KeyHash    string    `bun:"key_hash,type:varchar(64),notnull,unique"`
+	Scopes     string    `bun:"scopes,type:text"`
+	LastUsedAt time.Time `bun:"last_used_at,nullzero"`
+}
+
+func init() {
+	// API keys authenticate programs using the REST API on a user's behalf
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewCreateTable().
+			Model((*m20261018150000_APIKey)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create api_keys table: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018150000_APIKey)(nil)).
+			Index("idx_api_keys_user_id").
+			Column("user_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create user_id index: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropTable().
+			Model((*m20261018150000_APIKey)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop api_keys table: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,113 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018160000_Webhook struct {
+	bun.BaseModel `bun:"table:webhooks"`
+
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt  time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	ID         string    `bun:"id,pk,type:varchar(36)"`
+	InstanceID string    `bun:"instance_id,type:varchar(36),notnull"`
+	URL        string    `bun:"url,type:varchar(2048),notnull"`
+	Secret     string    `bun:"secret,type:varchar(64),notnull"`
+	Events     string    `bun:"events,type:text"`
+	Enabled    bool      `bun:"enabled,notnull,default:true"`
+}
+
+type m20261018160000_WebhookDelivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries"`
+
+	CreatedAt      time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt      time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	ID             string    `bun:"id,pk,type:varchar(36)"`
+	WebhookID      string    `bun:"webhook_id,type:varchar(36),notnull"`
+	InstanceID     string    `bun:"instance_id,type:varchar(36),notnull"`
+	Event          string    `bun:"event,type:varchar(50),notnull"`
+	DedupeKey      string    `bun:"dedupe_key,type:varchar(255),notnull"`
+	Payload        string    `bun:"payload,type:text,notnull"`
+	Status         string    `bun:"status,type:varchar(20),notnull"`
+	Attempts       int       `bun:"attempts,notnull,default:0"`
+	NextAttemptAt  time.Time `bun:"next_attempt_at,notnull"`
+	LastAttemptAt  time.Time `bun:"last_attempt_at,nullzero"`
+	ResponseStatus int       `bun:"response_status,notnull,default:0"`
+	Error          string    `bun:"error,type:text,notnull"`
+}
+
+func init() {
+	// Webhooks send a game's events to other systems, with a log of each
+	// delivery and its retries
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewCreateTable().
+			Model((*m20261018160000_Webhook)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create webhooks table: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018160000_Webhook)(nil)).
+			Index("idx_webhooks_instance_id").
+			Column("instance_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create instance_id index: %w", err)
+		}
+
+		_, err = db.NewCreateTable().
+			Model((*m20261018160000_WebhookDelivery)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create webhook_deliveries table: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018160000_WebhookDelivery)(nil)).
+			Index("idx_webhook_deliveries_dedupe").
+			Column("webhook_id", "dedupe_key").
+			Unique().
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create dedupe index: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018160000_WebhookDelivery)(nil)).
+			Index("idx_webhook_deliveries_due").
+			Column("status", "next_attempt_at").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create due index: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropTable().
+			Model((*m20261018160000_WebhookDelivery)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop webhook_deliveries table: %w", err)
+		}
+
+		_, err = db.NewDropTable().
+			Model((*m20261018160000_Webhook)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop webhooks table: %w", err)
+		}
+		return nil
+	})
+}
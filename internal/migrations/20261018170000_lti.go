@@ -0,0 +1,129 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018170000_LTIPlatform struct {
+	bun.BaseModel `bun:"table:lti_platforms"`
+
+	CreatedAt    time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt    time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	ID           string    `bun:"id,pk,type:varchar(36)"`
+	InstanceID   string    `bun:"instance_id,type:varchar(36),notnull"`
+	Name         string    `bun:"name,type:varchar(255),notnull"`
+	Issuer       string    `bun:"issuer,type:varchar(255),notnull"`
+	ClientID     string    `bun:"client_id,type:varchar(255),notnull"`
+	DeploymentID string    `bun:"deployment_id,type:varchar(255),notnull"`
+	AuthLoginURL string    `bun:"auth_login_url,type:varchar(2048),notnull"`
+	AuthTokenURL string    `bun:"auth_token_url,type:varchar(2048),notnull"`
+	KeySetURL    string    `bun:"key_set_url,type:varchar(2048),notnull"`
+	GradeMode    string    `bun:"grade_mode,type:varchar(20),notnull"`
+	ScoreMaximum int       `bun:"score_maximum,notnull,default:0"`
+}
+
+type m20261018170000_LTIUser struct {
+	bun.BaseModel `bun:"table:lti_users"`
+
+	CreatedAt   time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt   time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	ID          string    `bun:"id,pk,type:varchar(36)"`
+	PlatformID  string    `bun:"platform_id,type:varchar(36),notnull"`
+	Subject     string    `bun:"subject,type:varchar(255),notnull"`
+	Name        string    `bun:"name,type:varchar(255),notnull"`
+	Email       string    `bun:"email,type:varchar(255),notnull"`
+	TeamCode    string    `bun:"team_code,type:varchar(36),notnull"`
+	LineItemURL string    `bun:"line_item_url,type:varchar(2048),notnull"`
+	SyncedScore float64   `bun:"synced_score,notnull,default:0"`
+	SyncedAt    time.Time `bun:"synced_at,nullzero"`
+	SyncError   string    `bun:"sync_error,type:text,notnull"`
+}
+
+type m20261018170000_LTIKey struct {
+	bun.BaseModel `bun:"table:lti_keys"`
+
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt  time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	ID         string    `bun:"id,pk,type:varchar(36)"`
+	PrivateKey string    `bun:"private_key,type:text,notnull"`
+}
+
+func init() {
+	// LTI 1.3 lets an LMS launch a game and receive grades. Platforms are
+	// registered per game, and each LMS user is linked to their team
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewCreateTable().
+			Model((*m20261018170000_LTIPlatform)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create lti_platforms table: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018170000_LTIPlatform)(nil)).
+			Index("idx_lti_platforms_issuer_client").
+			Column("issuer", "client_id").
+			Unique().
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create issuer index: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018170000_LTIPlatform)(nil)).
+			Index("idx_lti_platforms_instance_id").
+			Column("instance_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create instance_id index: %w", err)
+		}
+
+		_, err = db.NewCreateTable().
+			Model((*m20261018170000_LTIUser)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create lti_users table: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018170000_LTIUser)(nil)).
+			Index("idx_lti_users_platform_subject").
+			Column("platform_id", "subject").
+			Unique().
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create subject index: %w", err)
+		}
+
+		_, err = db.NewCreateTable().
+			Model((*m20261018170000_LTIKey)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create lti_keys table: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		for _, model := range []any{
+			(*m20261018170000_LTIKey)(nil),
+			(*m20261018170000_LTIUser)(nil),
+			(*m20261018170000_LTIPlatform)(nil),
+		} {
+			_, err := db.NewDropTable().Model(model).IfExists().Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("drop lti tables: %w", err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,104 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018180000_User struct {
+	bun.BaseModel `bun:"table:users"`
+
+	ID string `bun:"id,pk,type:varchar(36)"`
+}
+
+type m20261018180000_Organisation struct {
+	bun.BaseModel `bun:"table:organisations"`
+
+	ID string `bun:"id,pk,type:varchar(36)"`
+}
+
+type m20261018180000_RecoveryCode struct {
+	bun.BaseModel `bun:"table:recovery_codes"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	ID        string    `bun:"id,pk,type:varchar(36)"`
+	UserID    string    `bun:"user_id,type:varchar(36),notnull"`
+	CodeHash  string    `bun:"code_hash,type:varchar(64),notnull,unique"`
+	UsedAt    time.Time `bun:"used_at,nullzero"`
+}
+
+func init() {
+	// Optional TOTP two-factor authentication for admin accounts, with
+	// one-time recovery codes, which organisations can require of members
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		columns := []struct {
+			model any
+			expr  string
+		}{
+			{(*m20261018180000_User)(nil), "totp_secret varchar(64)"},
+			{(*m20261018180000_User)(nil), "totp_enabled boolean NOT NULL DEFAULT false"},
+			{(*m20261018180000_User)(nil), "totp_last_step bigint NOT NULL DEFAULT 0"},
+			{(*m20261018180000_Organisation)(nil), "require_two_factor boolean NOT NULL DEFAULT false"},
+		}
+		for _, column := range columns {
+			_, err := db.NewAddColumn().
+				Model(column.model).
+				ColumnExpr(column.expr).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("add column %q: %w", column.expr, err)
+			}
+		}
+
+		_, err := db.NewCreateTable().
+			Model((*m20261018180000_RecoveryCode)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create recovery_codes table: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018180000_RecoveryCode)(nil)).
+			Index("idx_recovery_codes_user_id").
+			Column("user_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create user_id index: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropTable().
+			Model((*m20261018180000_RecoveryCode)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop recovery_codes table: %w", err)
+		}
+
+		columns := []struct {
+			model any
+			name  string
+		}{
+			{(*m20261018180000_Organisation)(nil), "require_two_factor"},
+			{(*m20261018180000_User)(nil), "totp_last_step"},
+			{(*m20261018180000_User)(nil), "totp_enabled"},
+			{(*m20261018180000_User)(nil), "totp_secret"},
+		}
+		for _, column := range columns {
+			_, err = db.NewDropColumn().
+				Model(column.model).
+				Column(column.name).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("drop column %s: %w", column.name, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,101 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018190000_AdminSession struct {
+	bun.BaseModel `bun:"table:admin_sessions"`
+
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	LastSeenAt time.Time `bun:"last_seen_at,nullzero,notnull,default:current_timestamp"`
+	ID         string    `bun:"id,pk,type:varchar(36)"`
+	UserID     string    `bun:"user_id,type:varchar(36),notnull"`
+	IPAddress  string    `bun:"ip_address,type:varchar(64)"`
+	UserAgent  string    `bun:"user_agent,type:text"`
+	Method     string    `bun:"method,type:varchar(64)"`
+}
+
+type m20261018190000_LoginAttempt struct {
+	bun.BaseModel `bun:"table:login_attempts"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	ID        string    `bun:"id,pk,type:varchar(36)"`
+	Email     string    `bun:"email,type:varchar(255),notnull"`
+	UserID    string    `bun:"user_id,type:varchar(36)"`
+	IPAddress string    `bun:"ip_address,type:varchar(64)"`
+	UserAgent string    `bun:"user_agent,type:text"`
+	Method    string    `bun:"method,type:varchar(64)"`
+	Success   bool      `bun:"success,notnull,default:false"`
+	Reason    string    `bun:"reason,type:varchar(64)"`
+}
+
+func init() {
+	// Server-side records of admin sessions so they can be revoked, and an
+	// audit log of login attempts used to lock out repeated failures
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewCreateTable().
+			Model((*m20261018190000_AdminSession)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create admin_sessions table: %w", err)
+		}
+
+		_, err = db.NewCreateTable().
+			Model((*m20261018190000_LoginAttempt)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create login_attempts table: %w", err)
+		}
+
+		indexes := []struct {
+			model   any
+			name    string
+			columns []string
+		}{
+			{(*m20261018190000_AdminSession)(nil), "idx_admin_sessions_user_id", []string{"user_id"}},
+			{
+				(*m20261018190000_LoginAttempt)(nil),
+				"idx_login_attempts_email_created_at",
+				[]string{"email", "created_at"},
+			},
+			{(*m20261018190000_LoginAttempt)(nil), "idx_login_attempts_user_id", []string{"user_id"}},
+		}
+		for _, index := range indexes {
+			_, err = db.NewCreateIndex().
+				Model(index.model).
+				Index(index.name).
+				Column(index.columns...).
+				IfNotExists().
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("create index %s: %w", index.name, err)
+			}
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropTable().
+			Model((*m20261018190000_LoginAttempt)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop login_attempts table: %w", err)
+		}
+
+		_, err = db.NewDropTable().
+			Model((*m20261018190000_AdminSession)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop admin_sessions table: %w", err)
+		}
+		return nil
+	})
+}
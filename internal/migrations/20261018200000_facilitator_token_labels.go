@@ -0,0 +1,109 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type m20261018200000_FacilitatorToken struct {
+	bun.BaseModel `bun:"table:facilitator_tokens"`
+
+	Token      string    `bun:"token,pk"`
+	ID         string    `bun:"id,type:varchar(36)"`
+	InstanceID string    `bun:"instance_id,notnull"`
+	Label      string    `bun:"label,type:varchar(100)"`
+	CreatedAt  time.Time `bun:"created_at,nullzero"`
+	LastUsedAt time.Time `bun:"last_used_at,nullzero"`
+}
+
+func init() {
+	// Labels facilitator links and records when they were last used so they
+	// can be listed and revoked. Links get an ID so the token itself is never
+	// needed to revoke them.
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		columns := []string{
+			"id varchar(36)",
+			"label varchar(100) NOT NULL DEFAULT ''",
+			"created_at timestamp",
+			"last_used_at timestamp",
+		}
+		for _, column := range columns {
+			_, err := db.NewAddColumn().
+				Model((*m20261018200000_FacilitatorToken)(nil)).
+				ColumnExpr(column).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("add column %q: %w", column, err)
+			}
+		}
+
+		var tokens []m20261018200000_FacilitatorToken
+		err := db.NewSelect().Model(&tokens).Column("token").Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select tokens: %w", err)
+		}
+		now := time.Now().UTC()
+		for _, token := range tokens {
+			_, err = db.NewUpdate().
+				Model((*m20261018200000_FacilitatorToken)(nil)).
+				Set("id = ?", uuid.New().String()).
+				Set("created_at = ?", now).
+				Where("token = ?", token.Token).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("set token id: %w", err)
+			}
+		}
+
+		indexes := []struct {
+			name   string
+			column string
+			unique bool
+		}{
+			{"idx_facilitator_tokens_id", "id", true},
+			{"idx_facilitator_tokens_instance_id", "instance_id", false},
+		}
+		for _, index := range indexes {
+			query := db.NewCreateIndex().
+				Model((*m20261018200000_FacilitatorToken)(nil)).
+				Index(index.name).
+				Column(index.column).
+				IfNotExists()
+			if index.unique {
+				query = query.Unique()
+			}
+			_, err = query.Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("create index %s: %w", index.name, err)
+			}
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		for _, index := range []string{"idx_facilitator_tokens_instance_id", "idx_facilitator_tokens_id"} {
+			_, err := db.NewDropIndex().
+				Model((*m20261018200000_FacilitatorToken)(nil)).
+				Index(index).
+				IfExists().
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("drop index %s: %w", index, err)
+			}
+		}
+
+		for _, column := range []string{"last_used_at", "created_at", "label", "id"} {
+			_, err := db.NewDropColumn().
+				Model((*m20261018200000_FacilitatorToken)(nil)).
+				Column(column).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("drop column %s: %w", column, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018210000_TeamMessage struct {
+	bun.BaseModel `bun:"table:team_messages"`
+
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	ID         string    `bun:"id,pk,type:varchar(36)"`
+	InstanceID string    `bun:"instance_id,type:varchar(36),notnull"`
+	TeamCode   string    `bun:"team_code,type:varchar(36),notnull"`
+	Sender     string    `bun:"sender,type:varchar(16),notnull"`
+	Author     string    `bun:"author,type:varchar(255)"`
+	Content    string    `bun:"content,type:text,notnull"`
+	ReadAt     time.Time `bun:"read_at,nullzero"`
+}
+
+func init() {
+	// Two-way messages between teams and the admins and facilitators running
+	// their game
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewCreateTable().
+			Model((*m20261018210000_TeamMessage)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create team_messages table: %w", err)
+		}
+
+		indexes := []struct {
+			name    string
+			columns []string
+		}{
+			{"idx_team_messages_instance_id_team_code", []string{"instance_id", "team_code"}},
+			{"idx_team_messages_team_code_created_at", []string{"team_code", "created_at"}},
+		}
+		for _, index := range indexes {
+			_, err = db.NewCreateIndex().
+				Model((*m20261018210000_TeamMessage)(nil)).
+				Index(index.name).
+				Column(index.columns...).
+				IfNotExists().
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("create index %s: %w", index.name, err)
+			}
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropTable().
+			Model((*m20261018210000_TeamMessage)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop team_messages table: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018220000_ScheduledNotification struct {
+	bun.BaseModel `bun:"table:scheduled_notifications"`
+
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	ID            string    `bun:"id,pk,type:varchar(36)"`
+	InstanceID    string    `bun:"instance_id,type:varchar(36),notnull"`
+	Content       string    `bun:"content,type:varchar(255),notnull"`
+	Timing        string    `bun:"timing,type:varchar(16),notnull"`
+	SendAt        time.Time `bun:"send_at,nullzero"`
+	OffsetMinutes int       `bun:"offset_minutes,notnull,default:0"`
+	Target        string    `bun:"target,type:varchar(16),notnull"`
+	TargetID      string    `bun:"target_id,type:varchar(36)"`
+	SentAt        time.Time `bun:"sent_at,nullzero"`
+	Recipients    int       `bun:"recipients,notnull,default:0"`
+	Skipped       string    `bun:"skipped,type:varchar(255)"`
+}
+
+func init() {
+	// Announcements sent at a set time, or relative to the start or end of
+	// the game, to some or all teams
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewCreateTable().
+			Model((*m20261018220000_ScheduledNotification)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create scheduled_notifications table: %w", err)
+		}
+
+		indexes := []struct {
+			name    string
+			columns []string
+		}{
+			{"idx_scheduled_notifications_instance_id", []string{"instance_id"}},
+			{"idx_scheduled_notifications_sent_at", []string{"sent_at"}},
+		}
+		for _, index := range indexes {
+			_, err = db.NewCreateIndex().
+				Model((*m20261018220000_ScheduledNotification)(nil)).
+				Index(index.name).
+				Column(index.columns...).
+				IfNotExists().
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("create index %s: %w", index.name, err)
+			}
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropTable().
+			Model((*m20261018220000_ScheduledNotification)(nil)).
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop scheduled_notifications table: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,86 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261018230000_PushSubscription struct {
+	bun.BaseModel `bun:"table:push_subscriptions"`
+
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	ID         string    `bun:"id,pk,type:varchar(36)"`
+	InstanceID string    `bun:"instance_id,type:varchar(36),notnull"`
+	TeamCode   string    `bun:"team_code,type:varchar(36),notnull"`
+	Endpoint   string    `bun:"endpoint,type:varchar(2048),notnull"`
+	P256dh     string    `bun:"p256dh,type:varchar(255),notnull"`
+	Auth       string    `bun:"auth,type:varchar(64),notnull"`
+}
+
+type m20261018230000_VAPIDKey struct {
+	bun.BaseModel `bun:"table:vapid_keys"`
+
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt  time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	ID         string    `bun:"id,pk,type:varchar(36)"`
+	PrivateKey string    `bun:"private_key,type:text,notnull"`
+}
+
+func init() {
+	// Web Push subscriptions for players' devices, and the key push requests
+	// are signed with
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewCreateTable().
+			Model((*m20261018230000_PushSubscription)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create push_subscriptions table: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018230000_PushSubscription)(nil)).
+			Index("idx_push_subscriptions_endpoint").
+			Column("endpoint").
+			Unique().
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_push_subscriptions_endpoint: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261018230000_PushSubscription)(nil)).
+			Index("idx_push_subscriptions_team_code").
+			Column("team_code").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_push_subscriptions_team_code: %w", err)
+		}
+
+		_, err = db.NewCreateTable().
+			Model((*m20261018230000_VAPIDKey)(nil)).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create vapid_keys table: %w", err)
+		}
+
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		for _, model := range []any{
+			(*m20261018230000_VAPIDKey)(nil),
+			(*m20261018230000_PushSubscription)(nil),
+		} {
+			_, err := db.NewDropTable().Model(model).IfExists().Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("drop push tables: %w", err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+type m20261019000000_LTIUser struct {
+	bun.BaseModel `bun:"table:lti_users"`
+
+	PlatformID string `bun:"platform_id,type:varchar(36),notnull"`
+	TeamKey    string `bun:"team_key,type:varchar(255),notnull"`
+}
+
+func init() {
+	// Lets LMS users who launch with the same team key share a team, rather
+	// than each playing alone
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewAddColumn().
+			Model((*m20261019000000_LTIUser)(nil)).
+			ColumnExpr("team_key varchar(255) NOT NULL DEFAULT ''").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("add team_key column: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*m20261019000000_LTIUser)(nil)).
+			Index("idx_lti_users_platform_team_key").
+			Column("platform_id", "team_key").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create team key index: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropIndex().
+			Model((*m20261019000000_LTIUser)(nil)).
+			Index("idx_lti_users_platform_team_key").
+			IfExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop team key index: %w", err)
+		}
+
+		_, err = db.NewDropColumn().
+			Model((*m20261019000000_LTIUser)(nil)).
+			Column("team_key").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("drop team_key column: %w", err)
+		}
+		return nil
+	})
+}
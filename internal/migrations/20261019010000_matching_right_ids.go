@@ -0,0 +1,218 @@
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type m20261019010000_Block struct {
+	bun.BaseModel `bun:"table:blocks"`
+
+	ID   string          `bun:"id,pk,notnull"`
+	Type string          `bun:"type"`
+	Data json.RawMessage `bun:"data,type:jsonb"`
+}
+
+type m20261019010000_BlockRevision struct {
+	bun.BaseModel `bun:"table:block_revisions"`
+
+	ID   string          `bun:"id,pk,type:varchar(36)"`
+	Type string          `bun:"type,type:varchar(50)"`
+	Data json.RawMessage `bun:"data,type:jsonb"`
+}
+
+type m20261019010000_TeamBlockState struct {
+	bun.BaseModel `bun:"table:team_block_states"`
+
+	TeamCode   string          `bun:"team_code,pk,notnull"`
+	BlockID    string          `bun:"block_id,pk,notnull"`
+	PlayerData json.RawMessage `bun:"player_data,type:jsonb"`
+}
+
+// m20261019010000_setRightIDs gives each pair in a matching block's data a
+// right ID from ids, adding new ones as needed, or removes them if ids is
+// nil.
+func m20261019010000_setRightIDs(data json.RawMessage, ids map[string]string) (json.RawMessage, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	pairs, _ := fields["pairs"].([]any)
+	for _, item := range pairs {
+		pair, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if ids == nil {
+			delete(pair, "right_id")
+			continue
+		}
+		id, _ := pair["id"].(string)
+		if _, found := ids[id]; !found {
+			ids[id] = uuid.New().String()
+		}
+		pair["right_id"] = ids[id]
+	}
+	return json.Marshal(fields)
+}
+
+// m20261019010000_mapMatches replaces the chosen right items in each team's
+// answers to the given blocks using ids.
+func m20261019010000_mapMatches(ctx context.Context, db *bun.DB, blockIDs []string, ids map[string]string) error {
+	if len(blockIDs) == 0 {
+		return nil
+	}
+	var states []m20261019010000_TeamBlockState
+	err := db.NewSelect().Model(&states).Where("block_id IN (?)", bun.In(blockIDs)).Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("select block states: %w", err)
+	}
+	for _, state := range states {
+		if len(state.PlayerData) == 0 {
+			continue
+		}
+		var fields map[string]any
+		if err = json.Unmarshal(state.PlayerData, &fields); err != nil {
+			continue
+		}
+		matches, _ := fields["matches"].(map[string]any)
+		for left, right := range matches {
+			if id, ok := right.(string); ok && ids[id] != "" {
+				matches[left] = ids[id]
+			}
+		}
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("encode player data: %w", err)
+		}
+		_, err = db.NewUpdate().
+			Model((*m20261019010000_TeamBlockState)(nil)).
+			Set("player_data = ?", string(data)).
+			Where("team_code = ? AND block_id = ?", state.TeamCode, state.BlockID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("update block state: %w", err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	// Matching blocks showed players each pair's ID on both sides, which gave
+	// the answers away. Right-hand items get their own IDs, and teams'
+	// answers are moved over to them
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		ids := make(map[string]string)
+
+		var matching []m20261019010000_Block
+		err := db.NewSelect().Model(&matching).Where("type = ?", "matching").Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select matching blocks: %w", err)
+		}
+		blockIDs := make([]string, 0, len(matching))
+		for _, block := range matching {
+			data, err := m20261019010000_setRightIDs(block.Data, ids)
+			if err != nil {
+				return fmt.Errorf("block %s: %w", block.ID, err)
+			}
+			_, err = db.NewUpdate().
+				Model((*m20261019010000_Block)(nil)).
+				Set("data = ?", string(data)).
+				Where("id = ?", block.ID).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("update block %s: %w", block.ID, err)
+			}
+			blockIDs = append(blockIDs, block.ID)
+		}
+
+		// Revisions share the block's right IDs, so restoring one keeps
+		// teams' answers
+		var revisions []m20261019010000_BlockRevision
+		err = db.NewSelect().Model(&revisions).Where("type = ?", "matching").Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select matching revisions: %w", err)
+		}
+		for _, revision := range revisions {
+			data, err := m20261019010000_setRightIDs(revision.Data, ids)
+			if err != nil {
+				return fmt.Errorf("revision %s: %w", revision.ID, err)
+			}
+			_, err = db.NewUpdate().
+				Model((*m20261019010000_BlockRevision)(nil)).
+				Set("data = ?", string(data)).
+				Where("id = ?", revision.ID).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("update revision %s: %w", revision.ID, err)
+			}
+		}
+
+		return m20261019010000_mapMatches(ctx, db, blockIDs, ids)
+	}, func(ctx context.Context, db *bun.DB) error {
+		var matching []m20261019010000_Block
+		err := db.NewSelect().Model(&matching).Where("type = ?", "matching").Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select matching blocks: %w", err)
+		}
+
+		// Map each right ID back to its pair's ID
+		pairIDs := make(map[string]string)
+		blockIDs := make([]string, 0, len(matching))
+		for _, block := range matching {
+			var fields struct {
+				Pairs []struct {
+					ID      string `json:"id"`
+					RightID string `json:"right_id"`
+				} `json:"pairs"`
+			}
+			if err = json.Unmarshal(block.Data, &fields); err != nil {
+				return fmt.Errorf("block %s: %w", block.ID, err)
+			}
+			for _, pair := range fields.Pairs {
+				if pair.RightID != "" {
+					pairIDs[pair.RightID] = pair.ID
+				}
+			}
+			data, err := m20261019010000_setRightIDs(block.Data, nil)
+			if err != nil {
+				return fmt.Errorf("block %s: %w", block.ID, err)
+			}
+			_, err = db.NewUpdate().
+				Model((*m20261019010000_Block)(nil)).
+				Set("data = ?", string(data)).
+				Where("id = ?", block.ID).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("update block %s: %w", block.ID, err)
+			}
+			blockIDs = append(blockIDs, block.ID)
+		}
+
+		var revisions []m20261019010000_BlockRevision
+		err = db.NewSelect().Model(&revisions).Where("type = ?", "matching").Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select matching revisions: %w", err)
+		}
+		for _, revision := range revisions {
+			data, err := m20261019010000_setRightIDs(revision.Data, nil)
+			if err != nil {
+				return fmt.Errorf("revision %s: %w", revision.ID, err)
+			}
+			_, err = db.NewUpdate().
+				Model((*m20261019010000_BlockRevision)(nil)).
+				Set("data = ?", string(data)).
+				Where("id = ?", revision.ID).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("update revision %s: %w", revision.ID, err)
+			}
+		}
+
+		return m20261019010000_mapMatches(ctx, db, blockIDs, pairIDs)
+	})
+}
@@ -20,6 +20,9 @@ type Scheduler struct {
 }
 
 var (
+	NextMinute = func() time.Time {
+		return time.Now().Truncate(time.Minute).Add(time.Minute)
+	}
 	NextDaily = func() time.Time {
 		now := time.Now()
 		tomorrow := now.AddDate(0, 0, 1)
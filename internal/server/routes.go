@@ -13,10 +13,13 @@ import (
 	"github.com/nathanhollows/Rapua/v6/filesystem"
 	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
 	admin "github.com/nathanhollows/Rapua/v6/internal/handlers/admin"
+	"github.com/nathanhollows/Rapua/v6/internal/handlers/api"
+	"github.com/nathanhollows/Rapua/v6/internal/handlers/lti"
 	players "github.com/nathanhollows/Rapua/v6/internal/handlers/players"
 	"github.com/nathanhollows/Rapua/v6/internal/handlers/public"
 	"github.com/nathanhollows/Rapua/v6/internal/handlers/static"
 	"github.com/nathanhollows/Rapua/v6/internal/middlewares"
+	"github.com/nathanhollows/Rapua/v6/models"
 )
 
 const (
@@ -29,6 +32,8 @@ func setupRouter(
 	publicHandler *public.Handler,
 	playerHandler *players.PlayerHandler,
 	adminHandler *admin.Handler,
+	apiHandler *api.Handler,
+	ltiHandler *lti.Handler,
 ) *chi.Mux {
 	// Get CSRF key from environment
 	csrfKey := os.Getenv("CSRF_KEY")
@@ -69,6 +74,14 @@ func setupRouter(
 	// Webhook routes that bypass CSRF protection
 	setupWebhookRoutes(router, adminHandler)
 
+	// API routes are authenticated by API key rather than a session cookie,
+	// so they bypass CSRF protection too
+	setupAPIRoutes(router, apiHandler)
+
+	// LTI launches are cross-site posts from an LMS, checked by signature and
+	// state rather than a CSRF token
+	setupLTIRoutes(router, ltiHandler)
+
 	// All other routes with CSRF protection
 	router.Group(func(r chi.Router) {
 		r.Use(CSRF)
@@ -85,6 +98,10 @@ func setupRouter(
 	// Image resizing handler for uploads (must come before general static handler)
 	router.Get("/static/uploads/*", static.ServeResizedImage(workDir))
 
+	// The service worker is served from the root so it can show alerts for
+	// every page
+	router.Get("/sw.js", static.ServeServiceWorker(workDir))
+
 	filesystem.FileServer(router, "/static", filesDir)
 
 	return router
@@ -143,6 +160,8 @@ func setupPlayerRoutes(router chi.Router, playerHandler *players.PlayerHandler,
 		r.Post("/validate", playerHandler.ValidateBlock)
 		r.Get("/{id}/team-name-block", playerHandler.GetTeamNameBlock)
 		r.Get("/{id}/game-status-alert", playerHandler.GetGameStatusAlertBlock)
+		r.Get("/poll/{id}/results", playerHandler.GetPollResults)
+		r.Get("/{id}/reveal", playerHandler.RevealBlock)
 		r.Get("/{id}/start-game-button", playerHandler.GetStartGameButtonBlock)
 	})
 
@@ -236,6 +255,29 @@ func setupPlayerRoutes(router chi.Router, playerHandler *players.PlayerHandler,
 		r.Get("/{id}", playerHandler.CheckInView)
 	})
 
+	// Two-way messages with the organisers
+	router.Route("/messages", func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return middlewares.TeamMiddleware(playerHandler.GetTeamService(),
+				middlewares.StartMiddleware(playerHandler.GetTeamService(), next))
+		})
+		r.Get("/", playerHandler.Messages)
+		r.Post("/", playerHandler.MessagesPost)
+		r.Get("/thread", playerHandler.MessagesThread)
+	})
+
+	// Push alerts for players' devices
+	router.Route("/push", func(r chi.Router) {
+		r.Get("/key", playerHandler.PushKey)
+		r.Group(func(r chi.Router) {
+			r.Use(func(next http.Handler) http.Handler {
+				return middlewares.TeamMiddleware(playerHandler.GetTeamService(), next)
+			})
+			r.Post("/", playerHandler.PushSubscribe)
+			r.Delete("/", playerHandler.PushUnsubscribe)
+		})
+	})
+
 	router.Post("/dismiss/{ID}", playerHandler.DismissNotificationPost)
 }
 
@@ -273,6 +315,8 @@ func setupPublicRoutes(router chi.Router, publicHandler *public.Handler) {
 	router.Route("/login", func(r chi.Router) {
 		r.Get("/", publicHandler.Login)
 		r.Post("/", publicHandler.LoginPost)
+		r.Get("/two-factor", publicHandler.TwoFactor)
+		r.Post("/two-factor", publicHandler.TwoFactorPost)
 	})
 	router.Get("/logout", publicHandler.Logout)
 	router.Route("/register", func(r chi.Router) {
@@ -313,7 +357,13 @@ func setupAdminRoutes(router chi.Router, adminHandler *admin.Handler) {
 		r.Use(func(next http.Handler) http.Handler {
 			return middlewares.AdminAuthMiddleware(adminHandler.GetIdentityService(), next)
 		})
+		r.Use(func(next http.Handler) http.Handler {
+			return middlewares.AdminTwoFactorMiddleware(adminHandler.GetTwoFactorService(), next)
+		})
 		r.Use(middlewares.AdminCheckInstanceMiddleware)
+		r.Use(func(next http.Handler) http.Handler {
+			return middlewares.AdminRoleMiddleware(adminHandler.GetAccessService(), adminHandler.Forbidden, next)
+		})
 
 		r.Route("/quickstart", func(r chi.Router) {
 			r.Get("/", adminHandler.Quickstart)
@@ -327,6 +377,7 @@ func setupAdminRoutes(router chi.Router, adminHandler *admin.Handler) {
 			r.Get("/team/{teamCode}", adminHandler.TeamActivity)
 			r.Get("/stats", adminHandler.ActivityStats)
 			r.Get("/locations", adminHandler.ActivityLocations)
+			r.Get("/polls", adminHandler.ActivityPolls)
 		})
 
 		r.Route("/locations", func(r chi.Router) {
@@ -335,6 +386,9 @@ func setupAdminRoutes(router chi.Router, adminHandler *admin.Handler) {
 			r.Post("/structure", adminHandler.SaveGameStructure)
 			r.Get("/new", adminHandler.LocationNew)
 			r.Post("/new", adminHandler.LocationNewPost)
+			r.Get("/import", adminHandler.LocationImport)
+			r.Post("/import", adminHandler.LocationImportPost)
+			r.Post("/import/preview", adminHandler.LocationImportPreview)
 			r.Get("/start", adminHandler.StartPageEdit)
 			r.Get("/complete", adminHandler.CompletePageEdit)
 			r.Get("/{id}", adminHandler.LocationEdit)
@@ -356,6 +410,56 @@ func setupAdminRoutes(router chi.Router, adminHandler *admin.Handler) {
 			r.Put("/{id}", adminHandler.BlockUpdate)      // PUT /admin/blocks/{id}
 			r.Delete("/{id}", adminHandler.BlockDelete)   // DELETE /admin/blocks/{id}
 			r.Post("/reorder", adminHandler.BlockReorder) // POST /admin/blocks/reorder
+			// Visibility conditions
+			r.Get("/{id}/visibility", adminHandler.BlockVisibilityGet)
+			r.Put("/{id}/visibility", adminHandler.BlockVisibilityUpdate)
+			r.Post("/{id}/unlink", adminHandler.BlockUnlink)
+			// Revision history
+			r.Get("/{id}/revisions", adminHandler.BlockRevisions)
+			r.Get("/deleted", adminHandler.BlockDeletedList)
+			r.Post("/revisions/{id}/restore", adminHandler.BlockRevisionRestore)
+		})
+
+		r.Route("/markers", func(r chi.Router) {
+			r.Get("/", adminHandler.MarkerLibrary)
+			r.Post("/", adminHandler.MarkerLibraryCreate)
+			r.Get("/search", adminHandler.MarkerLibrarySearch)
+			r.Get("/new", adminHandler.MarkerLibraryNew)
+			r.Post("/add", adminHandler.MarkerLibraryAdd)
+			r.Get("/{code}", adminHandler.MarkerLibraryEdit)
+			r.Post("/{code}", adminHandler.MarkerLibraryUpdate)
+			r.Delete("/{code}", adminHandler.MarkerLibraryRemove)
+		})
+
+		r.Route("/library", func(r chi.Router) {
+			r.Get("/", adminHandler.BlockLibrary)
+			r.Post("/", adminHandler.BlockLibrarySave)
+			r.Get("/picker", adminHandler.BlockLibraryPicker)
+			r.Put("/{id}", adminHandler.BlockLibraryRename)
+			r.Delete("/{id}", adminHandler.BlockLibraryDelete)
+			r.Post("/{id}/insert", adminHandler.BlockLibraryInsert)
+		})
+
+		r.Route("/trash", func(r chi.Router) {
+			r.Get("/", adminHandler.Trash)
+			r.Post("/{id}/restore", adminHandler.TrashRestore)
+			r.Delete("/{id}", adminHandler.TrashPurge)
+		})
+
+		r.Route("/organisations", func(r chi.Router) {
+			r.Get("/", adminHandler.Organisations)
+			r.Post("/", adminHandler.OrganisationCreate)
+			r.Get("/{id}", adminHandler.Organisation)
+			r.Put("/{id}", adminHandler.OrganisationRename)
+			r.Delete("/{id}", adminHandler.OrganisationDelete)
+			r.Get("/{id}/credits", adminHandler.OrganisationCredits)
+			r.Post("/{id}/members", adminHandler.OrganisationMemberAdd)
+			r.Put("/{id}/members/{userID}", adminHandler.OrganisationMemberRole)
+			r.Delete("/{id}/members/{userID}", adminHandler.OrganisationMemberRemove)
+			r.Put("/{id}/members/{userID}/limit", adminHandler.OrganisationCreditLimit)
+			r.Put("/{id}/two-factor", adminHandler.OrganisationTwoFactor)
+			r.Post("/{id}/instances", adminHandler.OrganisationShare)
+			r.Delete("/{id}/instances/{instanceID}", adminHandler.OrganisationUnshare)
 		})
 		r.Route("/teams", func(r chi.Router) {
 			r.Get("/", adminHandler.Teams)
@@ -363,6 +467,8 @@ func setupAdminRoutes(router chi.Router, adminHandler *admin.Handler) {
 			r.Get("/{teamCode}", adminHandler.TeamOverview)
 			r.Delete("/{teamCode}", adminHandler.TeamDelete)
 			r.Post("/{teamCode}/reset", adminHandler.TeamReset)
+			r.Get("/{teamCode}/messages", adminHandler.TeamMessages)
+			r.Post("/{teamCode}/messages", adminHandler.TeamMessageReply)
 		})
 
 		r.Route("/experience", func(r chi.Router) {
@@ -371,6 +477,21 @@ func setupAdminRoutes(router chi.Router, adminHandler *admin.Handler) {
 			r.Post("/preview", adminHandler.ExperiencePreview)
 		})
 
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Get("/", adminHandler.Webhooks)
+			r.Post("/", adminHandler.WebhookCreate)
+			r.Get("/deliveries", adminHandler.WebhookDeliveries)
+			r.Put("/{id}", adminHandler.WebhookToggle)
+			r.Delete("/{id}", adminHandler.WebhookDelete)
+		})
+
+		r.Route("/lti", func(r chi.Router) {
+			r.Get("/", adminHandler.LTI)
+			r.Post("/", adminHandler.LTIPlatformCreate)
+			r.Put("/{id}", adminHandler.LTIPlatformGrading)
+			r.Delete("/{id}", adminHandler.LTIPlatformDelete)
+		})
+
 		r.Route("/instances", func(r chi.Router) {
 			r.Get("/", adminHandler.Instances)
 			r.Post("/new", adminHandler.InstancesCreate)
@@ -395,13 +516,18 @@ func setupAdminRoutes(router chi.Router, adminHandler *admin.Handler) {
 		})
 
 		r.Route("/notify", func(r chi.Router) {
+			r.Get("/", adminHandler.Announcements)
+			r.Post("/", adminHandler.AnnouncementSchedule)
+			r.Delete("/{id}", adminHandler.AnnouncementCancel)
 			r.Post("/all", adminHandler.NotifyAllPost)
 			r.Post("/team", adminHandler.NotifyTeamPost)
 		})
 
 		r.Route("/facilitator", func(r chi.Router) {
+			r.Get("/", adminHandler.FacilitatorLinks)
 			r.Get("/create-link", adminHandler.FacilitatorShowModal)
 			r.Post("/create-link", adminHandler.FacilitatorCreateTokenLink)
+			r.Delete("/links/{id}", adminHandler.FacilitatorLinkRevoke)
 		})
 
 		r.Route("/templates", func(r chi.Router) {
@@ -434,6 +560,21 @@ func setupAdminRoutes(router chi.Router, adminHandler *admin.Handler) {
 			})
 			r.Get("/security", adminHandler.SettingsSecurity)
 			r.Post("/security", adminHandler.SettingsSecurityPost)
+			r.Route("/security/two-factor", func(r chi.Router) {
+				r.Post("/", adminHandler.SettingsTwoFactorBegin)
+				r.Post("/confirm", adminHandler.SettingsTwoFactorConfirm)
+				r.Post("/recovery-codes", adminHandler.SettingsTwoFactorRecoveryCodes)
+				r.Post("/disable", adminHandler.SettingsTwoFactorDisable)
+			})
+			r.Route("/security/sessions", func(r chi.Router) {
+				r.Delete("/", adminHandler.SettingsSessionsRevokeOthers)
+				r.Delete("/{id}", adminHandler.SettingsSessionRevoke)
+			})
+			r.Route("/api-keys", func(r chi.Router) {
+				r.Get("/", adminHandler.SettingsAPIKeys)
+				r.Post("/", adminHandler.SettingsAPIKeyCreate)
+				r.Delete("/{id}", adminHandler.SettingsAPIKeyRevoke)
+			})
 			r.Delete("/delete-account", adminHandler.DeleteAccount)
 		})
 
@@ -452,6 +593,14 @@ func setupFacilitatorRoutes(router chi.Router, adminHandler *admin.Handler) {
 	router.Route("/facilitator", func(r chi.Router) {
 		r.Get("/login/{token}", adminHandler.FacilitatorLogin)
 		r.Get("/dashboard", adminHandler.FacilitatorDashboard)
+		r.Get("/stations", adminHandler.FacilitatorStations)
+		r.Route("/locations/{locationID}/teams/{teamCode}", func(r chi.Router) {
+			r.Post("/check-in", adminHandler.FacilitatorCheckIn)
+			r.Post("/check-out", adminHandler.FacilitatorCheckOut)
+			r.Post("/blocks/{blockID}/approve", adminHandler.FacilitatorApproveBlock)
+		})
+		r.Get("/teams/{teamCode}/messages", adminHandler.FacilitatorMessages)
+		r.Post("/teams/{teamCode}/messages", adminHandler.FacilitatorMessageReply)
 	})
 }
 
@@ -460,3 +609,46 @@ func setupWebhookRoutes(router chi.Router, adminHandler *admin.Handler) {
 	// Webhook routes are registered before CSRF middleware, so they bypass it
 	router.Post("/webhooks/stripe", adminHandler.StripeWebhook)
 }
+
+// Setup the versioned JSON API.
+func setupAPIRoutes(router chi.Router, apiHandler *api.Handler) {
+	// scope wraps a handler so it only runs for keys with the scope
+	scope := func(scope models.APIScope, handler http.HandlerFunc) http.Handler {
+		return middlewares.APIScopeMiddleware(scope, handler)
+	}
+
+	router.Route("/api/v1", func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return middlewares.APIKeyMiddleware(apiHandler.GetAPIKeyService(), next)
+		})
+		r.NotFound(apiHandler.NotFound)
+
+		r.Method(http.MethodGet, "/instances", scope(models.ScopeInstancesRead, apiHandler.ListInstances))
+		r.Route("/instances/{instanceID}", func(r chi.Router) {
+			r.Method(http.MethodGet, "/", scope(models.ScopeInstancesRead, apiHandler.GetInstance))
+			r.Method(http.MethodGet, "/locations", scope(models.ScopeLocationsRead, apiHandler.ListLocations))
+			r.Method(http.MethodPost, "/locations", scope(models.ScopeLocationsWrite, apiHandler.CreateLocation))
+			r.Method(http.MethodGet, "/teams", scope(models.ScopeTeamsRead, apiHandler.ListTeams))
+			r.Method(http.MethodPost, "/teams", scope(models.ScopeTeamsWrite, apiHandler.CreateTeams))
+			r.Method(http.MethodGet, "/checkins", scope(models.ScopeResultsRead, apiHandler.ListCheckIns))
+			r.Method(http.MethodGet, "/leaderboard", scope(models.ScopeResultsRead, apiHandler.Leaderboard))
+			r.Method(
+				http.MethodPost,
+				"/notifications",
+				scope(models.ScopeNotificationsWrite, apiHandler.SendNotification),
+			)
+		})
+		r.Method(http.MethodGet, "/locations/{locationID}", scope(models.ScopeLocationsRead, apiHandler.GetLocation))
+		r.Method(http.MethodGet, "/teams/{code}", scope(models.ScopeTeamsRead, apiHandler.GetTeam))
+	})
+}
+
+// Setup the LTI 1.3 tool routes.
+func setupLTIRoutes(router chi.Router, ltiHandler *lti.Handler) {
+	router.Route("/lti", func(r chi.Router) {
+		r.Get("/login", ltiHandler.Login)
+		r.Post("/login", ltiHandler.Login)
+		r.Post("/launch", ltiHandler.Launch)
+		r.Get("/jwks", ltiHandler.KeySet)
+	})
+}
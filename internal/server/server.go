@@ -12,6 +12,8 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/nathanhollows/Rapua/v6/internal/handlers/admin"
+	"github.com/nathanhollows/Rapua/v6/internal/handlers/api"
+	"github.com/nathanhollows/Rapua/v6/internal/handlers/lti"
 	"github.com/nathanhollows/Rapua/v6/internal/handlers/players"
 	"github.com/nathanhollows/Rapua/v6/internal/handlers/public"
 	"github.com/nathanhollows/Rapua/v6/internal/scheduler"
@@ -32,9 +34,11 @@ func Start(
 	publicHandler *public.Handler,
 	playerHandler *players.PlayerHandler,
 	adminHandler *admin.Handler,
+	apiHandler *api.Handler,
+	ltiHandler *lti.Handler,
 	scheduler *scheduler.Scheduler,
 ) {
-	router = setupRouter(logger, publicHandler, playerHandler, adminHandler)
+	router = setupRouter(logger, publicHandler, playerHandler, adminHandler, apiHandler, ltiHandler)
 
 	killSig := make(chan os.Signal, 1)
 
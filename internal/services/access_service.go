@@ -2,17 +2,23 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 
 	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/models"
 	"github.com/nathanhollows/Rapua/v6/repositories"
 )
 
+// AccessService decides what an admin may do. Users own the games they
+// create, and have the role they hold in an organisation in the games shared
+// with it. Content checks (locations and blocks) require a role that can edit.
 type AccessService struct {
-	blockRepo    repositories.BlockRepository
-	instanceRepo repositories.InstanceRepository
-	locationRepo repositories.LocationRepository
-	markerRepo   repositories.MarkerRepository
+	blockRepo        repositories.BlockRepository
+	instanceRepo     repositories.InstanceRepository
+	locationRepo     repositories.LocationRepository
+	markerRepo       repositories.MarkerRepository
+	organisationRepo *repositories.OrganisationRepository
 }
 
 // NewAccessService creates a new instance of accessService.
@@ -21,39 +27,71 @@ func NewAccessService(
 	instanceRepository repositories.InstanceRepository,
 	locationRepository repositories.LocationRepository,
 	markerRepository repositories.MarkerRepository,
+	organisationRepository *repositories.OrganisationRepository,
 ) *AccessService {
 	return &AccessService{
-		blockRepo:    blockRepository,
-		instanceRepo: instanceRepository,
-		locationRepo: locationRepository,
-		markerRepo:   markerRepository,
+		blockRepo:        blockRepository,
+		instanceRepo:     instanceRepository,
+		locationRepo:     locationRepository,
+		markerRepo:       markerRepository,
+		organisationRepo: organisationRepository,
 	}
 }
 
-// CanAdminAccessInstance checks if the user can access the instance.
-func (s *AccessService) CanAdminAccessInstance(ctx context.Context, userID, instanceID string) (bool, error) {
+// InstanceRole returns the user's role in a game. Users own the games they
+// create; otherwise they have their role in the organisation the game is
+// shared with. The role is empty if the user has no access.
+func (s *AccessService) InstanceRole(
+	ctx context.Context,
+	userID, instanceID string,
+) (models.OrganisationRole, error) {
 	if userID == "" {
-		return false, ErrUserNotAuthenticated
+		return "", ErrUserNotAuthenticated
 	}
 	if instanceID == "" {
-		return false, errors.New("instance ID cannot be empty")
+		return "", errors.New("instance ID cannot be empty")
+	}
+
+	instance, err := s.instanceRepo.GetByID(ctx, instanceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	if instance.UserID == userID {
+		return models.RoleOwner, nil
 	}
+	if instance.OrganisationID == "" {
+		return "", nil
+	}
+
+	role, err := s.organisationRepo.InstanceRole(ctx, userID, instanceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return role, err
+}
 
-	instanceIDs, err := s.instanceRepo.FindByUserID(ctx, userID)
+// CanAdminAccessInstance checks if the user can access the instance.
+func (s *AccessService) CanAdminAccessInstance(ctx context.Context, userID, instanceID string) (bool, error) {
+	role, err := s.InstanceRole(ctx, userID, instanceID)
 	if err != nil {
 		return false, err
 	}
+	return role != "", nil
+}
 
-	for _, instance := range instanceIDs {
-		if instance.ID == instanceID {
-			return true, nil
-		}
+// CanAdminEditInstance checks if the user can change the instance's content
+// and settings.
+func (s *AccessService) CanAdminEditInstance(ctx context.Context, userID, instanceID string) (bool, error) {
+	role, err := s.InstanceRole(ctx, userID, instanceID)
+	if err != nil {
+		return false, err
 	}
-
-	return false, nil
+	return role.CanEdit(), nil
 }
 
-// CanAdminAccessLocation checks if the user can access the location in the given instance.
+// CanAdminAccessLocation checks if the user can edit the location in the given instance.
 func (s *AccessService) CanAdminAccessLocation(ctx context.Context, userID, locationID string) (bool, error) {
 	if userID == "" {
 		return false, errors.New("user ID cannot be empty")
@@ -62,21 +100,11 @@ func (s *AccessService) CanAdminAccessLocation(ctx context.Context, userID, loca
 		return false, errors.New("location ID cannot be empty")
 	}
 
-	instanceIDs, err := s.instanceRepo.FindByUserID(ctx, userID)
-	if err != nil {
-		return false, err
-	}
-
 	location, err := s.locationRepo.GetByID(ctx, locationID)
 	if err != nil {
 		return false, err
 	}
-	for _, instance := range instanceIDs {
-		if instance.ID == location.InstanceID {
-			return true, nil
-		}
-	}
-	return false, nil
+	return s.CanAdminEditInstance(ctx, userID, location.InstanceID)
 }
 
 // CanAdminAccessMarker checks if the user can access the marker in the given instance.
@@ -121,7 +149,12 @@ func (s *AccessService) CanAdminAccessBlockOwner(
 
 	// For start/complete blocks, owner is instanceID/complete
 	if blockContext == blocks.ContextStart || blockContext == blocks.ContextFinish {
-		return s.CanAdminAccessInstance(ctx, userID, ownerID)
+		return s.CanAdminEditInstance(ctx, userID, ownerID)
+	}
+
+	// Library blocks are owned by the user directly
+	if blockContext == blocks.ContextLibrary {
+		return ownerID == userID, nil
 	}
 
 	// For location blocks, owner is locationID
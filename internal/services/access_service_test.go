@@ -29,8 +29,9 @@ func setupAccessService(t *testing.T) (AccessService, func()) {
 	instanceRepo := repositories.NewInstanceRepository(dbc)
 	locationRepo := repositories.NewLocationRepository(dbc)
 	markerRepo := repositories.NewMarkerRepository(dbc)
+	organisationRepo := repositories.NewOrganisationRepository(dbc)
 
-	accessService := services.NewAccessService(blockRepo, instanceRepo, locationRepo, markerRepo)
+	accessService := services.NewAccessService(blockRepo, instanceRepo, locationRepo, markerRepo, organisationRepo)
 
 	return accessService, cleanup
 }
@@ -514,8 +515,9 @@ func TestAccessService_CanAdminAccessBlockOwner(t *testing.T) {
 	instanceRepo := repositories.NewInstanceRepository(dbc)
 	locationRepo := repositories.NewLocationRepository(dbc)
 	markerRepo := repositories.NewMarkerRepository(dbc)
+	organisationRepo := repositories.NewOrganisationRepository(dbc)
 
-	service := services.NewAccessService(blockRepo, instanceRepo, locationRepo, markerRepo)
+	service := services.NewAccessService(blockRepo, instanceRepo, locationRepo, markerRepo, organisationRepo)
 
 	ctx := context.Background()
 
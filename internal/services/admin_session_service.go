@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+const (
+	// AdminSessionIdleTimeout ends sessions that haven't been used for a
+	// while. It matches the lifetime of the session cookie.
+	AdminSessionIdleTimeout = 30 * 24 * time.Hour
+	// adminSessionTouchInterval limits how often a session's last seen time
+	// is written, so every request doesn't cost a write.
+	adminSessionTouchInterval = time.Minute
+	// LoginLockoutWindow is how far back failed attempts count towards a
+	// lockout, and so how long a lockout lasts.
+	LoginLockoutWindow = 15 * time.Minute
+	// loginLockoutThreshold is how many failed attempts lock an email address.
+	loginLockoutThreshold = 5
+	// loginAttemptRetention is how long login attempts are kept for.
+	loginAttemptRetention = 90 * 24 * time.Hour
+	// loginAttemptsShown is how many recent attempts the security page lists.
+	loginAttemptsShown = 20
+)
+
+// LoginClient describes the device a login came from.
+type LoginClient struct {
+	IPAddress string
+	UserAgent string
+}
+
+// AdminSessionService keeps server-side records of admin sessions so they
+// can be listed and revoked, and an audit log of login attempts that locks
+// out an email address after repeated failures.
+type AdminSessionService struct {
+	adminSessionRepo *repositories.AdminSessionRepository
+	loginAttemptRepo *repositories.LoginAttemptRepository
+	userRepo         repositories.UserRepository
+}
+
+func NewAdminSessionService(
+	adminSessionRepo *repositories.AdminSessionRepository,
+	loginAttemptRepo *repositories.LoginAttemptRepository,
+	userRepo repositories.UserRepository,
+) *AdminSessionService {
+	return &AdminSessionService{
+		adminSessionRepo: adminSessionRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// normaliseEmail makes attempts at the same address count together however
+// it was typed.
+func normaliseEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Start records a successful login and creates the session for it. The
+// session's ID goes in the admin cookie.
+func (s *AdminSessionService) Start(
+	ctx context.Context,
+	user *models.User,
+	method string,
+	client LoginClient,
+) (*models.AdminSession, error) {
+	now := time.Now().UTC()
+	session := &models.AdminSession{
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ID:         uuid.New().String(),
+		UserID:     user.ID,
+		IPAddress:  client.IPAddress,
+		UserAgent:  client.UserAgent,
+		Method:     method,
+	}
+	err := s.adminSessionRepo.Create(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("saving session: %w", err)
+	}
+
+	err = s.loginAttemptRepo.Create(ctx, &models.LoginAttempt{
+		CreatedAt: now,
+		ID:        uuid.New().String(),
+		Email:     normaliseEmail(user.Email),
+		UserID:    user.ID,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		Method:    method,
+		Success:   true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recording login: %w", err)
+	}
+
+	// Logins are infrequent enough to tidy up after
+	_ = s.adminSessionRepo.DeleteIdle(ctx, now.Add(-AdminSessionIdleTimeout))
+	_ = s.loginAttemptRepo.DeleteBefore(ctx, now.Add(-loginAttemptRetention))
+
+	return session, nil
+}
+
+// Authenticate checks that a session from a cookie still exists and belongs
+// to the user, and notes that it was used.
+func (s *AdminSessionService) Authenticate(ctx context.Context, sessionID, userID string) error {
+	if sessionID == "" {
+		return ErrSessionNotFound
+	}
+	session, err := s.adminSessionRepo.GetByID(ctx, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("finding session: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if session.UserID != userID || now.Sub(session.LastSeenAt) > AdminSessionIdleTimeout {
+		return ErrSessionNotFound
+	}
+
+	if now.Sub(session.LastSeenAt) > adminSessionTouchInterval {
+		// Last seen is informational, so a failed write shouldn't block the request
+		_ = s.adminSessionRepo.TouchLastSeen(ctx, session.ID, now)
+	}
+	return nil
+}
+
+// List returns the user's active sessions, most recently used first.
+func (s *AdminSessionService) List(ctx context.Context, userID string) ([]models.AdminSession, error) {
+	since := time.Now().UTC().Add(-AdminSessionIdleTimeout)
+	sessions, err := s.adminSessionRepo.FindByUserID(ctx, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("finding sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// Revoke ends one of the user's sessions. The device using it is logged out
+// on its next request.
+func (s *AdminSessionService) Revoke(ctx context.Context, userID, sessionID string) error {
+	err := s.adminSessionRepo.Delete(ctx, userID, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("deleting session: %w", err)
+	}
+	return nil
+}
+
+// RevokeOthers ends all of the user's sessions except the one they are
+// using, and returns how many were ended.
+func (s *AdminSessionService) RevokeOthers(ctx context.Context, userID, currentID string) (int, error) {
+	count, err := s.adminSessionRepo.DeleteOthers(ctx, userID, currentID)
+	if err != nil {
+		return 0, fmt.Errorf("deleting sessions: %w", err)
+	}
+	return count, nil
+}
+
+// CheckLockout returns ErrLoginLocked if too many attempts to log in as the
+// email address have failed recently. A successful login resets the count.
+func (s *AdminSessionService) CheckLockout(ctx context.Context, email string) error {
+	email = normaliseEmail(email)
+	since := time.Now().UTC().Add(-LoginLockoutWindow)
+	lastSuccess, err := s.loginAttemptRepo.LastSuccessAt(ctx, email)
+	if err != nil {
+		return fmt.Errorf("finding last login: %w", err)
+	}
+	if lastSuccess.After(since) {
+		since = lastSuccess
+	}
+
+	failures, err := s.loginAttemptRepo.CountFailuresSince(ctx, email, since)
+	if err != nil {
+		return fmt.Errorf("counting failed logins: %w", err)
+	}
+	if failures >= loginLockoutThreshold {
+		return ErrLoginLocked
+	}
+	return nil
+}
+
+// RecordFailure records a failed attempt to log in as the email address.
+// The attempt is linked to the account with that address, if there is one,
+// so its owner can see it.
+func (s *AdminSessionService) RecordFailure(
+	ctx context.Context,
+	email, method, reason string,
+	client LoginClient,
+) error {
+	attempt := &models.LoginAttempt{
+		CreatedAt: time.Now().UTC(),
+		ID:        uuid.New().String(),
+		Email:     normaliseEmail(email),
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		Method:    method,
+		Reason:    reason,
+	}
+	user, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(email))
+	if err == nil {
+		attempt.UserID = user.ID
+	}
+
+	err = s.loginAttemptRepo.Create(ctx, attempt)
+	if err != nil {
+		return fmt.Errorf("recording failed login: %w", err)
+	}
+	return nil
+}
+
+// RecentAttempts returns the latest attempts to log in to the user's
+// account, newest first.
+func (s *AdminSessionService) RecentAttempts(ctx context.Context, userID string) ([]models.LoginAttempt, error) {
+	attempts, err := s.loginAttemptRepo.FindByUserID(ctx, userID, loginAttemptsShown)
+	if err != nil {
+		return nil, fmt.Errorf("finding login attempts: %w", err)
+	}
+	return attempts, nil
+}
@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAdminSessionService(t *testing.T) (*services.AdminSessionService, repositories.UserRepository) {
+	t.Helper()
+	dbc, cleanup := setupDB(t)
+	t.Cleanup(cleanup)
+
+	userRepo := repositories.NewUserRepository(dbc)
+	service := services.NewAdminSessionService(
+		repositories.NewAdminSessionRepository(dbc),
+		repositories.NewLoginAttemptRepository(dbc),
+		userRepo,
+	)
+	return service, userRepo
+}
+
+var testLoginClient = services.LoginClient{
+	IPAddress: "203.0.113.7",
+	UserAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) Gecko/20100101 Firefox/128.0",
+}
+
+func TestAdminSessionService_Sessions(t *testing.T) {
+	service, userRepo := setupAdminSessionService(t)
+	ctx := context.Background()
+	user := createTestUser(t, userRepo, gofakeit.Email(), "password123")
+	other := createTestUser(t, userRepo, gofakeit.Email(), "password123")
+
+	laptop, err := service.Start(ctx, user, string(models.LoginMethodPassword), testLoginClient)
+	require.NoError(t, err)
+	phone, err := service.Start(ctx, user, "google", testLoginClient)
+	require.NoError(t, err)
+	tablet, err := service.Start(ctx, user, string(models.LoginMethodPassword), testLoginClient)
+	require.NoError(t, err)
+
+	require.NoError(t, service.Authenticate(ctx, laptop.ID, user.ID))
+	err = service.Authenticate(ctx, laptop.ID, other.ID)
+	require.ErrorIs(t, err, services.ErrSessionNotFound, "a session only works for its own user")
+	err = service.Authenticate(ctx, "", user.ID)
+	require.ErrorIs(t, err, services.ErrSessionNotFound, "cookies from before sessions were recorded are refused")
+
+	list, err := service.List(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, list, 3)
+	assert.Equal(t, testLoginClient.IPAddress, list[0].IPAddress)
+
+	t.Run("revoke one", func(t *testing.T) {
+		err := service.Revoke(ctx, other.ID, phone.ID)
+		require.ErrorIs(t, err, services.ErrSessionNotFound, "users can't revoke each other's sessions")
+
+		require.NoError(t, service.Revoke(ctx, user.ID, phone.ID))
+		err = service.Authenticate(ctx, phone.ID, user.ID)
+		require.ErrorIs(t, err, services.ErrSessionNotFound)
+	})
+
+	t.Run("revoke others", func(t *testing.T) {
+		count, err := service.RevokeOthers(ctx, user.ID, laptop.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		err = service.Authenticate(ctx, tablet.ID, user.ID)
+		require.ErrorIs(t, err, services.ErrSessionNotFound)
+		require.NoError(t, service.Authenticate(ctx, laptop.ID, user.ID))
+	})
+
+	attempts, err := service.RecentAttempts(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, attempts, 3)
+	for _, attempt := range attempts {
+		assert.True(t, attempt.Success)
+	}
+}
+
+func TestAdminSessionService_Lockout(t *testing.T) {
+	service, userRepo := setupAdminSessionService(t)
+	ctx := context.Background()
+	user := createTestUser(t, userRepo, gofakeit.Email(), "password123")
+	password := string(models.LoginMethodPassword)
+
+	for range 4 {
+		require.NoError(t, service.CheckLockout(ctx, user.Email))
+		err := service.RecordFailure(ctx, user.Email, password, models.LoginFailureInvalidPassword, testLoginClient)
+		require.NoError(t, err)
+	}
+
+	// A success resets the count
+	_, err := service.Start(ctx, user, password, testLoginClient)
+	require.NoError(t, err)
+	for range 4 {
+		err = service.RecordFailure(ctx, user.Email, password, models.LoginFailureInvalidPassword, testLoginClient)
+		require.NoError(t, err)
+	}
+	require.NoError(t, service.CheckLockout(ctx, user.Email))
+
+	// Addresses are matched however they are typed
+	twoFactor := string(models.LoginMethodTwoFactor)
+	err = service.RecordFailure(ctx, " "+user.Email+" ", twoFactor, models.LoginFailureInvalidCode, testLoginClient)
+	require.NoError(t, err)
+	err = service.CheckLockout(ctx, user.Email)
+	require.ErrorIs(t, err, services.ErrLoginLocked)
+
+	// Failures for other addresses don't count
+	require.NoError(t, service.CheckLockout(ctx, gofakeit.Email()))
+
+	attempts, err := service.RecentAttempts(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 10)
+	assert.Equal(t, twoFactor, attempts[0].Method)
+	assert.False(t, attempts[0].Success)
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+// apiKeyPrefix marks a string as a Rapua API key so it is easy to spot in
+// config files and secret scanners.
+const apiKeyPrefix = "rapua_"
+
+// APIKeyService creates and checks the keys programs use for the REST API.
+type APIKeyService struct {
+	apiKeyRepo *repositories.APIKeyRepository
+	userRepo   repositories.UserRepository
+}
+
+func NewAPIKeyService(
+	apiKeyRepo *repositories.APIKeyRepository,
+	userRepo repositories.UserRepository,
+) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// hashAPIKey returns the hex SHA-256 of a key. Keys are long and random, so a
+// fast hash is enough to keep them safe at rest.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create generates a new key for the user. The key itself is returned only
+// here; afterwards only its prefix is known.
+func (s *APIKeyService) Create(
+	ctx context.Context,
+	userID, name string,
+	scopes []models.APIScope,
+) (*models.APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", errors.New("name cannot be empty")
+	}
+	if len(scopes) == 0 {
+		return nil, "", errors.New("choose at least one scope")
+	}
+	scopeNames := make(models.StrArray, 0, len(scopes))
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidAPIScope, scope)
+		}
+		scopeNames = append(scopeNames, string(scope))
+	}
+
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, "", fmt.Errorf("generating key: %w", err)
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	key := &models.APIKey{
+		ID:      uuid.New().String(),
+		UserID:  userID,
+		Name:    name,
+		Prefix:  secret[:len(apiKeyPrefix)+6],
+		KeyHash: hashAPIKey(secret),
+		Scopes:  scopeNames,
+	}
+	err = s.apiKeyRepo.Create(ctx, key)
+	if err != nil {
+		return nil, "", fmt.Errorf("saving key: %w", err)
+	}
+	return key, secret, nil
+}
+
+// List returns the user's keys, newest first.
+func (s *APIKeyService) List(ctx context.Context, userID string) ([]models.APIKey, error) {
+	keys, err := s.apiKeyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke deletes one of the user's keys so it can no longer be used.
+func (s *APIKeyService) Revoke(ctx context.Context, userID, keyID string) error {
+	err := s.apiKeyRepo.Delete(ctx, userID, keyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("deleting key: %w", err)
+	}
+	return nil
+}
+
+// Authenticate returns the key and its owner for a key presented by a client.
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (*models.APIKey, *models.User, error) {
+	if !strings.HasPrefix(secret, apiKeyPrefix) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	key, err := s.apiKeyRepo.GetByHash(ctx, hashAPIKey(secret))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding key: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding user: %w", err)
+	}
+
+	// Usage is informational, so a failed write shouldn't block the request
+	_ = s.apiKeyRepo.TouchLastUsed(ctx, key.ID, time.Now().UTC())
+
+	return key, user, nil
+}
@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAPIKeyService(t *testing.T) (*services.APIKeyService, repositories.UserRepository, func()) {
+	t.Helper()
+	dbc, cleanup := setupDB(t)
+
+	userRepo := repositories.NewUserRepository(dbc)
+	service := services.NewAPIKeyService(
+		repositories.NewAPIKeyRepository(dbc),
+		userRepo,
+	)
+	return service, userRepo, cleanup
+}
+
+func TestAPIKeyService_CreateAndAuthenticate(t *testing.T) {
+	service, userRepo, cleanup := setupAPIKeyService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	user := &models.User{
+		ID:    gofakeit.UUID(),
+		Email: gofakeit.Email(),
+		Name:  gofakeit.Name(),
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	key, secret, err := service.Create(ctx, user.ID, "LMS", []models.APIScope{
+		models.ScopeTeamsWrite,
+		models.ScopeResultsRead,
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, "rapua_"))
+	assert.True(t, strings.HasPrefix(secret, key.Prefix))
+	assert.NotContains(t, key.KeyHash, secret, "Only the hash is stored")
+
+	found, owner, err := service.Authenticate(ctx, secret)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, found.ID)
+	assert.Equal(t, user.ID, owner.ID)
+	assert.True(t, found.HasScope(models.ScopeTeamsWrite))
+	assert.False(t, found.HasScope(models.ScopeLocationsWrite))
+
+	keys, err := service.List(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.False(t, keys[0].LastUsedAt.IsZero(), "Authenticating records use")
+
+	_, _, err = service.Authenticate(ctx, secret+"x")
+	require.ErrorIs(t, err, services.ErrInvalidAPIKey)
+	_, _, err = service.Authenticate(ctx, "not-a-key")
+	require.ErrorIs(t, err, services.ErrInvalidAPIKey)
+}
+
+func TestAPIKeyService_CreateValidation(t *testing.T) {
+	service, _, cleanup := setupAPIKeyService(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+
+	_, _, err := service.Create(ctx, userID, " ", []models.APIScope{models.ScopeTeamsRead})
+	require.Error(t, err, "Name is required")
+
+	_, _, err = service.Create(ctx, userID, "Empty", nil)
+	require.Error(t, err, "At least one scope is required")
+
+	_, _, err = service.Create(ctx, userID, "Bad", []models.APIScope{"teams:delete"})
+	require.ErrorIs(t, err, services.ErrInvalidAPIScope)
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	service, userRepo, cleanup := setupAPIKeyService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	user := &models.User{
+		ID:    gofakeit.UUID(),
+		Email: gofakeit.Email(),
+		Name:  gofakeit.Name(),
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	key, secret, err := service.Create(ctx, user.ID, "Script", []models.APIScope{models.ScopeInstancesRead})
+	require.NoError(t, err)
+
+	err = service.Revoke(ctx, gofakeit.UUID(), key.ID)
+	require.ErrorIs(t, err, services.ErrAPIKeyNotFound, "Other users cannot revoke the key")
+
+	require.NoError(t, service.Revoke(ctx, user.ID, key.ID))
+	_, _, err = service.Authenticate(ctx, secret)
+	require.ErrorIs(t, err, services.ErrInvalidAPIKey)
+
+	err = service.Revoke(ctx, user.ID, key.ID)
+	require.ErrorIs(t, err, services.ErrAPIKeyNotFound)
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+// LibraryBlock is a block saved to a user's library.
+type LibraryBlock struct {
+	Title  string
+	Block  blocks.Block
+	Linked int // Number of linked copies
+}
+
+// BlockLibraryService manages each user's library of reusable blocks.
+type BlockLibraryService struct {
+	transactor  db.Transactor
+	libraryRepo *repositories.BlockLibraryRepository
+	blockRepo   repositories.BlockRepository
+}
+
+func NewBlockLibraryService(
+	transactor db.Transactor,
+	libraryRepo *repositories.BlockLibraryRepository,
+	blockRepo repositories.BlockRepository,
+) *BlockLibraryService {
+	return &BlockLibraryService{
+		transactor:  transactor,
+		libraryRepo: libraryRepo,
+		blockRepo:   blockRepo,
+	}
+}
+
+// List returns the user's library blocks, sorted by title.
+func (s *BlockLibraryService) List(ctx context.Context, userID string) ([]LibraryBlock, error) {
+	items, err := s.libraryRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding library blocks: %w", err)
+	}
+	counts, err := s.libraryRepo.CountLinkedByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("counting linked blocks: %w", err)
+	}
+
+	library := make([]LibraryBlock, 0, len(items))
+	for i := range items {
+		block, err := libraryModelToBlock(&items[i])
+		if err != nil {
+			// Skip block types that are no longer registered
+			if errors.Is(err, blocks.ErrBlockTypeNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		library = append(library, LibraryBlock{
+			Title:  items[i].Title,
+			Block:  block,
+			Linked: counts[items[i].ID],
+		})
+	}
+	return library, nil
+}
+
+// ListForContext returns the user's library blocks that can be used in the
+// given context.
+func (s *BlockLibraryService) ListForContext(
+	ctx context.Context,
+	userID string,
+	blockContext blocks.BlockContext,
+) ([]LibraryBlock, error) {
+	library, err := s.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	usable := make([]LibraryBlock, 0, len(library))
+	for _, item := range library {
+		if blocks.CanBlockBeUsedInContext(item.Block.GetType(), blockContext) {
+			usable = append(usable, item)
+		}
+	}
+	return usable, nil
+}
+
+// Save copies a block into the user's library. Visibility conditions are not
+// copied as they refer to other blocks on the original page.
+func (s *BlockLibraryService) Save(
+	ctx context.Context,
+	userID string,
+	block blocks.Block,
+	title string,
+) (LibraryBlock, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = block.GetName()
+	}
+
+	copied, err := blocks.CreateFromBaseBlock(blocks.BaseBlock{
+		Type:   block.GetType(),
+		Data:   block.GetData(),
+		Points: block.GetPoints(),
+	})
+	if err != nil {
+		return LibraryBlock{}, fmt.Errorf("copying block: %w", err)
+	}
+	err = copied.ParseData()
+	if err != nil {
+		return LibraryBlock{}, fmt.Errorf("parsing block data: %w", err)
+	}
+	copied.SetVisibility(blocks.Visibility{})
+
+	item := &models.Block{
+		Type:               copied.GetType(),
+		Data:               copied.GetData(),
+		Points:             copied.GetPoints(),
+		ValidationRequired: copied.RequiresValidation(),
+		Title:              title,
+	}
+	err = s.libraryRepo.Create(ctx, userID, item)
+	if err != nil {
+		return LibraryBlock{}, fmt.Errorf("saving library block: %w", err)
+	}
+
+	saved, err := libraryModelToBlock(item)
+	if err != nil {
+		return LibraryBlock{}, err
+	}
+	return LibraryBlock{Title: item.Title, Block: saved}, nil
+}
+
+// Rename changes the title of a library block.
+func (s *BlockLibraryService) Rename(ctx context.Context, userID, itemID, title string) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return errors.New("title is required")
+	}
+	item, err := s.libraryRepo.GetByID(ctx, userID, itemID)
+	if err != nil {
+		return fmt.Errorf("getting library block: %w", err)
+	}
+	item.Title = title
+	return s.libraryRepo.UpdateTitle(ctx, item)
+}
+
+// Insert adds a copy of a library block to the end of the owner's blocks,
+// without any visibility conditions. Linked copies are updated whenever the
+// library block changes.
+func (s *BlockLibraryService) Insert(
+	ctx context.Context,
+	userID, itemID, ownerID string,
+	blockContext blocks.BlockContext,
+	linked bool,
+) (blocks.Block, error) {
+	item, err := s.libraryRepo.GetByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("getting library block: %w", err)
+	}
+	if !blocks.CanBlockBeUsedInContext(item.Type, blockContext) {
+		return nil, ErrBlockContextNotSupported
+	}
+
+	base := blocks.BaseBlock{
+		Type:   item.Type,
+		Data:   item.Data,
+		Points: item.Points,
+	}
+	if linked {
+		base.LibraryID = item.ID
+	}
+	block, err := blocks.CreateFromBaseBlock(base)
+	if err != nil {
+		return nil, fmt.Errorf("creating block: %w", err)
+	}
+	err = block.ParseData()
+	if err != nil {
+		return nil, fmt.Errorf("parsing block data: %w", err)
+	}
+	// Conditions on the library block would point at blocks elsewhere
+	block.SetVisibility(blocks.Visibility{})
+
+	return s.blockRepo.Create(ctx, block, ownerID, blockContext)
+}
+
+// SyncLinked copies a library block's content to all of its linked copies,
+// keeping each copy's visibility conditions. It returns the number of copies
+// updated.
+func (s *BlockLibraryService) SyncLinked(ctx context.Context, userID string, item blocks.Block) (int, error) {
+	if item.GetLocationID() != userID {
+		return 0, nil
+	}
+
+	linked, err := s.libraryRepo.FindLinked(ctx, item.GetID())
+	if err != nil {
+		return 0, fmt.Errorf("finding linked blocks: %w", err)
+	}
+
+	for _, copied := range linked {
+		var existing struct {
+			Visibility blocks.Visibility `json:"visibility"`
+		}
+		_ = json.Unmarshal(copied.Data, &existing)
+
+		block, err := blocks.CreateFromBaseBlock(blocks.BaseBlock{
+			ID:         copied.ID,
+			LocationID: copied.OwnerID,
+			Type:       item.GetType(),
+			Data:       item.GetData(),
+			Order:      copied.Ordering,
+			Points:     item.GetPoints(),
+			LibraryID:  item.GetID(),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("creating block: %w", err)
+		}
+		err = block.ParseData()
+		if err != nil {
+			return 0, fmt.Errorf("parsing block data: %w", err)
+		}
+		block.SetVisibility(existing.Visibility)
+
+		_, err = s.blockRepo.Update(ctx, block)
+		if err != nil {
+			return 0, fmt.Errorf("updating linked block %s: %w", copied.ID, err)
+		}
+	}
+	return len(linked), nil
+}
+
+// Unlink stops a block from following its library block.
+func (s *BlockLibraryService) Unlink(ctx context.Context, block blocks.Block) (blocks.Block, error) {
+	block.SetLibraryID("")
+	return s.blockRepo.Update(ctx, block)
+}
+
+// Delete removes a library block. Its linked copies are kept as regular
+// blocks.
+func (s *BlockLibraryService) Delete(ctx context.Context, userID, itemID string) error {
+	tx, err := s.transactor.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = s.libraryRepo.UnlinkAllWithTx(ctx, tx, itemID)
+	if err != nil {
+		return fmt.Errorf("unlinking blocks: %w", err)
+	}
+	err = s.libraryRepo.DeleteWithTx(ctx, tx, userID, itemID)
+	if err != nil {
+		return fmt.Errorf("deleting library block: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// libraryModelToBlock converts a stored library block.
+func libraryModelToBlock(item *models.Block) (blocks.Block, error) {
+	block, err := blocks.CreateFromBaseBlock(blocks.BaseBlock{
+		ID:         item.ID,
+		LocationID: item.OwnerID,
+		Type:       item.Type,
+		Data:       item.Data,
+		Points:     item.Points,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = block.ParseData()
+	if err != nil {
+		return nil, fmt.Errorf("parsing library block %s: %w", item.ID, err)
+	}
+	return block, nil
+}
@@ -0,0 +1,265 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBlockLibraryService(
+	t *testing.T,
+) (*services.BlockLibraryService, *services.BlockService, func()) {
+	t.Helper()
+	dbc, cleanup := setupDB(t)
+
+	blockStateRepo := repositories.NewBlockStateRepository(dbc)
+	blockRepo := repositories.NewBlockRepository(dbc, blockStateRepo)
+	libraryRepo := repositories.NewBlockLibraryRepository(dbc)
+
+	libraryService := services.NewBlockLibraryService(db.NewTransactor(dbc), libraryRepo, blockRepo)
+	blockService := services.NewBlockService(blockRepo, blockStateRepo)
+
+	return libraryService, blockService, cleanup
+}
+
+// newLibraryMarkdown saves a markdown block with the given content to the
+// user's library.
+func newLibraryMarkdown(
+	t *testing.T,
+	libraryService *services.BlockLibraryService,
+	blockService *services.BlockService,
+	userID, content string,
+) services.LibraryBlock {
+	t.Helper()
+	ctx := context.Background()
+
+	block, err := blockService.NewBlockWithOwnerAndContext(
+		ctx,
+		gofakeit.UUID(),
+		blocks.ContextLocationContent,
+		"markdown",
+	)
+	require.NoError(t, err)
+	block, err = blockService.UpdateBlock(ctx, block, map[string][]string{"content": {content}})
+	require.NoError(t, err)
+
+	item, err := libraryService.Save(ctx, userID, block, "Safety briefing")
+	require.NoError(t, err)
+	return item
+}
+
+func TestBlockLibraryService_Save(t *testing.T) {
+	libraryService, blockService, cleanup := setupBlockLibraryService(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+
+	item := newLibraryMarkdown(t, libraryService, blockService, userID, "Stay on the path")
+	assert.Equal(t, "Safety briefing", item.Title)
+	assert.Equal(t, userID, item.Block.GetLocationID())
+	assert.Equal(t, "Stay on the path", item.Block.(*blocks.MarkdownBlock).Content)
+
+	library, err := libraryService.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, library, 1)
+	assert.Equal(t, item.Block.GetID(), library[0].Block.GetID())
+
+	// Other users cannot see the block
+	library, err = libraryService.List(ctx, gofakeit.UUID())
+	require.NoError(t, err)
+	assert.Empty(t, library)
+
+	// Library blocks are not found among regular blocks
+	found, err := blockService.FindByOwnerIDAndContext(ctx, userID, blocks.ContextLocationContent)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	// Visibility conditions refer to the original page, so aren't saved
+	block, err := blockService.NewBlockWithOwnerAndContext(
+		ctx,
+		gofakeit.UUID(),
+		blocks.ContextLocationContent,
+		"markdown",
+	)
+	require.NoError(t, err)
+	block, err = blockService.UpdateVisibility(ctx, block, blocks.Visibility{AfterBlockID: gofakeit.UUID()})
+	require.NoError(t, err)
+	item, err = libraryService.Save(ctx, userID, block, "")
+	require.NoError(t, err)
+	assert.False(t, item.Block.GetVisibility().IsSet())
+}
+
+func TestBlockLibraryService_Rename(t *testing.T) {
+	libraryService, blockService, cleanup := setupBlockLibraryService(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+
+	item := newLibraryMarkdown(t, libraryService, blockService, userID, "Welcome")
+
+	require.Error(t, libraryService.Rename(ctx, userID, item.Block.GetID(), "  "))
+	require.Error(t, libraryService.Rename(ctx, gofakeit.UUID(), item.Block.GetID(), "Stolen"))
+	require.NoError(t, libraryService.Rename(ctx, userID, item.Block.GetID(), "Welcome message"))
+
+	library, err := libraryService.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, library, 1)
+	assert.Equal(t, "Welcome message", library[0].Title)
+}
+
+func TestBlockLibraryService_Insert(t *testing.T) {
+	libraryService, blockService, cleanup := setupBlockLibraryService(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+	ownerID := gofakeit.UUID()
+
+	item := newLibraryMarkdown(t, libraryService, blockService, userID, "Welcome")
+
+	copied, err := libraryService.Insert(ctx, userID, item.Block.GetID(), ownerID, blocks.ContextLocationContent, false)
+	require.NoError(t, err)
+	assert.Empty(t, copied.GetLibraryID())
+	assert.Equal(t, ownerID, copied.GetLocationID())
+
+	linked, err := libraryService.Insert(ctx, userID, item.Block.GetID(), ownerID, blocks.ContextLocationContent, true)
+	require.NoError(t, err)
+	assert.Equal(t, item.Block.GetID(), linked.GetLibraryID())
+
+	library, err := libraryService.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, library, 1)
+	assert.Equal(t, 1, library[0].Linked)
+
+	// Visibility conditions refer to the original page, so aren't copied
+	_, err = blockService.UpdateVisibility(ctx, item.Block, blocks.Visibility{
+		AfterBlockID: gofakeit.UUID(),
+		MinPoints:    10,
+	})
+	require.NoError(t, err)
+	copied, err = libraryService.Insert(ctx, userID, item.Block.GetID(), ownerID, blocks.ContextLocationContent, false)
+	require.NoError(t, err)
+	assert.False(t, copied.GetVisibility().IsSet())
+	stored, err := blockService.GetByBlockID(ctx, copied.GetID())
+	require.NoError(t, err)
+	assert.False(t, stored.GetVisibility().IsSet())
+
+	// Only the owner can insert their library blocks
+	_, err = libraryService.Insert(
+		ctx,
+		gofakeit.UUID(),
+		item.Block.GetID(),
+		ownerID,
+		blocks.ContextLocationContent,
+		true,
+	)
+	require.Error(t, err)
+}
+
+func TestBlockLibraryService_SyncLinked(t *testing.T) {
+	libraryService, blockService, cleanup := setupBlockLibraryService(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+	ownerID := gofakeit.UUID()
+
+	item := newLibraryMarkdown(t, libraryService, blockService, userID, "Version one")
+
+	linked, err := libraryService.Insert(ctx, userID, item.Block.GetID(), ownerID, blocks.ContextLocationContent, true)
+	require.NoError(t, err)
+	copied, err := libraryService.Insert(ctx, userID, item.Block.GetID(), ownerID, blocks.ContextLocationContent, false)
+	require.NoError(t, err)
+
+	// Visibility belongs to the copy, not the library block
+	_, err = blockService.UpdateVisibility(ctx, linked, blocks.Visibility{MinPoints: 10})
+	require.NoError(t, err)
+	linked, err = blockService.GetByBlockID(ctx, linked.GetID())
+	require.NoError(t, err)
+	require.NotEmpty(t, linked.GetLibraryID(), "Changing visibility should keep the link")
+
+	libraryBlock, err := blockService.GetByBlockID(ctx, item.Block.GetID())
+	require.NoError(t, err)
+	libraryBlock, err = blockService.UpdateBlock(ctx, libraryBlock, map[string][]string{"content": {"Version two"}})
+	require.NoError(t, err)
+
+	synced, err := libraryService.SyncLinked(ctx, userID, libraryBlock)
+	require.NoError(t, err)
+	assert.Equal(t, 1, synced)
+
+	linked, err = blockService.GetByBlockID(ctx, linked.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, "Version two", linked.(*blocks.MarkdownBlock).Content)
+	assert.Equal(t, 10, linked.GetVisibility().MinPoints)
+	assert.Equal(t, item.Block.GetID(), linked.GetLibraryID())
+
+	copied, err = blockService.GetByBlockID(ctx, copied.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, "Version one", copied.(*blocks.MarkdownBlock).Content)
+
+	// Regular blocks have no linked copies
+	synced, err = libraryService.SyncLinked(ctx, userID, copied)
+	require.NoError(t, err)
+	assert.Equal(t, 0, synced)
+}
+
+func TestBlockLibraryService_EditingCopyUnlinks(t *testing.T) {
+	libraryService, blockService, cleanup := setupBlockLibraryService(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+
+	item := newLibraryMarkdown(t, libraryService, blockService, userID, "Welcome")
+	linked, err := libraryService.Insert(
+		ctx,
+		userID,
+		item.Block.GetID(),
+		gofakeit.UUID(),
+		blocks.ContextLocationContent,
+		true,
+	)
+	require.NoError(t, err)
+
+	linked, err = blockService.UpdateBlock(ctx, linked, map[string][]string{"content": {"Kia ora"}})
+	require.NoError(t, err)
+	assert.Empty(t, linked.GetLibraryID())
+
+	linked, err = blockService.GetByBlockID(ctx, linked.GetID())
+	require.NoError(t, err)
+	assert.Empty(t, linked.GetLibraryID())
+}
+
+func TestBlockLibraryService_Delete(t *testing.T) {
+	libraryService, blockService, cleanup := setupBlockLibraryService(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+
+	item := newLibraryMarkdown(t, libraryService, blockService, userID, "Welcome")
+	linked, err := libraryService.Insert(
+		ctx,
+		userID,
+		item.Block.GetID(),
+		gofakeit.UUID(),
+		blocks.ContextLocationContent,
+		true,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, libraryService.Delete(ctx, userID, item.Block.GetID()))
+
+	library, err := libraryService.List(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, library)
+
+	// The copy is kept as a regular block
+	linked, err = blockService.GetByBlockID(ctx, linked.GetID())
+	require.NoError(t, err)
+	assert.Empty(t, linked.GetLibraryID())
+	assert.Equal(t, "Welcome", linked.(*blocks.MarkdownBlock).Content)
+}
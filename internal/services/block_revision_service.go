@@ -0,0 +1,231 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/helpers"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+// revisionMergeWindow is how long edits by the same user are merged into a
+// single revision. The editor saves on every change, so without merging a
+// single sitting would leave dozens of revisions.
+const revisionMergeWindow = 10 * time.Minute
+
+// BlockRevision is a saved version of a block and what changed from the
+// version before it.
+type BlockRevision struct {
+	models.BlockRevision
+	Changes []helpers.DiffLine
+}
+
+// BlockRevisionService keeps the history of block content.
+type BlockRevisionService struct {
+	revisionRepo *repositories.BlockRevisionRepository
+	blockRepo    repositories.BlockRepository
+}
+
+func NewBlockRevisionService(
+	revisionRepo *repositories.BlockRevisionRepository,
+	blockRepo repositories.BlockRepository,
+) *BlockRevisionService {
+	return &BlockRevisionService{
+		revisionRepo: revisionRepo,
+		blockRepo:    blockRepo,
+	}
+}
+
+// RecordOriginal saves the block's current content if it has no history yet,
+// so the first edit can be rolled back. Call it before changing a block.
+func (s *BlockRevisionService) RecordOriginal(ctx context.Context, blockID string) error {
+	revisions, err := s.revisionRepo.FindByBlockID(ctx, blockID)
+	if err != nil {
+		return fmt.Errorf("finding revisions: %w", err)
+	}
+	if len(revisions) > 0 {
+		return nil
+	}
+	_, err = s.revisionRepo.Snapshot(ctx, blockID, "", models.RevisionOriginal)
+	if err != nil {
+		return fmt.Errorf("saving original revision: %w", err)
+	}
+	return nil
+}
+
+// RecordEdit saves the block's current content after a user edits it. Edits
+// by the same user in quick succession replace each other.
+func (s *BlockRevisionService) RecordEdit(ctx context.Context, userID, blockID string) error {
+	revisions, err := s.revisionRepo.FindByBlockID(ctx, blockID)
+	if err != nil {
+		return fmt.Errorf("finding revisions: %w", err)
+	}
+	if len(revisions) > 0 {
+		latest := revisions[0]
+		if latest.Action == models.RevisionEdited &&
+			latest.UserID == userID &&
+			time.Since(latest.CreatedAt) < revisionMergeWindow {
+			err = s.revisionRepo.Delete(ctx, latest.ID)
+			if err != nil {
+				return fmt.Errorf("merging revision: %w", err)
+			}
+		}
+	}
+
+	_, err = s.revisionRepo.Snapshot(ctx, blockID, userID, models.RevisionEdited)
+	if err != nil {
+		return fmt.Errorf("saving revision: %w", err)
+	}
+	return nil
+}
+
+// List returns a block's revisions, newest first, with the changes each one
+// made to the version before it.
+func (s *BlockRevisionService) List(ctx context.Context, blockID string) ([]BlockRevision, error) {
+	revisions, err := s.revisionRepo.FindByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("finding revisions: %w", err)
+	}
+
+	history := make([]BlockRevision, len(revisions))
+	for i, revision := range revisions {
+		history[i] = BlockRevision{BlockRevision: revision}
+		if i+1 < len(revisions) {
+			history[i].Changes = helpers.DiffLines(
+				revisionText(revisions[i+1].Data),
+				revisionText(revision.Data),
+			)
+		}
+	}
+	return history, nil
+}
+
+// Get returns a single revision.
+func (s *BlockRevisionService) Get(ctx context.Context, revisionID string) (*models.BlockRevision, error) {
+	return s.revisionRepo.GetByID(ctx, revisionID)
+}
+
+// ListDeleted returns the last revision of each block deleted from an owner
+// that has not been restored, newest first.
+func (s *BlockRevisionService) ListDeleted(
+	ctx context.Context,
+	ownerID string,
+	blockContext blocks.BlockContext,
+) ([]models.BlockRevision, error) {
+	revisions, err := s.revisionRepo.FindDeleted(ctx, ownerID, blockContext)
+	if err != nil {
+		return nil, fmt.Errorf("finding deleted blocks: %w", err)
+	}
+
+	seen := make(map[string]bool, len(revisions))
+	deleted := make([]models.BlockRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		if seen[revision.BlockID] {
+			continue
+		}
+		seen[revision.BlockID] = true
+		deleted = append(deleted, revision)
+	}
+	return deleted, nil
+}
+
+// Restore rolls a block back to a revision. A deleted block is added back to
+// the end of its page with its original ID.
+func (s *BlockRevisionService) Restore(ctx context.Context, userID, revisionID string) (blocks.Block, error) {
+	revision, err := s.revisionRepo.GetByID(ctx, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("getting revision: %w", err)
+	}
+
+	existing, err := s.blockRepo.GetByID(ctx, revision.BlockID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("getting block: %w", err)
+	}
+
+	base := blocks.BaseBlock{
+		ID:         revision.BlockID,
+		LocationID: revision.OwnerID,
+		Type:       revision.Type,
+		Data:       revision.Data,
+		Order:      revision.Ordering,
+		Points:     revision.Points,
+	}
+	if existing != nil {
+		// Restoring content keeps the block where it is on the page
+		base.Order = existing.GetOrder()
+	}
+	block, err := blocks.CreateFromBaseBlock(base)
+	if err != nil {
+		return nil, fmt.Errorf("creating block: %w", err)
+	}
+	err = block.ParseData()
+	if err != nil {
+		return nil, fmt.Errorf("parsing block data: %w", err)
+	}
+
+	if existing != nil {
+		_, err = s.blockRepo.Update(ctx, block)
+		if err != nil {
+			return nil, fmt.Errorf("updating block: %w", err)
+		}
+	} else {
+		err = s.undelete(ctx, revision, block)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = s.revisionRepo.Snapshot(ctx, revision.BlockID, userID, models.RevisionRestored)
+	if err != nil {
+		return nil, fmt.Errorf("saving revision: %w", err)
+	}
+	return s.blockRepo.GetByID(ctx, revision.BlockID)
+}
+
+// undelete adds a deleted block back after the owner's other blocks.
+func (s *BlockRevisionService) undelete(
+	ctx context.Context,
+	revision *models.BlockRevision,
+	block blocks.Block,
+) error {
+	siblings, err := s.blockRepo.FindByOwnerIDAndContext(ctx, revision.OwnerID, revision.Context)
+	if err != nil {
+		return fmt.Errorf("finding blocks: %w", err)
+	}
+	ordering := 0
+	for _, sibling := range siblings {
+		ordering = max(ordering, sibling.GetOrder()+1)
+	}
+
+	err = s.revisionRepo.Undelete(ctx, &models.Block{
+		ID:                 revision.BlockID,
+		OwnerID:            revision.OwnerID,
+		Type:               revision.Type,
+		Context:            revision.Context,
+		Data:               block.GetData(),
+		Ordering:           ordering,
+		Points:             revision.Points,
+		ValidationRequired: block.RequiresValidation(),
+	})
+	if err != nil {
+		return fmt.Errorf("restoring block: %w", err)
+	}
+	return nil
+}
+
+// revisionText formats block data for comparing revisions.
+func revisionText(data json.RawMessage) string {
+	var out bytes.Buffer
+	err := json.Indent(&out, data, "", "  ")
+	if err != nil {
+		return string(data)
+	}
+	return out.String()
+}
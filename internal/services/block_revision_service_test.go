@@ -0,0 +1,179 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/helpers"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBlockRevisionService(t *testing.T) (
+	*services.BlockRevisionService,
+	*services.BlockService,
+	*services.DeleteService,
+	func(),
+) {
+	t.Helper()
+	deleteService, dbc, cleanup := setupDeleteService(t)
+
+	blockStateRepo := repositories.NewBlockStateRepository(dbc)
+	blockRepo := repositories.NewBlockRepository(dbc, blockStateRepo)
+	revisionRepo := repositories.NewBlockRevisionRepository(dbc)
+
+	revisionService := services.NewBlockRevisionService(revisionRepo, blockRepo)
+	blockService := services.NewBlockService(blockRepo, blockStateRepo)
+
+	return revisionService, blockService, deleteService, cleanup
+}
+
+// editMarkdown edits a markdown block the way the block editor does.
+func editMarkdown(
+	t *testing.T,
+	revisionService *services.BlockRevisionService,
+	blockService *services.BlockService,
+	userID string,
+	block blocks.Block,
+	content string,
+) blocks.Block {
+	t.Helper()
+	ctx := context.Background()
+
+	require.NoError(t, revisionService.RecordOriginal(ctx, block.GetID()))
+	block, err := blockService.UpdateBlock(ctx, block, map[string][]string{"content": {content}})
+	require.NoError(t, err)
+	require.NoError(t, revisionService.RecordEdit(ctx, userID, block.GetID()))
+	return block
+}
+
+func TestBlockRevisionService_RecordEdit(t *testing.T) {
+	revisionService, blockService, _, cleanup := setupBlockRevisionService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	block, err := blockService.NewBlockWithOwnerAndContext(
+		ctx,
+		gofakeit.UUID(),
+		blocks.ContextLocationContent,
+		"markdown",
+	)
+	require.NoError(t, err)
+
+	alice := gofakeit.UUID()
+	bob := gofakeit.UUID()
+	block = editMarkdown(t, revisionService, blockService, alice, block, "First draft")
+	block = editMarkdown(t, revisionService, blockService, alice, block, "Second draft")
+	editMarkdown(t, revisionService, blockService, bob, block, "Bob's version")
+
+	revisions, err := revisionService.List(ctx, block.GetID())
+	require.NoError(t, err)
+
+	// Alice's edits are merged as they were made close together
+	require.Len(t, revisions, 3)
+	assert.Equal(t, models.RevisionEdited, revisions[0].Action)
+	assert.Equal(t, bob, revisions[0].UserID)
+	assert.Equal(t, alice, revisions[1].UserID)
+	assert.Contains(t, string(revisions[1].Data), "Second draft")
+	assert.Equal(t, models.RevisionOriginal, revisions[2].Action)
+	assert.Empty(t, revisions[2].UserID)
+
+	// Each revision shows what changed from the one before
+	assert.True(t, helpers.DiffChanged(revisions[0].Changes))
+	assert.Contains(t, revisions[0].Changes, helpers.DiffLine{
+		Op:   helpers.DiffAdded,
+		Text: `  "content": "Bob's version"`,
+	})
+	assert.Nil(t, revisions[2].Changes)
+}
+
+func TestBlockRevisionService_Restore(t *testing.T) {
+	revisionService, blockService, _, cleanup := setupBlockRevisionService(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+	ownerID := gofakeit.UUID()
+
+	first, err := blockService.NewBlockWithOwnerAndContext(ctx, ownerID, blocks.ContextLocationContent, "markdown")
+	require.NoError(t, err)
+	block, err := blockService.NewBlockWithOwnerAndContext(ctx, ownerID, blocks.ContextLocationContent, "markdown")
+	require.NoError(t, err)
+	require.NoError(t, blockService.ReorderBlocks(ctx, []string{block.GetID(), first.GetID()}))
+	block, err = blockService.GetByBlockID(ctx, block.GetID())
+	require.NoError(t, err)
+
+	block = editMarkdown(t, revisionService, blockService, userID, block, "Carefully written")
+	editMarkdown(t, revisionService, blockService, gofakeit.UUID(), block, "Oops")
+
+	revisions, err := revisionService.List(ctx, block.GetID())
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+
+	restored, err := revisionService.Restore(ctx, userID, revisions[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Carefully written", restored.(*blocks.MarkdownBlock).Content)
+	assert.Equal(t, 0, restored.GetOrder(), "Restoring should not move the block")
+
+	revisions, err = revisionService.List(ctx, block.GetID())
+	require.NoError(t, err)
+	require.Len(t, revisions, 4)
+	assert.Equal(t, models.RevisionRestored, revisions[0].Action)
+	assert.Equal(t, userID, revisions[0].UserID)
+}
+
+func TestBlockRevisionService_Undelete(t *testing.T) {
+	revisionService, blockService, deleteService, cleanup := setupBlockRevisionService(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+	ownerID := gofakeit.UUID()
+
+	block, err := blockService.NewBlockWithOwnerAndContext(ctx, ownerID, blocks.ContextLocationContent, "markdown")
+	require.NoError(t, err)
+	block = editMarkdown(t, revisionService, blockService, userID, block, "Do not lose me")
+	other, err := blockService.NewBlockWithOwnerAndContext(ctx, ownerID, blocks.ContextLocationContent, "markdown")
+	require.NoError(t, err)
+
+	require.NoError(t, deleteService.DeleteBlock(ctx, userID, block.GetID()))
+	_, err = blockService.GetByBlockID(ctx, block.GetID())
+	require.Error(t, err)
+
+	deleted, err := revisionService.ListDeleted(ctx, ownerID, blocks.ContextLocationContent)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, block.GetID(), deleted[0].BlockID)
+	assert.Equal(t, models.RevisionDeleted, deleted[0].Action)
+
+	// Deleted blocks are only listed for their own owner and context
+	deleted, err = revisionService.ListDeleted(ctx, ownerID, blocks.ContextLocationClues)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+
+	deleted, err = revisionService.ListDeleted(ctx, ownerID, blocks.ContextLocationContent)
+	require.NoError(t, err)
+	restored, err := revisionService.Restore(ctx, userID, deleted[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, block.GetID(), restored.GetID())
+	assert.Equal(t, "Do not lose me", restored.(*blocks.MarkdownBlock).Content)
+	assert.Greater(t, restored.GetOrder(), other.GetOrder(), "Restored blocks go to the end")
+
+	found, err := blockService.FindByOwnerIDAndContext(ctx, ownerID, blocks.ContextLocationContent)
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+
+	deleted, err = revisionService.ListDeleted(ctx, ownerID, blocks.ContextLocationContent)
+	require.NoError(t, err)
+	assert.Empty(t, deleted, "Restored blocks are no longer listed as deleted")
+
+	// History is kept across the delete
+	revisions, err := revisionService.List(ctx, block.GetID())
+	require.NoError(t, err)
+	require.Len(t, revisions, 4)
+	assert.Equal(t, models.RevisionRestored, revisions[0].Action)
+	assert.Equal(t, models.RevisionDeleted, revisions[1].Action)
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/nathanhollows/Rapua/v6/blocks"
 	"github.com/nathanhollows/Rapua/v6/models"
@@ -126,6 +128,8 @@ func (s *BlockService) UpdateBlock(
 	if err != nil {
 		return nil, fmt.Errorf("updating block data: %w", err)
 	}
+	// Editing a linked copy detaches it from the library
+	block.SetLibraryID("")
 	return s.blockRepo.Update(ctx, block)
 }
 
@@ -287,16 +291,27 @@ func (s *BlockService) CheckValidationRequiredForLocation(ctx context.Context, l
 }
 
 // CheckValidationRequiredForCheckIn checks if any blocks still require validation for a check in.
+// Blocks the team is excluded from by their visibility conditions are not required, so the
+// team's instance and check-ins should be loaded.
 func (s *BlockService) CheckValidationRequiredForCheckIn(
 	ctx context.Context,
-	locationID, teamCode string,
+	locationID string,
+	team models.Team,
 ) (bool, error) {
-	blocks, state, err := s.FindByOwnerIDAndTeamCodeWithState(ctx, locationID, teamCode)
+	foundBlocks, state, err := s.FindByOwnerIDAndTeamCodeWithState(ctx, locationID, team.Code)
 	if err != nil {
 		return false, err
 	}
 
-	for _, block := range blocks {
+	progress := blocks.TeamProgress{
+		TeamCode: team.Code,
+		GroupID:  currentGroupID(&team.Instance.GameStructure, team),
+		Points:   team.Points,
+	}
+	for _, block := range foundBlocks {
+		if block.GetVisibility().Excludes(progress) {
+			continue
+		}
 		if block.RequiresValidation() {
 			if state[block.GetID()] == nil {
 				return true, nil
@@ -315,3 +330,63 @@ func (s *BlockService) CheckValidationRequiredForCheckIn(
 func (s *BlockService) UpdateState(ctx context.Context, state blocks.PlayerState) (blocks.PlayerState, error) {
 	return s.blockStateRepo.Update(ctx, state)
 }
+
+// GetPollResults returns the distribution of votes for a poll across every
+// team that has voted on it.
+func (s *BlockService) GetPollResults(ctx context.Context, poll *blocks.PollBlock) (blocks.PollResults, error) {
+	counts, err := s.blockStateRepo.CountPlayerDataByBlock(ctx, poll.ID)
+	if err != nil {
+		return blocks.PollResults{}, fmt.Errorf("counting poll votes: %w", err)
+	}
+
+	votes := make(map[string]int, len(counts))
+	for _, count := range counts {
+		data, err := poll.ParsePlayerData(count.PlayerData)
+		if err != nil {
+			return blocks.PollResults{}, fmt.Errorf("parsing poll vote: %w", err)
+		}
+		votes[data.Option] += count.Count
+	}
+
+	return poll.Results(votes), nil
+}
+
+// IsVisibleToTeam reports whether a team can currently see a block, given
+// when the team arrived at the block's location. The team's instance and
+// check-ins should be loaded for blocks shown to a group.
+func (s *BlockService) IsVisibleToTeam(
+	ctx context.Context,
+	block blocks.Block,
+	team models.Team,
+	arrivedAt time.Time,
+) (bool, error) {
+	visibility := block.GetVisibility()
+	if !visibility.IsSet() {
+		return true, nil
+	}
+
+	groupID := currentGroupID(&team.Instance.GameStructure, team)
+	progress := blocks.NewTeamProgress(team.Code, groupID, team.Points, arrivedAt, nil)
+	if visibility.AfterBlockID != "" {
+		state, err := s.blockStateRepo.GetByBlockAndTeam(ctx, visibility.AfterBlockID, team.Code)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("getting state for block %s: %w", visibility.AfterBlockID, err)
+		}
+		progress.Completed[visibility.AfterBlockID] = state != nil && state.IsComplete()
+	}
+
+	return visibility.IsVisible(progress), nil
+}
+
+// UpdateVisibility replaces the visibility conditions for a block.
+func (s *BlockService) UpdateVisibility(
+	ctx context.Context,
+	block blocks.Block,
+	visibility blocks.Visibility,
+) (blocks.Block, error) {
+	if visibility.AfterBlockID == block.GetID() {
+		return nil, errors.New("a block cannot depend on itself")
+	}
+	block.SetVisibility(visibility)
+	return s.blockRepo.Update(ctx, block)
+}
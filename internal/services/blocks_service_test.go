@@ -7,6 +7,7 @@ import (
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/nathanhollows/Rapua/v6/blocks"
 	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
 	"github.com/nathanhollows/Rapua/v6/repositories"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -628,6 +629,25 @@ func TestBlockService_CheckValidationRequiredForCheckIn(t *testing.T) {
 			},
 			wantVal: false,
 		},
+		{
+			name:       "Block for another group",
+			locationID: "LOC-CHIN-3",
+			teamCode:   "TEAMCHK3",
+			setupFn: func(svc services.BlockService, locID, _ string) error {
+				blk, err := svc.NewBlockWithOwnerAndContext(
+					context.Background(),
+					locID,
+					blocks.ContextLocationContent,
+					"checklist",
+				)
+				if err != nil {
+					return err
+				}
+				_, err = svc.UpdateVisibility(context.Background(), blk, blocks.Visibility{GroupID: "other"})
+				return err
+			},
+			wantVal: false,
+		},
 		{
 			name:       "Empty location or team code",
 			locationID: "",
@@ -645,7 +665,11 @@ func TestBlockService_CheckValidationRequiredForCheckIn(t *testing.T) {
 			err := tc.setupFn(svc, tc.locationID, tc.teamCode)
 			require.NoError(t, err, "setup should not fail")
 
-			valRequired, err := svc.CheckValidationRequiredForCheckIn(context.Background(), tc.locationID, tc.teamCode)
+			valRequired, err := svc.CheckValidationRequiredForCheckIn(
+				context.Background(),
+				tc.locationID,
+				models.Team{Code: tc.teamCode},
+			)
 			if tc.wantErr {
 				require.Error(t, err)
 			} else {
@@ -867,3 +891,30 @@ func TestBlockService_UpdateBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockService_GetPollResults(t *testing.T) {
+	svc, cleanup := setupBlocksService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	blk, err := svc.NewBlockWithOwnerAndContext(ctx, gofakeit.UUID(), blocks.ContextLocationContent, "poll")
+	require.NoError(t, err)
+	poll, ok := blk.(*blocks.PollBlock)
+	require.True(t, ok)
+	poll.Options = []blocks.PollOption{{ID: "yes", Text: "Yes"}, {ID: "no", Text: "No"}}
+
+	for _, option := range []string{"yes", "yes", "no"} {
+		st, err := svc.NewBlockState(ctx, poll.ID, gofakeit.UUID())
+		require.NoError(t, err)
+		st, err = poll.ValidatePlayerInput(st, map[string][]string{"option": {option}})
+		require.NoError(t, err)
+		_, err = svc.UpdateState(ctx, st)
+		require.NoError(t, err)
+	}
+
+	results, err := svc.GetPollResults(ctx, poll)
+	require.NoError(t, err)
+	assert.Equal(t, 3, results.Total)
+	assert.Equal(t, 2, results.Options[0].Votes)
+	assert.Equal(t, 1, results.Options[1].Votes)
+}
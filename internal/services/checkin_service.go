@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/nathanhollows/Rapua/v6/blocks"
 	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
@@ -30,6 +31,7 @@ type CheckInService struct {
 	blockService         *BlockService
 	locationStatsService LocationStatsService
 	navigationService    *NavigationService
+	webhooks             WebhookDispatcher
 }
 
 func NewCheckInService(
@@ -39,6 +41,7 @@ func NewCheckInService(
 	locationStatsService LocationStatsService,
 	navigationService *NavigationService,
 	blockService *BlockService,
+	webhooks WebhookDispatcher,
 ) *CheckInService {
 	return &CheckInService{
 		checkInRepo:          checkInRepo,
@@ -47,6 +50,7 @@ func NewCheckInService(
 		locationStatsService: locationStatsService,
 		navigationService:    navigationService,
 		blockService:         blockService,
+		webhooks:             webhooks,
 	}
 }
 
@@ -197,6 +201,12 @@ func (s *CheckInService) CheckIn(ctx context.Context, team *models.Team, locatio
 		return fmt.Errorf("updating team: %w", err)
 	}
 
+	dispatchWebhook(ctx, s.webhooks, team.InstanceID, models.EventTeamCheckedIn, "", webhookEventData{
+		Team:     newWebhookTeam(team),
+		Location: &webhookLocation{ID: location.ID, Name: location.Name, Points: pointsForCheckInRecord},
+	})
+	s.dispatchIfFinished(ctx, team)
+
 	return nil
 }
 
@@ -219,7 +229,7 @@ func (s *CheckInService) CheckOut(ctx context.Context, team *models.Team, locati
 	}
 
 	// Check if all blocks are completed
-	unfinishedCheckIn, err := s.blockService.CheckValidationRequiredForCheckIn(ctx, location.ID, team.Code)
+	unfinishedCheckIn, err := s.blockService.CheckValidationRequiredForCheckIn(ctx, location.ID, *team)
 	if err != nil {
 		return fmt.Errorf("checking if validation is required: %w", err)
 	}
@@ -254,9 +264,39 @@ func (s *CheckInService) CheckOut(ctx context.Context, team *models.Team, locati
 		return fmt.Errorf("updating team points: %w", err)
 	}
 
+	dispatchWebhook(ctx, s.webhooks, team.InstanceID, models.EventTeamCheckedOut, "", webhookEventData{
+		Team:     newWebhookTeam(team),
+		Location: &webhookLocation{ID: location.ID, Name: location.Name, Points: checkIn.Points},
+	})
+	s.dispatchIfFinished(ctx, team)
+
 	return nil
 }
 
+// dispatchIfFinished sends team.finished once a team has nowhere left to go.
+// Working this out means reloading the team, so it is skipped unless a
+// webhook wants the event.
+func (s *CheckInService) dispatchIfFinished(ctx context.Context, team *models.Team) {
+	if s.webhooks == nil || team.MustCheckOut != "" ||
+		!s.webhooks.Subscribed(ctx, team.InstanceID, models.EventTeamFinished) {
+		return
+	}
+
+	if err := s.teamRepo.LoadRelations(ctx, team); err != nil {
+		slog.ErrorContext(ctx, "loading team to check if finished", "team", team.Code, "error", err)
+		return
+	}
+	_, err := s.navigationService.GetNextLocations(ctx, team)
+	if !errors.Is(err, ErrAllLocationsVisited) {
+		return
+	}
+
+	key := string(models.EventTeamFinished) + ":" + team.Code
+	dispatchWebhook(ctx, s.webhooks, team.InstanceID, models.EventTeamFinished, key, webhookEventData{
+		Team: newWebhookTeam(team),
+	})
+}
+
 func (s *CheckInService) CompleteBlocks(ctx context.Context, teamCode string, locationID string) error {
 	checkIn, err := s.checkInRepo.FindCheckInByTeamAndLocation(ctx, teamCode, locationID)
 	if err != nil {
@@ -378,6 +418,24 @@ func (s *CheckInService) ValidateAndUpdateBlockState(
 		return state, block, nil
 	}
 
+	// Teams may only answer blocks they can see
+	if !isPreview && block.GetVisibility().IsSet() {
+		if err = s.loadProgress(ctx, &team); err != nil {
+			return nil, nil, err
+		}
+		checkIn, checkInErr := s.checkInRepo.FindCheckInByTeamAndLocation(ctx, team.Code, block.GetLocationID())
+		if checkInErr != nil {
+			return nil, nil, fmt.Errorf("finding check in: %w", checkInErr)
+		}
+		visible, visibleErr := s.blockService.IsVisibleToTeam(ctx, block, team, checkIn.TimeIn)
+		if visibleErr != nil {
+			return nil, nil, fmt.Errorf("checking block visibility: %w", visibleErr)
+		}
+		if !visible {
+			return nil, nil, ErrBlockHidden
+		}
+	}
+
 	// Validate the block
 	state, err = block.ValidatePlayerInput(state, data)
 	if err != nil {
@@ -394,29 +452,91 @@ func (s *CheckInService) ValidateAndUpdateBlockState(
 
 	// Only award points and update check-ins in regular mode, not preview mode
 	if !isPreview && state.IsComplete() {
-		team.Points += block.GetPoints()
-		err = s.teamRepo.Update(ctx, &team)
+		err = s.completeBlock(ctx, &team, block)
 		if err != nil {
-			return nil, nil, fmt.Errorf("awarding points: %w", err)
+			return nil, nil, err
 		}
+	}
 
-		// Update the check in all blocks have been completed
-		unfinishedCheckIn, checkErr := s.blockService.CheckValidationRequiredForCheckIn(
-			ctx,
-			block.GetLocationID(),
-			team.Code,
-		)
-		if checkErr != nil {
-			return nil, nil, fmt.Errorf("checking if validation is required: %w", checkErr)
+	return state, block, nil
+}
+
+// ApproveBlock marks a block complete for a team without their input, as
+// when a facilitator has watched them do the task. The team is awarded the
+// block's points as if they had completed it themselves.
+func (s *CheckInService) ApproveBlock(ctx context.Context, team *models.Team, blockID string) error {
+	block, state, err := s.blockService.GetBlockWithStateByBlockIDAndTeamCode(ctx, blockID, team.Code)
+	if err != nil {
+		return fmt.Errorf("getting block with state: %w", err)
+	}
+	if block == nil || state == nil {
+		return errors.New("block not found")
+	}
+	if state.IsComplete() {
+		return nil
+	}
+
+	state.SetComplete(true)
+	state.SetPointsAwarded(block.GetPoints())
+	_, err = s.blockService.UpdateState(ctx, state)
+	if err != nil {
+		return fmt.Errorf("updating block state: %w", err)
+	}
+	return s.completeBlock(ctx, team, block)
+}
+
+// loadProgress loads what block visibility is checked against, the team's
+// instance and check-ins, if they aren't already.
+func (s *CheckInService) loadProgress(ctx context.Context, team *models.Team) error {
+	if team.Instance.ID == "" {
+		if err := s.teamRepo.LoadInstance(ctx, team); err != nil {
+			return fmt.Errorf("loading instance: %w", err)
 		}
+	}
+	if len(team.CheckIns) == 0 {
+		if err := s.teamRepo.LoadCheckIns(ctx, team); err != nil {
+			return fmt.Errorf("loading check ins: %w", err)
+		}
+	}
+	return nil
+}
 
-		if !unfinishedCheckIn {
-			err = s.CompleteBlocks(ctx, team.Code, block.GetLocationID())
-			if err != nil {
-				return nil, nil, fmt.Errorf("completing blocks: %w", err)
-			}
+// completeBlock awards a team the points for a block they have completed,
+// and completes their check in once nothing else needs validating.
+func (s *CheckInService) completeBlock(ctx context.Context, team *models.Team, block blocks.Block) error {
+	team.Points += block.GetPoints()
+	err := s.teamRepo.Update(ctx, team)
+	if err != nil {
+		return fmt.Errorf("awarding points: %w", err)
+	}
+	if err = s.loadProgress(ctx, team); err != nil {
+		return err
+	}
+
+	// Update the check in all blocks have been completed
+	unfinishedCheckIn, err := s.blockService.CheckValidationRequiredForCheckIn(ctx, block.GetLocationID(), *team)
+	if err != nil {
+		return fmt.Errorf("checking if validation is required: %w", err)
+	}
+
+	if !unfinishedCheckIn {
+		err = s.CompleteBlocks(ctx, team.Code, block.GetLocationID())
+		if err != nil {
+			return fmt.Errorf("completing blocks: %w", err)
 		}
 	}
 
-	return state, block, nil
+	dispatchWebhook(ctx, s.webhooks, team.InstanceID, models.EventBlockCompleted, "", webhookEventData{
+		Team: newWebhookTeam(team),
+		Block: &webhookBlock{
+			ID:         block.GetID(),
+			Type:       block.GetType(),
+			LocationID: block.GetLocationID(),
+			Points:     block.GetPoints(),
+		},
+	})
+	if !unfinishedCheckIn {
+		s.dispatchIfFinished(ctx, team)
+	}
+	return nil
 }
@@ -31,6 +31,21 @@ type CreditRepository interface {
 
 	// TryDeductOneCredit atomically deducts one credit from free first, then paid. Returns ErrInsufficientCredits if not possible.
 	DeductOneCreditWithTx(ctx context.Context, tx *bun.Tx, userID string) error
+
+	// AddOrganisationCreditsWithTx atomically increments an organisation's credit pool.
+	AddOrganisationCreditsWithTx(
+		ctx context.Context,
+		tx *bun.Tx,
+		organisationID string,
+		freeCreditsToAdd int,
+		paidCreditsToAdd int,
+	) error
+	// FindCreditPoolWithTx returns the organisation that pays for a team start, or "" if the creator pays.
+	FindCreditPoolWithTx(ctx context.Context, tx *bun.Tx, userID, instanceID string, since time.Time) (string, error)
+	// DeductOneOrganisationCreditWithTx atomically deducts one credit from an organisation's pool.
+	DeductOneOrganisationCreditWithTx(ctx context.Context, tx *bun.Tx, organisationID string) error
+	// GetCreditAdjustmentsByOrganisationID returns all credit adjustments for an organisation's pool.
+	GetCreditAdjustmentsByOrganisationID(ctx context.Context, organisationID string) ([]models.CreditAdjustments, error)
 }
 
 const (
@@ -137,28 +152,50 @@ func (s *CreditService) AddCredits(
 }
 
 // DeductCreditForTeamStartWithTx handles credit deduction and team start logging within a transaction.
+// Games shared with an organisation draw from its pool while the creator is
+// under their monthly limit, falling back to the creator's own credits.
 func (s *CreditService) DeductCreditForTeamStartWithTx(
 	ctx context.Context,
 	tx *bun.Tx,
 	userID, teamID, instanceID string,
 ) error {
-	// Step 1: Atomically deduct one credit (free first, then paid)
-	err := s.creditRepo.DeductOneCreditWithTx(ctx, tx, userID)
+	// Step 1: Charge the organisation's pool if one covers this game
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	organisationID, err := s.creditRepo.FindCreditPoolWithTx(ctx, tx, userID, instanceID, monthStart)
 	if err != nil {
-		// Convert repository error to service error for consistency
-		if err.Error() == "insufficient credits to start team" {
-			return ErrInsufficientCredits
-		}
 		return err
 	}
+	if organisationID != "" {
+		err = s.creditRepo.DeductOneOrganisationCreditWithTx(ctx, tx, organisationID)
+		if err != nil && err.Error() != "insufficient credits to start team" {
+			return err
+		} else if err != nil {
+			// An empty pool falls back to the creator's credits
+			organisationID = ""
+		}
+	}
+
+	// Step 2: Otherwise atomically deduct one credit (free first, then paid)
+	if organisationID == "" {
+		err = s.creditRepo.DeductOneCreditWithTx(ctx, tx, userID)
+		if err != nil {
+			// Convert repository error to service error for consistency
+			if err.Error() == "insufficient credits to start team" {
+				return ErrInsufficientCredits
+			}
+			return err
+		}
+	}
 
-	// Step 2: Log the team start
+	// Step 3: Log the team start
 	log := &models.TeamStartLog{
-		ID:         uuid.New().String(),
-		CreatedAt:  time.Now(),
-		UserID:     userID,
-		TeamID:     teamID,
-		InstanceID: instanceID,
+		ID:             uuid.New().String(),
+		CreatedAt:      time.Now(),
+		UserID:         userID,
+		TeamID:         teamID,
+		InstanceID:     instanceID,
+		OrganisationID: organisationID,
 	}
 	return s.teamStartLogRepo.CreateWithTx(ctx, tx, log)
 }
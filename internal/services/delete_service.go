@@ -4,13 +4,17 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/config"
 	"github.com/nathanhollows/Rapua/v6/db"
 	"github.com/nathanhollows/Rapua/v6/models"
 	"github.com/nathanhollows/Rapua/v6/repositories"
@@ -22,6 +26,7 @@ type DeleteService struct {
 	transactor           db.Transactor
 	blockRepo            repositories.BlockRepository
 	blockStateRepo       repositories.BlockStateRepository
+	blockRevisionRepo    *repositories.BlockRevisionRepository
 	checkInRepo          repositories.CheckInRepository
 	instanceRepo         repositories.InstanceRepository
 	instanceSettingsRepo repositories.InstanceSettingsRepository
@@ -33,6 +38,14 @@ type DeleteService struct {
 	creditPurchaseRepo   *repositories.CreditPurchaseRepository
 	teamStartLogRepo     *repositories.TeamStartLogRepository
 	uploadsRepo          repositories.UploadsRepository
+	trashRepo            *repositories.TrashRepository
+	organisationRepo     *repositories.OrganisationRepository
+	apiKeyRepo           *repositories.APIKeyRepository
+	webhookRepo          *repositories.WebhookRepository
+	ltiRepo              *repositories.LTIRepository
+	recoveryCodeRepo     *repositories.RecoveryCodeRepository
+	adminSessionRepo     *repositories.AdminSessionRepository
+	loginAttemptRepo     *repositories.LoginAttemptRepository
 	db                   *bun.DB
 	uploadsDir           string
 	logger               *slog.Logger
@@ -43,6 +56,7 @@ func NewDeleteService(
 	transactor db.Transactor,
 	blockRepo repositories.BlockRepository,
 	blockStateRepo repositories.BlockStateRepository,
+	blockRevisionRepo *repositories.BlockRevisionRepository,
 	checkInRepo repositories.CheckInRepository,
 	instanceRepo repositories.InstanceRepository,
 	instanceSettingsRepo repositories.InstanceSettingsRepository,
@@ -54,6 +68,14 @@ func NewDeleteService(
 	creditPurchaseRepo *repositories.CreditPurchaseRepository,
 	teamStartLogRepo *repositories.TeamStartLogRepository,
 	uploadsRepo repositories.UploadsRepository,
+	trashRepo *repositories.TrashRepository,
+	organisationRepo *repositories.OrganisationRepository,
+	apiKeyRepo *repositories.APIKeyRepository,
+	webhookRepo *repositories.WebhookRepository,
+	ltiRepo *repositories.LTIRepository,
+	recoveryCodeRepo *repositories.RecoveryCodeRepository,
+	adminSessionRepo *repositories.AdminSessionRepository,
+	loginAttemptRepo *repositories.LoginAttemptRepository,
 	db *bun.DB,
 	uploadsDir string,
 	logger *slog.Logger,
@@ -62,6 +84,7 @@ func NewDeleteService(
 		transactor:           transactor,
 		blockRepo:            blockRepo,
 		blockStateRepo:       blockStateRepo,
+		blockRevisionRepo:    blockRevisionRepo,
 		checkInRepo:          checkInRepo,
 		instanceRepo:         instanceRepo,
 		instanceSettingsRepo: instanceSettingsRepo,
@@ -73,6 +96,14 @@ func NewDeleteService(
 		creditPurchaseRepo:   creditPurchaseRepo,
 		teamStartLogRepo:     teamStartLogRepo,
 		uploadsRepo:          uploadsRepo,
+		trashRepo:            trashRepo,
+		organisationRepo:     organisationRepo,
+		apiKeyRepo:           apiKeyRepo,
+		webhookRepo:          webhookRepo,
+		ltiRepo:              ltiRepo,
+		recoveryCodeRepo:     recoveryCodeRepo,
+		adminSessionRepo:     adminSessionRepo,
+		loginAttemptRepo:     loginAttemptRepo,
 		db:                   db,
 		uploadsDir:           uploadsDir,
 		logger:               logger,
@@ -96,7 +127,7 @@ func (s *DeleteService) DeleteUser(ctx context.Context, userID string) error {
 		}
 	}()
 
-	err = s.deleteUser(ctx, tx, userID)
+	uploads, err := s.deleteUser(ctx, tx, userID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			return fmt.Errorf("deleting user: %w; rollback failed: %w", err, rollbackErr)
@@ -104,11 +135,23 @@ func (s *DeleteService) DeleteUser(ctx context.Context, userID string) error {
 		return fmt.Errorf("deleting user: %w", err)
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	// Only cleanup files after transaction commits successfully
+	// Use background context so cleanup isn't cancelled when request ends
+	if len(uploads) > 0 {
+		go s.cleanupUploadFiles(context.Background(), uploads)
+	}
+
+	return nil
 }
 
 // DeleteBlock deletes a block and its associated player progress and uploads.
-func (s *DeleteService) DeleteBlock(ctx context.Context, blockID string) error {
+// The block's content is kept as a revision so it can be restored.
+func (s *DeleteService) DeleteBlock(ctx context.Context, userID, blockID string) error {
 	tx, err := s.transactor.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
@@ -133,6 +176,15 @@ func (s *DeleteService) DeleteBlock(ctx context.Context, blockID string) error {
 		return fmt.Errorf("fetching uploads: %w", err)
 	}
 
+	// Keep the content so the block can be restored
+	_, err = s.blockRevisionRepo.SnapshotWithTx(ctx, tx, blockID, userID, models.RevisionDeleted)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("saving revision: %w; rollback failed: %w", err, rollbackErr)
+		}
+		return fmt.Errorf("saving revision: %w", err)
+	}
+
 	// Delete the block and its states
 	err = s.deleteBlock(ctx, tx, blockID)
 	if err != nil {
@@ -168,7 +220,7 @@ func (s *DeleteService) DeleteBlock(ctx context.Context, blockID string) error {
 	return nil
 }
 
-// DeleteInstance deletes an instance and all its content.
+// DeleteInstance moves an instance and all its content to the trash.
 // Returns ErrUserNotAuthenticated if userID doesn't own the instance.
 func (s *DeleteService) DeleteInstance(ctx context.Context, userID, instanceID string) error {
 	if userID == "" {
@@ -184,19 +236,10 @@ func (s *DeleteService) DeleteInstance(ctx context.Context, userID, instanceID s
 	if err != nil {
 		return fmt.Errorf("finding instance: %w", err)
 	}
-
 	if userID != instance.UserID {
 		return ErrUserNotAuthenticated
 	}
 
-	// Collect all uploads for this instance before starting transaction
-	uploads, err := s.uploadsRepo.SearchByCriteria(ctx, map[string]string{
-		"instance_id": instanceID,
-	})
-	if err != nil {
-		return fmt.Errorf("fetching uploads for instance %s: %w", instanceID, err)
-	}
-
 	// Start transaction
 	tx, err := s.transactor.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
@@ -212,12 +255,12 @@ func (s *DeleteService) DeleteInstance(ctx context.Context, userID, instanceID s
 		}
 	}()
 
-	err = s.deleteInstance(ctx, tx, instanceID)
+	err = s.trashInstance(ctx, tx, instance)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			return fmt.Errorf("deleting instance: %w; rollback failed: %w", err, rollbackErr)
+			return fmt.Errorf("moving instance to trash: %w; rollback failed: %w", err, rollbackErr)
 		}
-		return fmt.Errorf("deleting instance: %w", err)
+		return fmt.Errorf("moving instance to trash: %w", err)
 	}
 
 	err = tx.Commit()
@@ -225,17 +268,21 @@ func (s *DeleteService) DeleteInstance(ctx context.Context, userID, instanceID s
 		return fmt.Errorf("committing transaction: %w", err)
 	}
 
-	// Only cleanup files after transaction commits successfully
-	// Use background context so cleanup isn't cancelled when request ends
-	if len(uploads) > 0 {
-		go s.cleanupUploadFiles(context.Background(), uploads)
-	}
-
 	return nil
 }
 
-// DeleteLocation deletes a location and all associated blocks and progress.
+// DeleteLocation moves a location and its blocks, progress and uploads to
+// the trash.
 func (s *DeleteService) DeleteLocation(ctx context.Context, locationID string) error {
+	location, err := s.locationRepo.GetByID(ctx, locationID)
+	if err != nil {
+		return fmt.Errorf("finding location: %w", err)
+	}
+	instance, err := s.instanceRepo.GetByID(ctx, location.InstanceID)
+	if err != nil {
+		return fmt.Errorf("finding instance: %w", err)
+	}
+
 	tx, err := s.transactor.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
@@ -251,26 +298,75 @@ func (s *DeleteService) DeleteLocation(ctx context.Context, locationID string) e
 		}
 	}()
 
-	err = s.deleteLocation(ctx, tx, locationID)
+	err = s.trashLocation(ctx, tx, instance.UserID, locationID)
 	if err != nil {
 		rollbackErr := tx.Rollback()
 		if rollbackErr != nil {
 			return fmt.Errorf("rolling back transaction: %w; %w", err, rollbackErr)
 		}
-		return fmt.Errorf("deleting location: %w", err)
+		return fmt.Errorf("moving location to trash: %w", err)
 	}
 
-	err = s.markerRepo.DeleteUnused(ctx, tx)
+	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("deleting unused markers: %w", err)
+		return fmt.Errorf("committing transaction: %w", err)
 	}
 
-	err = tx.Commit()
+	return nil
+}
+
+// DiscardLocations permanently deletes locations that were only just
+// created, with their blocks and markers, without keeping them in the trash.
+// It undoes an import that failed part way.
+func (s *DeleteService) DiscardLocations(ctx context.Context, locations []models.Location) error {
+	if len(locations) == 0 {
+		return nil
+	}
+
+	tx, err := s.transactor.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			rollbackErr := tx.Rollback()
+			if rollbackErr != nil {
+				panic(fmt.Errorf("rolling back transaction: %v; %w", p, rollbackErr))
+			}
+			panic(p)
+		}
+	}()
+
+	markerCodes := make([]string, 0, len(locations))
+	for _, location := range locations {
+		err = s.deleteLocation(ctx, tx, location.ID)
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			if rollbackErr != nil {
+				return fmt.Errorf("deleting location: %w; rollback failed: %w", err, rollbackErr)
+			}
+			return fmt.Errorf("deleting location: %w", err)
+		}
+		markerCodes = append(markerCodes, location.MarkerID)
+	}
+
+	// Each location had a new marker of its own
+	_, err = tx.NewDelete().
+		Model((*models.Marker)(nil)).
+		Where("code IN (?)", bun.In(markerCodes)).
+		Where("owner_id = ''").
+		Exec(ctx)
 	if err != nil {
 		rollbackErr := tx.Rollback()
 		if rollbackErr != nil {
-			return fmt.Errorf("rolling back transaction: %w; %w", err, rollbackErr)
+			return fmt.Errorf("deleting markers: %w; rollback failed: %w", err, rollbackErr)
 		}
+		return fmt.Errorf("deleting markers: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
 	}
 
@@ -369,6 +465,34 @@ func (s *DeleteService) ResetTeams(ctx context.Context, instanceID string, teamC
 		}
 	}
 
+	// Messages belong to the players being reset, not the team code
+	_, err = tx.NewDelete().
+		Model((*models.TeamMessage)(nil)).
+		Where("instance_id = ?", instanceID).
+		Where("team_code IN (?)", bun.In(teamCodes)).
+		Exec(ctx)
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			return fmt.Errorf("deleting messages: %w; rollback failed: %w", err, rollbackErr)
+		}
+		return fmt.Errorf("deleting messages: %w", err)
+	}
+
+	// So are their devices, which shouldn't be sent the next players' alerts
+	_, err = tx.NewDelete().
+		Model((*models.PushSubscription)(nil)).
+		Where("instance_id = ?", instanceID).
+		Where("team_code IN (?)", bun.In(teamCodes)).
+		Exec(ctx)
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			return fmt.Errorf("deleting push subscriptions: %w; rollback failed: %w", err, rollbackErr)
+		}
+		return fmt.Errorf("deleting push subscriptions: %w", err)
+	}
+
 	err = s.locationRepo.UpdateStatistics(ctx, tx, instanceID)
 	if err != nil {
 		rollbackErr := tx.Rollback()
@@ -392,18 +516,11 @@ func (s *DeleteService) ResetTeams(ctx context.Context, instanceID string, teamC
 	return nil
 }
 
-// DeleteTeams deletes teams and their associated progress data.
+// DeleteTeams moves teams and their progress data to the trash.
 func (s *DeleteService) DeleteTeams(ctx context.Context, instanceID string, teamCodes []string) error {
-	// Collect all uploads for these teams before starting transaction
-	var allUploads []*models.Upload
-	for _, teamCode := range teamCodes {
-		uploads, err := s.uploadsRepo.SearchByCriteria(ctx, map[string]string{
-			"team_code": teamCode,
-		})
-		if err != nil {
-			return fmt.Errorf("fetching uploads for team %s: %w", teamCode, err)
-		}
-		allUploads = append(allUploads, uploads...)
+	instance, err := s.instanceRepo.GetByID(ctx, instanceID)
+	if err != nil {
+		return fmt.Errorf("finding instance: %w", err)
 	}
 
 	tx, err := s.transactor.BeginTx(ctx, &sql.TxOptions{})
@@ -421,28 +538,13 @@ func (s *DeleteService) DeleteTeams(ctx context.Context, instanceID string, team
 		}
 	}()
 
-	err = s.deleteTeams(ctx, tx, instanceID, teamCodes)
+	err = s.trashTeams(ctx, tx, instance, teamCodes)
 	if err != nil {
 		rollbackErr := tx.Rollback()
 		if rollbackErr != nil {
-			return fmt.Errorf("deleting teams: %w; rollback failed: %w", err, rollbackErr)
-		}
-		return fmt.Errorf("deleting teams: %w", err)
-	}
-
-	// Delete upload records for these teams
-	for _, teamCode := range teamCodes {
-		_, err = tx.NewDelete().
-			Model((*models.Upload)(nil)).
-			Where("team_code = ?", teamCode).
-			Exec(ctx)
-		if err != nil {
-			rollbackErr := tx.Rollback()
-			if rollbackErr != nil {
-				return fmt.Errorf("deleting upload records: %w; rollback failed: %w", err, rollbackErr)
-			}
-			return fmt.Errorf("deleting upload records: %w", err)
+			return fmt.Errorf("moving teams to trash: %w; rollback failed: %w", err, rollbackErr)
 		}
+		return fmt.Errorf("moving teams to trash: %w", err)
 	}
 
 	err = tx.Commit()
@@ -450,12 +552,6 @@ func (s *DeleteService) DeleteTeams(ctx context.Context, instanceID string, team
 		return fmt.Errorf("committing transaction: %w", err)
 	}
 
-	// Only cleanup files after transaction commits successfully
-	// Use background context so cleanup isn't cancelled when request ends
-	if len(allUploads) > 0 {
-		go s.cleanupUploadFiles(context.Background(), allUploads)
-	}
-
 	return nil
 }
 
@@ -496,37 +592,6 @@ func (s *DeleteService) deleteTeamsByInstanceID(ctx context.Context, tx *bun.Tx,
 	return nil
 }
 
-// deleteTeams deletes specific teams by their codes.
-func (s *DeleteService) deleteTeams(ctx context.Context, tx *bun.Tx, instanceID string, teamCodes []string) error {
-	// Delete teams one by one (no bulk delete method available)
-	for _, teamCode := range teamCodes {
-		err := s.teamRepo.Delete(ctx, tx, instanceID, teamCode)
-		if err != nil {
-			return fmt.Errorf("deleting team %s: %w", teamCode, err)
-		}
-	}
-
-	// Delete check-ins for these teams
-	err := s.checkInRepo.DeleteByTeamCodes(ctx, tx, instanceID, teamCodes)
-	if err != nil {
-		return fmt.Errorf("deleting check ins: %w", err)
-	}
-
-	// Delete block states for these teams
-	err = s.blockStateRepo.DeleteByTeamCodes(ctx, tx, teamCodes)
-	if err != nil {
-		return fmt.Errorf("deleting block states: %w", err)
-	}
-
-	// Update location statistics
-	err = s.locationRepo.UpdateStatistics(ctx, tx, instanceID)
-	if err != nil {
-		return fmt.Errorf("updating location statistics: %w", err)
-	}
-
-	return nil
-}
-
 // deleteBlocksByLocationID deletes all blocks for a location.
 func (s *DeleteService) deleteBlocksByLocationID(ctx context.Context, tx *bun.Tx, locationID string) error {
 	// Delete all blocks (block states should cascade delete via database constraints)
@@ -535,48 +600,115 @@ func (s *DeleteService) deleteBlocksByLocationID(ctx context.Context, tx *bun.Tx
 		return fmt.Errorf("deleting blocks: %w", err)
 	}
 
+	err = s.blockRevisionRepo.DeleteByOwnerIDWithTx(ctx, tx, locationID)
+	if err != nil {
+		return fmt.Errorf("deleting block revisions: %w", err)
+	}
+
 	return nil
 }
 
-// deleteUser deletes a user and all their instances.
-func (s *DeleteService) deleteUser(ctx context.Context, tx *bun.Tx, userID string) error {
+// deleteUser deletes a user, all their instances and everything in their
+// trash. Returns the trashed uploads whose files should be removed once the
+// transaction commits.
+func (s *DeleteService) deleteUser(ctx context.Context, tx *bun.Tx, userID string) ([]*models.Upload, error) {
 	// Get all instances for this user to properly cascade delete
 	instances, err := s.instanceRepo.FindByUserID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("finding user instances: %w", err)
+		return nil, fmt.Errorf("finding user instances: %w", err)
 	}
 
 	// Delete each instance properly (this will cascade to locations, teams, etc.)
 	for _, instance := range instances {
 		err = s.deleteInstance(ctx, tx, instance.ID)
 		if err != nil {
-			return fmt.Errorf("deleting instance %s: %w", instance.ID, err)
+			return nil, fmt.Errorf("deleting instance %s: %w", instance.ID, err)
 		}
 	}
 
+	// Delete the user's block library
+	err = s.blockRepo.DeleteByOwnerID(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("deleting library blocks: %w", err)
+	}
+	err = s.blockRevisionRepo.DeleteByOwnerIDWithTx(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("deleting library block revisions: %w", err)
+	}
+
+	// Delete the user's marker library, keeping markers other games still use
+	err = s.markerRepo.ClearOwner(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("clearing marker library: %w", err)
+	}
+	err = s.markerRepo.DeleteUnused(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("deleting unused markers: %w", err)
+	}
+
+	// Leave organisations, handing ownership on where needed
+	err = s.organisationRepo.RemoveUserWithTx(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("leaving organisations: %w", err)
+	}
+
+	err = s.apiKeyRepo.DeleteByUserIDWithTx(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("deleting API keys: %w", err)
+	}
+
+	err = s.recoveryCodeRepo.DeleteByUserIDWithTx(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("deleting recovery codes: %w", err)
+	}
+
+	err = s.adminSessionRepo.DeleteByUserIDWithTx(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("deleting sessions: %w", err)
+	}
+
+	err = s.loginAttemptRepo.DeleteByUserIDWithTx(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("deleting login attempts: %w", err)
+	}
+
 	// Delete credit-related data
 	err = s.teamStartLogRepo.DeleteByUserID(ctx, tx, userID)
 	if err != nil {
-		return fmt.Errorf("deleting team start logs: %w", err)
+		return nil, fmt.Errorf("deleting team start logs: %w", err)
 	}
 
 	err = s.creditPurchaseRepo.DeleteByUserID(ctx, tx, userID)
 	if err != nil {
-		return fmt.Errorf("deleting credit purchases: %w", err)
+		return nil, fmt.Errorf("deleting credit purchases: %w", err)
 	}
 
 	err = s.creditRepo.DeleteCreditAdjustmentsByUserID(ctx, tx, userID)
 	if err != nil {
-		return fmt.Errorf("deleting credit adjustments: %w", err)
+		return nil, fmt.Errorf("deleting credit adjustments: %w", err)
+	}
+
+	// Empty the user's trash
+	items, err := s.trashRepo.FindByUserIDWithTx(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding trash: %w", err)
+	}
+	var uploads []*models.Upload
+	for i := range items {
+		purged, err := s.purgeTrashItem(ctx, tx, &items[i])
+		if err != nil {
+			return nil, fmt.Errorf("purging trash item %s: %w", items[i].ID, err)
+		}
+		uploads = append(uploads, purged...)
 	}
 
 	// Delete the user
 	err = s.userRepo.Delete(ctx, tx, userID)
 	if err != nil {
-		return fmt.Errorf("deleting user: %w", err)
+		return nil, fmt.Errorf("deleting user: %w", err)
 	}
 
-	return nil
+	return uploads, nil
 }
 
 // deleteBlock deletes a block and its states.
@@ -628,6 +760,36 @@ func (s *DeleteService) deleteInstance(ctx context.Context, tx *bun.Tx, instance
 		return fmt.Errorf("deleting uploads: %w", err)
 	}
 
+	// Delete scheduled notifications
+	_, err = tx.NewDelete().
+		Model((*models.ScheduledNotification)(nil)).
+		Where("instance_id = ?", instanceID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting scheduled notifications: %w", err)
+	}
+
+	// Delete players' push subscriptions
+	_, err = tx.NewDelete().
+		Model((*models.PushSubscription)(nil)).
+		Where("instance_id = ?", instanceID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting push subscriptions: %w", err)
+	}
+
+	// Delete webhooks and their delivery logs
+	err = s.webhookRepo.DeleteByInstanceIDWithTx(ctx, tx, instanceID)
+	if err != nil {
+		return fmt.Errorf("deleting webhooks: %w", err)
+	}
+
+	// Delete LMS registrations and their linked users
+	err = s.ltiRepo.DeleteByInstanceIDWithTx(ctx, tx, instanceID)
+	if err != nil {
+		return fmt.Errorf("deleting LTI platforms: %w", err)
+	}
+
 	// Delete instance settings
 	err = s.instanceSettingsRepo.Delete(ctx, tx, instanceID)
 	if err != nil {
@@ -643,6 +805,282 @@ func (s *DeleteService) deleteInstance(ctx context.Context, tx *bun.Tx, instance
 	return nil
 }
 
+// trashInstance moves an instance and its content to the trash.
+func (s *DeleteService) trashInstance(ctx context.Context, tx *bun.Tx, instance *models.Instance) error {
+	snapshot, err := s.trashRepo.CollectInstanceWithTx(ctx, tx, instance.ID)
+	if err != nil {
+		return fmt.Errorf("collecting instance: %w", err)
+	}
+
+	err = s.moveToTrash(ctx, tx, &models.TrashItem{
+		UserID:     instance.UserID,
+		InstanceID: instance.ID,
+		Type:       models.TrashInstance,
+		EntityID:   instance.ID,
+		Name:       instance.Name,
+	}, snapshot)
+	if err != nil {
+		return err
+	}
+
+	// The markers are kept in the trash
+	err = s.markerRepo.DeleteUnused(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("deleting unused markers: %w", err)
+	}
+
+	return nil
+}
+
+// trashLocation moves a location and its content to the trash.
+func (s *DeleteService) trashLocation(ctx context.Context, tx *bun.Tx, userID, locationID string) error {
+	snapshot, err := s.trashRepo.CollectLocationWithTx(ctx, tx, locationID)
+	if err != nil {
+		return fmt.Errorf("collecting location: %w", err)
+	}
+
+	location := snapshot.Locations[0]
+	err = s.moveToTrash(ctx, tx, &models.TrashItem{
+		UserID:     userID,
+		InstanceID: location.InstanceID,
+		Type:       models.TrashLocation,
+		EntityID:   location.ID,
+		Name:       location.Name,
+	}, snapshot)
+	if err != nil {
+		return err
+	}
+
+	err = s.markerRepo.DeleteUnused(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("deleting unused markers: %w", err)
+	}
+
+	return nil
+}
+
+// trashTeams moves each team to the trash separately so they can be
+// restored one at a time.
+func (s *DeleteService) trashTeams(
+	ctx context.Context,
+	tx *bun.Tx,
+	instance *models.Instance,
+	teamCodes []string,
+) error {
+	for _, teamCode := range teamCodes {
+		snapshot, err := s.trashRepo.CollectTeamWithTx(ctx, tx, instance.ID, teamCode)
+		if err != nil {
+			return fmt.Errorf("collecting team %s: %w", teamCode, err)
+		}
+
+		team := snapshot.Teams[0]
+		name := team.Name
+		if name == "" {
+			name = team.Code
+		}
+		err = s.moveToTrash(ctx, tx, &models.TrashItem{
+			UserID:     instance.UserID,
+			InstanceID: instance.ID,
+			Type:       models.TrashTeam,
+			EntityID:   team.ID,
+			Name:       name,
+		}, snapshot)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := s.locationRepo.UpdateStatistics(ctx, tx, instance.ID)
+	if err != nil {
+		return fmt.Errorf("updating location statistics: %w", err)
+	}
+
+	return nil
+}
+
+// moveToTrash removes the snapshot's rows and keeps them as a trash item
+// until the retention period ends. Upload files are left in place so they
+// can be restored.
+func (s *DeleteService) moveToTrash(
+	ctx context.Context,
+	tx *bun.Tx,
+	item *models.TrashItem,
+	snapshot *models.TrashSnapshot,
+) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+	item.ID = uuid.New().String()
+	item.CreatedAt = time.Now()
+	item.PurgeAt = item.CreatedAt.AddDate(0, 0, config.TrashRetentionDays())
+	item.Data = data
+
+	err = s.trashRepo.RemoveWithTx(ctx, tx, snapshot)
+	if err != nil {
+		return fmt.Errorf("removing rows: %w", err)
+	}
+
+	err = s.trashRepo.CreateWithTx(ctx, tx, item)
+	if err != nil {
+		return fmt.Errorf("saving trash item: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeTrash permanently deletes one of a user's trash items. Purging a game
+// also purges locations and teams that were trashed from it.
+func (s *DeleteService) PurgeTrash(ctx context.Context, userID, itemID string) error {
+	item, err := s.trashRepo.GetByID(ctx, userID, itemID)
+	if err != nil {
+		return fmt.Errorf("finding trash item: %w", err)
+	}
+
+	return s.purge(ctx, item)
+}
+
+// PurgeExpiredTrash permanently deletes every trash item past its retention
+// period. An item that can't be purged is logged and left for the next run,
+// and doesn't stop the others.
+func (s *DeleteService) PurgeExpiredTrash(ctx context.Context) error {
+	items, err := s.trashRepo.FindExpired(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("finding expired trash: %w", err)
+	}
+
+	var errs []error
+	for i := range items {
+		err = s.purge(ctx, &items[i])
+		if err != nil {
+			s.logger.ErrorContext(ctx, "purging trash item", "trash_item_id", items[i].ID, "error", err)
+			errs = append(errs, fmt.Errorf("purging trash item %s: %w", items[i].ID, err))
+		}
+	}
+
+	if purged := len(items) - len(errs); purged > 0 {
+		s.logger.InfoContext(ctx, "purged expired trash", "count", purged)
+	}
+
+	return errors.Join(errs...)
+}
+
+// purge permanently deletes a trash item, along with the items trashed from
+// it if it is a game, then removes the upload files.
+func (s *DeleteService) purge(ctx context.Context, item *models.TrashItem) error {
+	tx, err := s.transactor.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				slog.Error("transaction", "error", rollbackErr)
+			}
+			panic(p)
+		}
+	}()
+
+	items := []models.TrashItem{*item}
+	if item.Type == models.TrashInstance {
+		children, err := s.trashRepo.FindByInstanceIDWithTx(ctx, tx, item.InstanceID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return fmt.Errorf("finding trashed content: %w; rollback failed: %w", err, rollbackErr)
+			}
+			return fmt.Errorf("finding trashed content: %w", err)
+		}
+		items = append(items, children...)
+	}
+
+	var uploads []*models.Upload
+	for i := range items {
+		purged, err := s.purgeTrashItem(ctx, tx, &items[i])
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return fmt.Errorf("purging trash item: %w; rollback failed: %w", err, rollbackErr)
+			}
+			return fmt.Errorf("purging trash item: %w", err)
+		}
+		uploads = append(uploads, purged...)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	// Only cleanup files after transaction commits successfully
+	// Use background context so cleanup isn't cancelled when request ends
+	if len(uploads) > 0 {
+		go s.cleanupUploadFiles(context.Background(), uploads)
+	}
+
+	return nil
+}
+
+// purgeTrashItem deletes a trash item, the history of its blocks and the
+// webhooks of any game in it. Returns
+// the uploads whose files should be removed once the transaction commits.
+func (s *DeleteService) purgeTrashItem(
+	ctx context.Context,
+	tx *bun.Tx,
+	item *models.TrashItem,
+) ([]*models.Upload, error) {
+	snapshot, err := item.Snapshot()
+	if err != nil {
+		return nil, fmt.Errorf("decoding snapshot: %w", err)
+	}
+
+	ownerIDs := make([]string, 0, len(snapshot.Instances)+len(snapshot.Locations))
+	for _, instance := range snapshot.Instances {
+		ownerIDs = append(ownerIDs, instance.ID)
+		err = s.webhookRepo.DeleteByInstanceIDWithTx(ctx, tx, instance.ID)
+		if err != nil {
+			return nil, fmt.Errorf("deleting webhooks: %w", err)
+		}
+		err = s.ltiRepo.DeleteByInstanceIDWithTx(ctx, tx, instance.ID)
+		if err != nil {
+			return nil, fmt.Errorf("deleting LTI platforms: %w", err)
+		}
+		_, err = tx.NewDelete().
+			Model((*models.ScheduledNotification)(nil)).
+			Where("instance_id = ?", instance.ID).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("deleting scheduled notifications: %w", err)
+		}
+		_, err = tx.NewDelete().
+			Model((*models.PushSubscription)(nil)).
+			Where("instance_id = ?", instance.ID).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("deleting push subscriptions: %w", err)
+		}
+	}
+	for _, location := range snapshot.Locations {
+		ownerIDs = append(ownerIDs, location.ID)
+	}
+	for _, ownerID := range ownerIDs {
+		err = s.blockRevisionRepo.DeleteByOwnerIDWithTx(ctx, tx, ownerID)
+		if err != nil {
+			return nil, fmt.Errorf("deleting block revisions: %w", err)
+		}
+	}
+
+	err = s.trashRepo.DeleteWithTx(ctx, tx, item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("deleting trash item: %w", err)
+	}
+
+	uploads := make([]*models.Upload, len(snapshot.Uploads))
+	for i := range snapshot.Uploads {
+		uploads[i] = &snapshot.Uploads[i]
+	}
+	return uploads, nil
+}
+
 // cleanupUploadFiles deletes physical files from the filesystem based on upload records.
 // This runs in a goroutine with background context, so errors are only logged.
 func (s *DeleteService) cleanupUploadFiles(ctx context.Context, uploads []*models.Upload) {
@@ -19,6 +19,12 @@ import (
 func setupDeleteService(t *testing.T) (*services.DeleteService, *bun.DB, func()) {
 	t.Helper()
 	dbc, cleanup := setupDB(t)
+	return newDeleteService(t, dbc), dbc, cleanup
+}
+
+// newDeleteService creates a DeleteService for an existing database.
+func newDeleteService(t *testing.T, dbc *bun.DB) *services.DeleteService {
+	t.Helper()
 	transactor := db.NewTransactor(dbc)
 
 	// Initialize all required repositories
@@ -44,6 +50,7 @@ func setupDeleteService(t *testing.T) (*services.DeleteService, *bun.DB, func())
 		transactor,
 		blockRepo,
 		blockStateRepo,
+		repositories.NewBlockRevisionRepository(dbc),
 		checkInRepo,
 		instanceRepo,
 		instanceSettingsRepo,
@@ -55,12 +62,20 @@ func setupDeleteService(t *testing.T) (*services.DeleteService, *bun.DB, func())
 		creditPurchaseRepo,
 		teamStartLogRepo,
 		uploadRepo,
+		repositories.NewTrashRepository(dbc),
+		repositories.NewOrganisationRepository(dbc),
+		repositories.NewAPIKeyRepository(dbc),
+		repositories.NewWebhookRepository(dbc),
+		repositories.NewLTIRepository(dbc),
+		repositories.NewRecoveryCodeRepository(dbc),
+		repositories.NewAdminSessionRepository(dbc),
+		repositories.NewLoginAttemptRepository(dbc),
 		dbc,
 		uploadsDir,
 		newTLogger(t),
 	)
 
-	return deleteService, dbc, cleanup
+	return deleteService
 }
 
 func TestDeleteService_DeleteUser_WithCreditData(t *testing.T) {
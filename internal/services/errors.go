@@ -3,14 +3,48 @@ package services
 import "errors"
 
 var (
-	ErrAlreadyCheckedIn         = errors.New("player has already scanned in")
-	ErrCheckOutAtWrongLocation  = errors.New("team is not at the correct location to check out")
-	ErrInsufficientCredits      = errors.New("insufficient credits to start team")
-	ErrInstanceSettingsNotFound = errors.New("instance settings not found")
-	ErrLocationNotFound         = errors.New("location not found")
-	ErrPermissionDenied         = errors.New("permission denied")
-	ErrTeamNotFound             = errors.New("team not found")
-	ErrUnecessaryCheckOut       = errors.New("player does not need to scan out")
-	ErrUnfinishedCheckIn        = errors.New("unfinished check in")
-	ErrUserNotAuthenticated     = errors.New("user not authenticated")
+	ErrAPIKeyNotFound            = errors.New("api key not found")
+	ErrAlreadyCheckedIn          = errors.New("player has already scanned in")
+	ErrAlreadyMember             = errors.New("user is already a member of the organisation")
+	ErrBlockContextNotSupported  = errors.New("block cannot be used here")
+	ErrBlockHidden               = errors.New("block is hidden from the team")
+	ErrCheckOutAtWrongLocation   = errors.New("team is not at the correct location to check out")
+	ErrFacilitatorTokenNotFound  = errors.New("facilitator link not found")
+	ErrGameInTrash               = errors.New("the game is in the trash")
+	ErrImportEmpty               = errors.New("the file contains no locations")
+	ErrImportFormatUnsupported   = errors.New("unsupported file type, use a .csv, .gpx or .kml file")
+	ErrImportTooLarge            = errors.New("the file contains too many locations")
+	ErrInstanceSettingsNotFound  = errors.New("instance settings not found")
+	ErrInsufficientCredits       = errors.New("insufficient credits to start team")
+	ErrInvalidAPIKey             = errors.New("invalid api key")
+	ErrInvalidAPIScope           = errors.New("unknown api scope")
+	ErrInvalidLTILaunch          = errors.New("invalid LTI launch")
+	ErrInvalidNotificationTarget = errors.New("choose which teams to notify")
+	ErrInvalidNotificationTiming = errors.New("choose when to send the notification")
+	ErrInvalidPushSubscription   = errors.New("invalid push subscription")
+	ErrInvalidRole               = errors.New("unknown organisation role")
+	ErrInvalidTwoFactorCode      = errors.New("invalid two-factor code")
+	ErrInvalidWebhookEvent       = errors.New("unknown webhook event")
+	ErrInvalidWebhookURL         = errors.New("webhook URL must be an absolute http or https URL")
+	ErrLTIPlatformNotFound       = errors.New("LTI platform not registered")
+	ErrLastOwner                 = errors.New("an organisation needs at least one owner")
+	ErrLoginLocked               = errors.New("too many failed login attempts")
+	ErrLocationNotFound          = errors.New("location not found")
+	ErrMessageEmpty              = errors.New("message cannot be empty")
+	ErrMessageTooLong            = errors.New("message is too long")
+	ErrNoAccountForEmail         = errors.New("no account uses that email address")
+	ErrNotificationEmpty         = errors.New("notification cannot be empty")
+	ErrNotificationNotFound      = errors.New("notification not found or already sent")
+	ErrNotificationTooLong       = errors.New("notification is too long")
+	ErrOAuthEmailMissing         = errors.New("the identity provider did not share an email address")
+	ErrOAuthEmailNotVerified     = errors.New("the identity provider has not verified this email address")
+	ErrPermissionDenied          = errors.New("permission denied")
+	ErrTeamNotFound              = errors.New("team not found")
+	ErrTwoFactorEnabled          = errors.New("two-factor authentication is already turned on")
+	ErrTwoFactorNotEnabled       = errors.New("two-factor authentication is not turned on")
+	ErrTwoFactorRequired         = errors.New("an organisation requires two-factor authentication")
+	ErrUnecessaryCheckOut        = errors.New("player does not need to scan out")
+	ErrUnfinishedCheckIn         = errors.New("unfinished check in")
+	ErrUserNotAuthenticated      = errors.New("user not authenticated")
+	ErrWebhookNotFound           = errors.New("webhook not found")
 )
@@ -3,21 +3,81 @@ package services
 import (
 	"context"
 	"crypto/rand"
+	"database/sql"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/nathanhollows/Rapua/v6/blocks"
 	"github.com/nathanhollows/Rapua/v6/models"
 	"github.com/nathanhollows/Rapua/v6/repositories"
 )
 
+const (
+	// facilitatorLabelMaxLength is the most characters a link's label keeps.
+	facilitatorLabelMaxLength = 100
+	// facilitatorTouchInterval limits how often a link's last use is saved.
+	facilitatorTouchInterval = time.Minute
+)
+
 type FacilitatorService struct {
-	repo repositories.FacilitatorTokenRepo
+	repo              repositories.FacilitatorTokenRepo
+	instanceRepo      repositories.InstanceRepository
+	locationRepo      repositories.LocationRepository
+	teamRepo          repositories.TeamRepository
+	blockService      *BlockService
+	navigationService *NavigationService
+	checkInService    *CheckInService
+	messageService    *MessageService
+}
+
+func NewFacilitatorService(
+	repo repositories.FacilitatorTokenRepo,
+	instanceRepo repositories.InstanceRepository,
+	locationRepo repositories.LocationRepository,
+	teamRepo repositories.TeamRepository,
+	blockService *BlockService,
+	navigationService *NavigationService,
+	checkInService *CheckInService,
+	messageService *MessageService,
+) *FacilitatorService {
+	return &FacilitatorService{
+		repo:              repo,
+		instanceRepo:      instanceRepo,
+		locationRepo:      locationRepo,
+		teamRepo:          teamRepo,
+		blockService:      blockService,
+		navigationService: navigationService,
+		checkInService:    checkInService,
+		messageService:    messageService,
+	}
+}
+
+// FacilitatorStation is what a facilitator needs to run one location.
+type FacilitatorStation struct {
+	Location models.Location
+	// OnSite are the teams checked in here who haven't finished yet
+	OnSite []StationTeam
+	// DueNext are the teams who may check in here next
+	DueNext []StationTeam
+	// Visited counts the teams who have checked in here
+	Visited int
+	// Teams counts the teams who have started the game
+	Teams int
 }
 
-func NewFacilitatorService(repo repositories.FacilitatorTokenRepo) *FacilitatorService {
-	return &FacilitatorService{repo: repo}
+// StationTeam is a team at, or on their way to, a facilitator's location.
+type StationTeam struct {
+	Team    models.Team
+	CheckIn models.CheckIn
+	// Pending are the blocks the team must still complete before checking out
+	Pending []blocks.Block
+	// Unread counts the team's messages no organiser has read yet
+	Unread int
 }
 
 // Generate a secure random token.
@@ -32,20 +92,29 @@ func (s *FacilitatorService) generateToken() string {
 }
 
 // CreateFacilitatorToken generates and stores a facilitator access token.
+// The label helps admins tell their links apart.
 func (s *FacilitatorService) CreateFacilitatorToken(
 	ctx context.Context,
 	instanceID string,
+	label string,
 	locations []string,
 	duration time.Duration,
 ) (string, error) {
+	label = strings.TrimSpace(label)
+	if runes := []rune(label); len(runes) > facilitatorLabelMaxLength {
+		label = string(runes[:facilitatorLabelMaxLength])
+	}
+
 	token := s.generateToken()
-	expiry := time.Now().Add(duration)
+	now := time.Now().UTC()
 
 	newToken := models.FacilitatorToken{
 		Token:      token,
 		InstanceID: instanceID,
+		Label:      label,
 		Locations:  locations,
-		ExpiresAt:  expiry,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(duration),
 	}
 
 	err := s.repo.SaveToken(ctx, newToken)
@@ -55,7 +124,8 @@ func (s *FacilitatorService) CreateFacilitatorToken(
 	return token, nil
 }
 
-// ValidateToken checks if a token is valid and not expired.
+// ValidateToken checks if a token is valid and not expired. Revoked tokens
+// no longer exist, so they fail straight away.
 func (s *FacilitatorService) ValidateToken(ctx context.Context, token string) (*models.FacilitatorToken, error) {
 	facToken, err := s.repo.GetToken(ctx, token)
 	if err != nil {
@@ -63,14 +133,320 @@ func (s *FacilitatorService) ValidateToken(ctx context.Context, token string) (*
 	}
 
 	// Check expiration
-	if time.Now().After(facToken.ExpiresAt) {
+	now := time.Now().UTC()
+	if now.After(facToken.ExpiresAt) {
 		return nil, errors.New("token has expired")
 	}
 
+	if now.Sub(facToken.LastUsedAt) > facilitatorTouchInterval {
+		// Last used is informational, so a failed write shouldn't block the request
+		if s.repo.TouchLastUsed(ctx, token, now) == nil {
+			facToken.LastUsedAt = now
+		}
+	}
+
 	return facToken, nil
 }
 
+// List returns the instance's facilitator links that have not expired,
+// newest first.
+func (s *FacilitatorService) List(ctx context.Context, instanceID string) ([]models.FacilitatorToken, error) {
+	tokens, err := s.repo.FindActiveByInstanceID(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// Revoke deletes a facilitator link. Anyone using it is logged out on their
+// next request.
+func (s *FacilitatorService) Revoke(ctx context.Context, instanceID, id string) error {
+	err := s.repo.Delete(ctx, instanceID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrFacilitatorTokenNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("deleting token: %w", err)
+	}
+	return nil
+}
+
 // CleanupExpiredTokens removes all expired facilitator tokens.
 func (s *FacilitatorService) CleanupExpiredTokens(ctx context.Context) error {
 	return s.repo.CleanUpExpiredTokens(ctx)
 }
+
+// Stations returns the locations the token gives access to, with the teams
+// on site at each and the teams due there next.
+func (s *FacilitatorService) Stations(
+	ctx context.Context,
+	token *models.FacilitatorToken,
+) ([]FacilitatorStation, error) {
+	instance, err := s.instanceRepo.GetByID(ctx, token.InstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding instance: %w", err)
+	}
+	teams, err := s.teamRepo.FindAllWithScans(ctx, token.InstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding teams: %w", err)
+	}
+	unread, err := s.messageService.UnreadByTeam(ctx, token.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work out where each free team may go next
+	started := 0
+	nextLocations := make(map[string][]models.Location, len(teams))
+	for i := range teams {
+		if !teams[i].HasStarted {
+			continue
+		}
+		started++
+		if teams[i].MustCheckOut != "" {
+			continue
+		}
+		teams[i].Instance = *instance
+		locations, nextErr := s.navigationService.determineNextLocations(ctx, &teams[i])
+		if errors.Is(nextErr, ErrAllLocationsVisited) {
+			continue
+		} else if nextErr != nil {
+			return nil, fmt.Errorf("finding next locations for team %s: %w", teams[i].Code, nextErr)
+		}
+		nextLocations[teams[i].Code] = locations
+	}
+
+	locations := make([]models.Location, 0, len(instance.Locations))
+	for _, location := range instance.Locations {
+		if token.AllowsLocation(location.ID) {
+			locations = append(locations, location)
+		}
+	}
+	sort.SliceStable(locations, func(i, j int) bool {
+		return locations[i].Order < locations[j].Order
+	})
+
+	stations := make([]FacilitatorStation, 0, len(locations))
+	for _, location := range locations {
+		station := FacilitatorStation{Location: location, Teams: started}
+		for _, team := range teams {
+			checkIn, visited := checkInAt(team, location.ID)
+			if visited {
+				station.Visited++
+				if !onSite(team, checkIn) {
+					continue
+				}
+				pending, pendingErr := s.pendingBlocks(ctx, location.ID, team)
+				if pendingErr != nil {
+					return nil, pendingErr
+				}
+				station.OnSite = append(station.OnSite, StationTeam{
+					Team:    team,
+					CheckIn: checkIn,
+					Pending: pending,
+					Unread:  unread[team.Code],
+				})
+				continue
+			}
+			for _, next := range nextLocations[team.Code] {
+				if next.ID == location.ID {
+					station.DueNext = append(station.DueNext, StationTeam{Team: team, Unread: unread[team.Code]})
+					break
+				}
+			}
+		}
+		stations = append(stations, station)
+	}
+	return stations, nil
+}
+
+// CheckIn checks a team in at one of the token's locations on their behalf.
+func (s *FacilitatorService) CheckIn(
+	ctx context.Context,
+	token *models.FacilitatorToken,
+	locationID, teamCode string,
+) error {
+	location, team, err := s.stationTeam(ctx, token, locationID, teamCode)
+	if err != nil {
+		return err
+	}
+	return s.checkInService.CheckIn(ctx, team, location.MarkerID)
+}
+
+// CheckOut checks a team out of one of the token's locations on their
+// behalf. Teams with blocks left to complete can't be checked out.
+func (s *FacilitatorService) CheckOut(
+	ctx context.Context,
+	token *models.FacilitatorToken,
+	locationID, teamCode string,
+) error {
+	location, team, err := s.stationTeam(ctx, token, locationID, teamCode)
+	if err != nil {
+		return err
+	}
+	return s.checkInService.CheckOut(ctx, team, location.MarkerID)
+}
+
+// ApproveBlock completes a block for a team checked in at one of the
+// token's locations, for tasks the facilitator has seen the team do.
+func (s *FacilitatorService) ApproveBlock(
+	ctx context.Context,
+	token *models.FacilitatorToken,
+	locationID, teamCode, blockID string,
+) error {
+	location, team, err := s.stationTeam(ctx, token, locationID, teamCode)
+	if err != nil {
+		return err
+	}
+	if _, visited := checkInAt(*team, location.ID); !visited {
+		return ErrTeamNotFound
+	}
+
+	block, err := s.blockService.GetByBlockID(ctx, blockID)
+	if err != nil {
+		return fmt.Errorf("finding block: %w", err)
+	}
+	if block.GetLocationID() != location.ID {
+		return ErrPermissionDenied
+	}
+	return s.checkInService.ApproveBlock(ctx, team, blockID)
+}
+
+// Messages returns a team's conversation with the organisers, and marks
+// the team's messages as read.
+func (s *FacilitatorService) Messages(
+	ctx context.Context,
+	token *models.FacilitatorToken,
+	teamCode string,
+) (*models.Team, []models.TeamMessage, error) {
+	team, err := s.messageTeam(ctx, token, teamCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = s.messageService.MarkReadByOrganisers(ctx, team)
+	if err != nil {
+		return nil, nil, err
+	}
+	messages, err := s.messageService.Thread(ctx, team)
+	if err != nil {
+		return nil, nil, err
+	}
+	return team, messages, nil
+}
+
+// Reply sends a message to a team, signed with the link's label.
+func (s *FacilitatorService) Reply(
+	ctx context.Context,
+	token *models.FacilitatorToken,
+	teamCode, content string,
+) error {
+	team, err := s.messageTeam(ctx, token, teamCode)
+	if err != nil {
+		return err
+	}
+	author := token.Label
+	if author == "" {
+		author = "Facilitator"
+	}
+	_, err = s.messageService.Reply(ctx, team, author, content)
+	return err
+}
+
+// messageTeam finds a team the token may message. Links limited to some
+// locations only reach the teams at, or due at, those locations.
+func (s *FacilitatorService) messageTeam(
+	ctx context.Context,
+	token *models.FacilitatorToken,
+	teamCode string,
+) (*models.Team, error) {
+	team, err := s.teamRepo.GetByCode(ctx, teamCode)
+	if err != nil || team.InstanceID != token.InstanceID {
+		return nil, ErrTeamNotFound
+	}
+	if len(token.Locations) == 0 {
+		return team, nil
+	}
+
+	stations, err := s.Stations(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	isTeam := func(stationTeam StationTeam) bool {
+		return stationTeam.Team.Code == team.Code
+	}
+	for _, station := range stations {
+		if slices.ContainsFunc(station.OnSite, isTeam) || slices.ContainsFunc(station.DueNext, isTeam) {
+			return team, nil
+		}
+	}
+	return nil, ErrPermissionDenied
+}
+
+// stationTeam finds a location the token gives access to, and a team in
+// the same game.
+func (s *FacilitatorService) stationTeam(
+	ctx context.Context,
+	token *models.FacilitatorToken,
+	locationID, teamCode string,
+) (*models.Location, *models.Team, error) {
+	if !token.AllowsLocation(locationID) {
+		return nil, nil, ErrPermissionDenied
+	}
+	location, err := s.locationRepo.GetByID(ctx, locationID)
+	if err != nil || location.InstanceID != token.InstanceID {
+		return nil, nil, ErrLocationNotFound
+	}
+	team, err := s.teamRepo.GetByCode(ctx, teamCode)
+	if err != nil || team.InstanceID != token.InstanceID {
+		return nil, nil, ErrTeamNotFound
+	}
+	return location, team, nil
+}
+
+// pendingBlocks returns the blocks at a location the team must still
+// complete before they can check out.
+func (s *FacilitatorService) pendingBlocks(
+	ctx context.Context,
+	locationID string,
+	team models.Team,
+) ([]blocks.Block, error) {
+	found, states, err := s.blockService.FindByOwnerIDAndTeamCodeWithState(ctx, locationID, team.Code)
+	if err != nil {
+		return nil, fmt.Errorf("finding blocks for team %s: %w", team.Code, err)
+	}
+
+	var pending []blocks.Block
+	progress := blocks.TeamProgress{
+		TeamCode: team.Code,
+		GroupID:  currentGroupID(&team.Instance.GameStructure, team),
+		Points:   team.Points,
+	}
+	for _, block := range found {
+		if !block.RequiresValidation() || block.GetVisibility().Excludes(progress) {
+			continue
+		}
+		if state := states[block.GetID()]; state == nil || !state.IsComplete() {
+			pending = append(pending, block)
+		}
+	}
+	return pending, nil
+}
+
+// checkInAt returns the team's check in at a location, if they have one.
+func checkInAt(team models.Team, locationID string) (models.CheckIn, bool) {
+	for _, checkIn := range team.CheckIns {
+		if checkIn.LocationID == locationID {
+			return checkIn, true
+		}
+	}
+	return models.CheckIn{}, false
+}
+
+// onSite reports whether a team is still at the location they checked in
+// to: they must check out there, or have blocks there left to complete.
+func onSite(team models.Team, checkIn models.CheckIn) bool {
+	if team.MustCheckOut == checkIn.LocationID {
+		return true
+	}
+	return checkIn.TimeOut.IsZero() && !checkIn.BlocksCompleted
+}
@@ -5,27 +5,71 @@ import (
 	"testing"
 	"time"
 
+	"github.com/brianvoe/gofakeit/v7"
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/nathanhollows/Rapua/v6/blocks"
 	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
 	"github.com/nathanhollows/Rapua/v6/repositories"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
 )
 
 func setupFacilitatorService(t *testing.T) (services.FacilitatorService, func()) {
 	dbc, cleanup := setupDB(t)
 
-	repo := repositories.NewFacilitatorTokenRepo(dbc)
-	service := services.NewFacilitatorService(repo)
+	service, _ := newFacilitatorService(dbc)
 	return *service, cleanup
 }
+
+func newFacilitatorService(dbc *bun.DB) (*services.FacilitatorService, *services.BlockService) {
+	instanceRepo := repositories.NewInstanceRepository(dbc)
+	locationRepo := repositories.NewLocationRepository(dbc)
+	markerRepo := repositories.NewMarkerRepository(dbc)
+	teamRepo := repositories.NewTeamRepository(dbc)
+	blockStateRepo := repositories.NewBlockStateRepository(dbc)
+	blockRepo := repositories.NewBlockRepository(dbc, blockStateRepo)
+
+	blockService := services.NewBlockService(blockRepo, blockStateRepo)
+	gameStructureService := services.NewGameStructureService(locationRepo, instanceRepo)
+	gameStructureService.SetRelationLoader(
+		services.NewLocationService(locationRepo, markerRepo, blockRepo, services.NewMarkerService(markerRepo)),
+	)
+	navigationService := services.NewNavigationService(locationRepo, teamRepo, gameStructureService, blockService)
+	checkInService := services.NewCheckInService(
+		repositories.NewCheckInRepository(dbc),
+		locationRepo,
+		teamRepo,
+		services.NewLocationStatsService(locationRepo),
+		navigationService,
+		blockService,
+		nil,
+	)
+
+	service := services.NewFacilitatorService(
+		repositories.NewFacilitatorTokenRepo(dbc),
+		instanceRepo,
+		locationRepo,
+		teamRepo,
+		blockService,
+		navigationService,
+		checkInService,
+		services.NewMessageService(
+			repositories.NewTeamMessageRepository(dbc),
+			repositories.NewNotificationRepository(dbc),
+		),
+	)
+	return service, blockService
+}
+
 func TestFacilitatorService_CreateAndValidateToken(t *testing.T) {
 	service, cleanup := setupFacilitatorService(t)
 	defer cleanup()
 	ctx := context.Background()
 
 	// Create a new facilitator token
-	token, err := service.CreateFacilitatorToken(ctx, "game123", []string{"Park", "Tower"}, 24*time.Hour)
+	token, err := service.CreateFacilitatorToken(ctx, "game123", "", []string{"Park", "Tower"}, 24*time.Hour)
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
 
@@ -43,7 +87,7 @@ func TestFacilitatorService_ExpiredToken(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a token that expires immediately
-	token, err := service.CreateFacilitatorToken(ctx, "gameExpired", []string{"Lab"}, -1*time.Second)
+	token, err := service.CreateFacilitatorToken(ctx, "gameExpired", "", []string{"Lab"}, -1*time.Second)
 	require.NoError(t, err)
 
 	// Validate expired token
@@ -58,12 +102,12 @@ func TestFacilitatorService_CleanupExpiredTokens(t *testing.T) {
 	ctx := context.Background()
 
 	// Create expired token
-	token, err := service.CreateFacilitatorToken(ctx, "gameX", []string{"Castle"}, -24*time.Hour)
+	token, err := service.CreateFacilitatorToken(ctx, "gameX", "", []string{"Castle"}, -24*time.Hour)
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
 
 	// Create valid token
-	validToken, _ := service.CreateFacilitatorToken(ctx, "gameY", []string{"Castle"}, 24*time.Hour)
+	validToken, _ := service.CreateFacilitatorToken(ctx, "gameY", "", []string{"Castle"}, 24*time.Hour)
 
 	// Cleanup expired tokens
 	err = service.CleanupExpiredTokens(ctx)
@@ -79,3 +123,152 @@ func TestFacilitatorService_CleanupExpiredTokens(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, validTokenData)
 }
+
+func TestFacilitatorService_ListAndRevoke(t *testing.T) {
+	service, cleanup := setupFacilitatorService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	token, err := service.CreateFacilitatorToken(ctx, "game1", "  Library marshal  ", []string{"Library"}, time.Hour)
+	require.NoError(t, err)
+	_, err = service.CreateFacilitatorToken(ctx, "game1", "Expired", nil, -time.Hour)
+	require.NoError(t, err)
+	_, err = service.CreateFacilitatorToken(ctx, "game2", "Other game", nil, time.Hour)
+	require.NoError(t, err)
+
+	tokens, err := service.List(ctx, "game1")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1, "expired links and other games' links are not listed")
+	assert.Equal(t, "Library marshal", tokens[0].Label)
+	assert.NotEmpty(t, tokens[0].ID)
+	assert.True(t, tokens[0].LastUsedAt.IsZero())
+
+	_, err = service.ValidateToken(ctx, token)
+	require.NoError(t, err)
+	tokens, err = service.List(ctx, "game1")
+	require.NoError(t, err)
+	assert.False(t, tokens[0].LastUsedAt.IsZero(), "using a link records when")
+
+	err = service.Revoke(ctx, "game2", tokens[0].ID)
+	require.ErrorIs(t, err, services.ErrFacilitatorTokenNotFound, "links can only be revoked from their own game")
+	require.NoError(t, service.Revoke(ctx, "game1", tokens[0].ID))
+
+	_, err = service.ValidateToken(ctx, token)
+	require.Error(t, err, "revoked links stop working straight away")
+	tokens, err = service.List(ctx, "game1")
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestFacilitatorService_Stations(t *testing.T) {
+	dbc, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	service, blockService := newFacilitatorService(dbc)
+
+	// A game with two locations, one of which has a task to approve
+	instance := &models.Instance{ID: gofakeit.UUID(), Name: "Game", UserID: gofakeit.UUID()}
+	locations := []*models.Location{
+		{InstanceID: instance.ID, Name: "Park", MarkerID: "PARK", Order: 0},
+		{InstanceID: instance.ID, Name: "Tower", MarkerID: "TOWR", Order: 1},
+	}
+	for _, location := range locations {
+		require.NoError(t, repositories.NewLocationRepository(dbc).Create(ctx, location))
+	}
+	instance.GameStructure = models.GameStructure{
+		ID:     gofakeit.UUID(),
+		IsRoot: true,
+		SubGroups: []models.GameStructure{{
+			ID:             gofakeit.UUID(),
+			Name:           "Stations",
+			CompletionType: models.CompletionAll,
+			Routing:        models.RouteStrategyFreeRoam,
+			Navigation:     models.NavigationDisplayNames,
+			LocationIDs:    []string{locations[0].ID, locations[1].ID},
+		}},
+	}
+	require.NoError(t, repositories.NewInstanceRepository(dbc).Create(ctx, instance))
+	err := repositories.NewInstanceSettingsRepository(dbc).Create(ctx, &models.InstanceSettings{
+		InstanceID:   instance.ID,
+		MustCheckOut: true,
+	})
+	require.NoError(t, err)
+
+	block, err := blockService.NewBlockWithOwnerAndContext(
+		ctx, locations[0].ID, blocks.ContextLocationContent, "pincode",
+	)
+	require.NoError(t, err)
+	_, err = blockService.UpdateBlock(ctx, block, map[string][]string{
+		"prompt":  {"Ask the marshal"},
+		"pincode": {"1234"},
+		"points":  {"5"},
+	})
+	require.NoError(t, err)
+
+	teams := []models.Team{
+		{ID: gofakeit.UUID(), Code: "AAAA", Name: "Alpha", InstanceID: instance.ID, HasStarted: true},
+		{ID: gofakeit.UUID(), Code: "BBBB", Name: "Bravo", InstanceID: instance.ID, HasStarted: true},
+		{ID: gofakeit.UUID(), Code: "CCCC", Name: "Charlie", InstanceID: instance.ID},
+	}
+	require.NoError(t, repositories.NewTeamRepository(dbc).InsertBatch(ctx, teams))
+
+	// The token only covers the park
+	token := &models.FacilitatorToken{InstanceID: instance.ID, Locations: models.StrArray{locations[0].ID}}
+
+	teamCodes := func(teams []services.StationTeam) []string {
+		codes := make([]string, 0, len(teams))
+		for _, team := range teams {
+			codes = append(codes, team.Team.Code)
+		}
+		return codes
+	}
+
+	stations, err := service.Stations(ctx, token)
+	require.NoError(t, err)
+	require.Len(t, stations, 1)
+	assert.Equal(t, locations[0].ID, stations[0].Location.ID)
+	assert.Equal(t, 2, stations[0].Teams)
+	assert.Empty(t, stations[0].OnSite)
+	assert.ElementsMatch(t, []string{"AAAA", "BBBB"}, teamCodes(stations[0].DueNext))
+
+	err = service.CheckIn(ctx, token, locations[1].ID, "AAAA")
+	require.ErrorIs(t, err, services.ErrPermissionDenied)
+
+	require.NoError(t, service.CheckIn(ctx, token, locations[0].ID, "AAAA"))
+	stations, err = service.Stations(ctx, token)
+	require.NoError(t, err)
+	require.Len(t, stations[0].OnSite, 1)
+	assert.Equal(t, "AAAA", stations[0].OnSite[0].Team.Code)
+	require.Len(t, stations[0].OnSite[0].Pending, 1)
+	assert.Equal(t, block.GetID(), stations[0].OnSite[0].Pending[0].GetID())
+	assert.Equal(t, []string{"BBBB"}, teamCodes(stations[0].DueNext))
+
+	err = service.CheckOut(ctx, token, locations[0].ID, "AAAA")
+	require.ErrorIs(t, err, services.ErrUnfinishedCheckIn)
+
+	err = service.ApproveBlock(ctx, token, locations[0].ID, "BBBB", block.GetID())
+	require.ErrorIs(t, err, services.ErrTeamNotFound, "teams must be checked in to have tasks approved")
+	require.NoError(t, service.ApproveBlock(ctx, token, locations[0].ID, "AAAA", block.GetID()))
+	require.NoError(t, service.CheckOut(ctx, token, locations[0].ID, "AAAA"))
+
+	stations, err = service.Stations(ctx, token)
+	require.NoError(t, err)
+	assert.Empty(t, stations[0].OnSite)
+	assert.Equal(t, 1, stations[0].Visited)
+
+	team, err := repositories.NewTeamRepository(dbc).GetByCode(ctx, "AAAA")
+	require.NoError(t, err)
+	assert.Equal(t, 5, team.Points)
+	assert.Empty(t, team.MustCheckOut)
+
+	// Messages only reach the teams at, or due at, the token's locations
+	token.Label = "Park marshal"
+	require.NoError(t, service.Reply(ctx, token, "BBBB", "Come to the park"))
+	_, messages, err := service.Messages(ctx, token, "BBBB")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Park marshal", messages[0].Author)
+
+	err = service.Reply(ctx, token, "CCCC", "Hello")
+	require.ErrorIs(t, err, services.ErrPermissionDenied)
+}
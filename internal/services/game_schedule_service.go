@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/nathanhollows/Rapua/v6/models"
@@ -21,11 +22,16 @@ var (
 
 type GameScheduleService struct {
 	instanceRepo repositories.InstanceRepository
+	webhooks     WebhookDispatcher
 }
 
-func NewGameScheduleService(instanceRepo repositories.InstanceRepository) *GameScheduleService {
+func NewGameScheduleService(
+	instanceRepo repositories.InstanceRepository,
+	webhooks WebhookDispatcher,
+) *GameScheduleService {
 	return &GameScheduleService{
 		instanceRepo: instanceRepo,
+		webhooks:     webhooks,
 	}
 }
 
@@ -51,6 +57,7 @@ func (s *GameScheduleService) SetStartTime(ctx context.Context, instance *models
 		return fmt.Errorf("failed to update game start time: %w", err)
 	}
 
+	s.scheduleWebhooks(ctx, instance)
 	return nil
 }
 
@@ -68,6 +75,7 @@ func (s *GameScheduleService) SetEndTime(ctx context.Context, instance *models.I
 		return fmt.Errorf("failed to update game end time: %w", err)
 	}
 
+	s.scheduleWebhooks(ctx, instance)
 	return nil
 }
 
@@ -88,5 +96,25 @@ func (s *GameScheduleService) ScheduleGame(
 		return fmt.Errorf("failed to schedule game: %w", err)
 	}
 
+	s.scheduleWebhooks(ctx, instance)
 	return nil
 }
+
+// scheduleWebhooks queues game.started and game.ended for the game's start
+// and end times, replacing any queued for earlier times. Webhooks never stop
+// a game being scheduled, so failures are only logged.
+func (s *GameScheduleService) scheduleWebhooks(ctx context.Context, instance *models.Instance) {
+	if s.webhooks == nil {
+		return
+	}
+	data := webhookEventData{Game: newWebhookGame(instance)}
+	schedule := map[models.WebhookEvent]time.Time{
+		models.EventGameStarted: instance.StartTime.Time,
+		models.EventGameEnded:   instance.EndTime.Time,
+	}
+	for event, at := range schedule {
+		if err := s.webhooks.Schedule(ctx, instance.ID, event, at, data); err != nil {
+			slog.ErrorContext(ctx, "scheduling webhook", "event", event, "instance_id", instance.ID, "error", err)
+		}
+	}
+}
@@ -19,7 +19,7 @@ func setupGameScheduleService(t *testing.T) (*services.GameScheduleService, func
 
 	instanceRepo := repositories.NewInstanceRepository(dbc)
 
-	gameScheduleService := services.NewGameScheduleService(instanceRepo)
+	gameScheduleService := services.NewGameScheduleService(instanceRepo, nil)
 
 	return gameScheduleService, cleanup
 }
@@ -462,6 +462,30 @@ func (s *GameStructureService) InsertLocationIntoGroup(
 	return s.Save(ctx, instanceID, &instance.GameStructure)
 }
 
+// AppendLocationsToGroups adds locations to the end of groups, keyed by
+// group ID, and saves the structure once. Locations for a group that doesn't
+// exist are added to the root.
+func (s *GameStructureService) AppendLocationsToGroups(
+	ctx context.Context,
+	instanceID string,
+	groupLocationIDs map[string][]string,
+) error {
+	instance, err := s.instanceRepo.GetByID(ctx, instanceID)
+	if err != nil {
+		return fmt.Errorf("loading instance: %w", err)
+	}
+
+	for groupID, locationIDs := range groupLocationIDs {
+		target := s.FindGroupByID(&instance.GameStructure, groupID)
+		if target == nil {
+			target = &instance.GameStructure
+		}
+		target.LocationIDs = append(target.LocationIDs, locationIDs...)
+	}
+
+	return s.Save(ctx, instanceID, &instance.GameStructure)
+}
+
 // GetNextItemType returns what type of item should be next (placeholder implementation).
 func (s *GameStructureService) GetNextItemType(
 	_ *models.GameStructure,
@@ -34,6 +34,8 @@ type IdentityService interface {
 	GetAuthenticatedUser(r *http.Request) (*models.User, error)
 	IsUserAuthenticated(r *http.Request) bool
 	AllowGoogleLogin() bool
+	// OIDCProviders returns the OpenID Connect providers to offer on the login page
+	OIDCProviders() []sessions.OIDCProvider
 	OAuthLogin(ctx context.Context, provider string, user goth.User) (*models.User, error)
 	CheckUserRegisteredWithOAuth(ctx context.Context, provider, userID string) (*models.User, error)
 	CreateUserWithOAuth(ctx context.Context, user goth.User) (*models.User, error)
@@ -43,14 +45,19 @@ type IdentityService interface {
 }
 
 type AuthService struct {
-	userRepository repositories.UserRepository
-	emailService   EmailService
+	userRepository      repositories.UserRepository
+	adminSessionService *AdminSessionService
+	emailService        EmailService
 }
 
-func NewAuthService(userRepository repositories.UserRepository) IdentityService {
+func NewAuthService(
+	userRepository repositories.UserRepository,
+	adminSessionService *AdminSessionService,
+) IdentityService {
 	return &AuthService{
-		userRepository: userRepository,
-		emailService:   *NewEmailService(),
+		userRepository:      userRepository,
+		adminSessionService: adminSessionService,
+		emailService:        *NewEmailService(),
 	}
 }
 
@@ -79,11 +86,16 @@ func (s *AuthService) IsUserAuthenticated(r *http.Request) bool {
 		return false
 	}
 
-	_, ok := session.Values["user_id"].(string)
-	return ok
+	userID, ok := session.Values["user_id"].(string)
+	if !ok || userID == "" {
+		return false
+	}
+	sessionID, _ := session.Values["session_id"].(string)
+	return s.adminSessionService.Authenticate(r.Context(), sessionID, userID) == nil
 }
 
 // GetAuthenticatedUser retrieves the authenticated user from the session.
+// The session must not have been revoked.
 func (s *AuthService) GetAuthenticatedUser(r *http.Request) (*models.User, error) {
 	session, err := sessions.Get(r, "admin")
 	if err != nil {
@@ -94,6 +106,11 @@ func (s *AuthService) GetAuthenticatedUser(r *http.Request) (*models.User, error
 	if !ok || userID == "" {
 		return nil, errors.New("user not authenticated")
 	}
+	sessionID, _ := session.Values["session_id"].(string)
+	err = s.adminSessionService.Authenticate(r.Context(), sessionID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not authenticated: %w", err)
+	}
 
 	user, err := s.userRepository.GetByID(r.Context(), userID)
 	if err != nil {
@@ -109,8 +126,19 @@ func (s *AuthService) AllowGoogleLogin() bool {
 	return err == nil && provider != nil
 }
 
-// OAuthLogin handles User Login via OAuth.
+// OIDCProviders returns the OpenID Connect providers to offer on the login page.
+func (s *AuthService) OIDCProviders() []sessions.OIDCProvider {
+	return sessions.OIDCProviders()
+}
+
+// OAuthLogin handles User Login via OAuth. An existing account is only
+// signed in when the provider vouches for the email address, so that an
+// identity provider cannot be used to take over someone else's account.
 func (s *AuthService) OAuthLogin(ctx context.Context, _ string, oauthUser goth.User) (*models.User, error) {
+	if oauthUser.Email == "" {
+		return nil, ErrOAuthEmailMissing
+	}
+
 	existingUser, err := s.userRepository.GetByEmail(ctx, oauthUser.Email)
 	if err != nil {
 		// User doesn't exist, create a new one
@@ -122,9 +150,43 @@ func (s *AuthService) OAuthLogin(ctx context.Context, _ string, oauthUser goth.U
 		return newUser, nil
 	}
 
+	if !oauthEmailVerified(oauthUser) {
+		return nil, ErrOAuthEmailNotVerified
+	}
+	if !existingUser.EmailVerified {
+		// The provider has now proved the address
+		existingUser.EmailVerified = true
+		if err = s.userRepository.Update(ctx, existingUser); err != nil {
+			return nil, fmt.Errorf("verifying email: %w", err)
+		}
+	}
+
 	return existingUser, nil
 }
 
+// oauthEmailVerified reports whether the provider has verified the user's
+// email address. Google only shares verified addresses. OIDC providers say
+// so with the email_verified claim, unless they are configured as trusted.
+func oauthEmailVerified(user goth.User) bool {
+	if user.Provider == "google" {
+		return true
+	}
+	provider, ok := sessions.GetOIDCProvider(user.Provider)
+	if !ok {
+		return false
+	}
+	if provider.TrustEmail {
+		return true
+	}
+	switch verified := user.RawData["email_verified"].(type) {
+	case bool:
+		return verified
+	case string:
+		return verified == "true"
+	}
+	return false
+}
+
 // CheckUserRegisteredWithOAuth looks for user already registered with OAuth.
 func (s *AuthService) CheckUserRegisteredWithOAuth(ctx context.Context, provider, email string) (*models.User, error) {
 	user, err := s.userRepository.GetByEmailAndProvider(ctx, email, provider)
@@ -144,7 +206,10 @@ func (s *AuthService) CreateUserWithOAuth(ctx context.Context, user goth.User) (
 	case "email":
 		provider = models.ProviderEmail
 	default:
-		return nil, fmt.Errorf("unsupported provider: %s", user.Provider)
+		if _, ok := sessions.GetOIDCProvider(user.Provider); !ok {
+			return nil, fmt.Errorf("unsupported provider: %s", user.Provider)
+		}
+		provider = models.Provider(user.Provider)
 	}
 
 	uuid := uuid.New()
@@ -155,6 +220,9 @@ func (s *AuthService) CreateUserWithOAuth(ctx context.Context, user goth.User) (
 		Password: "",
 		Provider: provider,
 	}
+	if provider != models.ProviderGoogle && provider != models.ProviderEmail {
+		newUser.EmailVerified = oauthEmailVerified(user)
+	}
 
 	err := s.userRepository.Create(ctx, &newUser)
 	if err != nil {
@@ -26,7 +26,12 @@ func setupIdentityService(t *testing.T) (services.IdentityService, repositories.
 	dbc, cleanup := setupDB(t)
 
 	userRepo := repositories.NewUserRepository(dbc)
-	identityService := services.NewAuthService(userRepo)
+	adminSessionService := services.NewAdminSessionService(
+		repositories.NewAdminSessionRepository(dbc),
+		repositories.NewLoginAttemptRepository(dbc),
+		userRepo,
+	)
+	identityService := services.NewAuthService(userRepo, adminSessionService)
 
 	return identityService, userRepo, cleanup
 }
@@ -429,6 +434,67 @@ func TestIdentityService_OAuthLogin(t *testing.T) {
 	})
 }
 
+func TestIdentityService_OAuthLogin_OIDC(t *testing.T) {
+	service, userRepo, cleanup := setupIdentityService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	sessions.AddOIDCProvider(sessions.OIDCProvider{Name: "campus-oidc", Label: "Campus"})
+	sessions.AddOIDCProvider(sessions.OIDCProvider{Name: "trusted-oidc", Label: "Trusted", TrustEmail: true})
+
+	oidcUser := func(provider, email string, raw map[string]any) goth.User {
+		return goth.User{Provider: provider, Name: gofakeit.Name(), Email: email, RawData: raw}
+	}
+
+	t.Run("Links an existing account by verified email", func(t *testing.T) {
+		existing := createTestUser(t, userRepo, gofakeit.Email(), "password123")
+
+		user, err := service.OAuthLogin(ctx, "campus-oidc",
+			oidcUser("campus-oidc", existing.Email, map[string]any{"email_verified": true}))
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, user.ID)
+		assert.True(t, user.EmailVerified)
+	})
+
+	t.Run("Refuses an existing account without a verified email", func(t *testing.T) {
+		existing := createTestUser(t, userRepo, gofakeit.Email(), "password123")
+
+		user, err := service.OAuthLogin(ctx, "campus-oidc", oidcUser("campus-oidc", existing.Email, nil))
+		require.ErrorIs(t, err, services.ErrOAuthEmailNotVerified)
+		assert.Nil(t, user)
+
+		user, err = service.OAuthLogin(ctx, "campus-oidc",
+			oidcUser("campus-oidc", existing.Email, map[string]any{"email_verified": false}))
+		require.ErrorIs(t, err, services.ErrOAuthEmailNotVerified)
+		assert.Nil(t, user)
+	})
+
+	t.Run("Trusted provider links without the claim", func(t *testing.T) {
+		existing := createTestUser(t, userRepo, gofakeit.Email(), "password123")
+
+		user, err := service.OAuthLogin(ctx, "trusted-oidc", oidcUser("trusted-oidc", existing.Email, nil))
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, user.ID)
+	})
+
+	t.Run("Creates a new account", func(t *testing.T) {
+		email := gofakeit.Email()
+
+		user, err := service.OAuthLogin(ctx, "campus-oidc",
+			oidcUser("campus-oidc", email, map[string]any{"email_verified": "true"}))
+		require.NoError(t, err)
+		assert.Equal(t, email, user.Email)
+		assert.Equal(t, models.Provider("campus-oidc"), user.Provider)
+		assert.True(t, user.EmailVerified)
+	})
+
+	t.Run("Requires an email", func(t *testing.T) {
+		user, err := service.OAuthLogin(ctx, "campus-oidc", oidcUser("campus-oidc", "", nil))
+		require.ErrorIs(t, err, services.ErrOAuthEmailMissing)
+		assert.Nil(t, user)
+	})
+}
+
 func TestIdentityService_CheckUserRegisteredWithOAuth(t *testing.T) {
 	service, userRepo, cleanup := setupIdentityService(t)
 	defer cleanup()
@@ -107,6 +107,16 @@ func (s *InstanceService) FindByUserID(ctx context.Context, userID string) ([]mo
 	return instances, nil
 }
 
+// FindSharedWithUser returns the games other users share with the user
+// through their organisations.
+func (s *InstanceService) FindSharedWithUser(ctx context.Context, userID string) ([]models.Instance, error) {
+	instances, err := s.instanceRepo.FindSharedWithUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding shared instances: %w", err)
+	}
+	return instances, nil
+}
+
 // FindInstanceIDsForUser implements InstanceService.
 func (s *InstanceService) FindInstanceIDsForUser(ctx context.Context, userID string) ([]string, error) {
 	instances, err := s.instanceRepo.FindByUserID(ctx, userID)
@@ -80,7 +80,7 @@ func (s *LeaderBoardService) convertTeamToLeaderBoardData(team models.Team, loca
 		Points:       team.Points,
 		LastSeen:     lastSeen,
 		Progress:     checkInCount,
-		Status:       s.determineTeamStatus(team, locationCount),
+		Status:       determineTeamStatus(team, locationCount),
 		HasStarted:   team.HasStarted,
 		MustCheckOut: team.MustCheckOut,
 		CheckInCount: checkInCount,
@@ -88,7 +88,7 @@ func (s *LeaderBoardService) convertTeamToLeaderBoardData(team models.Team, loca
 }
 
 // determineTeamStatus determines the current status of a team.
-func (s *LeaderBoardService) determineTeamStatus(team models.Team, locationCount int) TeamStatus {
+func determineTeamStatus(team models.Team, locationCount int) TeamStatus {
 	if team.MustCheckOut != "" {
 		return StatusOnsite
 	}
@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nathanhollows/Rapua/v6/models"
+)
+
+// MaxLocationImportRows is the most locations a single file may create.
+const MaxLocationImportRows = 500
+
+// LocationImportRow is a single location read from an import file.
+type LocationImportRow struct {
+	Line   int // Row, waypoint or placemark number in the file
+	Name   string
+	Lat    float64
+	Lng    float64
+	Points int
+	Group  string // Group name given in the file, if any
+
+	// Set by Preview
+	GroupID   string
+	GroupName string
+	Warning   string // Problems that do not stop the row being imported
+	Err       error  // Problems that do stop the row being imported
+}
+
+// LocationImportService creates locations in bulk from CSV, GPX and KML files.
+type LocationImportService struct {
+	locationService      LocationService
+	gameStructureService *GameStructureService
+	deleteService        *DeleteService
+}
+
+// NewLocationImportService creates a new LocationImportService.
+func NewLocationImportService(
+	locationService LocationService,
+	gameStructureService *GameStructureService,
+	deleteService *DeleteService,
+) *LocationImportService {
+	return &LocationImportService{
+		locationService:      locationService,
+		gameStructureService: gameStructureService,
+		deleteService:        deleteService,
+	}
+}
+
+// Preview reads the file and works out what would be created without saving
+// anything. Rows are placed into the group named in the file when it matches
+// one of the game's groups, and into groupID otherwise. defaultPoints is used
+// for rows that do not set their own points.
+func (s *LocationImportService) Preview(
+	instance *models.Instance,
+	filename string,
+	file io.Reader,
+	groupID string,
+	defaultPoints int,
+) ([]LocationImportRow, error) {
+	rows, err := parseLocationImport(filename, file, defaultPoints)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrImportEmpty
+	}
+	if len(rows) > MaxLocationImportRows {
+		return nil, fmt.Errorf("%w, the limit is %d", ErrImportTooLarge, MaxLocationImportRows)
+	}
+
+	target := s.gameStructureService.FindGroupByID(&instance.GameStructure, groupID)
+	if target == nil {
+		target = &instance.GameStructure
+	}
+
+	groups := make(map[string]*models.GameStructure)
+	s.indexGroups(&instance.GameStructure, groups)
+
+	for i := range rows {
+		row := &rows[i]
+		row.GroupID, row.GroupName = target.ID, target.Name
+		if row.Group != "" {
+			if group, ok := groups[strings.ToLower(row.Group)]; ok {
+				row.GroupID, row.GroupName = group.ID, group.Name
+			} else {
+				row.Warning = fmt.Sprintf("No group named %q", row.Group)
+			}
+		}
+		if row.Err == nil {
+			row.Err = checkLocationData(instance.ID, row.Name, row.Lat, row.Lng)
+		}
+	}
+	return rows, nil
+}
+
+// Import creates a location and marker for each row without an error and
+// places it at the end of its group. It returns the number of locations
+// created. The import is all or nothing: if any location can't be created,
+// those already created are removed.
+func (s *LocationImportService) Import(
+	ctx context.Context,
+	instanceID string,
+	rows []LocationImportRow,
+) (int, error) {
+	var created []models.Location
+	groupLocationIDs := make(map[string][]string)
+	for _, row := range rows {
+		if row.Err != nil {
+			continue
+		}
+		location, err := s.locationService.CreateLocation(ctx, instanceID, row.Name, row.Lat, row.Lng, row.Points)
+		if err != nil {
+			return 0, s.discard(ctx, created, fmt.Errorf("creating location %q: %w", row.Name, err))
+		}
+		created = append(created, location)
+		groupLocationIDs[row.GroupID] = append(groupLocationIDs[row.GroupID], location.ID)
+	}
+
+	err := s.gameStructureService.AppendLocationsToGroups(ctx, instanceID, groupLocationIDs)
+	if err != nil {
+		return 0, s.discard(ctx, created, fmt.Errorf("adding locations to groups: %w", err))
+	}
+	return len(created), nil
+}
+
+// discard removes the locations created by a failed import and returns the
+// error that stopped it.
+func (s *LocationImportService) discard(ctx context.Context, created []models.Location, err error) error {
+	// Clean up even if the request has been cancelled
+	discardErr := s.deleteService.DiscardLocations(context.WithoutCancel(ctx), created)
+	if discardErr != nil {
+		return fmt.Errorf("%w; removing imported locations: %w", err, discardErr)
+	}
+	return err
+}
+
+// indexGroups maps the lowercase names of the visible groups to the groups.
+func (s *LocationImportService) indexGroups(group *models.GameStructure, groups map[string]*models.GameStructure) {
+	if !group.IsRoot && group.Name != "" {
+		key := strings.ToLower(strings.TrimSpace(group.Name))
+		if _, exists := groups[key]; !exists {
+			groups[key] = group
+		}
+	}
+	for i := range group.SubGroups {
+		s.indexGroups(&group.SubGroups[i], groups)
+	}
+}
+
+// parseLocationImport reads the rows from a file based on its extension.
+func parseLocationImport(filename string, file io.Reader, defaultPoints int) ([]LocationImportRow, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return parseLocationCSV(file, defaultPoints)
+	case ".gpx":
+		return parseLocationGPX(file, defaultPoints)
+	case ".kml":
+		return parseLocationKML(file, defaultPoints)
+	default:
+		return nil, ErrImportFormatUnsupported
+	}
+}
+
+// parseLocationCSV reads rows of name, lat, lng, points and group. A header
+// row may name the columns in any order; without one the columns are read in
+// that order.
+func parseLocationCSV(file io.Reader, defaultPoints int) ([]LocationImportRow, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{"name": 0, "lat": 1, "lng": 2, "points": 3, "group": 4}
+	start := 0
+	if header, ok := csvHeader(records[0]); ok {
+		columns = header
+		start = 1
+	}
+
+	rows := make([]LocationImportRow, 0, len(records)-start)
+	for i, record := range records[start:] {
+		field := func(column string) string {
+			idx, ok := columns[column]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		row := LocationImportRow{
+			Line:   start + i + 1,
+			Name:   field("name"),
+			Points: defaultPoints,
+			Group:  field("group"),
+		}
+		lat, lng := field("lat"), field("lng")
+		if lat != "" || lng != "" {
+			row.Lat, row.Lng, row.Err = parseCoords(lat, lng)
+		}
+		if points := field("points"); points != "" && row.Err == nil {
+			row.Points, err = strconv.Atoi(points)
+			if err != nil {
+				row.Err = fmt.Errorf("points must be a whole number, got: %q", points)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// csvHeader maps column names to their positions if the record is a header.
+func csvHeader(record []string) (map[string]int, bool) {
+	aliases := map[string]string{
+		"name":      "name",
+		"title":     "name",
+		"lat":       "lat",
+		"latitude":  "lat",
+		"lng":       "lng",
+		"lon":       "lng",
+		"long":      "lng",
+		"longitude": "lng",
+		"points":    "points",
+		"group":     "group",
+	}
+	columns := make(map[string]int)
+	for i, cell := range record {
+		// Spreadsheets often save CSV files with a byte order mark
+		cell = strings.TrimPrefix(cell, "\ufeff")
+		column, ok := aliases[strings.ToLower(strings.TrimSpace(cell))]
+		if ok {
+			if _, seen := columns[column]; !seen {
+				columns[column] = i
+			}
+		}
+	}
+	_, hasName := columns["name"]
+	return columns, hasName
+}
+
+func parseCoords(lat, lng string) (float64, float64, error) {
+	latitude, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("latitude must be a number, got: %q", lat)
+	}
+	longitude, err := strconv.ParseFloat(lng, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("longitude must be a number, got: %q", lng)
+	}
+	return latitude, longitude, nil
+}
+
+type gpxFile struct {
+	Waypoints []struct {
+		Lat  float64 `xml:"lat,attr"`
+		Lon  float64 `xml:"lon,attr"`
+		Name string  `xml:"name"`
+		Type string  `xml:"type"`
+	} `xml:"wpt"`
+}
+
+// parseLocationGPX reads a row for each waypoint. The waypoint type is used
+// as the group name.
+func parseLocationGPX(file io.Reader, defaultPoints int) ([]LocationImportRow, error) {
+	var gpx gpxFile
+	err := xml.NewDecoder(file).Decode(&gpx)
+	if err != nil {
+		return nil, fmt.Errorf("reading GPX: %w", err)
+	}
+
+	rows := make([]LocationImportRow, len(gpx.Waypoints))
+	for i, wpt := range gpx.Waypoints {
+		rows[i] = LocationImportRow{
+			Line:   i + 1,
+			Name:   strings.TrimSpace(wpt.Name),
+			Lat:    wpt.Lat,
+			Lng:    wpt.Lon,
+			Points: defaultPoints,
+			Group:  strings.TrimSpace(wpt.Type),
+		}
+	}
+	return rows, nil
+}
+
+type kmlContainer struct {
+	Name       string         `xml:"name"`
+	Documents  []kmlContainer `xml:"Document"`
+	Folders    []kmlContainer `xml:"Folder"`
+	Placemarks []struct {
+		Name  string `xml:"name"`
+		Point *struct {
+			Coordinates string `xml:"coordinates"`
+		} `xml:"Point"`
+	} `xml:"Placemark"`
+}
+
+// parseLocationKML reads a row for each placemark. The name of the folder
+// holding a placemark is used as the group name.
+func parseLocationKML(file io.Reader, defaultPoints int) ([]LocationImportRow, error) {
+	var kml kmlContainer
+	err := xml.NewDecoder(file).Decode(&kml)
+	if err != nil {
+		return nil, fmt.Errorf("reading KML: %w", err)
+	}
+
+	var rows []LocationImportRow
+	var walk func(container kmlContainer, group string)
+	walk = func(container kmlContainer, group string) {
+		for _, placemark := range container.Placemarks {
+			row := LocationImportRow{
+				Line:   len(rows) + 1,
+				Name:   strings.TrimSpace(placemark.Name),
+				Points: defaultPoints,
+				Group:  group,
+			}
+			if placemark.Point == nil {
+				row.Err = errors.New("only point placemarks can be imported")
+			} else {
+				// KML coordinates are longitude,latitude[,altitude]
+				parts := strings.Split(strings.TrimSpace(placemark.Point.Coordinates), ",")
+				if len(parts) < 2 {
+					row.Err = fmt.Errorf("invalid coordinates: %q", placemark.Point.Coordinates)
+				} else {
+					row.Lat, row.Lng, row.Err = parseCoords(strings.TrimSpace(parts[1]), strings.TrimSpace(parts[0]))
+				}
+			}
+			rows = append(rows, row)
+		}
+		for _, document := range container.Documents {
+			walk(document, group)
+		}
+		for _, folder := range container.Folders {
+			walk(folder, strings.TrimSpace(folder.Name))
+		}
+	}
+	walk(kml, "")
+	return rows, nil
+}
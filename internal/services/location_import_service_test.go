@@ -0,0 +1,182 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func setupLocationImportService(
+	t *testing.T,
+) (*services.LocationImportService, *models.Instance, *bun.DB, func()) {
+	t.Helper()
+	dbc, cleanup := setupDB(t)
+
+	instanceRepo := repositories.NewInstanceRepository(dbc)
+	locationRepo := repositories.NewLocationRepository(dbc)
+	markerRepo := repositories.NewMarkerRepository(dbc)
+	blockStateRepo := repositories.NewBlockStateRepository(dbc)
+	blockRepo := repositories.NewBlockRepository(dbc, blockStateRepo)
+
+	markerService := services.NewMarkerService(markerRepo)
+	locationService := services.NewLocationService(locationRepo, markerRepo, blockRepo, markerService)
+	gameStructureService := services.NewGameStructureService(locationRepo, instanceRepo)
+
+	instance := &models.Instance{
+		ID:     gofakeit.UUID(),
+		UserID: gofakeit.UUID(),
+		Name:   "Campus Tour",
+		GameStructure: models.GameStructure{
+			ID:     "root",
+			IsRoot: true,
+			SubGroups: []models.GameStructure{
+				{ID: "museum", Name: "Museum", Color: "primary"},
+				{ID: "library", Name: "Library", Color: "secondary"},
+			},
+		},
+	}
+	_, err := dbc.NewInsert().Model(instance).Exec(context.Background())
+	require.NoError(t, err)
+
+	service := services.NewLocationImportService(locationService, gameStructureService, newDeleteService(t, dbc))
+	return service, instance, dbc, cleanup
+}
+
+func TestLocationImportService_PreviewCSV(t *testing.T) {
+	service, instance, _, cleanup := setupLocationImportService(t)
+	defer cleanup()
+
+	file := "\ufeffLatitude,Longitude,Name,Group,Points\n" +
+		"-45.8665,170.5146,Clocktower,museum,20\n" +
+		"-45.8670,170.5150,Central Library,Stadium,\n" +
+		"not-a-number,170.5,Broken,,\n" +
+		"-45.8,170.5,,,\n"
+	rows, err := service.Preview(instance, "locations.CSV", strings.NewReader(file), "library", 10)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "Clocktower", rows[0].Name)
+	assert.InDelta(t, -45.8665, rows[0].Lat, 0.00001)
+	assert.InDelta(t, 170.5146, rows[0].Lng, 0.00001)
+	assert.Equal(t, 20, rows[0].Points)
+	assert.Equal(t, "museum", rows[0].GroupID, "Group names match regardless of case")
+	require.NoError(t, rows[0].Err)
+
+	assert.Equal(t, 10, rows[1].Points)
+	assert.Equal(t, "library", rows[1].GroupID, "Unknown groups fall back to the chosen group")
+	assert.NotEmpty(t, rows[1].Warning)
+	require.NoError(t, rows[1].Err)
+
+	require.Error(t, rows[2].Err, "Coordinates must be numbers")
+	require.Error(t, rows[3].Err, "Locations need a name")
+}
+
+func TestLocationImportService_PreviewGPXAndKML(t *testing.T) {
+	service, instance, _, cleanup := setupLocationImportService(t)
+	defer cleanup()
+
+	gpx := `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+	<wpt lat="-45.8665" lon="170.5146"><name>Clocktower</name><type>Museum</type></wpt>
+	<wpt lat="-45.8670" lon="170.5150"><name>Botanic Garden</name></wpt>
+</gpx>`
+	rows, err := service.Preview(instance, "walk.gpx", strings.NewReader(gpx), "", 5)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Clocktower", rows[0].Name)
+	assert.Equal(t, "museum", rows[0].GroupID)
+	assert.InDelta(t, 170.5146, rows[0].Lng, 0.00001)
+	assert.Equal(t, "root", rows[1].GroupID)
+	assert.Equal(t, 5, rows[1].Points)
+
+	kml := `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+	<Document>
+		<name>Campus</name>
+		<Placemark><name>Gate</name><Point><coordinates>170.51,-45.86,0</coordinates></Point></Placemark>
+		<Folder>
+			<name>Library</name>
+			<Placemark><name>Reading Room</name><Point><coordinates> 170.52,-45.87 </coordinates></Point></Placemark>
+			<Placemark><name>Path</name><LineString><coordinates>170.5,-45.8 170.6,-45.9</coordinates></LineString></Placemark>
+		</Folder>
+	</Document>
+</kml>`
+	rows, err = service.Preview(instance, "campus.kml", strings.NewReader(kml), "", 0)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "Gate", rows[0].Name)
+	assert.InDelta(t, -45.86, rows[0].Lat, 0.00001)
+	assert.InDelta(t, 170.51, rows[0].Lng, 0.00001)
+	assert.Equal(t, "root", rows[0].GroupID)
+	assert.Equal(t, "Reading Room", rows[1].Name)
+	assert.Equal(t, "library", rows[1].GroupID)
+	require.Error(t, rows[2].Err, "Only points can be imported")
+
+	_, err = service.Preview(instance, "campus.xlsx", strings.NewReader(kml), "", 0)
+	require.ErrorIs(t, err, services.ErrImportFormatUnsupported)
+	_, err = service.Preview(instance, "empty.csv", strings.NewReader(""), "", 0)
+	require.ErrorIs(t, err, services.ErrImportEmpty)
+}
+
+func TestLocationImportService_Import(t *testing.T) {
+	service, instance, dbc, cleanup := setupLocationImportService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	file := "Clocktower,-45.8665,170.5146,20,Museum\n" +
+		"Central Library,-45.8670,170.5150\n" +
+		"Nowhere,95,170.5\n"
+	rows, err := service.Preview(instance, "locations.csv", strings.NewReader(file), "library", 10)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Error(t, rows[2].Err, "Latitude is out of range")
+
+	created, err := service.Import(ctx, instance.ID, rows)
+	require.NoError(t, err)
+	assert.Equal(t, 2, created)
+
+	updated, err := repositories.NewInstanceRepository(dbc).GetByID(ctx, instance.ID)
+	require.NoError(t, err)
+	require.Len(t, updated.GameStructure.SubGroups, 2)
+	assert.Len(t, updated.GameStructure.SubGroups[0].LocationIDs, 1)
+	assert.Len(t, updated.GameStructure.SubGroups[1].LocationIDs, 1)
+	assert.Empty(t, updated.GameStructure.LocationIDs)
+}
+
+func TestLocationImportService_ImportRollsBack(t *testing.T) {
+	service, instance, dbc, cleanup := setupLocationImportService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	rows, err := service.Preview(instance, "locations.csv", strings.NewReader(
+		"Clocktower,-45.8665,170.5146\nCentral Library,-45.8670,170.5150\n",
+	), "museum", 10)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	// The second row can't be saved, as if it changed after the preview
+	rows[1].Lat = 95
+
+	created, err := service.Import(ctx, instance.ID, rows)
+	require.Error(t, err)
+	assert.Zero(t, created)
+
+	locations, err := repositories.NewLocationRepository(dbc).FindByInstance(ctx, instance.ID)
+	require.NoError(t, err)
+	assert.Empty(t, locations, "locations created before the failure should be removed")
+	markers, err := dbc.NewSelect().Model((*models.Marker)(nil)).Where("name = ?", "Clocktower").Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, markers)
+
+	updated, err := repositories.NewInstanceRepository(dbc).GetByID(ctx, instance.ID)
+	require.NoError(t, err)
+	assert.Empty(t, updated.GameStructure.SubGroups[0].LocationIDs)
+}
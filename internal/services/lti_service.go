@@ -0,0 +1,946 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+const (
+	ltiMessageTypeClaim  = "https://purl.imsglobal.org/spec/lti/claim/message_type"
+	ltiVersionClaim      = "https://purl.imsglobal.org/spec/lti/claim/version"
+	ltiDeploymentClaim   = "https://purl.imsglobal.org/spec/lti/claim/deployment_id"
+	ltiResourceLinkClaim = "https://purl.imsglobal.org/spec/lti/claim/resource_link"
+	ltiEndpointClaim     = "https://purl.imsglobal.org/spec/lti-ags/claim/endpoint"
+	ltiScoreScope        = "https://purl.imsglobal.org/spec/lti-ags/scope/score"
+
+	// ltiKeyBits is the size of the RSA key Rapua signs with.
+	ltiKeyBits = 2048
+	// ltiClockSkew allows for clocks that disagree a little.
+	ltiClockSkew = time.Minute
+	// ltiKeySetTTL is how long a platform's public keys are cached.
+	ltiKeySetTTL = 10 * time.Minute
+	// ltiKeySetRefetch is the least time after fetching a platform's keys
+	// before a missing key fetches them again, so tokens with made-up key
+	// IDs can't make Rapua fetch them over and over.
+	ltiKeySetRefetch = time.Minute
+	// ltiTeamParameter is the custom parameter that puts LMS users with the
+	// same value in the same team, and ltiTeamNameParameter names it.
+	ltiTeamParameter     = "team"
+	ltiTeamNameParameter = "team_name"
+	// ltiTeamKeyLength caps the length of a team parameter.
+	ltiTeamKeyLength = 255
+	// ltiSyncErrorLength caps the grade sync error kept for a user.
+	ltiSyncErrorLength = 500
+)
+
+// LTITeamService creates the teams that LMS users play as.
+type LTITeamService interface {
+	AddTeams(ctx context.Context, instanceID string, count int) ([]models.Team, error)
+	GetTeamByCode(ctx context.Context, code string) (*models.Team, error)
+	Update(ctx context.Context, team *models.Team) error
+	StartPlaying(ctx context.Context, teamCode string) error
+}
+
+// LTINavigationService tells whether a team has finished the game.
+type LTINavigationService interface {
+	GetNextLocations(ctx context.Context, team *models.Team) ([]models.Location, error)
+}
+
+// LTIToolConfig is what an LMS administrator enters to register Rapua.
+type LTIToolConfig struct {
+	LoginURL    string
+	RedirectURL string
+	KeySetURL   string
+}
+
+// LTIPlatformInput is the registration details an LMS gives for the tool.
+type LTIPlatformInput struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	DeploymentID string
+	AuthLoginURL string
+	AuthTokenURL string
+	KeySetURL    string
+	GradeMode    models.LTIGradeMode
+	ScoreMaximum int
+}
+
+// LTILoginRequest is a platform's request to start a launch.
+type LTILoginRequest struct {
+	Issuer         string
+	LoginHint      string
+	TargetLinkURI  string
+	LTIMessageHint string
+	ClientID       string
+	DeploymentID   string
+}
+
+// LTILoginRedirect sends the browser back to the platform to authenticate.
+// State and Nonce must be kept until the launch comes back.
+type LTILoginRedirect struct {
+	URL   string
+	State string
+	Nonce string
+}
+
+// ltiClaims are the parts of a launch Rapua uses.
+type ltiClaims struct {
+	Issuer          string   `json:"iss"`
+	Subject         string   `json:"sub"`
+	Audience        audience `json:"aud"`
+	AuthorizedParty string   `json:"azp"`
+	Expiry          int64    `json:"exp"`
+	IssuedAt        int64    `json:"iat"`
+	Nonce           string   `json:"nonce"`
+	Name            string   `json:"name"`
+	GivenName       string   `json:"given_name"`
+	FamilyName      string   `json:"family_name"`
+	Email           string   `json:"email"`
+	MessageType     string   `json:"https://purl.imsglobal.org/spec/lti/claim/message_type"`
+	Version         string   `json:"https://purl.imsglobal.org/spec/lti/claim/version"`
+	DeploymentID    string   `json:"https://purl.imsglobal.org/spec/lti/claim/deployment_id"`
+	ResourceLink    struct {
+		ID string `json:"id"`
+	} `json:"https://purl.imsglobal.org/spec/lti/claim/resource_link"`
+	Endpoint *struct {
+		Scope    []string `json:"scope"`
+		LineItem string   `json:"lineitem"`
+	} `json:"https://purl.imsglobal.org/spec/lti-ags/claim/endpoint"`
+	Custom map[string]any `json:"https://purl.imsglobal.org/spec/lti/claim/custom"`
+}
+
+// custom returns a custom parameter. Platforms pass variables they can't
+// fill in, such as a user's group when they are in none, through as is.
+func (c *ltiClaims) custom(name string) string {
+	value, _ := c.Custom[name].(string)
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "$") {
+		return ""
+	}
+	return value
+}
+
+// teamKey is the key shared by users who play as one team, if any.
+func (c *ltiClaims) teamKey() string {
+	key := c.custom(ltiTeamParameter)
+	if len(key) > ltiTeamKeyLength {
+		key = key[:ltiTeamKeyLength]
+	}
+	return key
+}
+
+// teamName is the name a new team is given.
+func (c *ltiClaims) teamName() string {
+	if c.teamKey() != "" {
+		if name := c.custom(ltiTeamNameParameter); name != "" {
+			return name
+		}
+	}
+	return c.displayName()
+}
+
+// displayName is the user's name.
+func (c *ltiClaims) displayName() string {
+	if name := strings.TrimSpace(c.Name); name != "" {
+		return name
+	}
+	if name := strings.TrimSpace(c.GivenName + " " + c.FamilyName); name != "" {
+		return name
+	}
+	return "LMS player"
+}
+
+// lineItem is the gradebook column to send scores to, if the platform allows.
+func (c *ltiClaims) lineItem() string {
+	if c.Endpoint == nil || c.Endpoint.LineItem == "" {
+		return ""
+	}
+	for _, scope := range c.Endpoint.Scope {
+		if scope == ltiScoreScope {
+			return c.Endpoint.LineItem
+		}
+	}
+	return ""
+}
+
+type ltiAccessToken struct {
+	token   string
+	expires time.Time
+}
+
+type ltiKeySet struct {
+	keys    map[string]*rsa.PublicKey
+	fetched time.Time
+}
+
+// ltiKeyFetch is a fetch of a platform's key set in progress. keys and err
+// are set before done is closed.
+type ltiKeyFetch struct {
+	done chan struct{}
+	keys map[string]*rsa.PublicKey
+	err  error
+}
+
+// LTIService lets an LMS launch games as an LTI 1.3 tool. A launch signs the
+// LMS user in as their own team, and their score is sent back to the LMS
+// gradebook.
+type LTIService struct {
+	ltiRepo           *repositories.LTIRepository
+	teamService       LTITeamService
+	navigationService LTINavigationService
+	client            *http.Client
+	logger            *slog.Logger
+
+	mu         sync.Mutex
+	signingKey *models.LTIKey
+	privateKey *rsa.PrivateKey
+	keySets    map[string]ltiKeySet
+	keyFetches map[string]*ltiKeyFetch
+	tokens     map[string]ltiAccessToken
+}
+
+func NewLTIService(
+	ltiRepo *repositories.LTIRepository,
+	teamService LTITeamService,
+	navigationService LTINavigationService,
+	client *http.Client,
+	logger *slog.Logger,
+) *LTIService {
+	return &LTIService{
+		ltiRepo:           ltiRepo,
+		teamService:       teamService,
+		navigationService: navigationService,
+		client:            client,
+		logger:            logger,
+		keySets:           make(map[string]ltiKeySet),
+		keyFetches:        make(map[string]*ltiKeyFetch),
+		tokens:            make(map[string]ltiAccessToken),
+	}
+}
+
+// siteURL is the address Rapua is served from.
+func (s *LTIService) siteURL() string {
+	return strings.TrimSuffix(os.Getenv("SITE_URL"), "/")
+}
+
+// ToolConfig returns the URLs an LMS needs to register Rapua.
+func (s *LTIService) ToolConfig() LTIToolConfig {
+	site := s.siteURL()
+	return LTIToolConfig{
+		LoginURL:    site + "/lti/login",
+		RedirectURL: site + "/lti/launch",
+		KeySetURL:   site + "/lti/jwks",
+	}
+}
+
+// validateLTIURL checks that a URL is an absolute http or https URL.
+func validateLTIURL(name, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return fmt.Errorf("%s must be an absolute http or https URL", name)
+	}
+	return nil
+}
+
+// validate trims the input and checks that it is complete.
+func (in *LTIPlatformInput) validate() error {
+	for _, field := range []*string{
+		&in.Name, &in.Issuer, &in.ClientID, &in.DeploymentID,
+		&in.AuthLoginURL, &in.AuthTokenURL, &in.KeySetURL,
+	} {
+		*field = strings.TrimSpace(*field)
+	}
+
+	if in.Name == "" || in.Issuer == "" || in.ClientID == "" {
+		return errors.New("name, issuer and client ID are required")
+	}
+	for name, rawURL := range map[string]string{
+		"login URL":   in.AuthLoginURL,
+		"token URL":   in.AuthTokenURL,
+		"key set URL": in.KeySetURL,
+	} {
+		if err := validateLTIURL(name, rawURL); err != nil {
+			return err
+		}
+	}
+	return validateLTIGrading(in.GradeMode, in.ScoreMaximum)
+}
+
+// validateLTIGrading checks a grade mode and, for points, its maximum.
+func validateLTIGrading(mode models.LTIGradeMode, scoreMaximum int) error {
+	if !mode.IsValid() {
+		return errors.New("unknown grade mode")
+	}
+	if mode == models.GradePoints && scoreMaximum <= 0 {
+		return errors.New("points grading needs a maximum above 0")
+	}
+	return nil
+}
+
+// CreatePlatform registers an LMS to launch an instance.
+func (s *LTIService) CreatePlatform(
+	ctx context.Context,
+	instanceID string,
+	in LTIPlatformInput,
+) (*models.LTIPlatform, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.ltiRepo.FindPlatformsByIssuer(ctx, in.Issuer, in.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("checking registrations: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, errors.New("this client ID is already registered")
+	}
+
+	platform := &models.LTIPlatform{
+		ID:           uuid.New().String(),
+		InstanceID:   instanceID,
+		Name:         in.Name,
+		Issuer:       in.Issuer,
+		ClientID:     in.ClientID,
+		DeploymentID: in.DeploymentID,
+		AuthLoginURL: in.AuthLoginURL,
+		AuthTokenURL: in.AuthTokenURL,
+		KeySetURL:    in.KeySetURL,
+		GradeMode:    in.GradeMode,
+		ScoreMaximum: in.ScoreMaximum,
+	}
+	if err = s.ltiRepo.CreatePlatform(ctx, platform); err != nil {
+		return nil, fmt.Errorf("saving platform: %w", err)
+	}
+	return platform, nil
+}
+
+// ListPlatforms returns an instance's registrations with their linked users.
+func (s *LTIService) ListPlatforms(ctx context.Context, instanceID string) ([]models.LTIPlatform, error) {
+	platforms, err := s.ltiRepo.FindPlatformsByInstanceID(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding platforms: %w", err)
+	}
+	return platforms, nil
+}
+
+// UpdateGrading changes what a registration sends to the gradebook.
+func (s *LTIService) UpdateGrading(
+	ctx context.Context,
+	instanceID, id string,
+	mode models.LTIGradeMode,
+	scoreMaximum int,
+) (*models.LTIPlatform, error) {
+	if err := validateLTIGrading(mode, scoreMaximum); err != nil {
+		return nil, err
+	}
+	platform, err := s.ltiRepo.GetPlatform(ctx, instanceID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLTIPlatformNotFound
+		}
+		return nil, fmt.Errorf("finding platform: %w", err)
+	}
+	platform.GradeMode = mode
+	platform.ScoreMaximum = scoreMaximum
+	if err = s.ltiRepo.UpdatePlatform(ctx, platform); err != nil {
+		return nil, fmt.Errorf("updating platform: %w", err)
+	}
+	return platform, nil
+}
+
+// DeletePlatform removes a registration. Teams stay, but their LMS users can
+// no longer launch the game.
+func (s *LTIService) DeletePlatform(ctx context.Context, instanceID, id string) error {
+	err := s.ltiRepo.DeletePlatform(ctx, instanceID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrLTIPlatformNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("deleting platform: %w", err)
+	}
+	return nil
+}
+
+// key returns the key Rapua signs with, creating one the first time.
+func (s *LTIService) key(ctx context.Context) (*models.LTIKey, *rsa.PrivateKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.privateKey != nil {
+		return s.signingKey, s.privateKey, nil
+	}
+
+	stored, err := s.ltiRepo.GetLatestKey(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		stored, err = s.createKey(ctx)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading signing key: %w", err)
+	}
+
+	block, _ := pem.Decode([]byte(stored.PrivateKey))
+	if block == nil {
+		return nil, nil, errors.New("signing key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing signing key: %w", err)
+	}
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("signing key is not an RSA key")
+	}
+
+	s.signingKey = stored
+	s.privateKey = privateKey
+	return stored, privateKey, nil
+}
+
+// createKey generates and saves a new signing key.
+func (s *LTIService) createKey(ctx context.Context) (*models.LTIKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, ltiKeyBits)
+	if err != nil {
+		return nil, fmt.Errorf("generating key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("encoding key: %w", err)
+	}
+	key := &models.LTIKey{
+		ID:         uuid.New().String(),
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}
+	if err = s.ltiRepo.CreateKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("saving key: %w", err)
+	}
+	return key, nil
+}
+
+// KeySet returns Rapua's public key as a JSON Web Key Set, for platforms to
+// check the requests Rapua signs.
+func (s *LTIService) KeySet(ctx context.Context) ([]byte, error) {
+	stored, privateKey, err := s.key(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jwkSet{Keys: []jwk{newJWK(stored.ID, &privateKey.PublicKey)}})
+}
+
+// randomToken returns a random URL-safe string.
+func randomToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Login answers a platform's login initiation by sending the browser back to
+// the platform to authenticate the user.
+func (s *LTIService) Login(ctx context.Context, req LTILoginRequest) (*LTILoginRedirect, error) {
+	if req.Issuer == "" || req.LoginHint == "" {
+		return nil, fmt.Errorf("%w: missing iss or login_hint", ErrInvalidLTILaunch)
+	}
+	platforms, err := s.ltiRepo.FindPlatformsByIssuer(ctx, req.Issuer, req.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("finding platform: %w", err)
+	}
+	if len(platforms) != 1 {
+		return nil, ErrLTIPlatformNotFound
+	}
+	platform := platforms[0]
+
+	state, err := randomToken()
+	if err != nil {
+		return nil, fmt.Errorf("generating state: %w", err)
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	query := url.Values{
+		"scope":         {"openid"},
+		"response_type": {"id_token"},
+		"response_mode": {"form_post"},
+		"prompt":        {"none"},
+		"client_id":     {platform.ClientID},
+		"redirect_uri":  {s.ToolConfig().RedirectURL},
+		"login_hint":    {req.LoginHint},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+	if req.LTIMessageHint != "" {
+		query.Set("lti_message_hint", req.LTIMessageHint)
+	}
+
+	separator := "?"
+	if strings.Contains(platform.AuthLoginURL, "?") {
+		separator = "&"
+	}
+	return &LTILoginRedirect{
+		URL:   platform.AuthLoginURL + separator + query.Encode(),
+		State: state,
+		Nonce: nonce,
+	}, nil
+}
+
+// Launch checks a launch's ID token and returns the team the LMS user plays
+// as, creating it on their first launch. Users launched with the same team
+// parameter join the same team. The team is started, so it may run out of
+// credits like any other.
+func (s *LTIService) Launch(ctx context.Context, idToken, nonce string) (*models.Team, error) {
+	platform, claims, err := s.verifyLaunch(ctx, idToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.ltiRepo.GetUser(ctx, platform.ID, claims.Subject)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+	isNew := user == nil
+	if isNew {
+		user = &models.LTIUser{
+			ID:         uuid.New().String(),
+			PlatformID: platform.ID,
+			Subject:    claims.Subject,
+		}
+	}
+
+	teamKey := claims.teamKey()
+	teamCode := user.TeamCode
+	if teamKey != "" && teamKey != user.TeamKey {
+		// The user is new to the team, so joins whoever is already on it
+		teamCode, err = s.ltiRepo.FindTeamCodeByKey(ctx, platform.ID, teamKey)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("finding team: %w", err)
+		}
+	}
+
+	var team *models.Team
+	if teamCode != "" {
+		team, err = s.teamService.GetTeamByCode(ctx, teamCode)
+		if err != nil || team.InstanceID != platform.InstanceID {
+			// The team was deleted, so the user starts again
+			team = nil
+		}
+	}
+	if team == nil {
+		teams, addErr := s.teamService.AddTeams(ctx, platform.InstanceID, 1)
+		if addErr != nil {
+			return nil, fmt.Errorf("creating team: %w", addErr)
+		}
+		team = &teams[0]
+		team.Name = claims.teamName()
+		if err = s.teamService.Update(ctx, team); err != nil {
+			return nil, fmt.Errorf("naming team: %w", err)
+		}
+	}
+
+	user.Name = claims.displayName()
+	user.Email = claims.Email
+	user.TeamCode = team.Code
+	if teamKey != "" {
+		user.TeamKey = teamKey
+	}
+	if lineItem := claims.lineItem(); lineItem != "" {
+		user.LineItemURL = lineItem
+	}
+	if isNew {
+		err = s.ltiRepo.CreateUser(ctx, user)
+	} else {
+		err = s.ltiRepo.UpdateUser(ctx, user)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("saving user: %w", err)
+	}
+
+	if err = s.teamService.StartPlaying(ctx, team.Code); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// verifyLaunch checks a launch's signature and claims and returns the
+// platform that sent it.
+func (s *LTIService) verifyLaunch(
+	ctx context.Context,
+	idToken, nonce string,
+) (*models.LTIPlatform, *ltiClaims, error) {
+	claims := &ltiClaims{}
+	header, err := splitJWT(idToken, claims)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidLTILaunch, err)
+	}
+	if header.Alg != "RS256" {
+		return nil, nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidLTILaunch, header.Alg)
+	}
+
+	clientID := claims.AuthorizedParty
+	if clientID == "" && len(claims.Audience) > 0 {
+		clientID = claims.Audience[0]
+	}
+	platforms, err := s.ltiRepo.FindPlatformsByIssuer(ctx, claims.Issuer, clientID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding platform: %w", err)
+	}
+	if len(platforms) != 1 {
+		return nil, nil, ErrLTIPlatformNotFound
+	}
+	platform := &platforms[0]
+
+	key, err := s.platformKey(ctx, platform, header.Kid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = verifyJWT(idToken, key); err != nil {
+		return nil, nil, fmt.Errorf("%w: bad signature", ErrInvalidLTILaunch)
+	}
+
+	now := time.Now()
+	switch {
+	case !claims.Audience.contains(platform.ClientID):
+		err = errors.New("token is for another client")
+	case time.Unix(claims.Expiry, 0).Before(now.Add(-ltiClockSkew)):
+		err = errors.New("token has expired")
+	case time.Unix(claims.IssuedAt, 0).After(now.Add(ltiClockSkew)):
+		err = errors.New("token was issued in the future")
+	case nonce == "" || claims.Nonce != nonce:
+		err = errors.New("nonce does not match")
+	case platform.DeploymentID != "" && claims.DeploymentID != platform.DeploymentID:
+		err = errors.New("unknown deployment")
+	case claims.MessageType != "LtiResourceLinkRequest":
+		err = fmt.Errorf("unsupported message type %q", claims.MessageType)
+	case claims.Version != "1.3.0":
+		err = fmt.Errorf("unsupported LTI version %q", claims.Version)
+	case claims.Subject == "":
+		err = errors.New("anonymous launches are not supported")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidLTILaunch, err)
+	}
+	return platform, claims, nil
+}
+
+// platformKey finds the platform's public key with the given ID, fetching
+// the platform's key set when the key is not cached. Launches that need the
+// same key set wait for a single fetch, and a key missing from a key set
+// fetched within ltiKeySetRefetch doesn't cause another.
+func (s *LTIService) platformKey(
+	ctx context.Context,
+	platform *models.LTIPlatform,
+	kid string,
+) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	cached, ok := s.keySets[platform.KeySetURL]
+	if ok && time.Since(cached.fetched) < ltiKeySetTTL {
+		key, found := cached.keys[kid]
+		if found || time.Since(cached.fetched) < ltiKeySetRefetch {
+			s.mu.Unlock()
+			if !found {
+				return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidLTILaunch, kid)
+			}
+			return key, nil
+		}
+	}
+	fetch, busy := s.keyFetches[platform.KeySetURL]
+	if !busy {
+		fetch = &ltiKeyFetch{done: make(chan struct{})}
+		s.keyFetches[platform.KeySetURL] = fetch
+	}
+	s.mu.Unlock()
+
+	if busy {
+		select {
+		case <-fetch.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	} else {
+		// Other launches are waiting on this fetch, so it carries on if this
+		// one gives up
+		fetch.keys, fetch.err = s.fetchKeySet(context.WithoutCancel(ctx), platform.KeySetURL)
+		s.mu.Lock()
+		if fetch.err == nil {
+			s.keySets[platform.KeySetURL] = ltiKeySet{keys: fetch.keys, fetched: time.Now()}
+		}
+		delete(s.keyFetches, platform.KeySetURL)
+		s.mu.Unlock()
+		close(fetch.done)
+	}
+
+	if fetch.err != nil {
+		return nil, fetch.err
+	}
+	key, found := fetch.keys[kid]
+	if !found {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidLTILaunch, kid)
+	}
+	return key, nil
+}
+
+// fetchKeySet fetches a platform's public keys.
+func (s *LTIService) fetchKeySet(ctx context.Context, keySetURL string) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, keySetURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching platform keys: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching platform keys: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching platform keys: %s", resp.Status)
+	}
+
+	var set jwkSet
+	if err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decoding platform keys: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if key, keyErr := k.publicKey(); keyErr == nil {
+			keys[k.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+// SyncGrades sends the score of every linked user whose score has changed to
+// their platform's gradebook. A failure for one user is recorded against them
+// and does not stop the others.
+func (s *LTIService) SyncGrades(ctx context.Context) error {
+	users, err := s.ltiRepo.FindUsersToGrade(ctx)
+	if err != nil {
+		return fmt.Errorf("finding users to grade: %w", err)
+	}
+
+	for i := range users {
+		user := &users[i]
+		if user.Platform == nil {
+			continue
+		}
+
+		syncErr := s.syncGrade(ctx, user)
+		if syncErr == nil {
+			continue
+		}
+		message := syncErr.Error()
+		if len(message) > ltiSyncErrorLength {
+			message = message[:ltiSyncErrorLength]
+		}
+		if user.SyncError == message {
+			continue
+		}
+		user.SyncError = message
+		if err = s.ltiRepo.UpdateUser(ctx, user); err != nil {
+			return fmt.Errorf("saving sync error: %w", err)
+		}
+		s.logger.WarnContext(ctx, "syncing LTI grade", "user_id", user.ID, "error", syncErr)
+	}
+	return nil
+}
+
+// errLTIGradeUnchanged means there is no new score to send.
+var errLTIGradeUnchanged = errors.New("grade unchanged")
+
+// syncGrade sends one user's score if it has changed.
+func (s *LTIService) syncGrade(ctx context.Context, user *models.LTIUser) error {
+	team, err := s.teamService.GetTeamByCode(ctx, user.TeamCode)
+	if err != nil {
+		return errors.New("the team no longer exists")
+	}
+
+	score, maximum, finished, err := s.score(ctx, user, team)
+	if errors.Is(err, errLTIGradeUnchanged) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	progress := "InProgress"
+	if finished {
+		progress = "Completed"
+	}
+	body, err := json.Marshal(map[string]any{
+		"userId":           user.Subject,
+		"scoreGiven":       score,
+		"scoreMaximum":     maximum,
+		"activityProgress": progress,
+		"gradingProgress":  "FullyGraded",
+		"timestamp":        time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return err
+	}
+
+	token, err := s.accessToken(ctx, user.Platform)
+	if err != nil {
+		return err
+	}
+
+	scoresURL, err := url.Parse(user.LineItemURL)
+	if err != nil {
+		return fmt.Errorf("parsing line item URL: %w", err)
+	}
+	scoresURL.Path = strings.TrimSuffix(scoresURL.Path, "/") + "/scores"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, scoresURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/vnd.ims.lis.v1.score+json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending score: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if resp.StatusCode == http.StatusUnauthorized {
+			s.mu.Lock()
+			delete(s.tokens, user.Platform.ID)
+			s.mu.Unlock()
+		}
+		return fmt.Errorf("sending score: platform responded with %s", resp.Status)
+	}
+
+	user.SyncedScore = score
+	user.SyncedAt = time.Now()
+	user.SyncError = ""
+	return s.ltiRepo.UpdateUser(ctx, user)
+}
+
+// score works out the grade to send for a team, or errLTIGradeUnchanged if
+// it matches the grade already sent. Nothing is sent until there is
+// something to report.
+func (s *LTIService) score(
+	ctx context.Context,
+	user *models.LTIUser,
+	team *models.Team,
+) (float64, float64, bool, error) {
+	switch user.Platform.GradeMode {
+	case models.GradePoints:
+		score := float64(team.Points)
+		if score == user.SyncedScore && (score == 0 || !user.SyncedAt.IsZero()) {
+			return 0, 0, false, errLTIGradeUnchanged
+		}
+		finished, err := s.finished(ctx, team)
+		return score, float64(user.Platform.ScoreMaximum), finished, err
+
+	case models.GradeCompletion:
+		if user.SyncedScore == 1 {
+			return 0, 0, false, errLTIGradeUnchanged
+		}
+		finished, err := s.finished(ctx, team)
+		if err != nil || !finished {
+			return 0, 0, false, errors.Join(err, errLTIGradeUnchanged)
+		}
+		return 1, 1, true, nil
+	}
+	return 0, 0, false, errLTIGradeUnchanged
+}
+
+// finished reports whether the team has nowhere left to go.
+func (s *LTIService) finished(ctx context.Context, team *models.Team) (bool, error) {
+	_, err := s.navigationService.GetNextLocations(ctx, team)
+	if errors.Is(err, ErrAllLocationsVisited) {
+		return team.MustCheckOut == "", nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("checking progress: %w", err)
+	}
+	return false, nil
+}
+
+// accessToken returns a token for sending scores to the platform, asking for
+// a new one with a signed client assertion when needed.
+func (s *LTIService) accessToken(ctx context.Context, platform *models.LTIPlatform) (string, error) {
+	s.mu.Lock()
+	cached, ok := s.tokens[platform.ID]
+	s.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.token, nil
+	}
+
+	stored, privateKey, err := s.key(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	assertion, err := signJWT(privateKey, stored.ID, map[string]any{
+		"iss": platform.ClientID,
+		"sub": platform.ClientID,
+		"aud": platform.AuthTokenURL,
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(), //nolint:mnd // short-lived assertion
+		"jti": uuid.New().String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("signing client assertion: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type":            {"client_credentials"},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {assertion},
+		"scope":                 {ltiScoreScope},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, platform.AuthTokenURL,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("requesting access token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("requesting access token: platform responded with %s", resp.Status)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding access token: %w", err)
+	}
+	if body.AccessToken == "" {
+		return "", errors.New("platform returned no access token")
+	}
+
+	// Renew a minute early so a token never expires mid-request
+	expires := now.Add(time.Duration(body.ExpiresIn)*time.Second - time.Minute)
+	s.mu.Lock()
+	s.tokens[platform.ID] = ltiAccessToken{token: body.AccessToken, expires: expires}
+	s.mu.Unlock()
+	return body.AccessToken, nil
+}
@@ -0,0 +1,400 @@
+package services_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ltiTeams stands in for the team service so launches don't need credits.
+type ltiTeams struct {
+	teams   map[string]*models.Team
+	started []string
+}
+
+func (s *ltiTeams) AddTeams(_ context.Context, instanceID string, count int) ([]models.Team, error) {
+	teams := make([]models.Team, 0, count)
+	for range count {
+		team := models.Team{ID: gofakeit.UUID(), Code: gofakeit.LetterN(4), InstanceID: instanceID}
+		s.teams[team.Code] = &team
+		teams = append(teams, team)
+	}
+	return teams, nil
+}
+
+func (s *ltiTeams) GetTeamByCode(_ context.Context, code string) (*models.Team, error) {
+	team, ok := s.teams[code]
+	if !ok {
+		return nil, services.ErrTeamNotFound
+	}
+	copied := *team
+	return &copied, nil
+}
+
+func (s *ltiTeams) Update(_ context.Context, team *models.Team) error {
+	copied := *team
+	s.teams[team.Code] = &copied
+	return nil
+}
+
+func (s *ltiTeams) StartPlaying(_ context.Context, teamCode string) error {
+	s.started = append(s.started, teamCode)
+	return nil
+}
+
+// ltiProgress reports every team as still playing.
+type ltiProgress struct{}
+
+func (ltiProgress) GetNextLocations(context.Context, *models.Team) ([]models.Location, error) {
+	return []models.Location{{}}, nil
+}
+
+// mockPlatform is a minimal LMS: it publishes a key set, issues access
+// tokens, and records the scores it is sent.
+type mockPlatform struct {
+	key    *rsa.PrivateKey
+	server *httptest.Server
+
+	mu          sync.Mutex
+	keyFetches  int
+	keyStatus   int           // Status the key set is served with, if not OK
+	keyDelay    time.Duration // How long the key set takes to serve
+	assertions  []string
+	scoreAuth   []string
+	scores      []map[string]any
+	scoresPaths []string
+}
+
+func newMockPlatform(t *testing.T) *mockPlatform {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	platform := &mockPlatform{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, _ *http.Request) {
+		platform.mu.Lock()
+		platform.keyFetches++
+		status, delay := platform.keyStatus, platform.keyDelay
+		platform.mu.Unlock()
+		time.Sleep(delay)
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "platform-key",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		platform.mu.Lock()
+		platform.assertions = append(platform.assertions, r.FormValue("client_assertion"))
+		platform.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "platform-token", "expires_in": 3600})
+	})
+	mux.HandleFunc("POST /lineitems/1/scores", func(w http.ResponseWriter, r *http.Request) {
+		var score map[string]any
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &score)
+		platform.mu.Lock()
+		platform.scoreAuth = append(platform.scoreAuth, r.Header.Get("Authorization"))
+		platform.scores = append(platform.scores, score)
+		platform.scoresPaths = append(platform.scoresPaths, r.URL.Path)
+		platform.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+	platform.server = httptest.NewServer(mux)
+	t.Cleanup(platform.server.Close)
+	return platform
+}
+
+// idToken signs a launch for the subject with the given key.
+func (p *mockPlatform) idToken(t *testing.T, key *rsa.PrivateKey, subject, nonce string) string {
+	t.Helper()
+	return p.customToken(t, key, "platform-key", subject, nonce, nil)
+}
+
+// customToken signs a launch with the given key ID and custom parameters.
+func (p *mockPlatform) customToken(
+	t *testing.T,
+	key *rsa.PrivateKey,
+	kid, subject, nonce string,
+	custom map[string]string,
+) string {
+	t.Helper()
+	now := time.Now()
+	claims := map[string]any{
+		"iss":   p.server.URL,
+		"sub":   subject,
+		"aud":   "rapua-client",
+		"azp":   "rapua-client",
+		"exp":   now.Add(5 * time.Minute).Unix(),
+		"iat":   now.Unix(),
+		"nonce": nonce,
+		"name":  "Ada Lovelace",
+		"email": "ada@example.edu",
+		"https://purl.imsglobal.org/spec/lti/claim/message_type":  "LtiResourceLinkRequest",
+		"https://purl.imsglobal.org/spec/lti/claim/version":       "1.3.0",
+		"https://purl.imsglobal.org/spec/lti/claim/deployment_id": "1",
+		"https://purl.imsglobal.org/spec/lti/claim/resource_link": map[string]string{"id": "link-1"},
+		"https://purl.imsglobal.org/spec/lti-ags/claim/endpoint": map[string]any{
+			"scope":    []string{"https://purl.imsglobal.org/spec/lti-ags/scope/score"},
+			"lineitem": p.server.URL + "/lineitems/1",
+		},
+	}
+	if custom != nil {
+		claims["https://purl.imsglobal.org/spec/lti/claim/custom"] = custom
+	}
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func setupLTIService(t *testing.T) (*services.LTIService, *ltiTeams, *mockPlatform, *models.LTIPlatform) {
+	t.Helper()
+	t.Setenv("SITE_URL", "https://rapua.example.com")
+	dbc, cleanup := setupDB(t)
+	t.Cleanup(cleanup)
+
+	mock := newMockPlatform(t)
+	teams := &ltiTeams{teams: make(map[string]*models.Team)}
+	service := services.NewLTIService(
+		repositories.NewLTIRepository(dbc),
+		teams,
+		ltiProgress{},
+		mock.server.Client(),
+		newTLogger(t),
+	)
+
+	platform, err := service.CreatePlatform(context.Background(), gofakeit.UUID(), services.LTIPlatformInput{
+		Name:         "Mock LMS",
+		Issuer:       mock.server.URL,
+		ClientID:     "rapua-client",
+		DeploymentID: "1",
+		AuthLoginURL: mock.server.URL + "/auth",
+		AuthTokenURL: mock.server.URL + "/token",
+		KeySetURL:    mock.server.URL + "/jwks",
+		GradeMode:    models.GradePoints,
+		ScoreMaximum: 100,
+	})
+	require.NoError(t, err)
+	return service, teams, mock, platform
+}
+
+func TestLTIService_Login(t *testing.T) {
+	service, _, mock, _ := setupLTIService(t)
+
+	redirect, err := service.Login(context.Background(), services.LTILoginRequest{
+		Issuer:    mock.server.URL,
+		LoginHint: "user-1",
+		ClientID:  "rapua-client",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, redirect.State)
+	assert.NotEmpty(t, redirect.Nonce)
+
+	parsed, err := url.Parse(redirect.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "/auth", parsed.Path)
+	query := parsed.Query()
+	assert.Equal(t, "id_token", query.Get("response_type"))
+	assert.Equal(t, "https://rapua.example.com/lti/launch", query.Get("redirect_uri"))
+	assert.Equal(t, redirect.State, query.Get("state"))
+	assert.Equal(t, redirect.Nonce, query.Get("nonce"))
+
+	_, err = service.Login(context.Background(), services.LTILoginRequest{
+		Issuer:    "https://unknown.example.edu",
+		LoginHint: "user-1",
+	})
+	require.ErrorIs(t, err, services.ErrLTIPlatformNotFound)
+}
+
+func TestLTIService_Launch(t *testing.T) {
+	service, teams, mock, platform := setupLTIService(t)
+	ctx := context.Background()
+
+	team, err := service.Launch(ctx, mock.idToken(t, mock.key, "student-1", "nonce-1"), "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, platform.InstanceID, team.InstanceID)
+	assert.Equal(t, "Ada Lovelace", team.Name)
+
+	t.Run("relaunch joins the same team", func(t *testing.T) {
+		again, err := service.Launch(ctx, mock.idToken(t, mock.key, "student-1", "nonce-2"), "nonce-2")
+		require.NoError(t, err)
+		assert.Equal(t, team.Code, again.Code)
+		assert.Len(t, teams.teams, 1)
+		assert.Equal(t, []string{team.Code, team.Code}, teams.started)
+	})
+
+	t.Run("nonce must match", func(t *testing.T) {
+		_, err := service.Launch(ctx, mock.idToken(t, mock.key, "student-2", "nonce-3"), "other")
+		require.ErrorIs(t, err, services.ErrInvalidLTILaunch)
+	})
+
+	t.Run("signature must match the platform key", func(t *testing.T) {
+		forged, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		_, err = service.Launch(ctx, mock.idToken(t, forged, "student-2", "nonce-4"), "nonce-4")
+		require.ErrorIs(t, err, services.ErrInvalidLTILaunch)
+	})
+
+	t.Run("unknown keys don't refetch the key set", func(t *testing.T) {
+		for i := range 3 {
+			nonce := fmt.Sprintf("nonce-kid-%d", i)
+			token := mock.customToken(t, mock.key, fmt.Sprintf("made-up-%d", i), "student-2", nonce, nil)
+			_, err := service.Launch(ctx, token, nonce)
+			require.ErrorIs(t, err, services.ErrInvalidLTILaunch)
+		}
+		mock.mu.Lock()
+		defer mock.mu.Unlock()
+		assert.Equal(t, 1, mock.keyFetches)
+	})
+
+	platforms, err := service.ListPlatforms(ctx, platform.InstanceID)
+	require.NoError(t, err)
+	require.Len(t, platforms, 1)
+	require.Len(t, platforms[0].Users, 1)
+	assert.Equal(t, team.Code, platforms[0].Users[0].TeamCode)
+	assert.Equal(t, mock.server.URL+"/lineitems/1", platforms[0].Users[0].LineItemURL)
+}
+
+func TestLTIService_LaunchKeySet(t *testing.T) {
+	service, _, mock, _ := setupLTIService(t)
+	ctx := context.Background()
+
+	t.Run("a failed fetch doesn't hold up the next launch", func(t *testing.T) {
+		mock.mu.Lock()
+		mock.keyStatus = http.StatusServiceUnavailable
+		mock.mu.Unlock()
+		_, err := service.Launch(ctx, mock.idToken(t, mock.key, "student-1", "nonce-1"), "nonce-1")
+		require.ErrorContains(t, err, "fetching platform keys")
+
+		mock.mu.Lock()
+		mock.keyStatus = 0
+		mock.keyDelay = 100 * time.Millisecond
+		mock.mu.Unlock()
+	})
+
+	t.Run("launches together share one fetch", func(t *testing.T) {
+		// The nonces don't match, so each launch stops once its key is found
+		var wg sync.WaitGroup
+		errs := make([]error, 10)
+		for i := range errs {
+			token := mock.idToken(t, mock.key, fmt.Sprintf("student-%d", i), "nonce")
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = service.Launch(ctx, token, "other")
+			}()
+		}
+		wg.Wait()
+		for _, err := range errs {
+			require.ErrorContains(t, err, "nonce does not match")
+		}
+
+		mock.mu.Lock()
+		defer mock.mu.Unlock()
+		assert.Equal(t, 2, mock.keyFetches)
+	})
+}
+
+func TestLTIService_LaunchTeamKey(t *testing.T) {
+	service, teams, mock, _ := setupLTIService(t)
+	ctx := context.Background()
+	launch := func(subject string, custom map[string]string) *models.Team {
+		t.Helper()
+		nonce := gofakeit.UUID()
+		team, err := service.Launch(ctx, mock.customToken(t, mock.key, "platform-key", subject, nonce, custom), nonce)
+		require.NoError(t, err)
+		return team
+	}
+
+	group := map[string]string{"team": "group-7", "team_name": "Group 7"}
+	first := launch("student-1", group)
+	assert.Equal(t, "Group 7", first.Name)
+
+	// Users with the same key join the same team
+	second := launch("student-2", group)
+	assert.Equal(t, first.Code, second.Code)
+	assert.Len(t, teams.teams, 1)
+
+	// Moving to another group in the LMS moves the user too
+	moved := launch("student-2", map[string]string{"team": "group-8"})
+	assert.NotEqual(t, first.Code, moved.Code)
+	assert.Equal(t, "Ada Lovelace", moved.Name)
+	assert.Equal(t, first.Code, launch("student-1", group).Code)
+
+	// Variables the platform couldn't fill in are ignored
+	solo := launch("student-3", map[string]string{"team": "$Canvas.group.contextIds"})
+	assert.NotEqual(t, first.Code, solo.Code)
+	assert.NotEqual(t, moved.Code, solo.Code)
+	assert.Equal(t, solo.Code, launch("student-3", nil).Code)
+}
+
+func TestLTIService_SyncGrades(t *testing.T) {
+	service, teams, mock, platform := setupLTIService(t)
+	ctx := context.Background()
+
+	team, err := service.Launch(ctx, mock.idToken(t, mock.key, "student-1", "nonce-1"), "nonce-1")
+	require.NoError(t, err)
+
+	// Nothing is sent before the team scores
+	require.NoError(t, service.SyncGrades(ctx))
+	assert.Empty(t, mock.scores)
+
+	teams.teams[team.Code].Points = 42
+	require.NoError(t, service.SyncGrades(ctx))
+	require.Len(t, mock.scores, 1)
+	assert.Equal(t, "Bearer platform-token", mock.scoreAuth[0])
+	assert.Equal(t, "/lineitems/1/scores", mock.scoresPaths[0])
+	assert.Equal(t, "student-1", mock.scores[0]["userId"])
+	assert.InDelta(t, 42, mock.scores[0]["scoreGiven"], 0)
+	assert.InDelta(t, 100, mock.scores[0]["scoreMaximum"], 0)
+	require.Len(t, mock.assertions, 1)
+	assert.NotEmpty(t, mock.assertions[0])
+
+	// An unchanged score is not sent again
+	require.NoError(t, service.SyncGrades(ctx))
+	assert.Len(t, mock.scores, 1)
+
+	platforms, err := service.ListPlatforms(ctx, platform.InstanceID)
+	require.NoError(t, err)
+	user := platforms[0].Users[0]
+	assert.InDelta(t, 42, user.SyncedScore, 0)
+	assert.False(t, user.SyncedAt.IsZero())
+	assert.Empty(t, user.SyncError)
+}
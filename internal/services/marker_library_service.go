@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+// earthRadiusMeters is the mean radius of the Earth.
+const earthRadiusMeters = 6371000
+
+// LibraryMarker is a marker in a user's marker library.
+type LibraryMarker struct {
+	Marker   models.Marker
+	Distance float64 // Metres from the search point, or -1 without one
+}
+
+// MarkerLibraryFilter narrows and orders a marker library.
+type MarkerLibraryFilter struct {
+	Query string // Matches names and notes
+	// Near sorts markers by distance from this point when set
+	Near     bool
+	Lat, Lng float64
+}
+
+// MarkerLibraryDetails are the details of a place in a marker library.
+type MarkerLibraryDetails struct {
+	Name     string
+	Lat      float64
+	Lng      float64
+	Notes    string
+	ImageURL string
+}
+
+// MarkerLibraryService manages each user's library of physical places, so the
+// same markers and QR codes can be used in many games.
+type MarkerLibraryService struct {
+	transactor db.Transactor
+	markerRepo repositories.MarkerRepository
+}
+
+func NewMarkerLibraryService(
+	transactor db.Transactor,
+	markerRepo repositories.MarkerRepository,
+) *MarkerLibraryService {
+	return &MarkerLibraryService{
+		transactor: transactor,
+		markerRepo: markerRepo,
+	}
+}
+
+// List returns the owner's library markers that match the filter, sorted by
+// name or by distance from the filter's point.
+func (s *MarkerLibraryService) List(
+	ctx context.Context,
+	ownerID string,
+	filter MarkerLibraryFilter,
+) ([]LibraryMarker, error) {
+	markers, err := s.markerRepo.FindByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("finding library markers: %w", err)
+	}
+
+	query := strings.ToLower(strings.TrimSpace(filter.Query))
+	library := make([]LibraryMarker, 0, len(markers))
+	for _, marker := range markers {
+		if query != "" &&
+			!strings.Contains(strings.ToLower(marker.Name), query) &&
+			!strings.Contains(strings.ToLower(marker.Notes), query) {
+			continue
+		}
+		item := LibraryMarker{Marker: marker, Distance: -1}
+		if filter.Near && marker.IsMapped() {
+			item.Distance = distanceMeters(filter.Lat, filter.Lng, marker.Lat, marker.Lng)
+		}
+		library = append(library, item)
+	}
+
+	if filter.Near {
+		// Unmapped markers go last
+		sort.SliceStable(library, func(i, j int) bool {
+			if library[i].Distance < 0 || library[j].Distance < 0 {
+				return library[j].Distance < 0 && library[i].Distance >= 0
+			}
+			return library[i].Distance < library[j].Distance
+		})
+	}
+	return library, nil
+}
+
+// Get returns a marker from the owner's library.
+func (s *MarkerLibraryService) Get(ctx context.Context, ownerID, code string) (*models.Marker, error) {
+	marker, err := s.markerRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("finding marker: %w", err)
+	}
+	if marker.OwnerID != ownerID {
+		return nil, ErrPermissionDenied
+	}
+	return marker, nil
+}
+
+// Create adds a new place to the owner's library.
+func (s *MarkerLibraryService) Create(
+	ctx context.Context,
+	ownerID string,
+	details MarkerLibraryDetails,
+) (*models.Marker, error) {
+	err := checkMarkerDetails(details)
+	if err != nil {
+		return nil, err
+	}
+
+	marker := &models.Marker{
+		Name:     strings.TrimSpace(details.Name),
+		Lat:      details.Lat,
+		Lng:      details.Lng,
+		Notes:    strings.TrimSpace(details.Notes),
+		ImageURL: strings.TrimSpace(details.ImageURL),
+		OwnerID:  ownerID,
+	}
+	err = s.markerRepo.Create(ctx, marker)
+	if err != nil {
+		return nil, fmt.Errorf("creating marker: %w", err)
+	}
+	return marker, nil
+}
+
+// Update changes a library marker. Every location using the marker moves with
+// it, as they all share the same physical place.
+func (s *MarkerLibraryService) Update(
+	ctx context.Context,
+	ownerID, code string,
+	details MarkerLibraryDetails,
+) (*models.Marker, error) {
+	err := checkMarkerDetails(details)
+	if err != nil {
+		return nil, err
+	}
+
+	marker, err := s.Get(ctx, ownerID, code)
+	if err != nil {
+		return nil, err
+	}
+	marker.Name = strings.TrimSpace(details.Name)
+	marker.Lat = details.Lat
+	marker.Lng = details.Lng
+	marker.Notes = strings.TrimSpace(details.Notes)
+	marker.ImageURL = strings.TrimSpace(details.ImageURL)
+	err = s.markerRepo.Update(ctx, marker)
+	if err != nil {
+		return nil, fmt.Errorf("updating marker: %w", err)
+	}
+	return marker, nil
+}
+
+// Add saves an existing marker, such as one used by a location, to the owner's
+// library.
+func (s *MarkerLibraryService) Add(ctx context.Context, ownerID, code string) (*models.Marker, error) {
+	marker, err := s.markerRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("finding marker: %w", err)
+	}
+	if marker.OwnerID == ownerID {
+		return marker, nil
+	}
+	if marker.InLibrary() {
+		return nil, ErrPermissionDenied
+	}
+
+	tx, err := s.transactor.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = s.markerRepo.SetOwner(ctx, tx, marker.Code, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("adding marker to library: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	marker.OwnerID = ownerID
+	return marker, nil
+}
+
+// Remove takes a marker out of the owner's library. Locations using the
+// marker keep it; otherwise it is deleted.
+func (s *MarkerLibraryService) Remove(ctx context.Context, ownerID, code string) error {
+	marker, err := s.Get(ctx, ownerID, code)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.transactor.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = s.markerRepo.SetOwner(ctx, tx, marker.Code, "")
+	if err != nil {
+		return fmt.Errorf("removing marker from library: %w", err)
+	}
+	err = s.markerRepo.DeleteUnused(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("deleting unused markers: %w", err)
+	}
+	return tx.Commit()
+}
+
+// checkMarkerDetails checks the details of a library marker are valid.
+func checkMarkerDetails(details MarkerLibraryDetails) error {
+	if strings.TrimSpace(details.Name) == "" {
+		return errors.New("name cannot be empty")
+	}
+	if details.Lat < -90 || details.Lat > 90 {
+		return ErrInvalidLatitude
+	}
+	if details.Lng < -180 || details.Lng > 180 {
+		return ErrInvalidLongitude
+	}
+	return nil
+}
+
+// distanceMeters returns the great-circle distance between two points.
+func distanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
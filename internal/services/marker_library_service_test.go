@@ -0,0 +1,119 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func setupMarkerLibraryService(t *testing.T) (*services.MarkerLibraryService, *bun.DB, func()) {
+	t.Helper()
+	dbc, cleanup := setupDB(t)
+
+	service := services.NewMarkerLibraryService(
+		db.NewTransactor(dbc),
+		repositories.NewMarkerRepository(dbc),
+	)
+	return service, dbc, cleanup
+}
+
+func TestMarkerLibraryService_SearchByNameAndDistance(t *testing.T) {
+	service, _, cleanup := setupMarkerLibraryService(t)
+	defer cleanup()
+	ctx := context.Background()
+	ownerID := gofakeit.UUID()
+
+	places := []services.MarkerLibraryDetails{
+		{Name: "Clocktower", Lat: -45.8665, Lng: 170.5146, Notes: "Poster by the main doors"},
+		{Name: "Botanic Garden", Lat: -45.8570, Lng: 170.5180},
+		{Name: "Octagon", Lat: -45.8742, Lng: 170.5036},
+		{Name: "Mobile Library"},
+	}
+	for _, place := range places {
+		_, err := service.Create(ctx, ownerID, place)
+		require.NoError(t, err)
+	}
+	_, err := service.Create(ctx, gofakeit.UUID(), services.MarkerLibraryDetails{Name: "Someone else's"})
+	require.NoError(t, err)
+
+	library, err := service.List(ctx, ownerID, services.MarkerLibraryFilter{})
+	require.NoError(t, err)
+	require.Len(t, library, 4)
+	assert.Equal(t, "Botanic Garden", library[0].Marker.Name, "Sorted by name without a point")
+	assert.Equal(t, -1.0, library[0].Distance)
+
+	library, err = service.List(ctx, ownerID, services.MarkerLibraryFilter{Query: "main DOORS"})
+	require.NoError(t, err)
+	require.Len(t, library, 1, "Notes are searched")
+	assert.Equal(t, "Clocktower", library[0].Marker.Name)
+
+	// Standing next to the Octagon
+	library, err = service.List(ctx, ownerID, services.MarkerLibraryFilter{Near: true, Lat: -45.8740, Lng: 170.5040})
+	require.NoError(t, err)
+	require.Len(t, library, 4)
+	assert.Equal(t, "Octagon", library[0].Marker.Name)
+	assert.Less(t, library[0].Distance, 50.0)
+	assert.Equal(t, "Clocktower", library[1].Marker.Name)
+	assert.Equal(t, "Botanic Garden", library[2].Marker.Name)
+	assert.Equal(t, "Mobile Library", library[3].Marker.Name, "Unmapped markers go last")
+}
+
+func TestMarkerLibraryService_AddAndRemove(t *testing.T) {
+	service, dbc, cleanup := setupMarkerLibraryService(t)
+	defer cleanup()
+	ctx := context.Background()
+	ownerID := gofakeit.UUID()
+
+	// A marker used by a location in one of the owner's games
+	marker := &models.Marker{Code: strings.ToUpper(gofakeit.LetterN(5)), Name: "Clocktower", Lat: -45.86, Lng: 170.51}
+	location := &models.Location{
+		ID:         gofakeit.UUID(),
+		InstanceID: gofakeit.UUID(),
+		Name:       "Clocktower",
+		MarkerID:   marker.Code,
+	}
+	for _, row := range []any{marker, location} {
+		_, err := dbc.NewInsert().Model(row).Exec(ctx)
+		require.NoError(t, err)
+	}
+
+	added, err := service.Add(ctx, ownerID, marker.Code)
+	require.NoError(t, err)
+	assert.True(t, added.InLibrary())
+
+	_, err = service.Add(ctx, gofakeit.UUID(), marker.Code)
+	require.ErrorIs(t, err, services.ErrPermissionDenied, "Markers belong to one library")
+	_, err = service.Update(ctx, gofakeit.UUID(), marker.Code, services.MarkerLibraryDetails{Name: "Mine now"})
+	require.ErrorIs(t, err, services.ErrPermissionDenied)
+
+	updated, err := service.Update(ctx, ownerID, marker.Code, services.MarkerLibraryDetails{
+		Name:  "Clocktower Building",
+		Lat:   -45.8665,
+		Lng:   170.5146,
+		Notes: "Ask reception for tape",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Clocktower Building", updated.Name)
+
+	// The location no longer needs the marker, but the library keeps it
+	_, err = dbc.NewDelete().Model(location).WherePK().Exec(ctx)
+	require.NoError(t, err)
+	library, err := service.List(ctx, ownerID, services.MarkerLibraryFilter{})
+	require.NoError(t, err)
+	require.Len(t, library, 1)
+	assert.Equal(t, "Ask reception for tape", library[0].Marker.Notes)
+	assert.Empty(t, library[0].Marker.Locations)
+
+	require.NoError(t, service.Remove(ctx, ownerID, marker.Code))
+	assert.Zero(t, countRows(t, dbc, (*models.Marker)(nil), "code = ?", marker.Code),
+		"Unused markers are deleted once removed from the library")
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+const (
+	// teamMessageMaxLength is the most characters a message may have.
+	teamMessageMaxLength = 1000
+	// replyNotification is the alert shown to a team when they get a reply.
+	replyNotification = "The organisers replied to your message. Open Messages to read it."
+)
+
+// MessageService runs the two-way conversations between teams and the
+// admins and facilitators running their game.
+type MessageService struct {
+	messageRepo      *repositories.TeamMessageRepository
+	notificationRepo repositories.NotificationRepository
+}
+
+func NewMessageService(
+	messageRepo *repositories.TeamMessageRepository,
+	notificationRepo repositories.NotificationRepository,
+) *MessageService {
+	return &MessageService{
+		messageRepo:      messageRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// SendFromTeam saves a message from a team to the organisers.
+func (s *MessageService) SendFromTeam(
+	ctx context.Context,
+	team *models.Team,
+	content string,
+) (*models.TeamMessage, error) {
+	return s.send(ctx, team, models.MessageFromTeam, "", content)
+}
+
+// Reply saves a message from an admin or facilitator to a team, and alerts
+// the team so they see it on whichever page they are on.
+func (s *MessageService) Reply(
+	ctx context.Context,
+	team *models.Team,
+	author, content string,
+) (*models.TeamMessage, error) {
+	author = strings.TrimSpace(author)
+	if author == "" {
+		author = "Organiser"
+	}
+	message, err := s.send(ctx, team, models.MessageFromOrganiser, author, content)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.notificationRepo.Create(ctx, &models.Notification{
+		TeamCode: team.Code,
+		Content:  replyNotification,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("alerting team: %w", err)
+	}
+	return message, nil
+}
+
+func (s *MessageService) send(
+	ctx context.Context,
+	team *models.Team,
+	sender models.MessageSender,
+	author, content string,
+) (*models.TeamMessage, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, ErrMessageEmpty
+	}
+	if len([]rune(content)) > teamMessageMaxLength {
+		return nil, ErrMessageTooLong
+	}
+
+	message := &models.TeamMessage{
+		CreatedAt:  time.Now().UTC(),
+		InstanceID: team.InstanceID,
+		TeamCode:   team.Code,
+		Sender:     sender,
+		Author:     author,
+		Content:    content,
+	}
+	err := s.messageRepo.Create(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("saving message: %w", err)
+	}
+	return message, nil
+}
+
+// Thread returns a team's messages, oldest first.
+func (s *MessageService) Thread(ctx context.Context, team *models.Team) ([]models.TeamMessage, error) {
+	messages, err := s.messageRepo.FindByTeamCode(ctx, team.InstanceID, team.Code)
+	if err != nil {
+		return nil, fmt.Errorf("finding messages: %w", err)
+	}
+	return messages, nil
+}
+
+// MarkReadByTeam records that the team has seen the organisers' replies.
+func (s *MessageService) MarkReadByTeam(ctx context.Context, team *models.Team) error {
+	return s.markRead(ctx, team, models.MessageFromOrganiser)
+}
+
+// MarkReadByOrganisers records that an admin or facilitator has seen the
+// team's messages.
+func (s *MessageService) MarkReadByOrganisers(ctx context.Context, team *models.Team) error {
+	return s.markRead(ctx, team, models.MessageFromTeam)
+}
+
+func (s *MessageService) markRead(ctx context.Context, team *models.Team, sender models.MessageSender) error {
+	err := s.messageRepo.MarkRead(ctx, team.InstanceID, team.Code, sender, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("marking messages read: %w", err)
+	}
+	return nil
+}
+
+// UnreadByTeam counts the messages from each team in the instance that no
+// organiser has read yet, keyed by team code.
+func (s *MessageService) UnreadByTeam(ctx context.Context, instanceID string) (map[string]int, error) {
+	counts, err := s.messageRepo.CountUnread(ctx, instanceID, models.MessageFromTeam)
+	if err != nil {
+		return nil, fmt.Errorf("counting unread messages: %w", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageService_Conversation(t *testing.T) {
+	dbc, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	notificationRepo := repositories.NewNotificationRepository(dbc)
+	service := services.NewMessageService(repositories.NewTeamMessageRepository(dbc), notificationRepo)
+
+	instanceID := gofakeit.UUID()
+	alpha := &models.Team{Code: "AAAA", InstanceID: instanceID}
+	bravo := &models.Team{Code: "BBBB", InstanceID: instanceID}
+
+	_, err := service.SendFromTeam(ctx, alpha, "   ")
+	require.ErrorIs(t, err, services.ErrMessageEmpty)
+	_, err = service.SendFromTeam(ctx, alpha, strings.Repeat("a", 1001))
+	require.ErrorIs(t, err, services.ErrMessageTooLong)
+
+	message, err := service.SendFromTeam(ctx, alpha, " The QR code at the fountain is missing ")
+	require.NoError(t, err)
+	assert.Equal(t, "The QR code at the fountain is missing", message.Content)
+	_, err = service.SendFromTeam(ctx, alpha, "Can someone help?")
+	require.NoError(t, err)
+	_, err = service.SendFromTeam(ctx, bravo, "Hello")
+	require.NoError(t, err)
+
+	unread, err := service.UnreadByTeam(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"AAAA": 2, "BBBB": 1}, unread)
+
+	// Replies alert the team, and don't count as unread for organisers
+	_, err = service.Reply(ctx, alpha, "Sam", "On our way")
+	require.NoError(t, err)
+	notifications, err := notificationRepo.FindByTeamCode(ctx, alpha.Code)
+	require.NoError(t, err)
+	assert.Len(t, notifications, 1)
+
+	require.NoError(t, service.MarkReadByOrganisers(ctx, alpha))
+	unread, err = service.UnreadByTeam(ctx, instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"BBBB": 1}, unread)
+
+	thread, err := service.Thread(ctx, alpha)
+	require.NoError(t, err)
+	require.Len(t, thread, 3)
+	assert.True(t, thread[0].FromTeam())
+	assert.False(t, thread[0].ReadAt.IsZero())
+	assert.Equal(t, models.MessageFromOrganiser, thread[2].Sender)
+	assert.Equal(t, "Sam", thread[2].Author)
+	assert.True(t, thread[2].ReadAt.IsZero())
+
+	require.NoError(t, service.MarkReadByTeam(ctx, alpha))
+	thread, err = service.Thread(ctx, alpha)
+	require.NoError(t, err)
+	assert.False(t, thread[2].ReadAt.IsZero())
+}
@@ -26,6 +26,8 @@ type CreditTopupRepository interface {
 	) error
 	// GetMostRecentCreditAdjustmentByReasonPrefix returns the most recent credit adjustment with reason starting with prefix
 	GetMostRecentCreditAdjustmentByReasonPrefix(ctx context.Context, reasonPrefix string) (*time.Time, error)
+	// TopUpOrganisationsWithTx raises organisation pools to their monthly limit and logs adjustments
+	TopUpOrganisationsWithTx(ctx context.Context, tx *bun.Tx, reason string) error
 }
 
 type MonthlyCreditTopupService struct {
@@ -88,6 +90,33 @@ func (s *MonthlyCreditTopupService) TopUpCredits(ctx context.Context) error {
 		}
 	}
 
+	// Organisation pools have their own limits, set per organisation
+	if err = s.processOrganisationCredits(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "topping up organisation credits", "error", err)
+	}
+
+	return nil
+}
+
+// processOrganisationCredits tops up every organisation's free credits to its
+// monthly limit in a single transaction.
+func (s *MonthlyCreditTopupService) processOrganisationCredits(ctx context.Context) error {
+	tx, err := s.transactor.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	reason := fmt.Sprintf("%s: topped up to organisation limit", models.CreditAdjustmentReasonPrefixMonthlyTopup)
+	err = s.creditRepo.TopUpOrganisationsWithTx(ctx, tx, reason)
+	if err != nil {
+		return fmt.Errorf("failed to top up organisations: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -345,3 +345,50 @@ func TestMonthlyCreditTopupService_ContextCancellation(t *testing.T) {
 		t.Logf("Service handled context cancellation: %v", err)
 	}
 }
+
+func TestMonthlyCreditTopupService_TopUpOrganisations(t *testing.T) {
+	dbc, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	creditRepo := repositories.NewCreditRepository(dbc)
+	service := services.NewMonthlyCreditTopupService(db.NewTransactor(dbc), creditRepo, newTLogger(t))
+
+	// Clear top-ups left by other tests so this month's run goes ahead
+	_, err := dbc.NewDelete().
+		Model((*models.CreditAdjustments)(nil)).
+		Where("reason LIKE ?", models.CreditAdjustmentReasonPrefixMonthlyTopup+"%").
+		Exec(ctx)
+	require.NoError(t, err)
+
+	orgs := []*models.Organisation{
+		{ID: "org-under", Name: "Under", FreeCredits: 5, MonthlyCreditLimit: 50},
+		{ID: "org-over", Name: "Over", FreeCredits: 80, MonthlyCreditLimit: 50},
+		{ID: "org-none", Name: "None", FreeCredits: 2},
+	}
+	for _, org := range orgs {
+		_, err = dbc.NewInsert().Model(org).Exec(ctx)
+		require.NoError(t, err)
+	}
+
+	err = service.TopUpCredits(ctx)
+	require.NoError(t, err)
+
+	want := map[string]int{"org-under": 50, "org-over": 80, "org-none": 2}
+	for id, credits := range want {
+		var org models.Organisation
+		err = dbc.NewSelect().Model(&org).Where("id = ?", id).Scan(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, credits, org.FreeCredits, id)
+	}
+
+	adjustments, err := creditRepo.GetCreditAdjustmentsByOrganisationID(ctx, "org-under")
+	require.NoError(t, err)
+	require.Len(t, adjustments, 1)
+	assert.Equal(t, 45, adjustments[0].Credits)
+	assert.Contains(t, adjustments[0].Reason, models.CreditAdjustmentReasonPrefixMonthlyTopup)
+
+	adjustments, err = creditRepo.GetCreditAdjustmentsByOrganisationID(ctx, "org-over")
+	require.NoError(t, err)
+	assert.Empty(t, adjustments)
+}
@@ -120,6 +120,15 @@ func (s *NavigationService) GetNextLocations(ctx context.Context, team *models.T
 	return locations, nil
 }
 
+// CurrentGroupID returns the ID of the group the team is currently in, or
+// an empty string if the game has no groups.
+func (s *NavigationService) CurrentGroupID(ctx context.Context, team *models.Team) (string, error) {
+	if err := s.ensureTeamRelationsLoaded(ctx, team); err != nil {
+		return "", fmt.Errorf("loading team relations: %w", err)
+	}
+	return currentGroupID(&team.Instance.GameStructure, *team), nil
+}
+
 // GetPlayerNavigationView returns a complete view of navigation data for the player UI.
 func (s *NavigationService) GetPlayerNavigationView(
 	ctx context.Context,
@@ -428,6 +437,18 @@ func (s *NavigationService) getCompletedLocationIDs(checkIns []models.CheckIn) [
 	return completed
 }
 
+// currentGroupID returns the group a team is currently in. The team's
+// check-ins must be loaded.
+func currentGroupID(structure *models.GameStructure, team models.Team) string {
+	completed := make([]string, 0, len(team.CheckIns))
+	for _, checkIn := range team.CheckIns {
+		if checkIn.BlocksCompleted {
+			completed = append(completed, checkIn.LocationID)
+		}
+	}
+	return navigation.ComputeCurrentGroup(structure, completed, team.SkippedGroupIDs)
+}
+
 // getScavengerHuntLocations returns locations for task display mode.
 // Uncompleted locations use the same routing logic as other modes (guided, random, free roam).
 // Completed locations are all locations in the group where BlocksCompleted is true.
@@ -2,29 +2,57 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/nathanhollows/Rapua/v6/models"
 	"github.com/nathanhollows/Rapua/v6/repositories"
 )
 
+const (
+	// notificationMaxLength is the most characters a notification may have.
+	notificationMaxLength = 255
+	// scheduledNotificationGrace is how late a scheduled notification may
+	// be sent once the game has ended, in case the job runs late. Older
+	// ones, such as those made due by ending a game early, are skipped.
+	scheduledNotificationGrace = 5 * time.Minute
+)
+
 type NotificationService struct {
 	notificationRepository repositories.NotificationRepository
+	scheduledRepository    *repositories.ScheduledNotificationRepository
+	instanceRepository     repositories.InstanceRepository
 	teamRepository         repositories.TeamRepository
+	// pushService alerts teams' devices, and may be nil
+	pushService *PushService
+	logger      *slog.Logger
 }
 
 func NewNotificationService(
 	notificationRepository repositories.NotificationRepository,
+	scheduledRepository *repositories.ScheduledNotificationRepository,
+	instanceRepository repositories.InstanceRepository,
 	teamRepository repositories.TeamRepository,
+	pushService *PushService,
+	logger *slog.Logger,
 ) *NotificationService {
 	return &NotificationService{
 		notificationRepository: notificationRepository,
+		scheduledRepository:    scheduledRepository,
+		instanceRepository:     instanceRepository,
 		teamRepository:         teamRepository,
+		pushService:            pushService,
+		logger:                 logger,
 	}
 }
 
-// SendNotification sends a notification to a team.
+// SendNotification sends a notification to a team, and pushes it to any
+// devices the team has turned alerts on for.
 func (s *NotificationService) SendNotification(
 	ctx context.Context,
 	teamCode string,
@@ -36,7 +64,18 @@ func (s *NotificationService) SendNotification(
 	}
 
 	err := s.notificationRepository.Create(ctx, &notification)
-	return notification, err
+	if err != nil {
+		return notification, err
+	}
+
+	if s.pushService != nil {
+		s.pushService.SendToTeam(ctx, teamCode, PushMessage{
+			Title: pushTitle,
+			Body:  content,
+			URL:   "/next",
+		})
+	}
+	return notification, nil
 }
 
 // SendNotificationToAllTeams sends a notification to all teams.
@@ -78,3 +117,216 @@ func (s *NotificationService) DismissNotification(ctx context.Context, notificat
 	}
 	return nil
 }
+
+// ScheduleNotification saves an announcement to send to some or all of the
+// instance's teams. It is sent straight away if it is already due.
+func (s *NotificationService) ScheduleNotification(
+	ctx context.Context,
+	instance *models.Instance,
+	notification *models.ScheduledNotification,
+) error {
+	notification.Content = strings.TrimSpace(notification.Content)
+	if notification.Content == "" {
+		return ErrNotificationEmpty
+	}
+	if len([]rune(notification.Content)) > notificationMaxLength {
+		return ErrNotificationTooLong
+	}
+
+	switch notification.Timing {
+	case models.NotifyAt:
+		if notification.SendAt.IsZero() {
+			return ErrInvalidNotificationTiming
+		}
+		notification.OffsetMinutes = 0
+	case models.NotifyAfterStart, models.NotifyBeforeEnd:
+		if notification.OffsetMinutes < 0 {
+			return ErrInvalidNotificationTiming
+		}
+		notification.SendAt = time.Time{}
+	default:
+		return ErrInvalidNotificationTiming
+	}
+
+	switch notification.Target {
+	case models.TargetAllTeams, models.TargetFinished, models.TargetUnfinished:
+		notification.TargetID = ""
+	case models.TargetTeam, models.TargetGroup, models.TargetNotVisited:
+		if notification.TargetID == "" {
+			return ErrInvalidNotificationTarget
+		}
+	default:
+		return ErrInvalidNotificationTarget
+	}
+
+	notification.InstanceID = instance.ID
+	notification.CreatedAt = time.Now().UTC()
+	notification.SendAt = notification.SendAt.UTC()
+	err := s.scheduledRepository.Create(ctx, notification)
+	if err != nil {
+		return fmt.Errorf("saving scheduled notification: %w", err)
+	}
+
+	due, ok := notification.DueAt(instance)
+	if ok && !due.After(time.Now()) {
+		return s.sendScheduled(ctx, instance, notification)
+	}
+	return nil
+}
+
+// ScheduledNotifications returns an instance's pending and sent scheduled
+// notifications, newest first.
+func (s *NotificationService) ScheduledNotifications(
+	ctx context.Context,
+	instanceID string,
+) ([]models.ScheduledNotification, error) {
+	notifications, err := s.scheduledRepository.FindByInstanceID(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding scheduled notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// CancelScheduledNotification deletes a notification that hasn't been sent.
+func (s *NotificationService) CancelScheduledNotification(ctx context.Context, instanceID, id string) error {
+	err := s.scheduledRepository.DeletePending(ctx, instanceID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotificationNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("deleting scheduled notification: %w", err)
+	}
+	return nil
+}
+
+// SendScheduledNotifications sends every pending notification that is due.
+// It is run by the scheduler each minute.
+func (s *NotificationService) SendScheduledNotifications(ctx context.Context) error {
+	pending, err := s.scheduledRepository.FindPending(ctx)
+	if err != nil {
+		return fmt.Errorf("finding pending notifications: %w", err)
+	}
+
+	now := time.Now()
+	instances := make(map[string]*models.Instance)
+	for i := range pending {
+		notification := &pending[i]
+
+		instance, ok := instances[notification.InstanceID]
+		if !ok {
+			instance, err = s.instanceRepository.GetByID(ctx, notification.InstanceID)
+			// Instances in the trash are left alone until they are restored
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				s.logger.ErrorContext(ctx, "finding instance for scheduled notification",
+					"notification_id", notification.ID, "instance_id", notification.InstanceID, "error", err)
+				continue
+			}
+			instances[notification.InstanceID] = instance
+		}
+		if instance == nil {
+			continue
+		}
+
+		due, ok := notification.DueAt(instance)
+		if !ok || due.After(now) {
+			continue
+		}
+		// One failing notification shouldn't hold up the rest
+		err = s.sendScheduled(ctx, instance, notification)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "sending scheduled notification",
+				"notification_id", notification.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// sendScheduled sends a due notification to the teams it targets, or skips
+// it if the game ended before it could be sent. It is marked sent even if
+// some teams couldn't be reached, so the others aren't sent it twice.
+func (s *NotificationService) sendScheduled(
+	ctx context.Context,
+	instance *models.Instance,
+	notification *models.ScheduledNotification,
+) error {
+	now := time.Now().UTC()
+	notification.SentAt = now
+
+	due, _ := notification.DueAt(instance)
+	end := instance.EndTime.Time
+	ended := !end.IsZero() && end.Before(now)
+	if ended && (due.After(end) || now.Sub(due) > scheduledNotificationGrace) {
+		notification.Skipped = "The game had ended"
+	} else {
+		teams, err := s.notificationTargets(ctx, instance, notification)
+		if err != nil {
+			return err
+		}
+		for _, team := range teams {
+			_, err = s.SendNotification(ctx, team.Code, notification.Content)
+			if err != nil {
+				s.logger.ErrorContext(ctx, "sending scheduled notification to team",
+					"notification_id", notification.ID, "team_code", team.Code, "error", err)
+				continue
+			}
+			notification.Recipients++
+		}
+	}
+
+	err := s.scheduledRepository.MarkSent(ctx, notification)
+	if err != nil {
+		return fmt.Errorf("marking notification sent: %w", err)
+	}
+	return nil
+}
+
+// notificationTargets returns the started teams a notification should be
+// sent to.
+func (s *NotificationService) notificationTargets(
+	ctx context.Context,
+	instance *models.Instance,
+	notification *models.ScheduledNotification,
+) ([]models.Team, error) {
+	teams, err := s.teamRepository.FindAllWithScans(ctx, instance.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding teams: %w", err)
+	}
+
+	targets := make([]models.Team, 0, len(teams))
+	for _, team := range teams {
+		if team.HasStarted && isNotificationTarget(instance, notification, team) {
+			targets = append(targets, team)
+		}
+	}
+	return targets, nil
+}
+
+// isNotificationTarget reports whether a team matches a notification's
+// target.
+func isNotificationTarget(
+	instance *models.Instance,
+	notification *models.ScheduledNotification,
+	team models.Team,
+) bool {
+	switch notification.Target {
+	case models.TargetAllTeams:
+		return true
+	case models.TargetTeam:
+		return strings.EqualFold(team.Code, notification.TargetID)
+	case models.TargetGroup:
+		// Teams stay in the last group once they finish, but aren't in it
+		if determineTeamStatus(team, len(instance.Locations)) == StatusFinished {
+			return false
+		}
+		return currentGroupID(&instance.GameStructure, team) == notification.TargetID
+	case models.TargetNotVisited:
+		return !slices.ContainsFunc(team.CheckIns, func(checkIn models.CheckIn) bool {
+			return checkIn.LocationID == notification.TargetID
+		})
+	case models.TargetFinished:
+		return determineTeamStatus(team, len(instance.Locations)) == StatusFinished
+	case models.TargetUnfinished:
+		return determineTeamStatus(team, len(instance.Locations)) != StatusFinished
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func TestNotificationService_ScheduledNotifications(t *testing.T) {
+	dbc, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	notificationRepo := repositories.NewNotificationRepository(dbc)
+	instanceRepo := repositories.NewInstanceRepository(dbc)
+	service := services.NewNotificationService(
+		notificationRepo,
+		repositories.NewScheduledNotificationRepository(dbc),
+		instanceRepo,
+		repositories.NewTeamRepository(dbc),
+		nil,
+		newTLogger(t),
+	)
+
+	// A game with two groups of one location each, visited in order, that
+	// started an hour ago and ends in 10 minutes
+	instance := &models.Instance{ID: gofakeit.UUID(), Name: "Game", UserID: gofakeit.UUID()}
+	instance.StartTime = bun.NullTime{Time: time.Now().UTC().Add(-time.Hour)}
+	instance.EndTime = bun.NullTime{Time: time.Now().UTC().Add(10 * time.Minute)}
+	locations := []*models.Location{
+		{InstanceID: instance.ID, Name: "Park", MarkerID: "PARK", Order: 0},
+		{InstanceID: instance.ID, Name: "Tower", MarkerID: "TOWR", Order: 1},
+	}
+	for _, location := range locations {
+		require.NoError(t, repositories.NewLocationRepository(dbc).Create(ctx, location))
+	}
+	group := func(name string, locationID string) models.GameStructure {
+		return models.GameStructure{
+			ID:             gofakeit.UUID(),
+			Name:           name,
+			CompletionType: models.CompletionAll,
+			Routing:        models.RouteStrategyOrdered,
+			Navigation:     models.NavigationDisplayNames,
+			LocationIDs:    []string{locationID},
+		}
+	}
+	first, second := group("First", locations[0].ID), group("Second", locations[1].ID)
+	instance.GameStructure = models.GameStructure{
+		ID:        gofakeit.UUID(),
+		IsRoot:    true,
+		SubGroups: []models.GameStructure{first, second},
+	}
+	require.NoError(t, instanceRepo.Create(ctx, instance))
+	instance, err := instanceRepo.GetByID(ctx, instance.ID)
+	require.NoError(t, err)
+
+	// Alpha is in the second group, Bravo hasn't checked in, Charlie has
+	// finished, and Delta hasn't started
+	teams := []models.Team{
+		{Code: "AAAA", InstanceID: instance.ID, HasStarted: true},
+		{Code: "BBBB", InstanceID: instance.ID, HasStarted: true},
+		{Code: "CCCC", InstanceID: instance.ID, HasStarted: true},
+		{Code: "DDDD", InstanceID: instance.ID},
+	}
+	require.NoError(t, repositories.NewTeamRepository(dbc).InsertBatch(ctx, teams))
+	checkIns := []models.CheckIn{
+		{InstanceID: instance.ID, TeamID: "AAAA", LocationID: locations[0].ID, BlocksCompleted: true},
+		{InstanceID: instance.ID, TeamID: "CCCC", LocationID: locations[0].ID, BlocksCompleted: true},
+		{InstanceID: instance.ID, TeamID: "CCCC", LocationID: locations[1].ID, BlocksCompleted: true},
+	}
+	_, err = dbc.NewInsert().Model(&checkIns).Exec(ctx)
+	require.NoError(t, err)
+
+	received := func(teamCode string) int {
+		notifications, err := notificationRepo.FindByTeamCode(ctx, teamCode)
+		require.NoError(t, err)
+		return len(notifications)
+	}
+
+	err = service.ScheduleNotification(ctx, instance, &models.ScheduledNotification{
+		Content: " ", Timing: models.NotifyAt, SendAt: time.Now(), Target: models.TargetAllTeams,
+	})
+	require.ErrorIs(t, err, services.ErrNotificationEmpty)
+	err = service.ScheduleNotification(ctx, instance, &models.ScheduledNotification{
+		Content: "Hello", Timing: models.NotifyAt, SendAt: time.Now(), Target: models.TargetGroup,
+	})
+	require.ErrorIs(t, err, services.ErrInvalidNotificationTarget)
+
+	t.Run("sends due notifications straight away", func(t *testing.T) {
+		tests := []struct {
+			target   models.NotificationTarget
+			targetID string
+			want     []string
+		}{
+			{models.TargetAllTeams, "", []string{"AAAA", "BBBB", "CCCC"}},
+			{models.TargetTeam, "BBBB", []string{"BBBB"}},
+			{models.TargetGroup, second.ID, []string{"AAAA"}},
+			{models.TargetNotVisited, locations[0].ID, []string{"BBBB"}},
+			{models.TargetFinished, "", []string{"CCCC"}},
+			{models.TargetUnfinished, "", []string{"AAAA", "BBBB"}},
+		}
+		for _, tt := range tests {
+			before := map[string]int{}
+			for _, team := range teams {
+				before[team.Code] = received(team.Code)
+			}
+
+			notification := &models.ScheduledNotification{
+				Content:  "Hello",
+				Timing:   models.NotifyAt,
+				SendAt:   time.Now(),
+				Target:   tt.target,
+				TargetID: tt.targetID,
+			}
+			require.NoError(t, service.ScheduleNotification(ctx, instance, notification))
+			assert.False(t, notification.Pending(), tt.target)
+			assert.Equal(t, len(tt.want), notification.Recipients, tt.target)
+
+			var got []string
+			for _, team := range teams {
+				if received(team.Code) > before[team.Code] {
+					got = append(got, team.Code)
+				}
+			}
+			assert.Equal(t, tt.want, got, tt.target)
+		}
+	})
+
+	t.Run("sends notifications relative to the game when due", func(t *testing.T) {
+		lastCall := &models.ScheduledNotification{
+			Content:       "15 minutes left!",
+			Timing:        models.NotifyBeforeEnd,
+			OffsetMinutes: 15,
+			Target:        models.TargetUnfinished,
+		}
+		require.NoError(t, service.ScheduleNotification(ctx, instance, lastCall))
+		assert.False(t, lastCall.Pending())
+		assert.Equal(t, 2, lastCall.Recipients)
+
+		later := &models.ScheduledNotification{
+			Content:       "Two hours in",
+			Timing:        models.NotifyAfterStart,
+			OffsetMinutes: 120,
+			Target:        models.TargetAllTeams,
+		}
+		require.NoError(t, service.ScheduleNotification(ctx, instance, later))
+		assert.True(t, later.Pending())
+		reminder := &models.ScheduledNotification{
+			Content:       "5 minutes left!",
+			Timing:        models.NotifyBeforeEnd,
+			OffsetMinutes: 5,
+			Target:        models.TargetAllTeams,
+		}
+		require.NoError(t, service.ScheduleNotification(ctx, instance, reminder))
+		assert.True(t, reminder.Pending())
+
+		// Notifications due after the game ended are skipped, as are those
+		// made due by ending the game early
+		instance.StartTime = bun.NullTime{Time: time.Now().UTC().Add(-3 * time.Hour)}
+		instance.EndTime = bun.NullTime{Time: time.Now().UTC().Add(-time.Minute)}
+		require.NoError(t, instanceRepo.Update(ctx, instance))
+		require.NoError(t, service.SendScheduledNotifications(ctx))
+
+		notifications, err := service.ScheduledNotifications(ctx, instance.ID)
+		require.NoError(t, err)
+		skipped := 0
+		for _, notification := range notifications {
+			if notification.ID == later.ID || notification.ID == reminder.ID {
+				assert.False(t, notification.Pending())
+				assert.Equal(t, "The game had ended", notification.Skipped)
+				assert.Zero(t, notification.Recipients)
+				skipped++
+			}
+		}
+		assert.Equal(t, 2, skipped)
+	})
+
+	t.Run("cancels pending notifications", func(t *testing.T) {
+		pending := &models.ScheduledNotification{
+			Content: "Tomorrow",
+			Timing:  models.NotifyAt,
+			SendAt:  time.Now().Add(24 * time.Hour),
+			Target:  models.TargetAllTeams,
+		}
+		require.NoError(t, service.ScheduleNotification(ctx, instance, pending))
+		assert.True(t, pending.Pending())
+
+		err := service.CancelScheduledNotification(ctx, gofakeit.UUID(), pending.ID)
+		require.ErrorIs(t, err, services.ErrNotificationNotFound)
+		require.NoError(t, service.CancelScheduledNotification(ctx, instance.ID, pending.ID))
+		err = service.CancelScheduledNotification(ctx, instance.ID, pending.ID)
+		require.ErrorIs(t, err, services.ErrNotificationNotFound)
+	})
+}
@@ -0,0 +1,463 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+// OrganisationService manages organisations, their members, and the games
+// and templates shared with them.
+type OrganisationService struct {
+	transactor       db.Transactor
+	organisationRepo *repositories.OrganisationRepository
+	instanceRepo     repositories.InstanceRepository
+	userRepo         repositories.UserRepository
+	creditRepo       CreditRepository
+	teamStartLogRepo *repositories.TeamStartLogRepository
+}
+
+func NewOrganisationService(
+	transactor db.Transactor,
+	organisationRepo *repositories.OrganisationRepository,
+	instanceRepo repositories.InstanceRepository,
+	userRepo repositories.UserRepository,
+	creditRepo CreditRepository,
+	teamStartLogRepo *repositories.TeamStartLogRepository,
+) *OrganisationService {
+	return &OrganisationService{
+		transactor:       transactor,
+		organisationRepo: organisationRepo,
+		instanceRepo:     instanceRepo,
+		userRepo:         userRepo,
+		creditRepo:       creditRepo,
+		teamStartLogRepo: teamStartLogRepo,
+	}
+}
+
+// MemberUsage is the number of credits a member's games drew from the pool.
+type MemberUsage struct {
+	Member models.OrganisationMember
+	Used   int
+}
+
+// OrganisationBilling summarises an organisation's credit pool and how its
+// members used it over a month.
+type OrganisationBilling struct {
+	Organisation models.Organisation
+	Role         models.OrganisationRole
+	Month        time.Time
+	Usage        []MemberUsage
+	// Used by people who have since left the organisation
+	FormerMembersUsed int
+	Adjustments       []models.CreditAdjustments
+}
+
+// TotalUsed returns the credits drawn from the pool over the month.
+func (b OrganisationBilling) TotalUsed() int {
+	total := b.FormerMembersUsed
+	for _, usage := range b.Usage {
+		total += usage.Used
+	}
+	return total
+}
+
+// Memberships returns the organisations a user belongs to and their role in
+// each.
+func (s *OrganisationService) Memberships(ctx context.Context, userID string) ([]models.OrganisationMember, error) {
+	members, err := s.organisationRepo.FindMembershipsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding memberships: %w", err)
+	}
+	return members, nil
+}
+
+// Create starts a new organisation with the user as its owner.
+func (s *OrganisationService) Create(ctx context.Context, userID, name string) (*models.Organisation, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+
+	org := &models.Organisation{
+		ID:   uuid.New().String(),
+		Name: name,
+	}
+
+	tx, err := s.transactor.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = s.organisationRepo.CreateWithTx(ctx, tx, org)
+	if err != nil {
+		return nil, fmt.Errorf("creating organisation: %w", err)
+	}
+	err = s.organisationRepo.AddMemberWithTx(ctx, tx, &models.OrganisationMember{
+		OrganisationID: org.ID,
+		UserID:         userID,
+		Role:           models.RoleOwner,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("adding owner: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return org, nil
+}
+
+// Get returns an organisation with its members and shared games, along with
+// the user's role in it.
+func (s *OrganisationService) Get(
+	ctx context.Context,
+	userID, organisationID string,
+) (*models.Organisation, models.OrganisationRole, error) {
+	role, err := s.role(ctx, userID, organisationID)
+	if err != nil {
+		return nil, "", err
+	}
+	org, err := s.organisationRepo.GetByID(ctx, organisationID)
+	if err != nil {
+		return nil, "", fmt.Errorf("finding organisation: %w", err)
+	}
+	return org, role, nil
+}
+
+// Rename changes an organisation's name. Only owners may rename it.
+func (s *OrganisationService) Rename(ctx context.Context, userID, organisationID, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("name cannot be empty")
+	}
+	err := s.requireOwner(ctx, userID, organisationID)
+	if err != nil {
+		return err
+	}
+	err = s.organisationRepo.Update(ctx, &models.Organisation{ID: organisationID, Name: name})
+	if err != nil {
+		return fmt.Errorf("renaming organisation: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an organisation. Its games are kept by the members who
+// created them. Only owners may delete it.
+func (s *OrganisationService) Delete(ctx context.Context, userID, organisationID string) error {
+	err := s.requireOwner(ctx, userID, organisationID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.transactor.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = s.organisationRepo.DeleteWithTx(ctx, tx, organisationID)
+	if err != nil {
+		return fmt.Errorf("deleting organisation: %w", err)
+	}
+	return tx.Commit()
+}
+
+// AddMember gives the user with the email address a role in the
+// organisation. Only owners may add members.
+func (s *OrganisationService) AddMember(
+	ctx context.Context,
+	userID, organisationID, email string,
+	role models.OrganisationRole,
+) (*models.OrganisationMember, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+	err := s.requireOwner(ctx, userID, organisationID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoAccountForEmail
+	} else if err != nil {
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+
+	_, err = s.organisationRepo.GetMember(ctx, organisationID, user.ID)
+	if err == nil {
+		return nil, ErrAlreadyMember
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("checking membership: %w", err)
+	}
+
+	member := &models.OrganisationMember{
+		OrganisationID: organisationID,
+		UserID:         user.ID,
+		Role:           role,
+	}
+	err = s.organisationRepo.AddMember(ctx, member)
+	if err != nil {
+		return nil, fmt.Errorf("adding member: %w", err)
+	}
+	member.User = user
+	return member, nil
+}
+
+// SetRole changes a member's role. Only owners may change roles, and the
+// last owner cannot step down.
+func (s *OrganisationService) SetRole(
+	ctx context.Context,
+	userID, organisationID, memberID string,
+	role models.OrganisationRole,
+) error {
+	if !role.IsValid() {
+		return ErrInvalidRole
+	}
+	err := s.requireOwner(ctx, userID, organisationID)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.organisationRepo.GetMember(ctx, organisationID, memberID)
+	if err != nil {
+		return fmt.Errorf("finding member: %w", err)
+	}
+	if member.Role == models.RoleOwner && role != models.RoleOwner {
+		err = s.checkOtherOwners(ctx, organisationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.organisationRepo.UpdateMemberRole(ctx, organisationID, memberID, role)
+	if err != nil {
+		return fmt.Errorf("updating role: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember takes a member out of an organisation. Owners may remove
+// anyone and members may remove themselves, but the last owner cannot leave.
+func (s *OrganisationService) RemoveMember(ctx context.Context, userID, organisationID, memberID string) error {
+	if userID != memberID {
+		err := s.requireOwner(ctx, userID, organisationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	member, err := s.organisationRepo.GetMember(ctx, organisationID, memberID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPermissionDenied
+	} else if err != nil {
+		return fmt.Errorf("finding member: %w", err)
+	}
+	if member.Role == models.RoleOwner {
+		err = s.checkOtherOwners(ctx, organisationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.organisationRepo.RemoveMember(ctx, organisationID, memberID)
+	if err != nil {
+		return fmt.Errorf("removing member: %w", err)
+	}
+	return nil
+}
+
+// ShareInstance shares a game or template with an organisation. Users may
+// share what they created with organisations they can edit in.
+func (s *OrganisationService) ShareInstance(ctx context.Context, userID, organisationID, instanceID string) error {
+	role, err := s.role(ctx, userID, organisationID)
+	if err != nil {
+		return err
+	}
+	if !role.CanEdit() {
+		return ErrPermissionDenied
+	}
+
+	instance, err := s.instanceRepo.GetByID(ctx, instanceID)
+	if err != nil {
+		return fmt.Errorf("finding instance: %w", err)
+	}
+	if instance.UserID != userID {
+		return ErrPermissionDenied
+	}
+
+	err = s.instanceRepo.SetOrganisation(ctx, instance.ID, organisationID)
+	if err != nil {
+		return fmt.Errorf("sharing instance: %w", err)
+	}
+	return nil
+}
+
+// UnshareInstance stops sharing a game or template. The user who created it
+// and the organisation's owners may stop sharing it.
+func (s *OrganisationService) UnshareInstance(ctx context.Context, userID, instanceID string) error {
+	instance, err := s.instanceRepo.GetByID(ctx, instanceID)
+	if err != nil {
+		return fmt.Errorf("finding instance: %w", err)
+	}
+	if instance.OrganisationID == "" {
+		return nil
+	}
+	if instance.UserID != userID {
+		err = s.requireOwner(ctx, userID, instance.OrganisationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.instanceRepo.SetOrganisation(ctx, instance.ID, "")
+	if err != nil {
+		return fmt.Errorf("unsharing instance: %w", err)
+	}
+	return nil
+}
+
+// Billing returns an organisation's credit balance, its history, and each
+// member's usage for the month containing the given time.
+func (s *OrganisationService) Billing(
+	ctx context.Context,
+	userID, organisationID string,
+	month time.Time,
+) (*OrganisationBilling, error) {
+	org, role, err := s.Get(ctx, userID, organisationID)
+	if err != nil {
+		return nil, err
+	}
+
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	counts, err := s.teamStartLogRepo.CountByOrganisationID(ctx, organisationID, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, fmt.Errorf("counting team starts: %w", err)
+	}
+	adjustments, err := s.creditRepo.GetCreditAdjustmentsByOrganisationID(ctx, organisationID)
+	if err != nil {
+		return nil, fmt.Errorf("finding credit adjustments: %w", err)
+	}
+
+	billing := &OrganisationBilling{
+		Organisation: *org,
+		Role:         role,
+		Month:        month,
+		Adjustments:  adjustments,
+	}
+	for _, member := range org.Members {
+		billing.Usage = append(billing.Usage, MemberUsage{
+			Member: member,
+			Used:   counts[member.UserID],
+		})
+		delete(counts, member.UserID)
+	}
+	for _, used := range counts {
+		billing.FormerMembersUsed += used
+	}
+	return billing, nil
+}
+
+// SetCreditLimit caps how many credits a member's games may draw from the
+// pool each month. A limit of 0 removes the cap. Only owners may set limits.
+func (s *OrganisationService) SetCreditLimit(
+	ctx context.Context,
+	userID, organisationID, memberID string,
+	limit int,
+) error {
+	if limit < 0 {
+		return errors.New("credit limit cannot be negative")
+	}
+	err := s.requireOwner(ctx, userID, organisationID)
+	if err != nil {
+		return err
+	}
+	_, err = s.role(ctx, memberID, organisationID)
+	if err != nil {
+		return err
+	}
+	err = s.organisationRepo.UpdateMemberCreditLimit(ctx, organisationID, memberID, limit)
+	if err != nil {
+		return fmt.Errorf("setting credit limit: %w", err)
+	}
+	return nil
+}
+
+// SetRequireTwoFactor sets whether members must use two-factor
+// authentication. Only owners may change it, and they must have turned it
+// on for themselves first.
+func (s *OrganisationService) SetRequireTwoFactor(
+	ctx context.Context,
+	userID, organisationID string,
+	require bool,
+) error {
+	err := s.requireOwner(ctx, userID, organisationID)
+	if err != nil {
+		return err
+	}
+	if require {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("finding user: %w", err)
+		}
+		if !user.TOTPEnabled {
+			return ErrTwoFactorNotEnabled
+		}
+	}
+	err = s.organisationRepo.UpdateRequireTwoFactor(ctx, organisationID, require)
+	if err != nil {
+		return fmt.Errorf("setting two-factor requirement: %w", err)
+	}
+	return nil
+}
+
+// role returns the user's role in an organisation, or ErrPermissionDenied if
+// they are not a member.
+func (s *OrganisationService) role(
+	ctx context.Context,
+	userID, organisationID string,
+) (models.OrganisationRole, error) {
+	member, err := s.organisationRepo.GetMember(ctx, organisationID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrPermissionDenied
+	} else if err != nil {
+		return "", fmt.Errorf("finding membership: %w", err)
+	}
+	return member.Role, nil
+}
+
+// requireOwner returns ErrPermissionDenied unless the user owns the
+// organisation.
+func (s *OrganisationService) requireOwner(ctx context.Context, userID, organisationID string) error {
+	role, err := s.role(ctx, userID, organisationID)
+	if err != nil {
+		return err
+	}
+	if !role.CanManage() {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// checkOtherOwners returns ErrLastOwner if an owner stepping down would leave
+// the organisation without one.
+func (s *OrganisationService) checkOtherOwners(ctx context.Context, organisationID string) error {
+	owners, err := s.organisationRepo.CountOwners(ctx, organisationID)
+	if err != nil {
+		return fmt.Errorf("counting owners: %w", err)
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
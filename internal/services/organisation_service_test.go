@@ -0,0 +1,327 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+type organisationTestEnv struct {
+	service      *services.OrganisationService
+	access       *services.AccessService
+	credits      *services.CreditService
+	userRepo     repositories.UserRepository
+	instanceRepo repositories.InstanceRepository
+	dbc          *bun.DB
+}
+
+func setupOrganisationService(t *testing.T) (organisationTestEnv, func()) {
+	t.Helper()
+	dbc, cleanup := setupDB(t)
+
+	organisationRepo := repositories.NewOrganisationRepository(dbc)
+	instanceRepo := repositories.NewInstanceRepository(dbc)
+	userRepo := repositories.NewUserRepository(dbc)
+	creditRepo := repositories.NewCreditRepository(dbc)
+	teamStartLogRepo := repositories.NewTeamStartLogRepository(dbc)
+	transactor := db.NewTransactor(dbc)
+
+	env := organisationTestEnv{
+		service: services.NewOrganisationService(
+			transactor,
+			organisationRepo,
+			instanceRepo,
+			userRepo,
+			creditRepo,
+			teamStartLogRepo,
+		),
+		access: services.NewAccessService(
+			repositories.NewBlockRepository(dbc, repositories.NewBlockStateRepository(dbc)),
+			instanceRepo,
+			repositories.NewLocationRepository(dbc),
+			repositories.NewMarkerRepository(dbc),
+			organisationRepo,
+		),
+		credits:      services.NewCreditService(transactor, creditRepo, teamStartLogRepo, userRepo),
+		userRepo:     userRepo,
+		instanceRepo: instanceRepo,
+		dbc:          dbc,
+	}
+	return env, cleanup
+}
+
+func (env organisationTestEnv) createUser(t *testing.T) *models.User {
+	t.Helper()
+	user := &models.User{
+		ID:    gofakeit.UUID(),
+		Email: gofakeit.Email(),
+		Name:  gofakeit.Name(),
+	}
+	require.NoError(t, env.userRepo.Create(context.Background(), user))
+	return user
+}
+
+func (env organisationTestEnv) createInstance(t *testing.T, userID string) *models.Instance {
+	t.Helper()
+	instance := &models.Instance{
+		Name:   gofakeit.Company(),
+		UserID: userID,
+	}
+	require.NoError(t, env.instanceRepo.Create(context.Background(), instance))
+	return instance
+}
+
+func TestOrganisationService_Members(t *testing.T) {
+	env, cleanup := setupOrganisationService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	owner := env.createUser(t)
+	editor := env.createUser(t)
+	outsider := env.createUser(t)
+
+	org, err := env.service.Create(ctx, owner.ID, "  Outdoor Education  ")
+	require.NoError(t, err)
+	assert.Equal(t, "Outdoor Education", org.Name)
+
+	_, err = env.service.AddMember(ctx, owner.ID, org.ID, editor.Email, models.RoleEditor)
+	require.NoError(t, err)
+
+	_, err = env.service.AddMember(ctx, owner.ID, org.ID, editor.Email, models.RoleViewer)
+	require.ErrorIs(t, err, services.ErrAlreadyMember)
+	_, err = env.service.AddMember(ctx, owner.ID, org.ID, "nobody@example.com", models.RoleViewer)
+	require.ErrorIs(t, err, services.ErrNoAccountForEmail)
+	_, err = env.service.AddMember(ctx, owner.ID, org.ID, outsider.Email, "admin")
+	require.ErrorIs(t, err, services.ErrInvalidRole)
+	_, err = env.service.AddMember(ctx, editor.ID, org.ID, outsider.Email, models.RoleViewer)
+	require.ErrorIs(t, err, services.ErrPermissionDenied, "Only owners add members")
+
+	_, _, err = env.service.Get(ctx, outsider.ID, org.ID)
+	require.ErrorIs(t, err, services.ErrPermissionDenied, "Non-members cannot see the organisation")
+
+	got, role, err := env.service.Get(ctx, editor.ID, org.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleEditor, role)
+	require.Len(t, got.Members, 2)
+	assert.Equal(t, owner.ID, got.Members[0].UserID, "Members are listed in the order they joined")
+
+	// The last owner can neither step down nor leave
+	err = env.service.SetRole(ctx, owner.ID, org.ID, owner.ID, models.RoleEditor)
+	require.ErrorIs(t, err, services.ErrLastOwner)
+	err = env.service.RemoveMember(ctx, owner.ID, org.ID, owner.ID)
+	require.ErrorIs(t, err, services.ErrLastOwner)
+
+	err = env.service.SetRole(ctx, owner.ID, org.ID, editor.ID, models.RoleOwner)
+	require.NoError(t, err)
+	err = env.service.RemoveMember(ctx, owner.ID, org.ID, owner.ID)
+	require.NoError(t, err, "Another owner can take over")
+
+	memberships, err := env.service.Memberships(ctx, owner.ID)
+	require.NoError(t, err)
+	assert.Empty(t, memberships)
+}
+
+func TestOrganisationService_SharedInstanceRoles(t *testing.T) {
+	env, cleanup := setupOrganisationService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	owner := env.createUser(t)
+	org, err := env.service.Create(ctx, owner.ID, "Geography")
+	require.NoError(t, err)
+
+	members := map[models.OrganisationRole]*models.User{}
+	for _, role := range []models.OrganisationRole{models.RoleEditor, models.RoleFacilitator, models.RoleViewer} {
+		members[role] = env.createUser(t)
+		_, err = env.service.AddMember(ctx, owner.ID, org.ID, members[role].Email, role)
+		require.NoError(t, err)
+	}
+	outsider := env.createUser(t)
+
+	game := env.createInstance(t, owner.ID)
+
+	// Before sharing only the creator has access
+	access, err := env.access.CanAdminAccessInstance(ctx, members[models.RoleEditor].ID, game.ID)
+	require.NoError(t, err)
+	assert.False(t, access)
+
+	err = env.service.ShareInstance(ctx, members[models.RoleEditor].ID, org.ID, game.ID)
+	require.ErrorIs(t, err, services.ErrPermissionDenied, "Only the creator can share a game")
+	err = env.service.ShareInstance(ctx, owner.ID, org.ID, game.ID)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		userID     string
+		wantRole   models.OrganisationRole
+		wantAccess bool
+		wantEdit   bool
+	}{
+		{"creator", owner.ID, models.RoleOwner, true, true},
+		{"editor", members[models.RoleEditor].ID, models.RoleEditor, true, true},
+		{"facilitator", members[models.RoleFacilitator].ID, models.RoleFacilitator, true, false},
+		{"viewer", members[models.RoleViewer].ID, models.RoleViewer, true, false},
+		{"outsider", outsider.ID, "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := env.access.InstanceRole(ctx, tt.userID, game.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRole, role)
+
+			access, err := env.access.CanAdminAccessInstance(ctx, tt.userID, game.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAccess, access)
+
+			edit, err := env.access.CanAdminEditInstance(ctx, tt.userID, game.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEdit, edit)
+		})
+	}
+
+	shared, err := env.instanceRepo.FindSharedWithUser(ctx, members[models.RoleViewer].ID)
+	require.NoError(t, err)
+	require.Len(t, shared, 1)
+	assert.Equal(t, game.ID, shared[0].ID)
+
+	// Deleting the organisation hands the game back to its creator
+	err = env.service.Delete(ctx, owner.ID, org.ID)
+	require.NoError(t, err)
+	access, err = env.access.CanAdminAccessInstance(ctx, members[models.RoleEditor].ID, game.ID)
+	require.NoError(t, err)
+	assert.False(t, access)
+	access, err = env.access.CanAdminAccessInstance(ctx, owner.ID, game.ID)
+	require.NoError(t, err)
+	assert.True(t, access)
+}
+
+func TestOrganisationService_UnshareInstance(t *testing.T) {
+	env, cleanup := setupOrganisationService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	owner := env.createUser(t)
+	editor := env.createUser(t)
+	org, err := env.service.Create(ctx, owner.ID, "Science")
+	require.NoError(t, err)
+	_, err = env.service.AddMember(ctx, owner.ID, org.ID, editor.Email, models.RoleEditor)
+	require.NoError(t, err)
+
+	ownersGame := env.createInstance(t, owner.ID)
+	editorsGame := env.createInstance(t, editor.ID)
+	require.NoError(t, env.service.ShareInstance(ctx, owner.ID, org.ID, ownersGame.ID))
+	require.NoError(t, env.service.ShareInstance(ctx, editor.ID, org.ID, editorsGame.ID))
+
+	err = env.service.UnshareInstance(ctx, editor.ID, ownersGame.ID)
+	require.ErrorIs(t, err, services.ErrPermissionDenied, "Editors cannot unshare other members' games")
+
+	err = env.service.UnshareInstance(ctx, owner.ID, editorsGame.ID)
+	require.NoError(t, err, "Owners can unshare any game")
+
+	got, _, err := env.service.Get(ctx, owner.ID, org.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Instances, 1)
+	assert.Equal(t, ownersGame.ID, got.Instances[0].ID)
+}
+
+// startTeam charges for a team start the way the team service does.
+func (env organisationTestEnv) startTeam(t *testing.T, instance *models.Instance) error {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := env.dbc.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+
+	err = env.credits.DeductCreditForTeamStartWithTx(ctx, &tx, instance.UserID, gofakeit.UUID(), instance.ID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func TestOrganisationService_CreditPool(t *testing.T) {
+	env, cleanup := setupOrganisationService(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	owner := env.createUser(t)
+	editor := env.createUser(t)
+	org, err := env.service.Create(ctx, owner.ID, "Faculty of Science")
+	require.NoError(t, err)
+	_, err = env.service.AddMember(ctx, owner.ID, org.ID, editor.Email, models.RoleEditor)
+	require.NoError(t, err)
+
+	_, err = env.dbc.NewUpdate().
+		Model((*models.Organisation)(nil)).
+		Set("paid_credits = 3").
+		Where("id = ?", org.ID).
+		Exec(ctx)
+	require.NoError(t, err)
+	_, err = env.dbc.NewUpdate().
+		Model((*models.User)(nil)).
+		Set("free_credits = 5").
+		Where("id = ?", editor.ID).
+		Exec(ctx)
+	require.NoError(t, err)
+
+	err = env.service.SetCreditLimit(ctx, editor.ID, org.ID, editor.ID, 10)
+	require.ErrorIs(t, err, services.ErrPermissionDenied, "Only owners set limits")
+	err = env.service.SetCreditLimit(ctx, owner.ID, org.ID, editor.ID, -1)
+	require.Error(t, err)
+	err = env.service.SetCreditLimit(ctx, owner.ID, org.ID, editor.ID, 2)
+	require.NoError(t, err)
+
+	game := env.createInstance(t, editor.ID)
+	unshared := env.createInstance(t, editor.ID)
+	require.NoError(t, env.service.ShareInstance(ctx, editor.ID, org.ID, game.ID))
+
+	// Two starts come from the pool, then the limit sends the third to the editor
+	for range 3 {
+		require.NoError(t, env.startTeam(t, game))
+	}
+	// Unshared games are always paid personally
+	require.NoError(t, env.startTeam(t, unshared))
+
+	billing, err := env.service.Billing(ctx, owner.ID, org.ID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, billing.Organisation.PaidCredits)
+	assert.Equal(t, 2, billing.TotalUsed())
+	require.Len(t, billing.Usage, 2)
+	assert.Equal(t, 0, billing.Usage[0].Used)
+	assert.Equal(t, 2, billing.Usage[1].Used)
+	assert.Equal(t, 2, billing.Usage[1].Member.CreditLimit)
+
+	editorAfter, err := env.userRepo.GetByID(ctx, editor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, editorAfter.FreeCredits)
+
+	// With no limit the pool is drained, then the editor pays again
+	err = env.service.SetCreditLimit(ctx, owner.ID, org.ID, editor.ID, 0)
+	require.NoError(t, err)
+	for range 2 {
+		require.NoError(t, env.startTeam(t, game))
+	}
+
+	billing, err = env.service.Billing(ctx, owner.ID, org.ID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, billing.Organisation.PaidCredits)
+	assert.Equal(t, 3, billing.TotalUsed())
+
+	editorAfter, err = env.userRepo.GetByID(ctx, editor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, editorAfter.FreeCredits)
+
+	// Earlier months have no usage
+	billing, err = env.service.Billing(ctx, owner.ID, org.ID, time.Now().AddDate(0, -1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, 0, billing.TotalUsed())
+}
@@ -0,0 +1,454 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+const (
+	// pushTTL is how long a push service holds a message for a device that
+	// is offline. Alerts are only useful during the game.
+	pushTTL = 2 * time.Hour
+	// vapidTokenTTL is how long a signed push request is valid for.
+	vapidTokenTTL = 12 * time.Hour
+	// pushRecordSize is the record size declared in encrypted messages.
+	pushRecordSize = 4096
+	// pushTitle is the heading devices show above an alert.
+	pushTitle = "Game alert"
+	// pushWorkers is how many teams' alerts are sent at once.
+	pushWorkers = 4
+	// pushQueueSize is how many teams' alerts may wait to be sent. Alerts
+	// beyond this are dropped, as the team still sees them in the game.
+	pushQueueSize = 1024
+	// pushSendTimeout is how long sending one team's alerts may take.
+	pushSendTimeout = time.Minute
+)
+
+// PushMessage is what a player's device shows when it receives a push.
+type PushMessage struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// URL is the page opened when the player taps the notification
+	URL string `json:"url"`
+}
+
+// PushSubscriptionKeys are the keys a browser gives for encrypting messages
+// to a device, as returned by PushSubscription.toJSON().
+type PushSubscriptionKeys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+// PushService sends Web Push notifications to players' devices, so alerts
+// reach them while their phones are locked.
+type PushService struct {
+	pushRepo *repositories.PushRepository
+	client   *http.Client
+	logger   *slog.Logger
+
+	mu         sync.Mutex
+	privateKey *ecdsa.PrivateKey
+
+	queue chan pushJob
+}
+
+// pushJob is an alert waiting to be sent to a team's devices.
+type pushJob struct {
+	teamCode string
+	message  PushMessage
+}
+
+func NewPushService(
+	pushRepo *repositories.PushRepository,
+	client *http.Client,
+	logger *slog.Logger,
+) *PushService {
+	return &PushService{
+		pushRepo: pushRepo,
+		client:   client,
+		logger:   logger,
+		queue:    make(chan pushJob, pushQueueSize),
+	}
+}
+
+// Start runs the workers that send queued alerts.
+func (s *PushService) Start() {
+	for range pushWorkers {
+		go func() {
+			for job := range s.queue {
+				ctx, cancel := context.WithTimeout(context.Background(), pushSendTimeout)
+				s.sendToTeam(ctx, job.teamCode, job.message)
+				cancel()
+			}
+		}()
+	}
+}
+
+// PublicKey returns the VAPID public key browsers need to subscribe, base64url
+// encoded.
+func (s *PushService) PublicKey(ctx context.Context) (string, error) {
+	privateKey, err := s.key(ctx)
+	if err != nil {
+		return "", err
+	}
+	publicKey, err := vapidPublicKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(publicKey), nil
+}
+
+// Subscribe saves a device's subscription so it receives the team's alerts.
+func (s *PushService) Subscribe(
+	ctx context.Context,
+	team *models.Team,
+	endpoint string,
+	keys PushSubscriptionKeys,
+) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return ErrInvalidPushSubscription
+	}
+	if _, err = decodePushKey(keys.P256dh); err != nil {
+		return ErrInvalidPushSubscription
+	}
+	if auth, err := decodePushKey(keys.Auth); err != nil || len(auth) != 16 {
+		return ErrInvalidPushSubscription
+	}
+
+	err = s.pushRepo.SaveSubscription(ctx, &models.PushSubscription{
+		CreatedAt:  time.Now().UTC(),
+		InstanceID: team.InstanceID,
+		TeamCode:   team.Code,
+		Endpoint:   endpoint,
+		P256dh:     keys.P256dh,
+		Auth:       keys.Auth,
+	})
+	if err != nil {
+		return fmt.Errorf("saving subscription: %w", err)
+	}
+	return nil
+}
+
+// Unsubscribe stops sending a team's alerts to a device.
+func (s *PushService) Unsubscribe(ctx context.Context, team *models.Team, endpoint string) error {
+	err := s.pushRepo.Delete(ctx, team.Code, endpoint)
+	if err != nil {
+		return fmt.Errorf("deleting subscription: %w", err)
+	}
+	return nil
+}
+
+// SendToTeam queues a message to push to every device subscribed for a
+// team, and returns without waiting for it to be sent. Failures are logged
+// rather than returned, as the alert is still shown when the player next
+// loads a page.
+func (s *PushService) SendToTeam(ctx context.Context, teamCode string, message PushMessage) {
+	select {
+	case s.queue <- pushJob{teamCode: teamCode, message: message}:
+	default:
+		s.logger.WarnContext(ctx, "push queue full, dropping alert", "team_code", teamCode)
+	}
+}
+
+// sendToTeam pushes a message to a team's devices. Devices that have
+// unsubscribed are forgotten.
+func (s *PushService) sendToTeam(ctx context.Context, teamCode string, message PushMessage) {
+	subscriptions, err := s.pushRepo.FindByTeamCode(ctx, teamCode)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "finding push subscriptions", "team_code", teamCode, "error", err)
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "encoding push message", "error", err)
+		return
+	}
+
+	for i := range subscriptions {
+		status, err := s.send(ctx, &subscriptions[i], payload)
+		switch {
+		case status == http.StatusNotFound || status == http.StatusGone:
+			err = s.pushRepo.Delete(ctx, teamCode, subscriptions[i].Endpoint)
+			if err != nil {
+				s.logger.ErrorContext(ctx, "deleting expired push subscription", "error", err)
+			}
+		case err != nil:
+			s.logger.WarnContext(ctx, "push failed",
+				"team_code", teamCode, "subscription_id", subscriptions[i].ID, "error", err)
+		}
+	}
+}
+
+// send encrypts a payload for a device and posts it to the device's push
+// service, returning the response status.
+func (s *PushService) send(ctx context.Context, subscription *models.PushSubscription, payload []byte) (int, error) {
+	privateKey, err := s.key(ctx)
+	if err != nil {
+		return 0, err
+	}
+	body, err := encryptPushPayload(subscription, payload)
+	if err != nil {
+		return 0, fmt.Errorf("encrypting message: %w", err)
+	}
+	authorization, err := vapidAuthorization(privateKey, subscription.Endpoint, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("signing request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(pushTTL.Seconds())))
+	req.Header.Set("Urgency", "high")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("push service responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// key returns the VAPID key push requests are signed with, creating one the
+// first time.
+func (s *PushService) key(ctx context.Context) (*ecdsa.PrivateKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.privateKey != nil {
+		return s.privateKey, nil
+	}
+
+	stored, err := s.pushRepo.GetLatestKey(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		stored, err = s.createKey(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading VAPID key: %w", err)
+	}
+
+	block, _ := pem.Decode([]byte(stored.PrivateKey))
+	if block == nil {
+		return nil, errors.New("VAPID key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing VAPID key: %w", err)
+	}
+	privateKey, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok || privateKey.Curve != elliptic.P256() {
+		return nil, errors.New("VAPID key is not a P-256 key")
+	}
+
+	s.privateKey = privateKey
+	return privateKey, nil
+}
+
+// createKey generates and saves a new VAPID key.
+func (s *PushService) createKey(ctx context.Context) (*models.VAPIDKey, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("encoding key: %w", err)
+	}
+	key := &models.VAPIDKey{
+		ID:         uuid.New().String(),
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}
+	if err = s.pushRepo.CreateKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("saving key: %w", err)
+	}
+	return key, nil
+}
+
+// vapidPublicKey returns the uncompressed point browsers expect as the
+// application server key.
+func vapidPublicKey(privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	publicKey, err := privateKey.PublicKey.ECDH()
+	if err != nil {
+		return nil, err
+	}
+	return publicKey.Bytes(), nil
+}
+
+// vapidSubject is the contact push services can use about Rapua's requests,
+// from VAPID_SUBJECT, CONTACT_EMAIL, or else the site's URL.
+func vapidSubject() string {
+	if subject := os.Getenv("VAPID_SUBJECT"); subject != "" {
+		return subject
+	}
+	if email := os.Getenv("CONTACT_EMAIL"); email != "" {
+		return "mailto:" + email
+	}
+	if site := strings.TrimSuffix(os.Getenv("SITE_URL"), "/"); strings.HasPrefix(site, "https://") {
+		return site
+	}
+	return "https://rapua.nz"
+}
+
+// vapidAuthorization returns the Authorization header identifying Rapua to
+// a push service, following RFC 8292.
+func vapidAuthorization(privateKey *ecdsa.PrivateKey, endpoint string, now time.Time) (string, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{"typ": "JWT", "alg": "ES256"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"aud": parsed.Scheme + "://" + parsed.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": vapidSubject(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, sig, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+	if err != nil {
+		return "", err
+	}
+	// ES256 signatures are the two 32 byte integers side by side
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	sig.FillBytes(signature[32:])
+
+	publicKey, err := vapidPublicKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("vapid t=%s.%s, k=%s",
+		signingInput,
+		base64.RawURLEncoding.EncodeToString(signature),
+		base64.RawURLEncoding.EncodeToString(publicKey),
+	), nil
+}
+
+// encryptPushPayload encrypts a message for a device with the aes128gcm
+// content encoding from RFC 8291, so only that device can read it.
+func encryptPushPayload(subscription *models.PushSubscription, payload []byte) ([]byte, error) {
+	rawDeviceKey, err := decodePushKey(subscription.P256dh)
+	if err != nil {
+		return nil, err
+	}
+	deviceKey, err := ecdh.P256().NewPublicKey(rawDeviceKey)
+	if err != nil {
+		return nil, err
+	}
+	authSecret, err := decodePushKey(subscription.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each message uses a new key pair and salt
+	localKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, err
+	}
+	sharedSecret, err := localKey.ECDH(deviceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	localPublic := localKey.PublicKey().Bytes()
+	keyInfo := "WebPush: info\x00" + string(rawDeviceKey) + string(localPublic)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// The message is a single record, ended by the last record delimiter
+	record := append(append([]byte{}, payload...), 0x02)
+	if len(record)+gcm.Overhead() > pushRecordSize {
+		return nil, errors.New("message is too long to push")
+	}
+
+	header := make([]byte, 0, 16+4+1+len(localPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, pushRecordSize)
+	header = append(header, byte(len(localPublic)))
+	header = append(header, localPublic...)
+	return gcm.Seal(header, nonce, record, nil), nil
+}
+
+// decodePushKey decodes a key from a browser, which may or may not be
+// padded.
+func decodePushKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
@@ -0,0 +1,251 @@
+package services_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pushEndpoint stands in for a browser's push service. It records the
+// requests sent to it and answers with status, after hold is closed if set.
+type pushEndpoint struct {
+	mu       sync.Mutex
+	status   int
+	hold     chan struct{}
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (p *pushEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	p.mu.Lock()
+	hold := p.hold
+	p.mu.Unlock()
+	if hold != nil {
+		<-hold
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, r)
+	p.bodies = append(p.bodies, body)
+	w.WriteHeader(p.status)
+}
+
+// received returns how many requests have been answered.
+func (p *pushEndpoint) received() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.requests)
+}
+
+// pushDevice is a browser subscribed to push messages.
+type pushDevice struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newPushDevice(t *testing.T) *pushDevice {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	require.NoError(t, err)
+	return &pushDevice{key: key, auth: auth}
+}
+
+func (d *pushDevice) keys() services.PushSubscriptionKeys {
+	return services.PushSubscriptionKeys{
+		P256dh: base64.RawURLEncoding.EncodeToString(d.key.PublicKey().Bytes()),
+		Auth:   base64.RawURLEncoding.EncodeToString(d.auth),
+	}
+}
+
+// decrypt reads an aes128gcm message the way a browser would.
+func (d *pushDevice) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	require.Greater(t, len(body), 21)
+	salt := body[:16]
+	assert.Equal(t, uint32(4096), binary.BigEndian.Uint32(body[16:20]))
+	idLen := int(body[20])
+	serverKey, err := ecdh.P256().NewPublicKey(body[21 : 21+idLen])
+	require.NoError(t, err)
+
+	sharedSecret, err := d.key.ECDH(serverKey)
+	require.NoError(t, err)
+	keyInfo := "WebPush: info\x00" + string(d.key.PublicKey().Bytes()) + string(serverKey.Bytes())
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, d.auth, keyInfo, 32)
+	require.NoError(t, err)
+	contentKey, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	require.NoError(t, err)
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(contentKey)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	record, err := gcm.Open(nil, nonce, body[21+idLen:], nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x02), record[len(record)-1], "last record delimiter")
+	return record[:len(record)-1]
+}
+
+// verifyVAPID checks the Authorization header was signed by the public key.
+func verifyVAPID(t *testing.T, header, publicKey string) {
+	t.Helper()
+	token, key, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	require.True(t, ok, header)
+	assert.Equal(t, publicKey, key)
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	require.Len(t, signature, 64)
+	rawKey, err := base64.RawURLEncoding.DecodeString(key)
+	require.NoError(t, err)
+
+	x, y := elliptic.Unmarshal(elliptic.P256(), rawKey) //nolint:staticcheck // Only used to verify in tests
+	require.NotNil(t, x)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	assert.True(t, ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, digest[:], r, s))
+}
+
+func TestPushService_SendNotification(t *testing.T) {
+	dbc, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	endpoint := &pushEndpoint{status: http.StatusCreated}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	pushRepo := repositories.NewPushRepository(dbc)
+	pushService := services.NewPushService(pushRepo, server.Client(), newTLogger(t))
+	pushService.Start()
+	notificationService := services.NewNotificationService(
+		repositories.NewNotificationRepository(dbc),
+		repositories.NewScheduledNotificationRepository(dbc),
+		repositories.NewInstanceRepository(dbc),
+		repositories.NewTeamRepository(dbc),
+		pushService,
+		newTLogger(t),
+	)
+
+	publicKey, err := pushService.PublicKey(ctx)
+	require.NoError(t, err)
+	rawKey, err := base64.RawURLEncoding.DecodeString(publicKey)
+	require.NoError(t, err)
+	assert.Len(t, rawKey, 65)
+
+	team := &models.Team{Code: "ABCD", InstanceID: gofakeit.UUID()}
+	device := newPushDevice(t)
+
+	t.Run("rejects invalid subscriptions", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			endpoint string
+			keys     services.PushSubscriptionKeys
+		}{
+			{"relative endpoint", "/push", device.keys()},
+			{"other scheme", "ftp://example.com/push", device.keys()},
+			{"missing keys", server.URL + "/push", services.PushSubscriptionKeys{}},
+			{"short auth", server.URL + "/push", services.PushSubscriptionKeys{
+				P256dh: device.keys().P256dh,
+				Auth:   "AAAA",
+			}},
+		}
+		for _, tt := range tests {
+			err := pushService.Subscribe(ctx, team, tt.endpoint, tt.keys)
+			assert.ErrorIs(t, err, services.ErrInvalidPushSubscription, tt.name)
+		}
+	})
+
+	t.Run("pushes notifications to subscribed devices", func(t *testing.T) {
+		require.NoError(t, pushService.Subscribe(ctx, team, server.URL+"/device", device.keys()))
+		// Subscribing again replaces the earlier subscription
+		require.NoError(t, pushService.Subscribe(ctx, team, server.URL+"/device", device.keys()))
+
+		// Sending doesn't wait for the push service to answer
+		hold := make(chan struct{})
+		endpoint.mu.Lock()
+		endpoint.hold = hold
+		endpoint.mu.Unlock()
+		_, err := notificationService.SendNotification(ctx, team.Code, "Game ends in 5 minutes, return to base")
+		require.NoError(t, err)
+		assert.Zero(t, endpoint.received())
+		close(hold)
+		require.Eventually(t, func() bool { return endpoint.received() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+		endpoint.mu.Lock()
+		defer endpoint.mu.Unlock()
+		require.Len(t, endpoint.requests, 1)
+		req := endpoint.requests[0]
+		assert.Equal(t, "/device", req.URL.Path)
+		assert.Equal(t, "aes128gcm", req.Header.Get("Content-Encoding"))
+		assert.Equal(t, "7200", req.Header.Get("TTL"))
+		assert.Equal(t, "high", req.Header.Get("Urgency"))
+		verifyVAPID(t, req.Header.Get("Authorization"), publicKey)
+
+		var message services.PushMessage
+		require.NoError(t, json.Unmarshal(device.decrypt(t, endpoint.bodies[0]), &message))
+		assert.Equal(t, "Game ends in 5 minutes, return to base", message.Body)
+		assert.Equal(t, "/next", message.URL)
+		assert.NotEmpty(t, message.Title)
+		endpoint.requests, endpoint.bodies, endpoint.hold = nil, nil, nil
+	})
+
+	t.Run("only unsubscribes the team's own devices", func(t *testing.T) {
+		other := &models.Team{Code: "WXYZ", InstanceID: team.InstanceID}
+		require.NoError(t, pushService.Unsubscribe(ctx, other, server.URL+"/device"))
+		subscriptions, err := pushRepo.FindByTeamCode(ctx, team.Code)
+		require.NoError(t, err)
+		assert.Len(t, subscriptions, 1)
+	})
+
+	t.Run("forgets devices that have unsubscribed", func(t *testing.T) {
+		endpoint.mu.Lock()
+		endpoint.status = http.StatusGone
+		endpoint.mu.Unlock()
+		pushService.SendToTeam(ctx, team.Code, services.PushMessage{Body: "Hello"})
+
+		require.Eventually(t, func() bool {
+			subscriptions, err := pushRepo.FindByTeamCode(ctx, team.Code)
+			return err == nil && len(subscriptions) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("keeps the same key", func(t *testing.T) {
+		restarted := services.NewPushService(pushRepo, server.Client(), newTLogger(t))
+		key, err := restarted.PublicKey(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, publicKey, key)
+	})
+}
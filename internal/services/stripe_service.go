@@ -42,6 +42,7 @@ type StripeService struct {
 	creditService   *CreditService
 	purchaseRepo    *repositories.CreditPurchaseRepository
 	userRepo        repositories.UserRepository
+	orgRepo         *repositories.OrganisationRepository
 	logger          *slog.Logger
 	stripeSecretKey string
 	webhookSecret   string
@@ -53,6 +54,7 @@ func NewStripeService(
 	creditService *CreditService,
 	purchaseRepo *repositories.CreditPurchaseRepository,
 	userRepo repositories.UserRepository,
+	orgRepo *repositories.OrganisationRepository,
 	logger *slog.Logger,
 ) *StripeService {
 	stripeSecretKey := os.Getenv("STRIPE_SECRET_KEY")
@@ -70,6 +72,7 @@ func NewStripeService(
 		creditService:   creditService,
 		purchaseRepo:    purchaseRepo,
 		userRepo:        userRepo,
+		orgRepo:         orgRepo,
 		logger:          logger,
 		stripeSecretKey: stripeSecretKey,
 		webhookSecret:   webhookSecret,
@@ -98,13 +101,87 @@ func (s *StripeService) CreateCheckoutSession(
 		return nil, fmt.Errorf("getting or creating customer: %w", err)
 	}
 
+	return s.createCheckoutSession(ctx, checkoutRequest{
+		userID:     userID,
+		customerID: customerID,
+		credits:    credits,
+		successURL: fmt.Sprintf("%s/admin/credits/success?session_id={CHECKOUT_SESSION_ID}", s.siteURL),
+		cancelURL:  fmt.Sprintf("%s/admin/credits/cancel", s.siteURL),
+	})
+}
+
+// CreateOrganisationCheckoutSession creates a Stripe Checkout session that
+// adds credits to an organisation's pool. Only owners may buy credits.
+func (s *StripeService) CreateOrganisationCheckoutSession(
+	ctx context.Context,
+	userID, organisationID string,
+	credits int,
+) (*stripe.CheckoutSession, error) {
+	if credits < MinCreditsPerPurchase {
+		return nil, ErrInvalidCreditAmount
+	}
+
+	if s.stripeSecretKey == "" || s.webhookSecret == "" {
+		return nil, ErrStripeNotConfigured
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, organisationID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPermissionDenied
+	} else if err != nil {
+		return nil, fmt.Errorf("getting membership: %w", err)
+	}
+	if !member.Role.CanManage() {
+		return nil, ErrPermissionDenied
+	}
+
+	customerID, err := s.getOrCreateOrganisationCustomer(ctx, userID, organisationID)
+	if err != nil {
+		return nil, fmt.Errorf("getting or creating customer: %w", err)
+	}
+
+	billingURL := fmt.Sprintf("%s/admin/organisations/%s/credits", s.siteURL, organisationID)
+	return s.createCheckoutSession(ctx, checkoutRequest{
+		userID:         userID,
+		organisationID: organisationID,
+		customerID:     customerID,
+		credits:        credits,
+		successURL:     billingURL + "?session_id={CHECKOUT_SESSION_ID}",
+		cancelURL:      billingURL,
+	})
+}
+
+// checkoutRequest describes a credit purchase for createCheckoutSession.
+type checkoutRequest struct {
+	userID         string
+	organisationID string
+	customerID     string
+	credits        int
+	successURL     string
+	cancelURL      string
+}
+
+// createCheckoutSession opens a Stripe Checkout session and records the
+// pending purchase.
+func (s *StripeService) createCheckoutSession(
+	ctx context.Context,
+	req checkoutRequest,
+) (*stripe.CheckoutSession, error) {
 	// Calculate amount
-	amountInCents := models.CalculatePurchaseAmount(credits)
+	amountInCents := models.CalculatePurchaseAmount(req.credits)
 
 	// Create checkout session
 	purchaseID := uuid.New().String()
+	metadata := map[string]string{
+		"user_id":     req.userID,
+		"purchase_id": purchaseID,
+		"credits":     strconv.Itoa(req.credits),
+	}
+	if req.organisationID != "" {
+		metadata["organisation_id"] = req.organisationID
+	}
 	params := &stripe.CheckoutSessionParams{
-		Customer: stripe.String(customerID),
+		Customer: stripe.String(req.customerID),
 		Mode:     stripe.String(string(stripe.CheckoutSessionModePayment)),
 		LineItems: []*stripe.CheckoutSessionLineItemParams{
 			{
@@ -112,20 +189,16 @@ func (s *StripeService) CreateCheckoutSession(
 					Currency: stripe.String("nzd"),
 					ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
 						Name:        stripe.String("Rapua Credits"),
-						Description: stripe.String(fmt.Sprintf("%d credits for team starts", credits)),
+						Description: stripe.String(fmt.Sprintf("%d credits for team starts", req.credits)),
 					},
 					UnitAmount: stripe.Int64(int64(config.CreditPriceCents())),
 				},
-				Quantity: stripe.Int64(int64(credits)),
+				Quantity: stripe.Int64(int64(req.credits)),
 			},
 		},
-		SuccessURL: stripe.String(fmt.Sprintf("%s/admin/credits/success?session_id={CHECKOUT_SESSION_ID}", s.siteURL)),
-		CancelURL:  stripe.String(fmt.Sprintf("%s/admin/credits/cancel", s.siteURL)),
-		Metadata: map[string]string{
-			"user_id":     userID,
-			"purchase_id": purchaseID,
-			"credits":     strconv.Itoa(credits),
-		},
+		SuccessURL: stripe.String(req.successURL),
+		CancelURL:  stripe.String(req.cancelURL),
+		Metadata:   metadata,
 	}
 
 	params.SetIdempotencyKey(purchaseID)
@@ -140,12 +213,13 @@ func (s *StripeService) CreateCheckoutSession(
 		ID:              purchaseID,
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
-		UserID:          userID,
-		Credits:         credits,
+		UserID:          req.userID,
+		OrganisationID:  req.organisationID,
+		Credits:         req.credits,
 		AmountPaid:      amountInCents,
 		StripeSessionID: sess.ID,
 		StripeCustomerID: sql.NullString{
-			String: customerID,
+			String: req.customerID,
 			Valid:  true,
 		},
 		Status: models.CreditPurchaseStatusPending,
@@ -157,9 +231,10 @@ func (s *StripeService) CreateCheckoutSession(
 	}
 
 	s.logger.InfoContext(ctx, "Created checkout session",
-		"user_id", userID,
+		"user_id", req.userID,
+		"organisation_id", req.organisationID,
 		"purchase_id", purchaseID,
-		"credits", credits,
+		"credits", req.credits,
 		"session_id", sess.ID,
 	)
 
@@ -212,6 +287,52 @@ func (s *StripeService) getOrCreateCustomer(ctx context.Context, userID string)
 	return cust.ID, nil
 }
 
+// getOrCreateOrganisationCustomer gets or creates a Stripe customer for an
+// organisation, using the purchasing owner's email for receipts.
+func (s *StripeService) getOrCreateOrganisationCustomer(
+	ctx context.Context,
+	userID, organisationID string,
+) (string, error) {
+	org, err := s.orgRepo.GetByID(ctx, organisationID)
+	if err != nil {
+		return "", fmt.Errorf("getting organisation: %w", err)
+	}
+
+	if org.StripeCustomerID.Valid && org.StripeCustomerID.String != "" {
+		return org.StripeCustomerID.String, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("getting user: %w", err)
+	}
+
+	params := &stripe.CustomerParams{
+		Email: stripe.String(user.Email),
+		Name:  stripe.String(org.Name),
+		Metadata: map[string]string{
+			"organisation_id": org.ID,
+		},
+	}
+
+	cust, err := customer.New(params)
+	if err != nil {
+		return "", fmt.Errorf("creating Stripe customer: %w", err)
+	}
+
+	err = s.orgRepo.UpdateStripeCustomerID(ctx, org.ID, cust.ID)
+	if err != nil {
+		return "", fmt.Errorf("updating organisation with customer ID: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Created Stripe customer",
+		"organisation_id", org.ID,
+		"customer_id", cust.ID,
+	)
+
+	return cust.ID, nil
+}
+
 // ProcessWebhook processes Stripe webhook events.
 func (s *StripeService) ProcessWebhook(ctx context.Context, payload []byte, signature string) error {
 	if s.webhookSecret == "" {
@@ -304,12 +425,22 @@ func (s *StripeService) handleCheckoutSessionCompleted(ctx context.Context, even
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	// Add credits to user account
+	// Add credits to the user's account, or the organisation's pool
 	reason := fmt.Sprintf("%s: via Stripe",
 		models.CreditAdjustmentReasonPrefixPurchase,
 	)
 
-	err = s.creditService.creditRepo.AddCreditsWithTx(ctx, tx, purchase.UserID, 0, purchase.Credits)
+	if purchase.OrganisationID != "" {
+		err = s.creditService.creditRepo.AddOrganisationCreditsWithTx(
+			ctx,
+			tx,
+			purchase.OrganisationID,
+			0,
+			purchase.Credits,
+		)
+	} else {
+		err = s.creditService.creditRepo.AddCreditsWithTx(ctx, tx, purchase.UserID, 0, purchase.Credits)
+	}
 	if err != nil {
 		return fmt.Errorf("adding credits: %w", err)
 	}
@@ -319,6 +450,7 @@ func (s *StripeService) handleCheckoutSessionCompleted(ctx context.Context, even
 		ID:               uuid.New().String(),
 		CreatedAt:        time.Now(),
 		UserID:           purchase.UserID,
+		OrganisationID:   purchase.OrganisationID,
 		Credits:          purchase.Credits,
 		Reason:           reason,
 		CreditPurchaseID: sql.NullString{String: purchase.ID, Valid: true},
@@ -373,6 +505,7 @@ func (s *StripeService) handleCheckoutSessionCompleted(ctx context.Context, even
 	s.logger.InfoContext(ctx, "Purchase completed successfully",
 		"purchase_id", purchase.ID,
 		"user_id", purchase.UserID,
+		"organisation_id", purchase.OrganisationID,
 		"credits", purchase.Credits,
 		"session_id", sess.ID,
 	)
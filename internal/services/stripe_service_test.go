@@ -31,7 +31,14 @@ func setupStripeService(
 	purchaseRepo := repositories.NewCreditPurchaseRepository(dbc)
 
 	creditService := services.NewCreditService(transactor, creditRepo, teamStartLogRepo, userRepo)
-	stripeService := services.NewStripeService(transactor, creditService, purchaseRepo, userRepo, newTLogger(t))
+	stripeService := services.NewStripeService(
+		transactor,
+		creditService,
+		purchaseRepo,
+		userRepo,
+		repositories.NewOrganisationRepository(dbc),
+		newTLogger(t),
+	)
 
 	return stripeService, userRepo, purchaseRepo, transactor, creditRepo, cleanup
 }
@@ -43,6 +43,7 @@ type TeamService struct {
 	creditService  TeamCreditService
 	blockStateRepo repositories.BlockStateRepository
 	locationRepo   repositories.LocationRepository
+	webhooks       WebhookDispatcher
 	batchSize      int
 }
 
@@ -54,6 +55,7 @@ func NewTeamService(
 	creditService TeamCreditService,
 	bsr repositories.BlockStateRepository,
 	lr repositories.LocationRepository,
+	webhooks WebhookDispatcher,
 ) *TeamService {
 	return &TeamService{
 		transactor:     transactor,
@@ -62,6 +64,7 @@ func NewTeamService(
 		creditService:  creditService,
 		blockStateRepo: bsr,
 		locationRepo:   lr,
+		webhooks:       webhooks,
 		batchSize:      batchSize,
 	}
 }
@@ -135,6 +138,11 @@ func (s *TeamService) FindAll(ctx context.Context, instanceID string) ([]models.
 	return s.teamRepo.FindAll(ctx, instanceID)
 }
 
+// FindAllWithScans returns all teams for an instance with their check-ins.
+func (s *TeamService) FindAllWithScans(ctx context.Context, instanceID string) ([]models.Team, error) {
+	return s.teamRepo.FindAllWithScans(ctx, instanceID)
+}
+
 // GetTeamByCode returns a team by code.
 func (s *TeamService) GetTeamByCode(ctx context.Context, code string) (*models.Team, error) {
 	code = strings.TrimSpace(strings.ToUpper(code))
@@ -286,7 +294,16 @@ func (s *TeamService) StartPlaying(ctx context.Context, teamCode string) error {
 		return errors.New("updating team as started: " + err.Error())
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	key := string(models.EventTeamStarted) + ":" + team.Code
+	dispatchWebhook(ctx, s.webhooks, team.InstanceID, models.EventTeamStarted, key, webhookEventData{
+		Team: newWebhookTeam(team),
+	})
+	return nil
 }
 
 // BuildLocationGroupMap creates a map from location ID to group info.
@@ -42,6 +42,7 @@ func setupTeamsService(t *testing.T) (services.TeamService, func()) {
 		creditService,
 		blockStateRepo,
 		locationRepo,
+		nil,
 	)
 
 	return *teamService, cleanup
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+// TrashService lists and restores deleted games, locations and teams.
+// Purging is handled by DeleteService.
+type TrashService struct {
+	transactor   db.Transactor
+	trashRepo    *repositories.TrashRepository
+	instanceRepo repositories.InstanceRepository
+	locationRepo repositories.LocationRepository
+}
+
+func NewTrashService(
+	transactor db.Transactor,
+	trashRepo *repositories.TrashRepository,
+	instanceRepo repositories.InstanceRepository,
+	locationRepo repositories.LocationRepository,
+) *TrashService {
+	return &TrashService{
+		transactor:   transactor,
+		trashRepo:    trashRepo,
+		instanceRepo: instanceRepo,
+		locationRepo: locationRepo,
+	}
+}
+
+// List returns the user's trash, most recently deleted first.
+func (s *TrashService) List(ctx context.Context, userID string) ([]models.TrashItem, error) {
+	items, err := s.trashRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding trash: %w", err)
+	}
+	return items, nil
+}
+
+// Restore puts a trashed item back with everything that was deleted with it.
+// Locations and teams can only be restored while their game exists, otherwise
+// ErrGameInTrash is returned.
+func (s *TrashService) Restore(ctx context.Context, userID, itemID string) (*models.TrashItem, error) {
+	item, err := s.trashRepo.GetByID(ctx, userID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("finding trash item: %w", err)
+	}
+
+	if item.Type != models.TrashInstance {
+		_, err = s.instanceRepo.GetByID(ctx, item.InstanceID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGameInTrash
+		}
+		if err != nil {
+			return nil, fmt.Errorf("finding instance: %w", err)
+		}
+	}
+
+	snapshot, err := item.Snapshot()
+	if err != nil {
+		return nil, fmt.Errorf("decoding snapshot: %w", err)
+	}
+
+	tx, err := s.transactor.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				slog.Error("transaction", "error", rollbackErr)
+			}
+			panic(p)
+		}
+	}()
+
+	err = s.trashRepo.RestoreWithTx(ctx, tx, snapshot)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return nil, fmt.Errorf("restoring rows: %w; rollback failed: %w", err, rollbackErr)
+		}
+		return nil, fmt.Errorf("restoring rows: %w", err)
+	}
+
+	// Restored teams count towards location visits again
+	if item.Type == models.TrashTeam {
+		err = s.locationRepo.UpdateStatistics(ctx, tx, item.InstanceID)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return nil, fmt.Errorf("updating location statistics: %w; rollback failed: %w", err, rollbackErr)
+			}
+			return nil, fmt.Errorf("updating location statistics: %w", err)
+		}
+	}
+
+	err = s.trashRepo.DeleteWithTx(ctx, tx, item.ID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return nil, fmt.Errorf("deleting trash item: %w; rollback failed: %w", err, rollbackErr)
+		}
+		return nil, fmt.Errorf("deleting trash item: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return item, nil
+}
@@ -0,0 +1,281 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func setupTrashService(t *testing.T) (*services.TrashService, *services.DeleteService, *bun.DB, func()) {
+	t.Helper()
+	deleteService, dbc, cleanup := setupDeleteService(t)
+
+	trashService := services.NewTrashService(
+		db.NewTransactor(dbc),
+		repositories.NewTrashRepository(dbc),
+		repositories.NewInstanceRepository(dbc),
+		repositories.NewLocationRepository(dbc),
+	)
+	return trashService, deleteService, dbc, cleanup
+}
+
+// trashTestGame is a game with one location and one team that has played it.
+type trashTestGame struct {
+	instance *models.Instance
+	location *models.Location
+	block    *models.Block
+	team     *models.Team
+}
+
+func newTrashTestGame(t *testing.T, dbc *bun.DB) trashTestGame {
+	t.Helper()
+	ctx := context.Background()
+
+	game := trashTestGame{
+		instance: &models.Instance{
+			ID:     gofakeit.UUID(),
+			UserID: gofakeit.UUID(),
+			Name:   "Treasure Hunt",
+		},
+		location: &models.Location{
+			ID:       gofakeit.UUID(),
+			Name:     "Clock Tower",
+			MarkerID: gofakeit.LetterN(5),
+		},
+		team: &models.Team{
+			ID:   gofakeit.UUID(),
+			Code: gofakeit.LetterN(5),
+			Name: "Explorers",
+		},
+	}
+	game.location.InstanceID = game.instance.ID
+	game.team.InstanceID = game.instance.ID
+	game.block = &models.Block{
+		ID:      gofakeit.UUID(),
+		OwnerID: game.location.ID,
+		Type:    "markdown",
+		Context: blocks.ContextLocationContent,
+		Data:    []byte(`{"content":"Welcome"}`),
+	}
+
+	rows := []any{
+		game.instance,
+		&models.InstanceSettings{InstanceID: game.instance.ID, EnablePoints: true},
+		&models.Marker{Code: game.location.MarkerID, Name: "Clock Tower", Lat: -45.86, Lng: 170.51},
+		game.location,
+		game.block,
+		game.team,
+		&models.TeamBlockState{TeamCode: game.team.Code, BlockID: game.block.ID, IsComplete: true},
+		&models.CheckIn{
+			InstanceID: game.instance.ID,
+			TeamID:     game.team.Code,
+			LocationID: game.location.ID,
+			TimeIn:     time.Now(),
+		},
+		&models.Upload{
+			ID:          gofakeit.UUID(),
+			OriginalURL: "/static/uploads/2026/10/18/photo.jpg",
+			InstanceID:  game.instance.ID,
+			LocationID:  game.location.ID,
+			BlockID:     game.block.ID,
+			TeamCode:    game.team.Code,
+			Storage:     "local",
+			Type:        models.MediaTypeImage,
+		},
+	}
+	for _, row := range rows {
+		_, err := dbc.NewInsert().Model(row).Exec(ctx)
+		require.NoError(t, err)
+	}
+	return game
+}
+
+func countRows(t *testing.T, dbc *bun.DB, model any, where string, args ...any) int {
+	t.Helper()
+	count, err := dbc.NewSelect().Model(model).Where(where, args...).Count(context.Background())
+	require.NoError(t, err)
+	return count
+}
+
+func TestTrashService_RestoreLocation(t *testing.T) {
+	trashService, deleteService, dbc, cleanup := setupTrashService(t)
+	defer cleanup()
+	ctx := context.Background()
+	game := newTrashTestGame(t, dbc)
+
+	require.NoError(t, deleteService.DeleteLocation(ctx, game.location.ID))
+
+	assert.Zero(t, countRows(t, dbc, (*models.Location)(nil), "id = ?", game.location.ID))
+	assert.Zero(t, countRows(t, dbc, (*models.Block)(nil), "owner_id = ?", game.location.ID))
+	assert.Zero(t, countRows(t, dbc, (*models.TeamBlockState)(nil), "block_id = ?", game.block.ID))
+	assert.Zero(t, countRows(t, dbc, (*models.CheckIn)(nil), "location_id = ?", game.location.ID))
+	assert.Zero(t, countRows(t, dbc, (*models.Upload)(nil), "block_id = ?", game.block.ID))
+	assert.Zero(t, countRows(t, dbc, (*models.Marker)(nil), "code = ?", game.location.MarkerID),
+		"Unused markers are cleaned up")
+	assert.Equal(t, 1, countRows(t, dbc, (*models.Team)(nil), "code = ?", game.team.Code),
+		"Teams are not affected")
+
+	items, err := trashService.List(ctx, game.instance.UserID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, models.TrashLocation, items[0].Type)
+	assert.Equal(t, "Clock Tower", items[0].Name)
+	assert.True(t, items[0].PurgeAt.After(time.Now()))
+
+	_, err = trashService.Restore(ctx, gofakeit.UUID(), items[0].ID)
+	require.Error(t, err, "Only the game's owner can restore it")
+
+	restored, err := trashService.Restore(ctx, game.instance.UserID, items[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, game.location.ID, restored.EntityID)
+
+	assert.Equal(t, 1, countRows(t, dbc, (*models.Location)(nil), "id = ?", game.location.ID))
+	assert.Equal(t, 1, countRows(t, dbc, (*models.Block)(nil), "owner_id = ?", game.location.ID))
+	assert.Equal(t, 1, countRows(t, dbc, (*models.TeamBlockState)(nil), "block_id = ?", game.block.ID))
+	assert.Equal(t, 1, countRows(t, dbc, (*models.CheckIn)(nil), "location_id = ?", game.location.ID))
+	assert.Equal(t, 1, countRows(t, dbc, (*models.Upload)(nil), "block_id = ?", game.block.ID))
+	assert.Equal(t, 1, countRows(t, dbc, (*models.Marker)(nil), "code = ?", game.location.MarkerID))
+
+	items, err = trashService.List(ctx, game.instance.UserID)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestTrashService_RestoreTeamNeedsGame(t *testing.T) {
+	trashService, deleteService, dbc, cleanup := setupTrashService(t)
+	defer cleanup()
+	ctx := context.Background()
+	game := newTrashTestGame(t, dbc)
+	userID := game.instance.UserID
+
+	require.NoError(t, deleteService.DeleteTeams(ctx, game.instance.ID, []string{game.team.Code}))
+	assert.Zero(t, countRows(t, dbc, (*models.Team)(nil), "code = ?", game.team.Code))
+	assert.Zero(t, countRows(t, dbc, (*models.TeamBlockState)(nil), "team_code = ?", game.team.Code))
+
+	require.NoError(t, deleteService.DeleteInstance(ctx, userID, game.instance.ID))
+	assert.Zero(t, countRows(t, dbc, (*models.Instance)(nil), "id = ?", game.instance.ID))
+	assert.Zero(t, countRows(t, dbc, (*models.InstanceSettings)(nil), "instance_id = ?", game.instance.ID))
+
+	items, err := trashService.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, models.TrashInstance, items[0].Type)
+	assert.Equal(t, models.TrashTeam, items[1].Type)
+	assert.Equal(t, "Explorers", items[1].Name)
+
+	// The team cannot come back without its game
+	_, err = trashService.Restore(ctx, userID, items[1].ID)
+	require.ErrorIs(t, err, services.ErrGameInTrash)
+
+	_, err = trashService.Restore(ctx, userID, items[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, countRows(t, dbc, (*models.Location)(nil), "instance_id = ?", game.instance.ID))
+	assert.Equal(t, 1, countRows(t, dbc, (*models.Block)(nil), "id = ?", game.block.ID))
+	assert.Zero(t, countRows(t, dbc, (*models.Team)(nil), "code = ?", game.team.Code))
+
+	_, err = trashService.Restore(ctx, userID, items[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, countRows(t, dbc, (*models.Team)(nil), "code = ?", game.team.Code))
+	assert.Equal(t, 1, countRows(t, dbc, (*models.TeamBlockState)(nil), "team_code = ?", game.team.Code))
+	assert.Equal(t, 1, countRows(t, dbc, (*models.CheckIn)(nil), "team_code = ?", game.team.Code))
+	assert.Equal(t, 1, countRows(t, dbc, (*models.Upload)(nil), "team_code = ?", game.team.Code))
+
+	settings := new(models.InstanceSettings)
+	require.NoError(t, dbc.NewSelect().Model(settings).Where("instance_id = ?", game.instance.ID).Scan(ctx))
+	assert.True(t, settings.EnablePoints)
+}
+
+func TestDeleteService_PurgeTrash(t *testing.T) {
+	trashService, deleteService, dbc, cleanup := setupTrashService(t)
+	defer cleanup()
+	ctx := context.Background()
+	game := newTrashTestGame(t, dbc)
+	userID := game.instance.UserID
+
+	require.NoError(t, deleteService.DeleteLocation(ctx, game.location.ID))
+	require.NoError(t, deleteService.DeleteInstance(ctx, userID, game.instance.ID))
+
+	items, err := trashService.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// Purging the game also purges the location trashed from it
+	require.NoError(t, deleteService.PurgeTrash(ctx, userID, items[0].ID))
+	items, err = trashService.List(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestDeleteService_PurgeExpiredTrash(t *testing.T) {
+	trashService, deleteService, dbc, cleanup := setupTrashService(t)
+	defer cleanup()
+	ctx := context.Background()
+	game := newTrashTestGame(t, dbc)
+	userID := game.instance.UserID
+
+	require.NoError(t, deleteService.DeleteTeams(ctx, game.instance.ID, []string{game.team.Code}))
+	require.NoError(t, deleteService.DeleteLocation(ctx, game.location.ID))
+
+	items, err := trashService.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// Only the location has reached the end of its retention period
+	_, err = dbc.NewUpdate().
+		Model((*models.TrashItem)(nil)).
+		Set("purge_at = ?", time.Now().Add(-time.Hour)).
+		Where("type = ?", models.TrashLocation).
+		Exec(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, deleteService.PurgeExpiredTrash(ctx))
+
+	items, err = trashService.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, models.TrashTeam, items[0].Type)
+}
+
+func TestDeleteService_PurgeExpiredTrashSkipsFailures(t *testing.T) {
+	trashService, deleteService, dbc, cleanup := setupTrashService(t)
+	defer cleanup()
+	ctx := context.Background()
+	game := newTrashTestGame(t, dbc)
+	userID := game.instance.UserID
+
+	require.NoError(t, deleteService.DeleteTeams(ctx, game.instance.ID, []string{game.team.Code}))
+	require.NoError(t, deleteService.DeleteLocation(ctx, game.location.ID))
+
+	// Both items have expired, but the team's can't be purged
+	_, err := dbc.NewUpdate().
+		Model((*models.TrashItem)(nil)).
+		Set("purge_at = ?", time.Now().Add(-time.Hour)).
+		Where("1 = 1").
+		Exec(ctx)
+	require.NoError(t, err)
+	_, err = dbc.NewUpdate().
+		Model((*models.TrashItem)(nil)).
+		Set("data = ?", "not a snapshot").
+		Where("type = ?", models.TrashTeam).
+		Exec(ctx)
+	require.NoError(t, err)
+
+	err = deleteService.PurgeExpiredTrash(ctx)
+	require.ErrorContains(t, err, "decoding snapshot")
+
+	// The location is purged anyway, and the team is tried again next time
+	items, err := trashService.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, models.TrashTeam, items[0].Type)
+}
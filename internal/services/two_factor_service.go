@@ -0,0 +1,267 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/nathanhollows/Rapua/v6/security"
+	go_qr "github.com/piglig/go-qr"
+)
+
+const (
+	// totpIssuer names Rapua in authenticator apps.
+	totpIssuer = "Rapua"
+	// recoveryCodeCount is how many recovery codes a user gets at a time.
+	recoveryCodeCount = 10
+	// totpQRScale keeps the enrolment QR code small enough for the page.
+	totpQRScale = 6
+)
+
+// recoveryCodeEncoding avoids characters that are easy to misread.
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijkmnpqrstuvwxyz23456789").WithPadding(base32.NoPadding)
+
+// TwoFactorService manages TOTP two-factor authentication for admin
+// accounts, and the recovery codes users can log in with instead.
+type TwoFactorService struct {
+	userRepo         repositories.UserRepository
+	recoveryCodeRepo *repositories.RecoveryCodeRepository
+	organisationRepo *repositories.OrganisationRepository
+}
+
+func NewTwoFactorService(
+	userRepo repositories.UserRepository,
+	recoveryCodeRepo *repositories.RecoveryCodeRepository,
+	organisationRepo *repositories.OrganisationRepository,
+) *TwoFactorService {
+	return &TwoFactorService{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		organisationRepo: organisationRepo,
+	}
+}
+
+// TwoFactorEnrolment is what a user needs to add their account to an
+// authenticator app.
+type TwoFactorEnrolment struct {
+	// Secret is for typing into an app that can't scan QR codes
+	Secret string
+	// QRCode is a PNG data URI of the otpauth:// URI
+	QRCode string
+}
+
+// TwoFactorStatus summarises a user's two-factor authentication.
+type TwoFactorStatus struct {
+	Enabled bool
+	// Required is set when one of the user's organisations requires it
+	Required          bool
+	RecoveryCodesLeft int
+}
+
+// Status returns whether the user has two-factor authentication, whether
+// they must, and how many recovery codes they have left.
+func (s *TwoFactorService) Status(ctx context.Context, user *models.User) (TwoFactorStatus, error) {
+	status := TwoFactorStatus{Enabled: user.TOTPEnabled}
+	required, err := s.Required(ctx, user.ID)
+	if err != nil {
+		return status, err
+	}
+	status.Required = required
+	if user.TOTPEnabled {
+		status.RecoveryCodesLeft, err = s.recoveryCodeRepo.CountUnused(ctx, user.ID)
+		if err != nil {
+			return status, fmt.Errorf("counting recovery codes: %w", err)
+		}
+	}
+	return status, nil
+}
+
+// Required reports whether any of the user's organisations requires
+// two-factor authentication.
+func (s *TwoFactorService) Required(ctx context.Context, userID string) (bool, error) {
+	required, err := s.organisationRepo.RequiresTwoFactor(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("checking organisations: %w", err)
+	}
+	return required, nil
+}
+
+// BeginEnrolment gives the user a new secret to add to their authenticator
+// app. Two-factor authentication is not turned on until they confirm a code
+// with ConfirmEnrolment.
+func (s *TwoFactorService) BeginEnrolment(ctx context.Context, user *models.User) (*TwoFactorEnrolment, error) {
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	secret, err := security.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("generating secret: %w", err)
+	}
+	qr, err := go_qr.EncodeText(security.TOTPURI(totpIssuer, user.Email, secret), go_qr.Medium)
+	if err != nil {
+		return nil, fmt.Errorf("encoding QR code: %w", err)
+	}
+	var png bytes.Buffer
+	err = qr.WriteAsPNG(go_qr.NewQrCodeImgConfig(totpQRScale, qrCodeBorder), &png)
+	if err != nil {
+		return nil, fmt.Errorf("drawing QR code: %w", err)
+	}
+
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	err = s.userRepo.UpdateTwoFactor(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("saving secret: %w", err)
+	}
+
+	return &TwoFactorEnrolment{
+		Secret: secret,
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(png.Bytes()),
+	}, nil
+}
+
+// ConfirmEnrolment turns on two-factor authentication once the user enters
+// a code from their app. It returns the user's recovery codes, which are
+// only shown this once.
+func (s *TwoFactorService) ConfirmEnrolment(ctx context.Context, user *models.User, code string) ([]string, error) {
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, errors.New("two-factor enrolment has not been started")
+	}
+
+	step, ok := security.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	err := s.userRepo.UpdateTwoFactor(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("enabling two-factor: %w", err)
+	}
+	return s.newRecoveryCodes(ctx, user.ID)
+}
+
+// Verify checks a code from the user's authenticator app, or one of their
+// recovery codes. Each code only works once.
+func (s *TwoFactorService) Verify(ctx context.Context, user *models.User, code string) error {
+	if !user.TOTPEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+
+	step, ok := security.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if ok {
+		if step <= user.TOTPLastStep {
+			return ErrInvalidTwoFactorCode
+		}
+		user.TOTPLastStep = step
+		err := s.userRepo.UpdateTwoFactor(ctx, user)
+		if err != nil {
+			return fmt.Errorf("saving last code: %w", err)
+		}
+		return nil
+	}
+
+	err := s.recoveryCodeRepo.Use(ctx, user.ID, hashRecoveryCode(code))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidTwoFactorCode
+	} else if err != nil {
+		return fmt.Errorf("using recovery code: %w", err)
+	}
+	return nil
+}
+
+// Disable turns off two-factor authentication after checking a current
+// code. Members of an organisation that requires it can't turn it off.
+func (s *TwoFactorService) Disable(ctx context.Context, user *models.User, code string) error {
+	required, err := s.Required(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrTwoFactorRequired
+	}
+	err = s.Verify(ctx, user, code)
+	if err != nil {
+		return err
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	err = s.userRepo.UpdateTwoFactor(ctx, user)
+	if err != nil {
+		return fmt.Errorf("disabling two-factor: %w", err)
+	}
+	err = s.recoveryCodeRepo.DeleteByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("deleting recovery codes: %w", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking
+// a current code. The old codes stop working.
+func (s *TwoFactorService) RegenerateRecoveryCodes(
+	ctx context.Context,
+	user *models.User,
+	code string,
+) ([]string, error) {
+	err := s.Verify(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.newRecoveryCodes(ctx, user.ID)
+}
+
+// newRecoveryCodes replaces a user's recovery codes and returns the new
+// ones in plain text.
+func (s *TwoFactorService) newRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	records := make([]models.RecoveryCode, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, fmt.Errorf("generating recovery code: %w", err)
+		}
+		encoded := recoveryCodeEncoding.EncodeToString(raw)[:10]
+		code := encoded[:5] + "-" + encoded[5:]
+		codes = append(codes, code)
+		records = append(records, models.RecoveryCode{
+			ID:       uuid.New().String(),
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(code),
+		})
+	}
+
+	err := s.recoveryCodeRepo.Replace(ctx, userID, records)
+	if err != nil {
+		return nil, fmt.Errorf("saving recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// hashRecoveryCode returns the hex SHA-256 of a recovery code, ignoring case,
+// spaces, and hyphens so the code can be typed loosely.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
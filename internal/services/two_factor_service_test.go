@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/nathanhollows/Rapua/v6/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTwoFactorService(t *testing.T) (*services.TwoFactorService, organisationTestEnv) {
+	t.Helper()
+	env, cleanup := setupOrganisationService(t)
+	t.Cleanup(cleanup)
+
+	service := services.NewTwoFactorService(
+		env.userRepo,
+		repositories.NewRecoveryCodeRepository(env.dbc),
+		repositories.NewOrganisationRepository(env.dbc),
+	)
+	return service, env
+}
+
+// totpCode returns the user's code for the time step offset from now.
+func totpCode(t *testing.T, user *models.User, offset int64) string {
+	t.Helper()
+	code, err := security.TOTPCode(user.TOTPSecret, security.TOTPStep(time.Now())+offset)
+	require.NoError(t, err)
+	return code
+}
+
+// enrolTwoFactor turns on two-factor authentication for the user and returns
+// their recovery codes.
+func enrolTwoFactor(t *testing.T, service *services.TwoFactorService, user *models.User) []string {
+	t.Helper()
+	_, err := service.BeginEnrolment(context.Background(), user)
+	require.NoError(t, err)
+	codes, err := service.ConfirmEnrolment(context.Background(), user, totpCode(t, user, 0))
+	require.NoError(t, err)
+	return codes
+}
+
+func TestTwoFactorService_Enrolment(t *testing.T) {
+	service, env := setupTwoFactorService(t)
+	ctx := context.Background()
+	user := env.createUser(t)
+
+	enrolment, err := service.BeginEnrolment(ctx, user)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(enrolment.QRCode, "data:image/png;base64,"))
+	assert.Equal(t, user.TOTPSecret, enrolment.Secret)
+
+	_, err = service.ConfirmEnrolment(ctx, user, "000000")
+	require.ErrorIs(t, err, services.ErrInvalidTwoFactorCode)
+
+	codes, err := service.ConfirmEnrolment(ctx, user, totpCode(t, user, 0))
+	require.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	saved, err := env.userRepo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, saved.TOTPEnabled)
+	assert.Equal(t, enrolment.Secret, saved.TOTPSecret)
+
+	_, err = service.BeginEnrolment(ctx, saved)
+	require.ErrorIs(t, err, services.ErrTwoFactorEnabled)
+}
+
+func TestTwoFactorService_Verify(t *testing.T) {
+	service, env := setupTwoFactorService(t)
+	ctx := context.Background()
+	user := env.createUser(t)
+	recoveryCodes := enrolTwoFactor(t, service, user)
+
+	t.Run("a code can't be reused", func(t *testing.T) {
+		err := service.Verify(ctx, user, totpCode(t, user, 0))
+		require.ErrorIs(t, err, services.ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("a later code is accepted", func(t *testing.T) {
+		require.NoError(t, service.Verify(ctx, user, totpCode(t, user, 1)))
+	})
+
+	t.Run("recovery codes work once", func(t *testing.T) {
+		require.NoError(t, service.Verify(ctx, user, recoveryCodes[0]))
+		err := service.Verify(ctx, user, recoveryCodes[0])
+		require.ErrorIs(t, err, services.ErrInvalidTwoFactorCode)
+
+		loose := strings.ToUpper(strings.ReplaceAll(recoveryCodes[1], "-", " "))
+		require.NoError(t, service.Verify(ctx, user, loose))
+
+		status, err := service.Status(ctx, user)
+		require.NoError(t, err)
+		assert.Equal(t, 8, status.RecoveryCodesLeft)
+	})
+
+	t.Run("new recovery codes replace the old ones", func(t *testing.T) {
+		fresh, err := service.RegenerateRecoveryCodes(ctx, user, recoveryCodes[2])
+		require.NoError(t, err)
+		require.Len(t, fresh, 10)
+
+		err = service.Verify(ctx, user, recoveryCodes[3])
+		require.ErrorIs(t, err, services.ErrInvalidTwoFactorCode)
+		require.NoError(t, service.Verify(ctx, user, fresh[0]))
+		recoveryCodes = fresh
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		fresh, err := service.RegenerateRecoveryCodes(ctx, user, totpCode(t, user, -1))
+		require.ErrorIs(t, err, services.ErrInvalidTwoFactorCode, "codes before the last one used are refused")
+		assert.Nil(t, fresh)
+
+		require.NoError(t, service.Disable(ctx, user, recoveryCodes[1]))
+		saved, err := env.userRepo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.False(t, saved.TOTPEnabled)
+		assert.Empty(t, saved.TOTPSecret)
+
+		err = service.Verify(ctx, saved, recoveryCodes[2])
+		require.ErrorIs(t, err, services.ErrTwoFactorNotEnabled)
+	})
+}
+
+func TestTwoFactorService_Required(t *testing.T) {
+	service, env := setupTwoFactorService(t)
+	ctx := context.Background()
+	owner := env.createUser(t)
+	member := env.createUser(t)
+
+	org, err := env.service.Create(ctx, owner.ID, "University")
+	require.NoError(t, err)
+	_, err = env.service.AddMember(ctx, owner.ID, org.ID, member.Email, models.RoleEditor)
+	require.NoError(t, err)
+
+	// Owners must use two-factor themselves before requiring it
+	err = env.service.SetRequireTwoFactor(ctx, owner.ID, org.ID, true)
+	require.ErrorIs(t, err, services.ErrTwoFactorNotEnabled)
+
+	recoveryCodes := enrolTwoFactor(t, service, owner)
+	err = env.service.SetRequireTwoFactor(ctx, member.ID, org.ID, true)
+	require.ErrorIs(t, err, services.ErrPermissionDenied)
+	require.NoError(t, env.service.SetRequireTwoFactor(ctx, owner.ID, org.ID, true))
+
+	required, err := service.Required(ctx, member.ID)
+	require.NoError(t, err)
+	assert.True(t, required)
+
+	status, err := service.Status(ctx, member)
+	require.NoError(t, err)
+	assert.True(t, status.Required)
+	assert.False(t, status.Enabled)
+
+	err = service.Disable(ctx, owner, recoveryCodes[0])
+	require.ErrorIs(t, err, services.ErrTwoFactorRequired)
+
+	require.NoError(t, env.service.SetRequireTwoFactor(ctx, owner.ID, org.ID, false))
+	required, err = service.Required(ctx, member.ID)
+	require.NoError(t, err)
+	assert.False(t, required)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/nathanhollows/Rapua/v6/config"
@@ -183,7 +184,13 @@ func (s *UserService) SwitchInstance(ctx context.Context, user *models.User, ins
 
 	// Make sure the user has permission to switch to this instance
 	if instance.UserID != user.ID {
-		return ErrPermissionDenied
+		shared, err := s.instanceRepo.FindSharedWithUser(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("finding shared instances: %w", err)
+		}
+		if !slices.ContainsFunc(shared, func(i models.Instance) bool { return i.ID == instance.ID }) {
+			return ErrPermissionDenied
+		}
 	}
 
 	user.CurrentInstanceID = instance.ID
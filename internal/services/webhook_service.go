@@ -0,0 +1,568 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+)
+
+const (
+	// webhookSecretPrefix marks a string as a webhook signing secret.
+	webhookSecretPrefix = "whsec_"
+	// webhookBatchSize caps how many deliveries one run of the worker sends.
+	webhookBatchSize = 100
+	// webhookTimeout is how long a receiver has to respond.
+	webhookTimeout = 10 * time.Second
+	// webhookDeliveryRetention is how long finished deliveries stay in the log.
+	webhookDeliveryRetention = 30 * 24 * time.Hour
+	// webhookErrorLength caps the error saved in the delivery log.
+	webhookErrorLength = 500
+)
+
+// webhookBackoff is how long to wait after each failed attempt. A delivery
+// is given up once it has failed once more than there are steps.
+var webhookBackoff = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	6 * time.Hour,
+}
+
+var errWebhookPrivateAddress = errors.New("webhook URL resolves to a private address")
+
+// WebhookDispatcher queues game events for an instance's webhooks.
+type WebhookDispatcher interface {
+	// Dispatch queues an event to send now. A non-empty key stops the same
+	// event being sent to a webhook twice.
+	Dispatch(ctx context.Context, instanceID string, event models.WebhookEvent, key string, data any) error
+	// Schedule queues an event to send at a set time, replacing any of the
+	// same event that are still waiting. A zero time only cancels them.
+	Schedule(ctx context.Context, instanceID string, event models.WebhookEvent, at time.Time, data any) error
+	// Subscribed reports whether any of the instance's webhooks want the event.
+	Subscribed(ctx context.Context, instanceID string, event models.WebhookEvent) bool
+}
+
+// dispatchWebhook queues an event if the service has a dispatcher. Webhooks
+// never stop a game from being played, so failures are only logged.
+func dispatchWebhook(
+	ctx context.Context,
+	dispatcher WebhookDispatcher,
+	instanceID string,
+	event models.WebhookEvent,
+	key string,
+	data any,
+) {
+	if dispatcher == nil {
+		return
+	}
+	if err := dispatcher.Dispatch(ctx, instanceID, event, key, data); err != nil {
+		slog.ErrorContext(ctx, "dispatching webhook", "event", event, "instance_id", instanceID, "error", err)
+	}
+}
+
+// webhookEnvelope is the body sent to a webhook.
+type webhookEnvelope struct {
+	ID         string              `json:"id"`
+	Event      models.WebhookEvent `json:"event"`
+	CreatedAt  time.Time           `json:"created_at"`
+	InstanceID string              `json:"instance_id"`
+	Data       any                 `json:"data"`
+}
+
+// webhookEventData describes what an event happened to. Only the parts that
+// apply to the event are set.
+type webhookEventData struct {
+	Team     *webhookTeam     `json:"team,omitempty"`
+	Location *webhookLocation `json:"location,omitempty"`
+	Block    *webhookBlock    `json:"block,omitempty"`
+	Game     *webhookGame     `json:"game,omitempty"`
+}
+
+type webhookTeam struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+}
+
+type webhookLocation struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+}
+
+type webhookBlock struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	LocationID string `json:"location_id"`
+	Points     int    `json:"points"`
+}
+
+type webhookGame struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+}
+
+func newWebhookTeam(team *models.Team) *webhookTeam {
+	return &webhookTeam{
+		Code:   team.Code,
+		Name:   team.Name,
+		Points: team.Points,
+	}
+}
+
+func newWebhookGame(instance *models.Instance) *webhookGame {
+	game := &webhookGame{
+		ID:   instance.ID,
+		Name: instance.Name,
+	}
+	if !instance.StartTime.IsZero() {
+		game.StartTime = &instance.StartTime.Time
+	}
+	if !instance.EndTime.IsZero() {
+		game.EndTime = &instance.EndTime.Time
+	}
+	return game
+}
+
+// WebhookService manages webhooks and sends them the events they subscribe to.
+type WebhookService struct {
+	webhookRepo *repositories.WebhookRepository
+	client      *http.Client
+	logger      *slog.Logger
+}
+
+func NewWebhookService(
+	webhookRepo *repositories.WebhookRepository,
+	client *http.Client,
+	logger *slog.Logger,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		client:      client,
+		logger:      logger,
+	}
+}
+
+// NewWebhookHTTPClient returns a client for sending webhooks. It refuses to
+// connect to private, loopback and link-local addresses, so a webhook cannot
+// be used to reach the server's own network, and does not follow redirects.
+func NewWebhookHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !isPublicIP(ip) {
+				return errWebhookPrivateAddress
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isPublicIP reports whether an address can be reached from the internet.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast()
+}
+
+// validateWebhookURL checks that a URL is an absolute http or https URL.
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// validateWebhookEvents checks that at least one event is chosen and that all
+// are known.
+func validateWebhookEvents(events []models.WebhookEvent) (models.StrArray, error) {
+	if len(events) == 0 {
+		return nil, errors.New("choose at least one event")
+	}
+	names := make(models.StrArray, 0, len(events))
+	for _, event := range events {
+		if !event.IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidWebhookEvent, event)
+		}
+		names = append(names, string(event))
+	}
+	return names, nil
+}
+
+// Create adds a webhook to an instance with a new signing secret.
+func (s *WebhookService) Create(
+	ctx context.Context,
+	instanceID, rawURL string,
+	events []models.WebhookEvent,
+) (*models.Webhook, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if err := validateWebhookURL(rawURL); err != nil {
+		return nil, err
+	}
+	names, err := validateWebhookEvents(events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 24)
+	if _, err = rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("generating secret: %w", err)
+	}
+
+	webhook := &models.Webhook{
+		ID:         uuid.New().String(),
+		InstanceID: instanceID,
+		URL:        rawURL,
+		Secret:     webhookSecretPrefix + hex.EncodeToString(secret),
+		Events:     names,
+		Enabled:    true,
+	}
+	if err = s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("saving webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// List returns an instance's webhooks.
+func (s *WebhookService) List(ctx context.Context, instanceID string) ([]models.Webhook, error) {
+	webhooks, err := s.webhookRepo.FindByInstanceID(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// SetEnabled turns a webhook on or off.
+func (s *WebhookService) SetEnabled(ctx context.Context, instanceID, id string, enabled bool) (*models.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, instanceID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("finding webhook: %w", err)
+	}
+	webhook.Enabled = enabled
+	if err = s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("updating webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// Delete removes a webhook and its delivery log.
+func (s *WebhookService) Delete(ctx context.Context, instanceID, id string) error {
+	err := s.webhookRepo.Delete(ctx, instanceID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrWebhookNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("deleting webhook: %w", err)
+	}
+	return nil
+}
+
+// Deliveries returns the most recent deliveries for an instance's webhooks.
+func (s *WebhookService) Deliveries(
+	ctx context.Context,
+	instanceID string,
+	limit int,
+) ([]models.WebhookDelivery, error) {
+	deliveries, err := s.webhookRepo.FindDeliveries(ctx, instanceID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("finding deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// subscribers returns the instance's enabled webhooks that want the event.
+func (s *WebhookService) subscribers(
+	ctx context.Context,
+	instanceID string,
+	event models.WebhookEvent,
+) ([]models.Webhook, error) {
+	webhooks, err := s.webhookRepo.FindByInstanceID(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding webhooks: %w", err)
+	}
+	subscribed := make([]models.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(event) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	return subscribed, nil
+}
+
+// Subscribed reports whether any of the instance's webhooks want the event.
+func (s *WebhookService) Subscribed(ctx context.Context, instanceID string, event models.WebhookEvent) bool {
+	webhooks, err := s.subscribers(ctx, instanceID, event)
+	return err == nil && len(webhooks) > 0
+}
+
+// Dispatch queues an event for each webhook that wants it, to be sent by the
+// next run of ProcessDeliveries.
+func (s *WebhookService) Dispatch(
+	ctx context.Context,
+	instanceID string,
+	event models.WebhookEvent,
+	key string,
+	data any,
+) error {
+	webhooks, err := s.subscribers(ctx, instanceID, event)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for _, webhook := range webhooks {
+		if err = s.queue(ctx, &webhook, event, key, now, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Schedule queues an event to be sent at a set time. Deliveries of the same
+// event that have not been attempted yet are replaced, and one that was
+// already sent for the same time is not sent again.
+func (s *WebhookService) Schedule(
+	ctx context.Context,
+	instanceID string,
+	event models.WebhookEvent,
+	at time.Time,
+	data any,
+) error {
+	webhooks, err := s.subscribers(ctx, instanceID, event)
+	if err != nil {
+		return err
+	}
+	for _, webhook := range webhooks {
+		if err = s.webhookRepo.CancelPending(ctx, webhook.ID, event); err != nil {
+			return fmt.Errorf("cancelling scheduled deliveries: %w", err)
+		}
+		if at.IsZero() {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", event, at.Unix())
+		if err = s.queue(ctx, &webhook, event, key, at.UTC(), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queue saves a delivery of the event to the webhook.
+func (s *WebhookService) queue(
+	ctx context.Context,
+	webhook *models.Webhook,
+	event models.WebhookEvent,
+	key string,
+	at time.Time,
+	data any,
+) error {
+	id := uuid.New().String()
+	payload, err := json.Marshal(webhookEnvelope{
+		ID:         id,
+		Event:      event,
+		CreatedAt:  at,
+		InstanceID: webhook.InstanceID,
+		Data:       data,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+	if key == "" {
+		key = id
+	}
+
+	delivery := &models.WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhook.ID,
+		InstanceID:    webhook.InstanceID,
+		Event:         event,
+		DedupeKey:     key,
+		Payload:       string(payload),
+		Status:        models.DeliveryPending,
+		NextAttemptAt: at,
+	}
+	if err = s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+		return fmt.Errorf("queueing delivery: %w", err)
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the X-Rapua-Signature header for a payload sent
+// at the given time. Receivers recompute it to check a payload is genuine.
+func SignWebhookPayload(secret string, sentAt time.Time, payload []byte) string {
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ProcessDeliveries sends deliveries that are due. Failed deliveries are
+// retried with backoff until they run out of attempts.
+func (s *WebhookService) ProcessDeliveries(ctx context.Context) error {
+	deliveries, err := s.webhookRepo.FindDue(ctx, time.Now().UTC(), webhookBatchSize)
+	if err != nil {
+		return fmt.Errorf("finding due deliveries: %w", err)
+	}
+
+	webhooks := make(map[string]*models.Webhook)
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = s.webhookRepo.GetByID(ctx, delivery.InstanceID, delivery.WebhookID)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("finding webhook: %w", err)
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		switch {
+		case webhook == nil:
+			s.finish(delivery, models.DeliveryFailed, 0, "webhook was deleted")
+		case !webhook.Enabled:
+			s.finish(delivery, models.DeliveryFailed, 0, "webhook is disabled")
+		default:
+			s.attempt(ctx, webhook, delivery)
+		}
+
+		if err = s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return fmt.Errorf("updating delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// attempt sends a delivery once and records the outcome.
+func (s *WebhookService) attempt(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) {
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = now
+
+	status, err := s.send(ctx, webhook, delivery, now)
+	if err == nil {
+		s.finish(delivery, models.DeliveryDelivered, status, "")
+		return
+	}
+
+	if delivery.Attempts > len(webhookBackoff) {
+		s.finish(delivery, models.DeliveryFailed, status, err.Error())
+		s.logger.WarnContext(ctx, "webhook delivery failed",
+			"webhook_id", webhook.ID, "delivery_id", delivery.ID, "error", err)
+		return
+	}
+	s.finish(delivery, models.DeliveryPending, status, err.Error())
+	delivery.NextAttemptAt = now.Add(webhookBackoff[delivery.Attempts-1])
+}
+
+// finish records the result of a delivery.
+func (s *WebhookService) finish(
+	delivery *models.WebhookDelivery,
+	status models.WebhookDeliveryStatus,
+	responseStatus int,
+	message string,
+) {
+	if len(message) > webhookErrorLength {
+		message = message[:webhookErrorLength]
+	}
+	delivery.Status = status
+	delivery.ResponseStatus = responseStatus
+	delivery.Error = message
+}
+
+// send posts the payload to the webhook and returns the response status. Any
+// status other than 2xx is an error.
+func (s *WebhookService) send(
+	ctx context.Context,
+	webhook *models.Webhook,
+	delivery *models.WebhookDelivery,
+	sentAt time.Time,
+) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Rapua-Webhooks/1.0")
+	req.Header.Set("X-Rapua-Event", string(delivery.Event))
+	req.Header.Set("X-Rapua-Delivery", delivery.ID)
+	req.Header.Set("X-Rapua-Signature", SignWebhookPayload(webhook.Secret, sentAt, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// PurgeDeliveries removes finished deliveries from the log once they are
+// older than the retention period.
+func (s *WebhookService) PurgeDeliveries(ctx context.Context) error {
+	removed, err := s.webhookRepo.DeleteDeliveriesBefore(ctx, time.Now().Add(-webhookDeliveryRetention))
+	if err != nil {
+		return fmt.Errorf("purging webhook deliveries: %w", err)
+	}
+	if removed > 0 {
+		s.logger.InfoContext(ctx, "purged webhook deliveries", "count", removed)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookReceiver records the requests sent to it and answers with status.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rec *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.requests = append(rec.requests, r)
+	rec.bodies = append(rec.bodies, body)
+	w.WriteHeader(rec.status)
+}
+
+func setupWebhookService(t *testing.T) (*services.WebhookService, *webhookReceiver, *httptest.Server, func()) {
+	t.Helper()
+	dbc, cleanup := setupDB(t)
+
+	receiver := &webhookReceiver{status: http.StatusNoContent}
+	server := httptest.NewServer(receiver)
+
+	service := services.NewWebhookService(
+		repositories.NewWebhookRepository(dbc),
+		server.Client(),
+		newTLogger(t),
+	)
+	return service, receiver, server, func() {
+		server.Close()
+		cleanup()
+	}
+}
+
+func TestWebhookService_Create(t *testing.T) {
+	service, _, _, cleanup := setupWebhookService(t)
+	defer cleanup()
+	ctx := context.Background()
+	instanceID := gofakeit.UUID()
+
+	tests := []struct {
+		name    string
+		url     string
+		events  []models.WebhookEvent
+		wantErr bool
+	}{
+		{"valid", "https://example.com/hook", []models.WebhookEvent{models.EventTeamFinished}, false},
+		{"relative url", "/hook", []models.WebhookEvent{models.EventTeamFinished}, true},
+		{"other scheme", "ftp://example.com/hook", []models.WebhookEvent{models.EventTeamFinished}, true},
+		{"no events", "https://example.com/hook", nil, true},
+		{"unknown event", "https://example.com/hook", []models.WebhookEvent{"team.exploded"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook, err := service.Create(ctx, instanceID, tt.url, tt.events)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(webhook.Secret, "whsec_"))
+			assert.True(t, webhook.Enabled)
+		})
+	}
+}
+
+func TestWebhookService_DispatchSignsAndDedupes(t *testing.T) {
+	service, receiver, server, cleanup := setupWebhookService(t)
+	defer cleanup()
+	ctx := context.Background()
+	instanceID := gofakeit.UUID()
+
+	webhook, err := service.Create(ctx, instanceID, server.URL, []models.WebhookEvent{models.EventTeamFinished})
+	require.NoError(t, err)
+
+	data := map[string]string{"team": "ABCD"}
+	require.NoError(t, service.Dispatch(ctx, instanceID, models.EventTeamFinished, "finished:ABCD", data))
+	require.NoError(t, service.Dispatch(ctx, instanceID, models.EventTeamFinished, "finished:ABCD", data))
+	require.NoError(t, service.Dispatch(ctx, instanceID, models.EventTeamCheckedIn, "", data),
+		"Events the webhook does not subscribe to are ignored")
+	require.NoError(t, service.ProcessDeliveries(ctx))
+
+	require.Len(t, receiver.requests, 1, "The same key is only sent once")
+	req, body := receiver.requests[0], receiver.bodies[0]
+	assert.Equal(t, string(models.EventTeamFinished), req.Header.Get("X-Rapua-Event"))
+
+	var envelope map[string]any
+	require.NoError(t, json.Unmarshal(body, &envelope))
+	assert.Equal(t, req.Header.Get("X-Rapua-Delivery"), envelope["id"])
+	assert.Equal(t, instanceID, envelope["instance_id"])
+
+	signature := req.Header.Get("X-Rapua-Signature")
+	timestamp, _, _ := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, services.SignWebhookPayload(webhook.Secret, time.Unix(unix, 0), body), signature)
+
+	deliveries, err := service.Deliveries(ctx, instanceID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, models.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, http.StatusNoContent, deliveries[0].ResponseStatus)
+}
+
+func TestWebhookService_RetriesFailedDeliveries(t *testing.T) {
+	service, receiver, server, cleanup := setupWebhookService(t)
+	defer cleanup()
+	ctx := context.Background()
+	instanceID := gofakeit.UUID()
+	receiver.status = http.StatusInternalServerError
+
+	_, err := service.Create(ctx, instanceID, server.URL, []models.WebhookEvent{models.EventTeamStarted})
+	require.NoError(t, err)
+	require.NoError(t, service.Dispatch(ctx, instanceID, models.EventTeamStarted, "", nil))
+	require.NoError(t, service.ProcessDeliveries(ctx))
+
+	deliveries, err := service.Deliveries(ctx, instanceID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, models.DeliveryPending, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].ResponseStatus)
+	assert.True(t, deliveries[0].NextAttemptAt.After(time.Now()), "The retry waits")
+
+	require.NoError(t, service.ProcessDeliveries(ctx))
+	assert.Len(t, receiver.requests, 1, "Nothing is sent before the retry is due")
+}
+
+func TestWebhookService_ScheduleReplacesPending(t *testing.T) {
+	service, receiver, server, cleanup := setupWebhookService(t)
+	defer cleanup()
+	ctx := context.Background()
+	instanceID := gofakeit.UUID()
+
+	_, err := service.Create(ctx, instanceID, server.URL, []models.WebhookEvent{models.EventGameStarted})
+	require.NoError(t, err)
+
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, service.Schedule(ctx, instanceID, models.EventGameStarted, later, nil))
+	require.NoError(t, service.Schedule(ctx, instanceID, models.EventGameStarted, later.Add(time.Hour), nil))
+
+	deliveries, err := service.Deliveries(ctx, instanceID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1, "Rescheduling replaces the waiting delivery")
+	assert.WithinDuration(t, later.Add(time.Hour), deliveries[0].NextAttemptAt, time.Second)
+
+	require.NoError(t, service.Schedule(ctx, instanceID, models.EventGameStarted, time.Time{}, nil))
+	deliveries, err = service.Deliveries(ctx, instanceID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries, "A zero time cancels the delivery")
+
+	now := time.Now().Add(-time.Second)
+	require.NoError(t, service.Schedule(ctx, instanceID, models.EventGameStarted, now, nil))
+	require.NoError(t, service.ProcessDeliveries(ctx))
+	require.NoError(t, service.Schedule(ctx, instanceID, models.EventGameStarted, now, nil))
+	require.NoError(t, service.ProcessDeliveries(ctx))
+	assert.Len(t, receiver.requests, 1, "An event already sent for the same time is not sent again")
+}
@@ -0,0 +1,93 @@
+package sessions
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/markbates/goth"
+	"github.com/markbates/goth/providers/openidConnect"
+	"github.com/nathanhollows/Rapua/v6/config"
+)
+
+// OIDCProvider is an OpenID Connect provider users can log in with.
+type OIDCProvider struct {
+	// Name is the goth provider name, used in /auth/{name}
+	Name string
+	// Label is shown on the login button
+	Label string
+	// TrustEmail treats every email from the provider as verified
+	TrustEmail bool
+}
+
+var (
+	oidcMu        sync.RWMutex
+	oidcProviders []OIDCProvider
+)
+
+// startOIDC registers the OpenID Connect providers from the environment.
+// Each provider's discovery document is fetched now, so a provider that
+// cannot be reached is left off the login page until the next restart.
+func startOIDC() {
+	configs, invalid := config.OIDCProviders()
+	for _, id := range invalid {
+		slog.Warn("skipping OIDC provider with an invalid ID or missing settings", "provider", id)
+	}
+
+	for _, cfg := range configs {
+		provider, err := openidConnect.NewNamed(
+			cfg.ID,
+			cfg.ClientID,
+			cfg.ClientSecret,
+			// NewNamed names the provider <id>-oidc
+			os.Getenv("SITE_URL")+"/auth/"+cfg.ID+"-oidc/callback",
+			cfg.DiscoveryURL,
+			cfg.Scopes...,
+		)
+		if err != nil {
+			slog.Error("registering OIDC provider", "provider", cfg.ID, "error", err)
+			continue
+		}
+		goth.UseProviders(provider)
+		AddOIDCProvider(OIDCProvider{
+			Name:       provider.Name(),
+			Label:      cfg.Name,
+			TrustEmail: cfg.TrustEmail,
+		})
+		slog.Info("registered OIDC provider", "provider", provider.Name())
+	}
+}
+
+// AddOIDCProvider lists a provider on the login page. The goth provider of
+// the same name must be registered separately.
+func AddOIDCProvider(provider OIDCProvider) {
+	oidcMu.Lock()
+	defer oidcMu.Unlock()
+	for i, existing := range oidcProviders {
+		if existing.Name == provider.Name {
+			oidcProviders[i] = provider
+			return
+		}
+	}
+	oidcProviders = append(oidcProviders, provider)
+}
+
+// OIDCProviders returns the providers shown on the login page, in the order
+// they were configured.
+func OIDCProviders() []OIDCProvider {
+	oidcMu.RLock()
+	defer oidcMu.RUnlock()
+	return append([]OIDCProvider(nil), oidcProviders...)
+}
+
+// GetOIDCProvider finds a registered provider by its goth name.
+func GetOIDCProvider(name string) (OIDCProvider, bool) {
+	oidcMu.RLock()
+	defer oidcMu.RUnlock()
+	for _, provider := range oidcProviders {
+		if provider.Name == name {
+			return provider, true
+		}
+	}
+	return OIDCProvider{}, false
+}
@@ -3,6 +3,7 @@ package sessions
 import (
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/gorilla/sessions"
 	"github.com/markbates/goth"
@@ -14,10 +15,15 @@ import (
 var store *sessions.CookieStore
 
 const (
-	adminSession  = "admin"
-	playerSession = "scanscout"
+	adminSession     = "admin"
+	playerSession    = "scanscout"
+	twoFactorSession = "two_factor"
 )
 
+// TwoFactorTimeout is how long a user has to enter their two-factor code
+// after their password.
+const TwoFactorTimeout = 5 * time.Minute
+
 func Start() {
 	store = sessions.NewCookieStore([]byte(os.Getenv("SESSION_KEY")))
 	store.Options.Path = "/"
@@ -40,6 +46,7 @@ func Start() {
 			"profile",
 		),
 	)
+	startOIDC()
 }
 
 // GetAdmin returns the admin session for the given request.
@@ -47,6 +54,17 @@ func GetAdmin(r *http.Request) (*sessions.Session, error) {
 	return store.Get(r, adminSession)
 }
 
+// AdminSessionID returns the ID of the server-side record of the request's
+// admin session, or an empty string if there isn't one.
+func AdminSessionID(r *http.Request) string {
+	session, err := store.Get(r, adminSession)
+	if err != nil {
+		return ""
+	}
+	id, _ := session.Values["session_id"].(string)
+	return id
+}
+
 // GetPlayer returns the player session for the given request.
 func GetPlayer(r *http.Request) (*sessions.Session, error) {
 	return store.Get(r, playerSession)
@@ -72,16 +90,43 @@ func NewFromTeam(r *http.Request, team models.Team) (*sessions.Session, error) {
 	return session, nil
 }
 
-// NewFromUser session for the given request and user.
-func NewFromUser(r *http.Request, user models.User) (*sessions.Session, error) {
+// NewFromUser session for the given request and user. The session ID refers
+// to the server-side record of the session, which can be revoked.
+func NewFromUser(r *http.Request, user models.User, sessionID string) (*sessions.Session, error) {
 	session, err := store.Get(r, adminSession)
 	if err != nil {
 		return nil, err
 	}
 
 	session.Values["user_id"] = user.ID
+	session.Values["session_id"] = sessionID
 	session.Options.Secure = true
 	session.Options.SameSite = http.SameSiteLaxMode
 
 	return session, nil
 }
+
+// NewTwoFactor starts the second step of logging in for a user who has
+// entered their password. The admin session is only issued once they enter a
+// code. The method is how they logged in for the first step.
+func NewTwoFactor(r *http.Request, user models.User, method string) (*sessions.Session, error) {
+	session, err := store.Get(r, twoFactorSession)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Values["user_id"] = user.ID
+	session.Values["method"] = method
+	session.Values["expires"] = time.Now().Add(TwoFactorTimeout).Unix()
+	session.Values["attempts"] = 0
+	session.Options.MaxAge = int(TwoFactorTimeout.Seconds())
+	session.Options.Secure = true
+	session.Options.SameSite = http.SameSiteLaxMode
+
+	return session, nil
+}
+
+// GetTwoFactor returns the session of a user part way through logging in.
+func GetTwoFactor(r *http.Request) (*sessions.Session, error) {
+	return store.Get(r, twoFactorSession)
+}
@@ -82,7 +82,7 @@ func EditPage(data EditPageData) templ.Component {
 			return templ_7745c5c3_Err
 		}
 		for _, block := range data.PageBlocks {
-			templ_7745c5c3_Err = bTemplates.RenderAdminBlock(data.Settings, block, len(data.PageBlocks) < 4).Render(ctx, templ_7745c5c3_Buffer)
+			templ_7745c5c3_Err = bTemplates.RenderAdminBlock(data.Settings, block, getContextForPageType(data.PageType), len(data.PageBlocks) < 4).Render(ctx, templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
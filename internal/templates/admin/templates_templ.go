@@ -514,7 +514,7 @@ func templateModals() templ.Component {
 			templ_7745c5c3_Var28 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, "<!-- Create template modal --><dialog id=\"create_template_modal\" class=\"modal modal-bottom sm:modal-middle\"><div class=\"modal-box prose\"><h3 class=\"text-lg font-bold\">Save as template</h3><p class=\"pt-4\">You are saving <code id=\"template-modal-instance-name\"></code> as a template. The template will include all settings, locations, and content of the game. It will not include any teams or history.</p><form hx-post=\"/admin/templates/create\" hx-target=\"#templates\" hx-swap=\"outerHTML\"><input type=\"hidden\" name=\"id\" value=\"\"><fieldset class=\"fieldset not-prose\"><legend class=\"fieldset-legend\">New template name</legend> <input type=\"text\" class=\"input w-full\" name=\"name\" required autocomplete=\"off\"><p class=\"label\">You can change this later.</p></fieldset><div class=\"modal-action\"><button type=\"button\" class=\"btn\" onclick=\"create_template_modal.close()\">Nevermind</button> <button type=\"submit\" class=\"btn btn-primary\" onclick=\"create_template_modal.close()\">Save as template</button></div></form><form method=\"dialog\"><button class=\"btn btn-sm btn-circle btn-ghost absolute right-2 top-2\">✕</button></form></div></dialog><!-- Delete template modal --><dialog id=\"confirm_delete_template_modal\" class=\"modal modal-bottom sm:modal-middle p-1\"><div class=\"modal-box prose outline outline-2 outline-offset-1 outline-error\"><h3 class=\"text-lg font-bold\">Delete a template</h3><p class=\"pt-4\">You are about to delete your <code id=\"delete-template-name\"></code> template and any share links you may have generated. Games that were created from this template will not be impacted.</p><p>This action cannot be undone. Are you sure?</p><form hx-delete=\"/admin/templates\" hx-swap=\"outerHTML\" hx-target=\"#templates\"><div class=\"modal-action\"><input type=\"hidden\" name=\"id\" value=\"\"> <button type=\"button\" class=\"btn\" onclick=\"confirm_delete_template_modal.close()\">Nevermind</button> <button type=\"submit\" class=\"btn btn-error\" onclick=\"confirm_delete_template_modal.close()\">Delete</button></div></form><form method=\"dialog\"><button class=\"btn btn-sm btn-circle btn-ghost absolute right-2 top-2\">✕</button></form></div></dialog><!-- Launch template modal --><dialog id=\"launch_template_modal\" class=\"modal modal-bottom sm:modal-middle\"><div class=\"modal-box prose overflow-y-visible\"><h3 class=\"text-lg font-bold\">Launch a new game</h3><p>You are about to launch a new game from the <code id=\"launch-template-name\"></code> template.</p><form hx-post=\"/admin/templates/launch\" hx-swap=\"none\"><input type=\"hidden\" name=\"id\" value=\"\"><fieldset class=\"fieldset not-prose\"><legend class=\"fieldset-legend\">What is the name of the new game?</legend> <input type=\"text\" class=\"input w-full\" name=\"name\" required autocomplete=\"off\"><p class=\"label\">You can change this later.</p></fieldset><label class=\"form-control w-full\"></label><!-- TODO --><div class=\"form-control my-5 hidden\"><label class=\"label cursor-pointer\"><span class=\"label-text flex items-center\"><div class=\"dropdown dropdown-hover dropdown-top not-prose\"><div tabindex=\"0\" role=\"button\" class=\"btn btn-circle btn-ghost btn-xs text-info\" onclick=\"event.preventDefault();\"><svg tabindex=\"0\" xmlns=\"http://www.w3.org/2000/svg\" fill=\"none\" viewBox=\"0 0 24 24\" class=\"h-4 w-4 stroke-current\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M13 16h-1v-4h-1m1-4h.01M21 12a9 9 0 11-18 0 9 9 0 0118 0z\"></path></svg></div><div tabindex=\"0\" class=\"card compact dropdown-content bg-base-200 rounded-box z-[1] w-80 shadow\"><div tabindex=\"0\" class=\"card-body\"><h2 class=\"card-title\">Location codes</h2><p>Sharing location codes allows players in different games to scan the same QR codes and URLs, enabling tailored content for different audiences, such as adults and children, in the same space.</p><p>Generating new location codes creates a unique set for this game, useful when adapting an existing game to a new environment, like a health and safety tour at a different site.</p></div></div></div>Generate new location codes?</span> <input type=\"checkbox\" name=\"regenerate\" class=\"checkbox self-end\" disabled></label></div><div class=\"modal-action\"><button class=\"btn\" type=\"button\" onclick=\"launch_template_modal.close()\">Nevermind</button> <button type=\"submit\" class=\"btn btn-primary\">Launch</button></div></form><form method=\"dialog\"><button class=\"btn btn-sm btn-circle btn-ghost absolute right-2 top-2\">✕</button></form></div></dialog><!-- Share template modal --><dialog id=\"share_template_modal\" class=\"modal modal-bottom sm:modal-middle\"></dialog>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, "<!-- Create template modal --><dialog id=\"create_template_modal\" class=\"modal modal-bottom sm:modal-middle\"><div class=\"modal-box prose\"><h3 class=\"text-lg font-bold\">Save as template</h3><p class=\"pt-4\">You are saving <code id=\"template-modal-instance-name\"></code> as a template. The template will include all settings, locations, and content of the game. It will not include any teams or history.</p><form hx-post=\"/admin/templates/create\" hx-target=\"#templates\" hx-swap=\"outerHTML\"><input type=\"hidden\" name=\"id\" value=\"\"><fieldset class=\"fieldset not-prose\"><legend class=\"fieldset-legend\">New template name</legend> <input type=\"text\" class=\"input w-full\" name=\"name\" required autocomplete=\"off\"><p class=\"label\">You can change this later.</p></fieldset><div class=\"modal-action\"><button type=\"button\" class=\"btn\" onclick=\"create_template_modal.close()\">Nevermind</button> <button type=\"submit\" class=\"btn btn-primary\" onclick=\"create_template_modal.close()\">Save as template</button></div></form><form method=\"dialog\"><button class=\"btn btn-sm btn-circle btn-ghost absolute right-2 top-2\">✕</button></form></div></dialog><!-- Delete template modal --><dialog id=\"confirm_delete_template_modal\" class=\"modal modal-bottom sm:modal-middle p-1\"><div class=\"modal-box prose outline outline-2 outline-offset-1 outline-error\"><h3 class=\"text-lg font-bold\">Delete a template</h3><p class=\"pt-4\">You are about to delete your <code id=\"delete-template-name\"></code> template and any share links you may have generated. Games that were created from this template will not be impacted.</p><p>The template will be kept in the <a href=\"/admin/trash\">trash</a>, where you can restore it until it expires. Are you sure?</p><form hx-delete=\"/admin/templates\" hx-swap=\"outerHTML\" hx-target=\"#templates\"><div class=\"modal-action\"><input type=\"hidden\" name=\"id\" value=\"\"> <button type=\"button\" class=\"btn\" onclick=\"confirm_delete_template_modal.close()\">Nevermind</button> <button type=\"submit\" class=\"btn btn-error\" onclick=\"confirm_delete_template_modal.close()\">Delete</button></div></form><form method=\"dialog\"><button class=\"btn btn-sm btn-circle btn-ghost absolute right-2 top-2\">✕</button></form></div></dialog><!-- Launch template modal --><dialog id=\"launch_template_modal\" class=\"modal modal-bottom sm:modal-middle\"><div class=\"modal-box prose overflow-y-visible\"><h3 class=\"text-lg font-bold\">Launch a new game</h3><p>You are about to launch a new game from the <code id=\"launch-template-name\"></code> template.</p><form hx-post=\"/admin/templates/launch\" hx-swap=\"none\"><input type=\"hidden\" name=\"id\" value=\"\"><fieldset class=\"fieldset not-prose\"><legend class=\"fieldset-legend\">What is the name of the new game?</legend> <input type=\"text\" class=\"input w-full\" name=\"name\" required autocomplete=\"off\"><p class=\"label\">You can change this later.</p></fieldset><label class=\"form-control w-full\"></label><!-- TODO --><div class=\"form-control my-5 hidden\"><label class=\"label cursor-pointer\"><span class=\"label-text flex items-center\"><div class=\"dropdown dropdown-hover dropdown-top not-prose\"><div tabindex=\"0\" role=\"button\" class=\"btn btn-circle btn-ghost btn-xs text-info\" onclick=\"event.preventDefault();\"><svg tabindex=\"0\" xmlns=\"http://www.w3.org/2000/svg\" fill=\"none\" viewBox=\"0 0 24 24\" class=\"h-4 w-4 stroke-current\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M13 16h-1v-4h-1m1-4h.01M21 12a9 9 0 11-18 0 9 9 0 0118 0z\"></path></svg></div><div tabindex=\"0\" class=\"card compact dropdown-content bg-base-200 rounded-box z-[1] w-80 shadow\"><div tabindex=\"0\" class=\"card-body\"><h2 class=\"card-title\">Location codes</h2><p>Sharing location codes allows players in different games to scan the same QR codes and URLs, enabling tailored content for different audiences, such as adults and children, in the same space.</p><p>Generating new location codes creates a unique set for this game, useful when adapting an existing game to a new environment, like a health and safety tour at a different site.</p></div></div></div>Generate new location codes?</span> <input type=\"checkbox\" name=\"regenerate\" class=\"checkbox self-end\" disabled></label></div><div class=\"modal-action\"><button class=\"btn\" type=\"button\" onclick=\"launch_template_modal.close()\">Nevermind</button> <button type=\"submit\" class=\"btn btn-primary\">Launch</button></div></form><form method=\"dialog\"><button class=\"btn btn-sm btn-circle btn-ghost absolute right-2 top-2\">✕</button></form></div></dialog><!-- Share template modal --><dialog id=\"share_template_modal\" class=\"modal modal-bottom sm:modal-middle\"></dialog>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
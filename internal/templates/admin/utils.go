@@ -4,10 +4,16 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/a-h/templ"
 	"github.com/kaugesaar/lucide-go"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/internal/services"
+	"github.com/nathanhollows/Rapua/v6/internal/sessions"
+	"github.com/nathanhollows/Rapua/v6/models"
 )
 
 var cssVersion string
@@ -39,6 +45,425 @@ func filter[T any](items []T, fn func(T) bool) []T {
 	return filtered
 }
 
+// creditTopupVals builds the hx-vals for a credit purchase, adding the
+// organisation when buying for its pool.
+func creditTopupVals(organisationID string) string {
+	if organisationID == "" {
+		return `js:{credits: document.getElementById("credit_amount").value}`
+	}
+	return fmt.Sprintf(
+		`js:{credits: document.getElementById("credit_amount").value, organisation_id: %q}`,
+		organisationID,
+	)
+}
+
 func icon(icon string, attrs templ.Attributes) templ.Component {
 	return templ.Raw(lucide.Icon(icon, attrs))
 }
+
+// revisionActionLabel describes why a revision was saved.
+func revisionActionLabel(action models.RevisionAction) string {
+	switch action {
+	case models.RevisionOriginal:
+		return "Original"
+	case models.RevisionRestored:
+		return "Restored"
+	case models.RevisionDeleted:
+		return "Deleted"
+	default:
+		return "Edited"
+	}
+}
+
+// revisionAuthor names the user who saved a revision.
+func revisionAuthor(revision models.BlockRevision) string {
+	switch {
+	case revision.User != nil && revision.User.Name != "":
+		return revision.User.Name
+	case revision.User != nil:
+		return revision.User.Email
+	case revision.UserID == "":
+		return "Unknown author"
+	default:
+		return "A former user"
+	}
+}
+
+// deletedBlockName names a deleted block by its type.
+func deletedBlockName(revision models.BlockRevision) string {
+	block, err := blocks.CreateFromBaseBlock(blocks.BaseBlock{Type: revision.Type})
+	if err != nil {
+		return revision.Type
+	}
+	return block.GetName()
+}
+
+// organisationRoleLabel names a role for display.
+func organisationRoleLabel(role models.OrganisationRole) string {
+	switch role {
+	case models.RoleOwner:
+		return "Owner"
+	case models.RoleEditor:
+		return "Editor"
+	case models.RoleFacilitator:
+		return "Facilitator"
+	case models.RoleViewer:
+		return "Viewer"
+	}
+	return string(role)
+}
+
+// apiScopeDescription explains what an API scope allows.
+func apiScopeDescription(scope models.APIScope) string {
+	switch scope {
+	case models.ScopeInstancesRead:
+		return "List your games and read their details"
+	case models.ScopeLocationsRead:
+		return "Read the locations in a game"
+	case models.ScopeLocationsWrite:
+		return "Add locations to a game"
+	case models.ScopeTeamsRead:
+		return "Read the teams in a game"
+	case models.ScopeTeamsWrite:
+		return "Add teams to a game"
+	case models.ScopeResultsRead:
+		return "Read check-ins and the leaderboard"
+	case models.ScopeNotificationsWrite:
+		return "Send notifications to teams"
+	}
+	return ""
+}
+
+// webhookEventDescription explains when a webhook event is sent.
+func webhookEventDescription(event models.WebhookEvent) string {
+	switch event {
+	case models.EventTeamStarted:
+		return "A team starts playing"
+	case models.EventTeamCheckedIn:
+		return "A team checks in at a location"
+	case models.EventTeamCheckedOut:
+		return "A team checks out of a location"
+	case models.EventBlockCompleted:
+		return "A team completes an activity"
+	case models.EventTeamFinished:
+		return "A team has visited every location"
+	case models.EventGameStarted:
+		return "The game starts"
+	case models.EventGameEnded:
+		return "The game ends"
+	}
+	return ""
+}
+
+// webhookDeliveryStatusClass colours a delivery's status badge.
+func webhookDeliveryStatusClass(status models.WebhookDeliveryStatus) string {
+	switch status {
+	case models.DeliveryDelivered:
+		return "badge-success"
+	case models.DeliveryFailed:
+		return "badge-error"
+	}
+	return "badge-warning"
+}
+
+// webhookURL finds the URL of the webhook a delivery was sent to.
+func webhookURL(webhooks []models.Webhook, id string) string {
+	for _, webhook := range webhooks {
+		if webhook.ID == id {
+			return webhook.URL
+		}
+	}
+	return ""
+}
+
+// announcementTiming describes when an announcement is or was due.
+func announcementTiming(notification models.ScheduledNotification) string {
+	switch notification.Timing {
+	case models.NotifyAt:
+		return notification.SendAt.Local().Format("02 Jan 03:04 PM")
+	case models.NotifyAfterStart:
+		return fmt.Sprintf("%d min after the game starts", notification.OffsetMinutes)
+	case models.NotifyBeforeEnd:
+		return fmt.Sprintf("%d min before the game ends", notification.OffsetMinutes)
+	}
+	return ""
+}
+
+// announcementTarget describes which teams an announcement is for.
+func announcementTarget(instance models.Instance, notification models.ScheduledNotification) string {
+	switch notification.Target {
+	case models.TargetAllTeams:
+		return "All teams"
+	case models.TargetTeam:
+		return "Team " + notification.TargetID
+	case models.TargetGroup:
+		for _, group := range announcementGroups(instance.GameStructure) {
+			if group.ID == notification.TargetID {
+				return "Teams in " + group.Name
+			}
+		}
+		return "Teams in a deleted group"
+	case models.TargetNotVisited:
+		for _, location := range instance.Locations {
+			if location.ID == notification.TargetID {
+				return "Teams yet to visit " + location.Name
+			}
+		}
+		return "Teams yet to visit a deleted location"
+	case models.TargetFinished:
+		return "Finished teams"
+	case models.TargetUnfinished:
+		return "Unfinished teams"
+	}
+	return ""
+}
+
+// announcementGroups lists the visible groups in a game's structure, in
+// order, for targeting announcements.
+func announcementGroups(structure models.GameStructure) []models.GameStructure {
+	var groups []models.GameStructure
+	for _, group := range structure.SubGroups {
+		groups = append(groups, group)
+		groups = append(groups, announcementGroups(group)...)
+	}
+	return groups
+}
+
+// ltiGradeModeLabel describes what a grade mode sends to the gradebook.
+func ltiGradeModeLabel(mode models.LTIGradeMode) string {
+	switch mode {
+	case models.GradePoints:
+		return "Points out of a maximum"
+	case models.GradeCompletion:
+		return "Full marks once finished"
+	case models.GradeNone:
+		return "Don't send grades"
+	}
+	return string(mode)
+}
+
+// groupSecret splits a two-factor secret into groups of four so it is easier
+// to type into an authenticator app.
+func groupSecret(secret string) string {
+	groups := make([]string, 0, len(secret)/4+1)
+	for len(secret) > 4 {
+		groups = append(groups, secret[:4])
+		secret = secret[4:]
+	}
+	return strings.Join(append(groups, secret), " ")
+}
+
+// deviceName describes the browser and operating system in a user agent,
+// such as "Firefox on macOS".
+func deviceName(userAgent string) string {
+	browser := ""
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(userAgent, "Firefox/"), strings.Contains(userAgent, "FxiOS/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"), strings.Contains(userAgent, "CriOS/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	}
+
+	system := ""
+	switch {
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"):
+		system = "iOS"
+	case strings.Contains(userAgent, "Android"):
+		system = "Android"
+	case strings.Contains(userAgent, "Windows"):
+		system = "Windows"
+	case strings.Contains(userAgent, "CrOS"):
+		system = "ChromeOS"
+	case strings.Contains(userAgent, "Mac OS X"):
+		system = "macOS"
+	case strings.Contains(userAgent, "Linux"):
+		system = "Linux"
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+	return "Unknown device"
+}
+
+// loginMethodLabel names how a user logged in.
+func loginMethodLabel(method string) string {
+	switch models.LoginMethod(method) {
+	case models.LoginMethodPassword:
+		return "Password"
+	case models.LoginMethodTwoFactor:
+		return "Two-factor code"
+	case models.LoginMethodMagicLink:
+		return "Login link"
+	}
+	if method == "google" {
+		return "Google"
+	}
+	if provider, ok := sessions.GetOIDCProvider(method); ok {
+		return provider.Label
+	}
+	return method
+}
+
+// loginFailureLabel explains why a login attempt failed.
+func loginFailureLabel(reason string) string {
+	switch reason {
+	case models.LoginFailureInvalidPassword:
+		return "Wrong password"
+	case models.LoginFailureInvalidCode:
+		return "Wrong code"
+	case models.LoginFailureLocked:
+		return "Locked out"
+	}
+	return "Failed"
+}
+
+// trashTypeLabel names the kind of item in the trash.
+func trashTypeLabel(trashType models.TrashType) string {
+	switch trashType {
+	case models.TrashInstance:
+		return "Game"
+	case models.TrashLocation:
+		return "Location"
+	default:
+		return "Team"
+	}
+}
+
+// trashTimeLeft describes how long until an item is purged.
+func trashTimeLeft(item models.TrashItem) string {
+	days := int(time.Until(item.PurgeAt).Hours() / 24) //nolint:mnd // hours in a day
+	switch {
+	case days < 1:
+		return "Today"
+	case days == 1:
+		return "In 1 day"
+	default:
+		return fmt.Sprintf("In %d days", days)
+	}
+}
+
+// trashPurgeConfirm warns what purging an item deletes.
+func trashPurgeConfirm(item models.TrashItem) string {
+	if item.Type == models.TrashInstance {
+		return fmt.Sprintf("Delete %q forever? Its locations, teams and uploads cannot be recovered.", item.Name)
+	}
+	return fmt.Sprintf("Delete %q forever? This cannot be undone.", item.Name)
+}
+
+// importSummary describes how many locations an import will create and
+// whether any rows will be skipped.
+func importSummary(rows []services.LocationImportRow) (string, bool) {
+	valid := 0
+	for _, row := range rows {
+		if row.Err == nil {
+			valid++
+		}
+	}
+	skipped := len(rows) - valid
+
+	summary := fmt.Sprintf("%d locations will be created.", valid)
+	if valid == 1 {
+		summary = "1 location will be created."
+	}
+	switch {
+	case skipped == 1:
+		summary += " 1 row will be skipped."
+	case skipped > 1:
+		summary += fmt.Sprintf(" %d rows will be skipped.", skipped)
+	}
+	return summary, skipped > 0
+}
+
+// markerDistance formats a distance in metres for the marker library.
+func markerDistance(meters float64) string {
+	if meters < 1000 {
+		return fmt.Sprintf("%.0f m", meters)
+	}
+	return fmt.Sprintf("%.1f km", meters/1000)
+}
+
+// markerNotes returns the notes of a marker being edited.
+func markerNotes(marker *models.Marker) string {
+	if marker == nil {
+		return ""
+	}
+	return marker.Notes
+}
+
+// markerRemoveConfirm warns what removing a marker from the library does.
+func markerRemoveConfirm(marker models.Marker) string {
+	if len(marker.Locations) == 0 {
+		return fmt.Sprintf("Remove %s from your library? It is not used by any location, so it will be deleted.", marker.Name)
+	}
+	return fmt.Sprintf("Remove %s from your library? Locations using it will keep it.", marker.Name)
+}
+
+// teamLabel returns a team's name, or their code if they haven't chosen one.
+func teamLabel(team models.Team) string {
+	if team.Name != "" {
+		return team.Name
+	}
+	return team.Code
+}
+
+// facilitatorTeamURL returns the URL for a facilitator's action on a team at
+// one of their locations.
+func facilitatorTeamURL(location models.Location, team models.Team, action string) string {
+	return fmt.Sprintf("/facilitator/locations/%s/teams/%s/%s", location.ID, team.Code, action)
+}
+
+// facilitatorMessagesURL returns the URL of a team's messages for
+// facilitators.
+func facilitatorMessagesURL(team models.Team) string {
+	return fmt.Sprintf("/facilitator/teams/%s/messages", team.Code)
+}
+
+// timeOnSite returns how long ago a team checked in, to the minute.
+func timeOnSite(checkIn models.CheckIn) string {
+	minutes := int(time.Since(checkIn.TimeIn).Minutes())
+	if minutes < 1 {
+		return "under a minute"
+	} else if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
+// facilitatorLinkLabel names a facilitator link, falling back to when it
+// was created for links without a label.
+func facilitatorLinkLabel(token models.FacilitatorToken) string {
+	if token.Label != "" {
+		return token.Label
+	}
+	if token.CreatedAt.IsZero() {
+		return "Unlabelled link"
+	}
+	return "Link created " + token.CreatedAt.Local().Format("2 Jan 15:04")
+}
+
+// facilitatorLinkLocations returns the names of the locations a facilitator
+// link covers.
+func facilitatorLinkLocations(token models.FacilitatorToken, locations []models.Location) []string {
+	if len(token.Locations) == 0 {
+		return []string{"All locations"}
+	}
+	names := make([]string, 0, len(token.Locations))
+	for _, location := range locations {
+		if token.AllowsLocation(location.ID) {
+			names = append(names, location.Name)
+		}
+	}
+	return names
+}
@@ -1,9 +1,16 @@
 package blocks_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/helpers"
+	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
 	templates "github.com/nathanhollows/Rapua/v6/internal/templates/blocks"
 	"github.com/nathanhollows/Rapua/v6/models"
 )
@@ -18,7 +25,7 @@ func TestBlocks_MethodsExist(t *testing.T) {
 			t.Errorf("Block %s is missing a RenderAdminEdit view", block.GetName())
 		}
 
-		template = templates.RenderAdminBlock(instanceSettings, block, true)
+		template = templates.RenderAdminBlock(instanceSettings, block, blocks.ContextLocationContent, true)
 		if template == nil {
 			t.Errorf("Block %s is missing a RenderAdminBlock view", block.GetName())
 		}
@@ -34,3 +41,76 @@ func TestBlocks_MethodsExist(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderPlayerView_TemplateVars(t *testing.T) {
+	block := blocks.NewMarkdownBlock(blocks.BaseBlock{ID: "block"})
+	block.Content = "Welcome, {{team.name}}!"
+
+	ctx := context.WithValue(context.Background(), contextkeys.VarsKey, helpers.TemplateVars{"team.name": "Kiwis"})
+	var buf bytes.Buffer
+	err := templates.RenderPlayerView(models.InstanceSettings{}, block, nil).Render(ctx, &buf)
+	if err != nil {
+		t.Fatalf("rendering block: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Welcome, Kiwis!") {
+		t.Errorf("expected team name in output, got %s", buf.String())
+	}
+
+	// Without variables, content is shown as written
+	buf.Reset()
+	err = templates.RenderPlayerView(models.InstanceSettings{}, block, nil).Render(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("rendering block: %v", err)
+	}
+	if !strings.Contains(buf.String(), "{{team.name}}") {
+		t.Errorf("expected variable as written, got %s", buf.String())
+	}
+}
+
+// playerState is a team's state for a block they haven't answered yet.
+type playerState struct {
+	blockID, playerID string
+}
+
+func (s *playerState) GetBlockID() string              { return s.blockID }
+func (s *playerState) GetPlayerID() string             { return s.playerID }
+func (s *playerState) GetPlayerData() json.RawMessage  { return nil }
+func (s *playerState) SetPlayerData(_ json.RawMessage) {}
+func (s *playerState) IsComplete() bool                { return false }
+func (s *playerState) SetComplete(_ bool)              {}
+func (s *playerState) GetPointsAwarded() int           { return 0 }
+func (s *playerState) SetPointsAwarded(_ int)          {}
+
+func TestRenderPlayerView_MatchingHidesAnswers(t *testing.T) {
+	block := blocks.NewMatchingBlock(blocks.BaseBlock{ID: "block"})
+	err := block.UpdateBlockData(map[string][]string{
+		"matching-left":  {"Kererū", "Pīwakawaka", "Kōtare"},
+		"matching-right": {"Wood pigeon", "Fantail", "Kingfisher"},
+	})
+	if err != nil {
+		t.Fatalf("updating block: %v", err)
+	}
+
+	var buf bytes.Buffer
+	state := &playerState{blockID: block.ID, playerID: "TEAM"}
+	err = templates.RenderPlayerView(models.InstanceSettings{}, block, state).Render(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("rendering block: %v", err)
+	}
+
+	lefts := regexp.MustCompile(`name="matching-left" value="([^"]+)"`).FindAllStringSubmatch(buf.String(), -1)
+	options := regexp.MustCompile(`<option value="([^"]+)"`).FindAllStringSubmatch(buf.String(), -1)
+	if len(lefts) != len(block.Pairs) {
+		t.Fatalf("expected %d left inputs, got %d", len(block.Pairs), len(lefts))
+	}
+	if len(options) != len(block.Pairs)*len(block.Pairs) {
+		t.Fatalf("expected %d options, got %d", len(block.Pairs)*len(block.Pairs), len(options))
+	}
+	for _, left := range lefts {
+		for _, option := range options {
+			if left[1] == option[1] {
+				t.Errorf("option value %q matches a left input, giving away the answer", option[1])
+			}
+		}
+	}
+}
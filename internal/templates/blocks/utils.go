@@ -1,11 +1,14 @@
 package blocks
 
 import (
+	"context"
 	"html/template"
 
 	"github.com/a-h/templ"
 	"github.com/kaugesaar/lucide-go"
 	"github.com/nathanhollows/Rapua/v6/helpers"
+	"github.com/nathanhollows/Rapua/v6/internal/contextkeys"
+	"github.com/nathanhollows/Rapua/v6/models"
 )
 
 func stringToMarkdown(s string) template.HTML {
@@ -18,6 +21,33 @@ func stringToMarkdown(s string) template.HTML {
 	return md
 }
 
+// templateVars returns the variables for the team being rendered, if any.
+func templateVars(ctx context.Context) helpers.TemplateVars {
+	vars, _ := ctx.Value(contextkeys.VarsKey).(helpers.TemplateVars)
+	return vars
+}
+
+// teamMarkdown renders markdown with the team's variables filled in.
+func teamMarkdown(ctx context.Context, s string) template.HTML {
+	return stringToMarkdown(helpers.ExpandTemplateVarsMarkdown(s, templateVars(ctx)))
+}
+
+// teamText fills in the team's variables in plain text.
+func teamText(ctx context.Context, s string) string {
+	return helpers.ExpandTemplateVars(s, templateVars(ctx))
+}
+
 func icon(icon string, attrs templ.Attributes) templ.Component {
 	return templ.Raw(lucide.Icon(icon, attrs))
 }
+
+// visibilityGroups lists the game's groups, depth first, for choosing which
+// teams see a block.
+func visibilityGroups(structure models.GameStructure) []models.GameStructure {
+	var groups []models.GameStructure
+	for _, group := range structure.SubGroups {
+		groups = append(groups, group)
+		groups = append(groups, visibilityGroups(group)...)
+	}
+	return groups
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// LoginMethod is how a user proved who they are when logging in.
+type LoginMethod string
+
+const (
+	// LoginMethodPassword is an email address and password.
+	LoginMethodPassword LoginMethod = "password"
+	// LoginMethodTwoFactor is the code entered after a password or single
+	// sign-on when two-factor authentication is on.
+	LoginMethodTwoFactor LoginMethod = "two_factor"
+	// LoginMethodMagicLink is a one-time link generated from the command line.
+	LoginMethodMagicLink LoginMethod = "magic_link"
+)
+
+// Reasons a login attempt failed.
+const (
+	LoginFailureInvalidPassword = "invalid_password"
+	LoginFailureInvalidCode     = "invalid_code"
+	// LoginFailureLocked is an attempt made while too many recent attempts
+	// had failed. These don't count towards the lockout themselves.
+	LoginFailureLocked = "locked"
+)
+
+// AdminSession is the server-side record of a logged in admin, so sessions
+// can be listed and revoked. The session cookie only holds the ID.
+type AdminSession struct {
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	LastSeenAt time.Time `bun:"last_seen_at,nullzero,notnull,default:current_timestamp"`
+
+	ID        string `bun:"id,pk,type:varchar(36)"`
+	UserID    string `bun:"user_id,type:varchar(36),notnull"`
+	IPAddress string `bun:"ip_address,type:varchar(64)"`
+	UserAgent string `bun:"user_agent,type:text"`
+	// Method is a LoginMethod, or the single sign-on provider's name
+	Method string `bun:"method,type:varchar(64)"`
+}
+
+// LoginAttempt records an attempt to log in, whether or not it worked.
+type LoginAttempt struct {
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+
+	ID string `bun:"id,pk,type:varchar(36)"`
+	// Email is the address that was entered, in lower case
+	Email string `bun:"email,type:varchar(255),notnull"`
+	// UserID is empty when no account has the email address
+	UserID    string `bun:"user_id,type:varchar(36)"`
+	IPAddress string `bun:"ip_address,type:varchar(64)"`
+	UserAgent string `bun:"user_agent,type:text"`
+	Method    string `bun:"method,type:varchar(64)"`
+	Success   bool   `bun:"success,notnull,default:false"`
+	// Reason says why a failed attempt failed
+	Reason string `bun:"reason,type:varchar(64)"`
+}
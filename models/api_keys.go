@@ -0,0 +1,63 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// APIScope grants an API key access to one part of the REST API.
+type APIScope string
+
+const (
+	// ScopeInstancesRead lists games and reads their details.
+	ScopeInstancesRead APIScope = "instances:read"
+	// ScopeLocationsRead lists a game's locations.
+	ScopeLocationsRead APIScope = "locations:read"
+	// ScopeLocationsWrite adds locations to a game.
+	ScopeLocationsWrite APIScope = "locations:write"
+	// ScopeTeamsRead lists a game's teams.
+	ScopeTeamsRead APIScope = "teams:read"
+	// ScopeTeamsWrite adds teams to a game.
+	ScopeTeamsWrite APIScope = "teams:write"
+	// ScopeResultsRead reads check-ins and the leaderboard.
+	ScopeResultsRead APIScope = "results:read"
+	// ScopeNotificationsWrite sends notifications to teams.
+	ScopeNotificationsWrite APIScope = "notifications:write"
+)
+
+// APIScopes lists every scope a key may be given.
+var APIScopes = []APIScope{
+	ScopeInstancesRead,
+	ScopeLocationsRead,
+	ScopeLocationsWrite,
+	ScopeTeamsRead,
+	ScopeTeamsWrite,
+	ScopeResultsRead,
+	ScopeNotificationsWrite,
+}
+
+// IsValid reports whether the scope is one of the known scopes.
+func (s APIScope) IsValid() bool {
+	return slices.Contains(APIScopes, s)
+}
+
+// APIKey lets a program use the REST API on behalf of a user, limited to its
+// scopes and to the games the user can access.
+type APIKey struct {
+	baseModel
+
+	ID     string `bun:"id,pk,type:varchar(36)"`
+	UserID string `bun:"user_id,type:varchar(36),notnull"`
+	Name   string `bun:"name,type:varchar(255),notnull"`
+	// The start of the key, so users can tell their keys apart
+	Prefix string `bun:"prefix,type:varchar(16),notnull"`
+	// SHA-256 of the key, which is only shown once
+	KeyHash    string    `bun:"key_hash,type:varchar(64),notnull,unique"`
+	Scopes     StrArray  `bun:"scopes,type:text"`
+	LastUsedAt time.Time `bun:"last_used_at,nullzero"`
+}
+
+// HasScope reports whether the key was given the scope.
+func (k *APIKey) HasScope(scope APIScope) bool {
+	return slices.Contains(k.Scopes, string(scope))
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/blocks"
+)
+
+// RevisionAction records why a block revision was saved.
+type RevisionAction string
+
+const (
+	RevisionOriginal RevisionAction = "original" // Content from before history was kept
+	RevisionEdited   RevisionAction = "edited"
+	RevisionRestored RevisionAction = "restored"
+	RevisionDeleted  RevisionAction = "deleted"
+)
+
+// BlockRevision is a snapshot of a block's content. Revisions are kept after
+// the block is deleted so it can be restored.
+type BlockRevision struct {
+	ID        string              `bun:"id,pk,type:varchar(36)"`
+	CreatedAt time.Time           `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	BlockID   string              `bun:"block_id,notnull,type:varchar(36)"`
+	OwnerID   string              `bun:"owner_id,notnull,type:varchar(36)"`
+	Context   blocks.BlockContext `bun:"context,type:string"`
+	Type      string              `bun:"type,type:varchar(50)"`
+	Data      json.RawMessage     `bun:"data,type:jsonb"`
+	Ordering  int                 `bun:"ordering,type:int"`
+	Points    int                 `bun:"points,type:int"`
+	UserID    string              `bun:"user_id,type:varchar(36),nullzero"` // Who made the change
+	Action    RevisionAction      `bun:"action,type:varchar(20),notnull"`
+
+	User *User `bun:"rel:belongs-to,join:user_id=id"`
+}
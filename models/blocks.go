@@ -15,6 +15,8 @@ type Block struct {
 	Ordering           int                 `bun:"ordering,type:int"`
 	Points             int                 `bun:"points,type:int"`
 	ValidationRequired bool                `bun:"validation_required,type:bool"`
+	Title              string              `bun:"title,type:varchar(255)"`              // Name of a library block
+	LibraryID          string              `bun:"library_id,type:varchar(36),nullzero"` // Library block this is a linked copy of
 }
 
 type TeamBlockState struct {
@@ -10,6 +10,7 @@ type CreditAdjustments struct {
 
 	ID               string         `bun:"id,unique,pk,type:varchar(36)"`
 	UserID           string         `bun:"user_id,notnull,type:varchar(36)"`
+	OrganisationID   string         `bun:"organisation_id,notnull,type:varchar(36)"` // Set for organisation pools
 	Credits          int            `bun:"credits,type:int,notnull"`
 	Reason           string         `bun:"reason,type:varchar(255),notnull"`
 	CreditPurchaseID sql.NullString `bun:"credit_purchase_id,type:varchar(36),nullzero"`
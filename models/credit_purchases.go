@@ -14,6 +14,7 @@ type CreditPurchase struct {
 
 	ID               string         `bun:"id,unique,pk,type:varchar(36)"`
 	UserID           string         `bun:"user_id,notnull,type:varchar(36)"`
+	OrganisationID   string         `bun:"organisation_id,notnull,type:varchar(36)"` // Set when buying for an organisation
 	Credits          int            `bun:"credits,type:int,notnull"`
 	AmountPaid       int            `bun:"amount_paid,type:int,notnull"` // Amount in cents
 	StripePaymentID  sql.NullString `bun:"stripe_payment_id,type:varchar(255),nullzero"`
@@ -4,9 +4,29 @@ import (
 	"time"
 )
 
+// FacilitatorToken is a passwordless link to the facilitator dashboard. The
+// token is the secret in the link; the ID identifies it when managing links.
 type FacilitatorToken struct {
 	Token      string    `bun:"token,pk"`
+	ID         string    `bun:"id,type:varchar(36)"`
 	InstanceID string    `bun:"instance_id,notnull"`
+	Label      string    `bun:"label,type:varchar(100)"`
 	Locations  StrArray  `bun:"locations,type:text"`
+	CreatedAt  time.Time `bun:"created_at,nullzero"`
+	LastUsedAt time.Time `bun:"last_used_at,nullzero"`
 	ExpiresAt  time.Time `bun:"expires_at,type:datetime"`
 }
+
+// AllowsLocation reports whether the token gives access to the location. A
+// token without any locations gives access to them all.
+func (t *FacilitatorToken) AllowsLocation(locationID string) bool {
+	if len(t.Locations) == 0 {
+		return true
+	}
+	for _, id := range t.Locations {
+		if id == locationID {
+			return true
+		}
+	}
+	return false
+}
@@ -14,6 +14,7 @@ type Instance struct {
 	ID                    string        `bun:"id,pk,type:varchar(36)"`
 	Name                  string        `bun:"name,type:varchar(255)"`
 	UserID                string        `bun:"user_id,type:varchar(36)"`
+	OrganisationID        string        `bun:"organisation_id,type:varchar(36),notnull"` // Set when shared
 	IsTemplate            bool          `bun:"is_template,type:bool"`
 	TemplateID            string        `bun:"template_id,type:varchar(36),nullzero"`
 	StartTime             bun.NullTime  `bun:"start_time,nullzero"`
@@ -22,10 +23,11 @@ type Instance struct {
 	IsQuickStartDismissed bool          `bun:"is_quick_start_dismissed,type:bool"`
 	GameStructure         GameStructure `bun:"game_structure,type:string"`
 
-	Teams      []Team           `bun:"rel:has-many,join:id=instance_id"`
-	Locations  []Location       `bun:"rel:has-many,join:id=instance_id"`
-	Settings   InstanceSettings `bun:"rel:has-one,join:id=instance_id"`
-	ShareLinks []ShareLink      `bun:"rel:has-many,join:id=template_id"`
+	Teams        []Team           `bun:"rel:has-many,join:id=instance_id"`
+	Locations    []Location       `bun:"rel:has-many,join:id=instance_id"`
+	Settings     InstanceSettings `bun:"rel:has-one,join:id=instance_id"`
+	ShareLinks   []ShareLink      `bun:"rel:has-many,join:id=template_id"`
+	Organisation *Organisation    `bun:"rel:belongs-to,join:organisation_id=id"`
 }
 
 // GetStatus returns the status of the instance.
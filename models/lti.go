@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// LTIGradeMode is what a game reports back to an LMS gradebook.
+type LTIGradeMode string
+
+const (
+	// GradeNone does not send grades
+	GradeNone LTIGradeMode = "none"
+	// GradePoints sends the team's points out of a set maximum
+	GradePoints LTIGradeMode = "points"
+	// GradeCompletion sends full marks once the team has finished the game
+	GradeCompletion LTIGradeMode = "completion"
+)
+
+// IsValid reports whether the mode is one of the known modes.
+func (m LTIGradeMode) IsValid() bool {
+	return m == GradeNone || m == GradePoints || m == GradeCompletion
+}
+
+// LTIPlatform is an LMS, such as Moodle or Canvas, registered to launch a
+// game as an LTI 1.3 tool.
+type LTIPlatform struct {
+	baseModel
+
+	ID         string `bun:"id,pk,type:varchar(36)"`
+	InstanceID string `bun:"instance_id,type:varchar(36),notnull"`
+	Name       string `bun:"name,type:varchar(255),notnull"`
+	// Issuer and ClientID identify the registration in launches
+	Issuer       string `bun:"issuer,type:varchar(255),notnull"`
+	ClientID     string `bun:"client_id,type:varchar(255),notnull"`
+	DeploymentID string `bun:"deployment_id,type:varchar(255),notnull"`
+	// The platform's OIDC authorisation, OAuth 2 token, and public key URLs
+	AuthLoginURL string       `bun:"auth_login_url,type:varchar(2048),notnull"`
+	AuthTokenURL string       `bun:"auth_token_url,type:varchar(2048),notnull"`
+	KeySetURL    string       `bun:"key_set_url,type:varchar(2048),notnull"`
+	GradeMode    LTIGradeMode `bun:"grade_mode,type:varchar(20),notnull"`
+	// ScoreMaximum is the points that count as full marks in GradePoints mode
+	ScoreMaximum int `bun:"score_maximum,notnull,default:0"`
+
+	Users []LTIUser `bun:"rel:has-many,join:id=platform_id"`
+}
+
+// LTIUser links an LMS user to the team they play as, and remembers the
+// last grade sent for them.
+type LTIUser struct {
+	baseModel
+
+	ID         string `bun:"id,pk,type:varchar(36)"`
+	PlatformID string `bun:"platform_id,type:varchar(36),notnull"`
+	// Subject is the platform's ID for the user
+	Subject  string `bun:"subject,type:varchar(255),notnull"`
+	Name     string `bun:"name,type:varchar(255),notnull"`
+	Email    string `bun:"email,type:varchar(255),notnull"`
+	TeamCode string `bun:"team_code,type:varchar(36),notnull"`
+	// TeamKey is set by the platform for users who share a team
+	TeamKey string `bun:"team_key,type:varchar(255),notnull"`
+	// LineItemURL is the gradebook column to send grades to, if any
+	LineItemURL string    `bun:"line_item_url,type:varchar(2048),notnull"`
+	SyncedScore float64   `bun:"synced_score,notnull,default:0"`
+	SyncedAt    time.Time `bun:"synced_at,nullzero"`
+	SyncError   string    `bun:"sync_error,type:text,notnull"`
+
+	Platform *LTIPlatform `bun:"rel:belongs-to,join:platform_id=id"`
+}
+
+// LTIKey is an RSA key Rapua uses to sign requests to platforms. Platforms
+// fetch the public half from the tool's key set.
+type LTIKey struct {
+	baseModel
+
+	ID         string `bun:"id,pk,type:varchar(36)"`
+	PrivateKey string `bun:"private_key,type:text,notnull"`
+}
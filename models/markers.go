@@ -10,10 +10,18 @@ type Marker struct {
 	TotalVisits  int     `bun:"total_visits,type:int"`
 	CurrentCount int     `bun:"current_count,type:int"`
 	AvgDuration  float64 `bun:"avg_duration,type:float"`
+	OwnerID      string  `bun:"owner_id,type:varchar(36),notnull"` // User whose marker library holds the place
+	Notes        string  `bun:"notes,type:text,notnull"`
+	ImageURL     string  `bun:"image_url,type:varchar(512),notnull"`
 
 	Locations []Location `bun:"rel:has-many,join:code=marker_id"`
 }
 
+// InLibrary reports whether the marker is saved to a marker library.
+func (m Marker) InLibrary() bool {
+	return m.OwnerID != ""
+}
+
 func (m Marker) IsMapped() bool {
 	return m.Lat != 0 && m.Lng != 0
 }
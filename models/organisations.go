@@ -0,0 +1,80 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// OrganisationRole is what a member may do with an organisation's games.
+type OrganisationRole string
+
+const (
+	// RoleOwner manages the organisation and its members, and edits games.
+	RoleOwner OrganisationRole = "owner"
+	// RoleEditor edits games and templates.
+	RoleEditor OrganisationRole = "editor"
+	// RoleFacilitator runs games: teams, notifications and scheduling.
+	RoleFacilitator OrganisationRole = "facilitator"
+	// RoleViewer can look but not change anything.
+	RoleViewer OrganisationRole = "viewer"
+)
+
+// OrganisationRoles lists the roles from most to least access.
+var OrganisationRoles = []OrganisationRole{RoleOwner, RoleEditor, RoleFacilitator, RoleViewer}
+
+// IsValid reports whether the role is one of the known roles.
+func (r OrganisationRole) IsValid() bool {
+	switch r {
+	case RoleOwner, RoleEditor, RoleFacilitator, RoleViewer:
+		return true
+	}
+	return false
+}
+
+// CanManage reports whether the role may manage members and sharing.
+func (r OrganisationRole) CanManage() bool {
+	return r == RoleOwner
+}
+
+// CanEdit reports whether the role may change a game's content and settings.
+func (r OrganisationRole) CanEdit() bool {
+	return r == RoleOwner || r == RoleEditor
+}
+
+// CanFacilitate reports whether the role may run a live game.
+func (r OrganisationRole) CanFacilitate() bool {
+	return r.CanEdit() || r == RoleFacilitator
+}
+
+// Organisation is a group of users who share games and templates.
+type Organisation struct {
+	baseModel
+
+	ID   string `bun:"id,pk,type:varchar(36)"`
+	Name string `bun:"name,type:varchar(255),notnull"`
+
+	// The credit pool members' shared games draw from
+	FreeCredits        int            `bun:"free_credits,type:int,notnull,default:0"`
+	PaidCredits        int            `bun:"paid_credits,type:int,notnull,default:0"`
+	MonthlyCreditLimit int            `bun:"monthly_credit_limit,type:int,notnull,default:0"`
+	StripeCustomerID   sql.NullString `bun:"stripe_customer_id,type:varchar(255),nullzero"`
+
+	// Members must set up two-factor authentication to use the admin
+	RequireTwoFactor bool `bun:"require_two_factor,type:boolean,notnull,default:false"`
+
+	Members   []OrganisationMember `bun:"rel:has-many,join:id=organisation_id"`
+	Instances []Instance           `bun:"rel:has-many,join:id=organisation_id"`
+}
+
+// OrganisationMember gives a user a role in an organisation.
+type OrganisationMember struct {
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+
+	OrganisationID string           `bun:"organisation_id,pk,type:varchar(36)"`
+	UserID         string           `bun:"user_id,pk,type:varchar(36)"`
+	Role           OrganisationRole `bun:"role,type:varchar(20),notnull"`
+	CreditLimit    int              `bun:"credit_limit,type:int,notnull,default:0"` // Monthly, 0 for no limit
+
+	Organisation *Organisation `bun:"rel:belongs-to,join:organisation_id=id"`
+	User         *User         `bun:"rel:belongs-to,join:user_id=id"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// PushSubscription is a player's device that has agreed to receive Web Push
+// notifications for their team.
+type PushSubscription struct {
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+
+	ID         string `bun:"id,pk,type:varchar(36)"`
+	InstanceID string `bun:"instance_id,type:varchar(36),notnull"`
+	TeamCode   string `bun:"team_code,type:varchar(36),notnull"`
+	// Endpoint is the push service URL for the device. Each device has one,
+	// so it identifies the subscription.
+	Endpoint string `bun:"endpoint,type:varchar(2048),notnull"`
+	// P256dh and Auth are the device's keys for encrypting messages, base64url
+	// encoded
+	P256dh string `bun:"p256dh,type:varchar(255),notnull"`
+	Auth   string `bun:"auth,type:varchar(64),notnull"`
+}
+
+// VAPIDKey is the P-256 key Rapua signs push requests with. Browsers are
+// given the public half when a device subscribes, and only accept messages
+// signed by it.
+type VAPIDKey struct {
+	baseModel
+
+	ID         string `bun:"id,pk,type:varchar(36)"`
+	PrivateKey string `bun:"private_key,type:text,notnull"`
+}
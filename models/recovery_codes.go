@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// RecoveryCode lets a user log in once without their authenticator app.
+type RecoveryCode struct {
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+
+	ID     string `bun:"id,pk,type:varchar(36)"`
+	UserID string `bun:"user_id,type:varchar(36),notnull"`
+	// SHA-256 of the code, which is only shown once
+	CodeHash string    `bun:"code_hash,type:varchar(64),notnull,unique"`
+	UsedAt   time.Time `bun:"used_at,nullzero"`
+}
@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// NotificationTiming is how a scheduled notification's send time is worked
+// out.
+type NotificationTiming string
+
+const (
+	// NotifyAt sends at a fixed time.
+	NotifyAt NotificationTiming = "at"
+	// NotifyAfterStart sends a number of minutes after the game starts.
+	NotifyAfterStart NotificationTiming = "after_start"
+	// NotifyBeforeEnd sends a number of minutes before the game ends.
+	NotifyBeforeEnd NotificationTiming = "before_end"
+)
+
+// NotificationTarget is which teams a scheduled notification goes to. Only
+// teams that have started playing are ever sent one.
+type NotificationTarget string
+
+const (
+	// TargetAllTeams sends to every team.
+	TargetAllTeams NotificationTarget = "all"
+	// TargetTeam sends to the team whose code is the target ID.
+	TargetTeam NotificationTarget = "team"
+	// TargetGroup sends to teams currently in the group with the target ID.
+	TargetGroup NotificationTarget = "group"
+	// TargetNotVisited sends to teams that haven't checked in at the
+	// location with the target ID.
+	TargetNotVisited NotificationTarget = "not_visited"
+	// TargetFinished sends to teams that have visited every location.
+	TargetFinished NotificationTarget = "finished"
+	// TargetUnfinished sends to teams that still have locations to visit.
+	TargetUnfinished NotificationTarget = "unfinished"
+)
+
+// ScheduledNotification is an announcement waiting to be sent to some or
+// all of a game's teams, or a record of one that was.
+type ScheduledNotification struct {
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+
+	ID         string             `bun:"id,pk,type:varchar(36)"`
+	InstanceID string             `bun:"instance_id,type:varchar(36),notnull"`
+	Content    string             `bun:"content,type:varchar(255),notnull"`
+	Timing     NotificationTiming `bun:"timing,type:varchar(16),notnull"`
+	// SendAt is the fixed send time, used when Timing is NotifyAt
+	SendAt time.Time `bun:"send_at,nullzero"`
+	// OffsetMinutes is used by the timings relative to the game's start
+	// and end
+	OffsetMinutes int                `bun:"offset_minutes,notnull,default:0"`
+	Target        NotificationTarget `bun:"target,type:varchar(16),notnull"`
+	// TargetID is the team code, group ID or location ID the target needs
+	TargetID string `bun:"target_id,type:varchar(36)"`
+
+	// SentAt is when the notification was sent or skipped. It is zero while
+	// the notification is pending.
+	SentAt     time.Time `bun:"sent_at,nullzero"`
+	Recipients int       `bun:"recipients,notnull,default:0"`
+	// Skipped explains why a notification wasn't sent, such as the game
+	// having already ended
+	Skipped string `bun:"skipped,type:varchar(255)"`
+}
+
+// Pending reports whether the notification is still waiting to be sent.
+func (n *ScheduledNotification) Pending() bool {
+	return n.SentAt.IsZero()
+}
+
+// DueAt returns when the notification should be sent in the instance. It
+// returns false if that isn't known yet, such as a notification relative to
+// the end of a game with no end time.
+func (n *ScheduledNotification) DueAt(instance *Instance) (time.Time, bool) {
+	offset := time.Duration(n.OffsetMinutes) * time.Minute
+	switch n.Timing {
+	case NotifyAt:
+		return n.SendAt, !n.SendAt.IsZero()
+	case NotifyAfterStart:
+		if instance.StartTime.Time.IsZero() {
+			return time.Time{}, false
+		}
+		return instance.StartTime.Time.Add(offset), true
+	case NotifyBeforeEnd:
+		if instance.EndTime.Time.IsZero() {
+			return time.Time{}, false
+		}
+		return instance.EndTime.Time.Add(-offset), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// MessageSender is which side of a team's conversation wrote a message.
+type MessageSender string
+
+const (
+	// MessageFromTeam is a message a team sent to the organisers.
+	MessageFromTeam MessageSender = "team"
+	// MessageFromOrganiser is a reply from an admin or facilitator.
+	MessageFromOrganiser MessageSender = "organiser"
+)
+
+// TeamMessage is one message in the conversation between a team and the
+// people running their game.
+type TeamMessage struct {
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+
+	ID         string        `bun:"id,pk,type:varchar(36)"`
+	InstanceID string        `bun:"instance_id,type:varchar(36),notnull"`
+	TeamCode   string        `bun:"team_code,type:varchar(36),notnull"`
+	Sender     MessageSender `bun:"sender,type:varchar(16),notnull"`
+	// Author names who replied: an admin, or a facilitator link's label.
+	// It is empty for messages from the team.
+	Author  string `bun:"author,type:varchar(255)"`
+	Content string `bun:"content,type:text,notnull"`
+	// ReadAt is when the other side first saw the message
+	ReadAt time.Time `bun:"read_at,nullzero"`
+}
+
+// FromTeam reports whether the team wrote the message.
+func (m TeamMessage) FromTeam() bool {
+	return m.Sender == MessageFromTeam
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TrashType is the kind of entity held in the trash.
+type TrashType string
+
+const (
+	TrashInstance TrashType = "instance"
+	TrashLocation TrashType = "location"
+	TrashTeam     TrashType = "team"
+)
+
+// TrashItem is a deleted game, location or team that can be restored until
+// it is purged.
+type TrashItem struct {
+	ID         string          `bun:"id,pk,type:varchar(36)"`
+	CreatedAt  time.Time       `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	PurgeAt    time.Time       `bun:"purge_at,notnull"`
+	UserID     string          `bun:"user_id,notnull,type:varchar(36)"`     // Owner of the game
+	InstanceID string          `bun:"instance_id,notnull,type:varchar(36)"` // Game the entity belonged to
+	Type       TrashType       `bun:"type,type:varchar(20),notnull"`
+	EntityID   string          `bun:"entity_id,notnull,type:varchar(36)"`
+	Name       string          `bun:"name,type:varchar(255)"`
+	Data       json.RawMessage `bun:"data,type:jsonb"` // TrashSnapshot of the deleted rows
+}
+
+// TrashSnapshot holds every row removed along with a trashed entity, so it
+// can be put back exactly as it was.
+type TrashSnapshot struct {
+	Instances        []Instance         `json:"instances,omitempty"`
+	InstanceSettings []InstanceSettings `json:"instance_settings,omitempty"`
+	Locations        []Location         `json:"locations,omitempty"`
+	Markers          []Marker           `json:"markers,omitempty"`
+	Blocks           []Block            `json:"blocks,omitempty"`
+	BlockStates      []TeamBlockState   `json:"block_states,omitempty"`
+	Teams            []Team             `json:"teams,omitempty"`
+	CheckIns         []CheckIn          `json:"check_ins,omitempty"`
+	Uploads          []Upload           `json:"uploads,omitempty"`
+	TeamMessages     []TeamMessage      `json:"team_messages,omitempty"`
+}
+
+// Snapshot decodes the rows held by the item.
+func (t *TrashItem) Snapshot() (*TrashSnapshot, error) {
+	snapshot := new(TrashSnapshot)
+	err := json.Unmarshal(t.Data, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
@@ -23,6 +23,13 @@ type User struct {
 	MonthlyCreditLimit int            `bun:"monthly_credit_limit,type:int,default:10"` // Monthly free credit allocation
 	StripeCustomerID   sql.NullString `bun:"stripe_customer_id,type:varchar(255),nullzero"`
 
+	// Two-factor authentication. The secret is set when enrolment starts and
+	// TOTPEnabled once the user confirms a code from their app.
+	TOTPSecret  string `bun:"totp_secret,type:varchar(64)"`
+	TOTPEnabled bool   `bun:"totp_enabled,type:boolean,notnull,default:false"`
+	// The last time step a code was accepted for, so a code can't be reused
+	TOTPLastStep int64 `bun:"totp_last_step,type:bigint,notnull,default:0"`
+
 	Instances         []Instance       `bun:"rel:has-many,join:id=user_id"`
 	CurrentInstanceID string           `bun:"current_instance_id,type:varchar(36)"`
 	CurrentInstance   Instance         `bun:"rel:has-one,join:current_instance_id=id"`
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// WebhookEvent is something that happens in a game that a webhook can
+// subscribe to.
+type WebhookEvent string
+
+const (
+	EventTeamStarted    WebhookEvent = "team.started"
+	EventTeamCheckedIn  WebhookEvent = "team.checked_in"
+	EventTeamCheckedOut WebhookEvent = "team.checked_out"
+	EventBlockCompleted WebhookEvent = "block.completed"
+	EventTeamFinished   WebhookEvent = "team.finished"
+	EventGameStarted    WebhookEvent = "game.started"
+	EventGameEnded      WebhookEvent = "game.ended"
+)
+
+// WebhookEvents lists every event a webhook may subscribe to.
+var WebhookEvents = []WebhookEvent{
+	EventTeamStarted,
+	EventTeamCheckedIn,
+	EventTeamCheckedOut,
+	EventBlockCompleted,
+	EventTeamFinished,
+	EventGameStarted,
+	EventGameEnded,
+}
+
+// IsValid reports whether the event is one of the known events.
+func (e WebhookEvent) IsValid() bool {
+	return slices.Contains(WebhookEvents, e)
+}
+
+// WebhookDeliveryStatus is where a delivery is in its retries.
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliveryDelivered WebhookDeliveryStatus = "delivered"
+	DeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// Webhook sends a game's events to a URL.
+type Webhook struct {
+	baseModel
+
+	ID         string `bun:"id,pk,type:varchar(36)"`
+	InstanceID string `bun:"instance_id,type:varchar(36),notnull"`
+	URL        string `bun:"url,type:varchar(2048),notnull"`
+	// Signs each payload so the receiver can check it came from Rapua
+	Secret  string   `bun:"secret,type:varchar(64),notnull"`
+	Events  StrArray `bun:"events,type:text"`
+	Enabled bool     `bun:"enabled,notnull,default:true"`
+
+	Deliveries []WebhookDelivery `bun:"rel:has-many,join:id=webhook_id"`
+}
+
+// Subscribes reports whether the webhook wants the event.
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	return w.Enabled && slices.Contains(w.Events, string(event))
+}
+
+// WebhookDelivery is one event sent, or waiting to be sent, to a webhook.
+type WebhookDelivery struct {
+	baseModel
+
+	ID         string       `bun:"id,pk,type:varchar(36)"`
+	WebhookID  string       `bun:"webhook_id,type:varchar(36),notnull"`
+	InstanceID string       `bun:"instance_id,type:varchar(36),notnull"`
+	Event      WebhookEvent `bun:"event,type:varchar(50),notnull"`
+	// Stops an event that should only happen once from being sent twice
+	DedupeKey      string                `bun:"dedupe_key,type:varchar(255),notnull"`
+	Payload        string                `bun:"payload,type:text,notnull"`
+	Status         WebhookDeliveryStatus `bun:"status,type:varchar(20),notnull"`
+	Attempts       int                   `bun:"attempts,notnull,default:0"`
+	NextAttemptAt  time.Time             `bun:"next_attempt_at,notnull"`
+	LastAttemptAt  time.Time             `bun:"last_attempt_at,nullzero"`
+	ResponseStatus int                   `bun:"response_status,notnull,default:0"`
+	Error          string                `bun:"error,type:text,notnull"`
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// AdminSessionRepository stores the server-side records of admin sessions.
+type AdminSessionRepository struct {
+	db *bun.DB
+}
+
+func NewAdminSessionRepository(db *bun.DB) *AdminSessionRepository {
+	return &AdminSessionRepository{
+		db: db,
+	}
+}
+
+// Create saves a new session.
+func (r *AdminSessionRepository) Create(ctx context.Context, session *models.AdminSession) error {
+	_, err := r.db.NewInsert().Model(session).Exec(ctx)
+	return err
+}
+
+// GetByID finds a session by its ID.
+func (r *AdminSessionRepository) GetByID(ctx context.Context, id string) (*models.AdminSession, error) {
+	session := &models.AdminSession{}
+	err := r.db.NewSelect().
+		Model(session).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// FindByUserID returns a user's sessions seen since the given time, most
+// recently seen first.
+func (r *AdminSessionRepository) FindByUserID(
+	ctx context.Context,
+	userID string,
+	seenSince time.Time,
+) ([]models.AdminSession, error) {
+	var sessions []models.AdminSession
+	err := r.db.NewSelect().
+		Model(&sessions).
+		Where("user_id = ?", userID).
+		Where("last_seen_at > ?", seenSince).
+		Order("last_seen_at DESC").
+		Scan(ctx)
+	return sessions, err
+}
+
+// TouchLastSeen records when a session was last used.
+func (r *AdminSessionRepository) TouchLastSeen(ctx context.Context, id string, seenAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.AdminSession)(nil)).
+		Set("last_seen_at = ?", seenAt).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// Delete removes one of a user's sessions. It returns sql.ErrNoRows if the
+// user has no session with that ID.
+func (r *AdminSessionRepository) Delete(ctx context.Context, userID, id string) error {
+	result, err := r.db.NewDelete().
+		Model((*models.AdminSession)(nil)).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteOthers removes all of a user's sessions except one, and returns how
+// many were removed.
+func (r *AdminSessionRepository) DeleteOthers(ctx context.Context, userID, keepID string) (int, error) {
+	result, err := r.db.NewDelete().
+		Model((*models.AdminSession)(nil)).
+		Where("user_id = ?", userID).
+		Where("id != ?", keepID).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
+
+// DeleteIdle removes sessions that haven't been used since the given time.
+func (r *AdminSessionRepository) DeleteIdle(ctx context.Context, seenBefore time.Time) error {
+	_, err := r.db.NewDelete().
+		Model((*models.AdminSession)(nil)).
+		Where("last_seen_at < ?", seenBefore).
+		Exec(ctx)
+	return err
+}
+
+// DeleteByUserIDWithTx removes all of a user's sessions.
+func (r *AdminSessionRepository) DeleteByUserIDWithTx(ctx context.Context, tx *bun.Tx, userID string) error {
+	_, err := tx.NewDelete().
+		Model((*models.AdminSession)(nil)).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	return err
+}
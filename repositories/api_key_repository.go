@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// APIKeyRepository stores the keys users create for the REST API.
+type APIKeyRepository struct {
+	db *bun.DB
+}
+
+func NewAPIKeyRepository(db *bun.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		db: db,
+	}
+}
+
+// Create saves a new API key.
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	_, err := r.db.NewInsert().Model(key).Exec(ctx)
+	return err
+}
+
+// GetByHash finds the key with the given hash.
+func (r *APIKeyRepository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	err := r.db.NewSelect().
+		Model(key).
+		Where("key_hash = ?", hash).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// FindByUserID returns a user's keys, newest first.
+func (r *APIKeyRepository) FindByUserID(ctx context.Context, userID string) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.NewSelect().
+		Model(&keys).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Scan(ctx)
+	return keys, err
+}
+
+// TouchLastUsed records when a key was last used.
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.APIKey)(nil)).
+		Set("last_used_at = ?", usedAt).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// Delete removes one of a user's keys. It returns sql.ErrNoRows if the user
+// has no key with that ID.
+func (r *APIKeyRepository) Delete(ctx context.Context, userID, id string) error {
+	result, err := r.db.NewDelete().
+		Model((*models.APIKey)(nil)).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteByUserIDWithTx removes all of a user's keys.
+func (r *APIKeyRepository) DeleteByUserIDWithTx(ctx context.Context, tx *bun.Tx, userID string) error {
+	_, err := tx.NewDelete().
+		Model((*models.APIKey)(nil)).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	return err
+}
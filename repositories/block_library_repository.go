@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// BlockLibraryRepository stores blocks saved to a user's library. Library
+// blocks are regular blocks owned by the user in the library context.
+type BlockLibraryRepository struct {
+	db *bun.DB
+}
+
+func NewBlockLibraryRepository(db *bun.DB) *BlockLibraryRepository {
+	return &BlockLibraryRepository{
+		db: db,
+	}
+}
+
+// Create saves a new library block for the user.
+func (r *BlockLibraryRepository) Create(ctx context.Context, userID string, item *models.Block) error {
+	if item == nil {
+		return errors.New("library block is required")
+	}
+	item.ID = uuid.New().String()
+	item.OwnerID = userID
+	item.Context = blocks.ContextLibrary
+	item.Ordering = 0
+	item.LibraryID = ""
+	_, err := r.db.NewInsert().Model(item).Exec(ctx)
+	return err
+}
+
+// GetByID fetches a library block belonging to the user.
+func (r *BlockLibraryRepository) GetByID(ctx context.Context, userID, itemID string) (*models.Block, error) {
+	item := new(models.Block)
+	err := r.db.NewSelect().
+		Model(item).
+		Where("id = ?", itemID).
+		Where("owner_id = ? AND context = ?", userID, blocks.ContextLibrary).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// FindByUserID fetches all library blocks for the user, sorted by title.
+func (r *BlockLibraryRepository) FindByUserID(ctx context.Context, userID string) ([]models.Block, error) {
+	var items []models.Block
+	err := r.db.NewSelect().
+		Model(&items).
+		Where("owner_id = ? AND context = ?", userID, blocks.ContextLibrary).
+		Order("title ASC").
+		Scan(ctx)
+	return items, err
+}
+
+// CountLinkedByUserID counts the linked copies of each of the user's library
+// blocks, keyed by library block ID.
+func (r *BlockLibraryRepository) CountLinkedByUserID(ctx context.Context, userID string) (map[string]int, error) {
+	var rows []struct {
+		LibraryID string `bun:"library_id"`
+		Count     int    `bun:"count"`
+	}
+	err := r.db.NewSelect().
+		Model((*models.Block)(nil)).
+		Column("library_id").
+		ColumnExpr("COUNT(*) AS count").
+		Where("library_id IN (?)", r.db.NewSelect().
+			Model((*models.Block)(nil)).
+			Column("id").
+			Where("owner_id = ? AND context = ?", userID, blocks.ContextLibrary)).
+		Group("library_id").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.LibraryID] = row.Count
+	}
+	return counts, nil
+}
+
+// FindLinked fetches every block that is a linked copy of the library block.
+func (r *BlockLibraryRepository) FindLinked(ctx context.Context, itemID string) ([]models.Block, error) {
+	var linked []models.Block
+	err := r.db.NewSelect().
+		Model(&linked).
+		Where("library_id = ?", itemID).
+		Scan(ctx)
+	return linked, err
+}
+
+// UpdateTitle renames a library block.
+func (r *BlockLibraryRepository) UpdateTitle(ctx context.Context, item *models.Block) error {
+	_, err := r.db.NewUpdate().
+		Model(item).
+		Column("title").
+		WherePK().
+		Where("context = ?", blocks.ContextLibrary).
+		Exec(ctx)
+	return err
+}
+
+// UnlinkAllWithTx turns every linked copy of the library block into a
+// regular block.
+func (r *BlockLibraryRepository) UnlinkAllWithTx(ctx context.Context, tx *bun.Tx, itemID string) error {
+	_, err := tx.NewUpdate().
+		Model((*models.Block)(nil)).
+		Set("library_id = NULL").
+		Where("library_id = ?", itemID).
+		Exec(ctx)
+	return err
+}
+
+// DeleteWithTx deletes a library block belonging to the user.
+func (r *BlockLibraryRepository) DeleteWithTx(ctx context.Context, tx *bun.Tx, userID, itemID string) error {
+	_, err := tx.NewDelete().
+		Model((*models.Block)(nil)).
+		Where("id = ?", itemID).
+		Where("owner_id = ? AND context = ?", userID, blocks.ContextLibrary).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,111 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBlockLibraryRepo(t *testing.T) (
+	*repositories.BlockLibraryRepository,
+	repositories.BlockRepository,
+	db.Transactor,
+	func(),
+) {
+	t.Helper()
+	dbc, cleanup := setupDB(t)
+
+	blockStateRepo := repositories.NewBlockStateRepository(dbc)
+	blockRepo := repositories.NewBlockRepository(dbc, blockStateRepo)
+	return repositories.NewBlockLibraryRepository(dbc), blockRepo, db.NewTransactor(dbc), cleanup
+}
+
+func newLibraryItem(t *testing.T, repo *repositories.BlockLibraryRepository, userID, title string) *models.Block {
+	t.Helper()
+	item := &models.Block{
+		Type:  "markdown",
+		Data:  []byte(`{"content":"Hello"}`),
+		Title: title,
+	}
+	require.NoError(t, repo.Create(context.Background(), userID, item))
+	return item
+}
+
+func TestBlockLibraryRepository_CreateAndFind(t *testing.T) {
+	repo, _, _, cleanup := setupBlockLibraryRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+
+	newLibraryItem(t, repo, userID, "Zebra crossing")
+	item := newLibraryItem(t, repo, userID, "Alpha")
+	newLibraryItem(t, repo, gofakeit.UUID(), "Someone else's")
+
+	assert.Equal(t, blocks.ContextLibrary, item.Context)
+	assert.Equal(t, userID, item.OwnerID)
+
+	items, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Alpha", items[0].Title)
+	assert.Equal(t, "Zebra crossing", items[1].Title)
+
+	found, err := repo.GetByID(ctx, userID, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Alpha", found.Title)
+
+	_, err = repo.GetByID(ctx, gofakeit.UUID(), item.ID)
+	require.Error(t, err)
+}
+
+func TestBlockLibraryRepository_Linked(t *testing.T) {
+	repo, blockRepo, transactor, cleanup := setupBlockLibraryRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+	userID := gofakeit.UUID()
+
+	item := newLibraryItem(t, repo, userID, "Welcome")
+	for range 2 {
+		block, err := blocks.CreateFromBaseBlock(blocks.BaseBlock{
+			Type:      "markdown",
+			Data:      item.Data,
+			LibraryID: item.ID,
+		})
+		require.NoError(t, err)
+		_, err = blockRepo.Create(ctx, block, gofakeit.UUID(), blocks.ContextLocationContent)
+		require.NoError(t, err)
+	}
+
+	counts, err := repo.CountLinkedByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, counts[item.ID])
+
+	linked, err := repo.FindLinked(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Len(t, linked, 2)
+
+	owns, err := blockRepo.UserOwnsBlock(ctx, userID, item.ID)
+	require.NoError(t, err)
+	assert.True(t, owns, "Users own their library blocks")
+
+	tx, err := transactor.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.UnlinkAllWithTx(ctx, tx, item.ID))
+	require.NoError(t, repo.DeleteWithTx(ctx, tx, userID, item.ID))
+	require.NoError(t, tx.Commit())
+
+	linked, err = repo.FindLinked(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Empty(t, linked)
+
+	items, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// BlockRevisionRepository stores snapshots of block content.
+type BlockRevisionRepository struct {
+	db *bun.DB
+}
+
+func NewBlockRevisionRepository(db *bun.DB) *BlockRevisionRepository {
+	return &BlockRevisionRepository{
+		db: db,
+	}
+}
+
+// Snapshot saves the block's stored content as a new revision.
+func (r *BlockRevisionRepository) Snapshot(
+	ctx context.Context,
+	blockID, userID string,
+	action models.RevisionAction,
+) (*models.BlockRevision, error) {
+	return r.snapshot(ctx, r.db, blockID, userID, action)
+}
+
+// SnapshotWithTx saves the block's stored content as a new revision within a
+// transaction.
+func (r *BlockRevisionRepository) SnapshotWithTx(
+	ctx context.Context,
+	tx *bun.Tx,
+	blockID, userID string,
+	action models.RevisionAction,
+) (*models.BlockRevision, error) {
+	return r.snapshot(ctx, tx, blockID, userID, action)
+}
+
+func (r *BlockRevisionRepository) snapshot(
+	ctx context.Context,
+	db bun.IDB,
+	blockID, userID string,
+	action models.RevisionAction,
+) (*models.BlockRevision, error) {
+	block := new(models.Block)
+	err := db.NewSelect().Model(block).Where("id = ?", blockID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &models.BlockRevision{
+		ID:        uuid.New().String(),
+		CreatedAt: time.Now(), // Sub-second precision keeps revisions in order
+		BlockID:   block.ID,
+		OwnerID:   block.OwnerID,
+		Context:   block.Context,
+		Type:      block.Type,
+		Data:      block.Data,
+		Ordering:  block.Ordering,
+		Points:    block.Points,
+		UserID:    userID,
+		Action:    action,
+	}
+	_, err = db.NewInsert().Model(revision).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// GetByID fetches a revision with the user who made it.
+func (r *BlockRevisionRepository) GetByID(ctx context.Context, revisionID string) (*models.BlockRevision, error) {
+	revision := new(models.BlockRevision)
+	err := r.db.NewSelect().
+		Model(revision).
+		Relation("User").
+		Where("block_revision.id = ?", revisionID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// FindByBlockID fetches a block's revisions, newest first.
+func (r *BlockRevisionRepository) FindByBlockID(ctx context.Context, blockID string) ([]models.BlockRevision, error) {
+	var revisions []models.BlockRevision
+	err := r.db.NewSelect().
+		Model(&revisions).
+		Relation("User").
+		Where("block_revision.block_id = ?", blockID).
+		Order("block_revision.created_at DESC").
+		Scan(ctx)
+	return revisions, err
+}
+
+// FindDeleted fetches the revisions saved when blocks were deleted from an
+// owner, newest first. Blocks that have since been restored are left out.
+func (r *BlockRevisionRepository) FindDeleted(
+	ctx context.Context,
+	ownerID string,
+	blockContext blocks.BlockContext,
+) ([]models.BlockRevision, error) {
+	var revisions []models.BlockRevision
+	err := r.db.NewSelect().
+		Model(&revisions).
+		Relation("User").
+		Where("block_revision.owner_id = ?", ownerID).
+		Where("block_revision.context = ?", blockContext).
+		Where("block_revision.action = ?", models.RevisionDeleted).
+		Where("block_revision.block_id NOT IN (?)", r.db.NewSelect().
+			Model((*models.Block)(nil)).
+			Column("id").
+			Where("owner_id = ?", ownerID)).
+		Order("block_revision.created_at DESC").
+		Scan(ctx)
+	return revisions, err
+}
+
+// Delete removes a single revision.
+func (r *BlockRevisionRepository) Delete(ctx context.Context, revisionID string) error {
+	_, err := r.db.NewDelete().
+		Model((*models.BlockRevision)(nil)).
+		Where("id = ?", revisionID).
+		Exec(ctx)
+	return err
+}
+
+// DeleteByOwnerIDWithTx removes the history of every block that belonged to
+// an owner.
+func (r *BlockRevisionRepository) DeleteByOwnerIDWithTx(ctx context.Context, tx *bun.Tx, ownerID string) error {
+	_, err := tx.NewDelete().
+		Model((*models.BlockRevision)(nil)).
+		Where("owner_id = ?", ownerID).
+		Exec(ctx)
+	return err
+}
+
+// Undelete inserts a deleted block again with its original ID.
+func (r *BlockRevisionRepository) Undelete(ctx context.Context, block *models.Block) error {
+	if block == nil || block.ID == "" {
+		return errors.New("block ID is required")
+	}
+	_, err := r.db.NewInsert().Model(block).Exec(ctx)
+	return err
+}
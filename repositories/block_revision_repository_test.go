@@ -0,0 +1,77 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/blocks"
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBlockRevisionRepo(t *testing.T) (
+	*repositories.BlockRevisionRepository,
+	repositories.BlockRepository,
+	db.Transactor,
+	func(),
+) {
+	t.Helper()
+	dbc, cleanup := setupDB(t)
+
+	blockStateRepo := repositories.NewBlockStateRepository(dbc)
+	blockRepo := repositories.NewBlockRepository(dbc, blockStateRepo)
+	return repositories.NewBlockRevisionRepository(dbc), blockRepo, db.NewTransactor(dbc), cleanup
+}
+
+func TestBlockRevisionRepository_Snapshot(t *testing.T) {
+	repo, blockRepo, transactor, cleanup := setupBlockRevisionRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+	ownerID := gofakeit.UUID()
+	userID := gofakeit.UUID()
+
+	block, err := blocks.CreateFromBaseBlock(blocks.BaseBlock{Type: "markdown", Points: 5})
+	require.NoError(t, err)
+	block, err = blockRepo.Create(ctx, block, ownerID, blocks.ContextLocationContent)
+	require.NoError(t, err)
+
+	revision, err := repo.Snapshot(ctx, block.GetID(), userID, models.RevisionEdited)
+	require.NoError(t, err)
+	assert.Equal(t, ownerID, revision.OwnerID)
+	assert.Equal(t, blocks.ContextLocationContent, revision.Context)
+	assert.Equal(t, 5, revision.Points)
+
+	_, err = repo.Snapshot(ctx, gofakeit.UUID(), userID, models.RevisionEdited)
+	require.Error(t, err, "Missing blocks cannot be snapshotted")
+
+	// Deleting the block leaves its history
+	tx, err := transactor.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	_, err = repo.SnapshotWithTx(ctx, tx, block.GetID(), userID, models.RevisionDeleted)
+	require.NoError(t, err)
+	require.NoError(t, blockRepo.Delete(ctx, tx, block.GetID()))
+	require.NoError(t, tx.Commit())
+
+	revisions, err := repo.FindByBlockID(ctx, block.GetID())
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, models.RevisionDeleted, revisions[0].Action)
+
+	deleted, err := repo.FindDeleted(ctx, ownerID, blocks.ContextLocationContent)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+
+	// Deleting the owner removes the history
+	tx, err = transactor.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.DeleteByOwnerIDWithTx(ctx, tx, ownerID))
+	require.NoError(t, tx.Commit())
+
+	revisions, err = repo.FindByBlockID(ctx, block.GetID())
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
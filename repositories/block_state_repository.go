@@ -19,6 +19,9 @@ type BlockStateRepository interface {
 
 	// GetByBlockAndTeam gets a player state by block ID and team code
 	GetByBlockAndTeam(ctx context.Context, blockID string, teamCode string) (blocks.PlayerState, error)
+	// CountPlayerDataByBlock counts completed player states for a block,
+	// grouped by identical player data
+	CountPlayerDataByBlock(ctx context.Context, blockID string) ([]PlayerDataCount, error)
 
 	// Update updates an existing player state
 	Update(ctx context.Context, block blocks.PlayerState) (blocks.PlayerState, error)
@@ -42,6 +45,12 @@ func NewBlockStateRepository(db *bun.DB) BlockStateRepository {
 	}
 }
 
+// PlayerDataCount is the number of completed states sharing the same player data.
+type PlayerDataCount struct {
+	PlayerData json.RawMessage `bun:"player_data"`
+	Count      int             `bun:"count"`
+}
+
 // PlayerStateData struct implements the PlayerState interface.
 type PlayerStateData struct {
 	blockID       string
@@ -124,6 +133,28 @@ func (r *blockStateRepository) GetByBlockAndTeam(
 	return convertModelToPlayerStateData(modelState), nil
 }
 
+// CountPlayerDataByBlock groups the completed states for a block by their
+// player data and counts each group. The grouping is done by the database so
+// aggregates do not require loading every state.
+func (r *blockStateRepository) CountPlayerDataByBlock(
+	ctx context.Context,
+	blockID string,
+) ([]PlayerDataCount, error) {
+	var counts []PlayerDataCount
+	err := r.db.NewSelect().
+		Model((*models.TeamBlockState)(nil)).
+		Column("player_data").
+		ColumnExpr("COUNT(*) AS count").
+		Where("block_id = ?", blockID).
+		Where("is_complete = ?", true).
+		Group("player_data").
+		Scan(ctx, &counts)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
 // Create inserts a new team block state into the database.
 func (r *blockStateRepository) Create(ctx context.Context, state blocks.PlayerState) (blocks.PlayerState, error) {
 	modelState := convertPlayerStateToModelData(state)
@@ -284,3 +284,49 @@ func TestBlockStateRepository_DeleteByTeamCodes(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockStateRepository_CountPlayerDataByBlock(t *testing.T) {
+	repo, _, cleanup := setupBlockStateRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	blockID := gofakeit.UUID()
+	votes := []struct {
+		data     string
+		complete bool
+	}{
+		{`{"option":"a"}`, true},
+		{`{"option":"a"}`, true},
+		{`{"option":"b"}`, true},
+		{`{"option":"b"}`, false}, // Incomplete states are not counted
+	}
+	for _, vote := range votes {
+		state, err := repo.NewBlockState(ctx, blockID, gofakeit.UUID())
+		require.NoError(t, err)
+		state.SetPlayerData([]byte(vote.data))
+		state.SetComplete(vote.complete)
+		_, err = repo.Create(ctx, state)
+		require.NoError(t, err)
+	}
+
+	// A state for another block must not be counted
+	other, err := repo.NewBlockState(ctx, gofakeit.UUID(), gofakeit.UUID())
+	require.NoError(t, err)
+	other.SetPlayerData([]byte(`{"option":"a"}`))
+	other.SetComplete(true)
+	_, err = repo.Create(ctx, other)
+	require.NoError(t, err)
+
+	counts, err := repo.CountPlayerDataByBlock(ctx, blockID)
+	require.NoError(t, err)
+
+	got := make(map[string]int)
+	for _, count := range counts {
+		got[string(count.PlayerData)] = count.Count
+	}
+	assert.Equal(t, map[string]int{`{"option":"a"}`: 2, `{"option":"b"}`: 1}, got)
+
+	counts, err = repo.CountPlayerDataByBlock(ctx, gofakeit.UUID())
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+}
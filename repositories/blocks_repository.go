@@ -133,10 +133,12 @@ func (r *blockRepository) GetByID(ctx context.Context, blockID string) (blocks.B
 }
 
 // UserOwnsBlock checks if a user owns a block by checking ownership of the block's owner (instance or location).
+// Blocks in games shared with the user's organisation count when the user may edit them.
 func (r *blockRepository) UserOwnsBlock(ctx context.Context, userID, blockID string) (bool, error) {
 	// Query to check block ownership through instances
 	// For start/complete blocks: owner_id IS the instance_id
 	// For location blocks: owner_id IS the location_id, which belongs to an instance
+	// For library blocks: owner_id IS the user_id
 	count, err := r.db.NewSelect().
 		Model((*models.Block)(nil)).
 		ColumnExpr("1").
@@ -150,13 +152,22 @@ func (r *blockRepository) UserOwnsBlock(ctx context.Context, userID, blockID str
 							"context IN (?)",
 							bun.In([]blocks.BlockContext{blocks.ContextStart, blocks.ContextFinish}),
 						).
-						Where("owner_id IN (SELECT id FROM instances WHERE user_id = ?)", userID)
+						Where("owner_id IN (?)", editableInstanceIDs(r.db, userID))
 				}).
 				// Location-owned blocks (all other contexts)
 				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
 					return q.
 						Where("context NOT IN (?)", bun.In([]blocks.BlockContext{blocks.ContextStart, blocks.ContextFinish})).
-						Where("owner_id IN (SELECT id FROM locations WHERE instance_id IN (SELECT id FROM instances WHERE user_id = ?))", userID)
+						Where(
+							"owner_id IN (SELECT id FROM locations WHERE instance_id IN (?))",
+							editableInstanceIDs(r.db, userID),
+						)
+				}).
+				// User-owned blocks (library)
+				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("context = ?", blocks.ContextLibrary).
+						Where("owner_id = ?", userID)
 				})
 		}).
 		Limit(1).
@@ -183,6 +194,7 @@ func (r *blockRepository) Create(
 		Ordering:           block.GetOrder(),
 		Points:             block.GetPoints(),
 		ValidationRequired: block.RequiresValidation(),
+		LibraryID:          block.GetLibraryID(),
 	}
 
 	count, err := r.db.NewSelect().
@@ -213,7 +225,7 @@ func (r *blockRepository) Update(ctx context.Context, block blocks.Block) (block
 	modelBlock := convertBlockToModel(block)
 	_, err := r.db.NewUpdate().
 		Model(&modelBlock).
-		Column("data", "ordering", "points").
+		Column("data", "ordering", "points", "library_id").
 		WherePK().
 		Exec(ctx)
 	if err != nil {
@@ -238,6 +250,7 @@ func convertBlockToModel(block blocks.Block) models.Block {
 		Data:               block.GetData(),
 		Points:             block.GetPoints(),
 		ValidationRequired: block.RequiresValidation(),
+		LibraryID:          block.GetLibraryID(),
 	}
 }
 
@@ -266,6 +279,7 @@ func convertModelToBlock(model *models.Block) (blocks.Block, error) {
 		Data:       model.Data,
 		Order:      model.Ordering,
 		Points:     model.Points,
+		LibraryID:  model.LibraryID,
 	})
 	if err != nil {
 		return nil, err
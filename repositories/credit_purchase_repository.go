@@ -211,10 +211,12 @@ func (r *CreditPurchaseRepository) UpdateReceiptURLWithTx(
 }
 
 // DeleteByUserID deletes all credit purchases for a user within a transaction.
+// Purchases made for an organisation are kept in its billing history.
 func (r *CreditPurchaseRepository) DeleteByUserID(ctx context.Context, tx *bun.Tx, userID string) error {
 	_, err := tx.NewDelete().
 		Model(&models.CreditPurchase{}).
 		Where("user_id = ?", userID).
+		Where("organisation_id = ''").
 		Exec(ctx)
 	return err
 }
@@ -52,6 +52,35 @@ func (r *CreditRepository) AddCreditsWithTx(
 	return nil
 }
 
+// AddOrganisationCreditsWithTx atomically increments an organisation's credit pool.
+func (r *CreditRepository) AddOrganisationCreditsWithTx(
+	ctx context.Context,
+	tx *bun.Tx,
+	organisationID string,
+	freeCreditsToAdd int,
+	paidCreditsToAdd int,
+) error {
+	result, err := tx.NewUpdate().
+		Model(&models.Organisation{}).
+		Set("free_credits = free_credits + ?", freeCreditsToAdd).
+		Set("paid_credits = paid_credits + ?", paidCreditsToAdd).
+		Where("id = ?", organisationID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("organisation not found")
+	}
+
+	return nil
+}
+
 // GetCreditAdjustmentsByUserID returns all credit adjustments for a user.
 func (r *CreditRepository) GetCreditAdjustmentsByUserID(
 	ctx context.Context,
@@ -62,6 +91,7 @@ func (r *CreditRepository) GetCreditAdjustmentsByUserID(
 		Model(&adjustments).
 		Relation("CreditPurchase").
 		Where("credit_adjustments.user_id = ?", userID).
+		Where("credit_adjustments.organisation_id = ''").
 		Order("credit_adjustments.created_at DESC").
 		Scan(ctx)
 	if err != nil {
@@ -81,6 +111,7 @@ func (r *CreditRepository) GetCreditAdjustmentsByUserIDWithPagination(
 		Model(&adjustments).
 		Relation("CreditPurchase").
 		Where("credit_adjustments.user_id = ?", userID).
+		Where("credit_adjustments.organisation_id = ''").
 		Order("credit_adjustments.created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -97,8 +128,8 @@ func (r *CreditRepository) CreateCreditAdjustmentWithTx(
 	tx *bun.Tx,
 	adjustment *models.CreditAdjustments,
 ) error {
-	if adjustment.UserID == "" {
-		return errors.New("userID is required for credit adjustment")
+	if adjustment.UserID == "" && adjustment.OrganisationID == "" {
+		return errors.New("userID or organisationID is required for credit adjustment")
 	}
 	if adjustment.Credits == 0 {
 		return errors.New("credits cannot be zero")
@@ -229,11 +260,136 @@ func (r *CreditRepository) DeductOneCreditWithTx(ctx context.Context, tx *bun.Tx
 	return nil
 }
 
+// FindCreditPoolWithTx returns the organisation whose credit pool pays for a
+// team start in the instance, or an empty string if the creator pays. The
+// instance must be shared with an organisation the creator belongs to, and
+// the creator must be under their monthly limit since the given time.
+func (r *CreditRepository) FindCreditPoolWithTx(
+	ctx context.Context,
+	tx *bun.Tx,
+	userID, instanceID string,
+	since time.Time,
+) (string, error) {
+	var organisationIDs []string
+	err := tx.NewSelect().
+		Model((*models.OrganisationMember)(nil)).
+		Column("organisation_member.organisation_id").
+		Join("JOIN instances ON instances.organisation_id = organisation_member.organisation_id").
+		Where("instances.id = ?", instanceID).
+		Where("organisation_member.user_id = ?", userID).
+		Where("organisation_member.credit_limit = 0 OR organisation_member.credit_limit > (?)",
+			tx.NewSelect().
+				Model((*models.TeamStartLog)(nil)).
+				ColumnExpr("COUNT(*)").
+				Where("organisation_id = organisation_member.organisation_id").
+				Where("user_id = organisation_member.user_id").
+				Where("created_at >= ?", since),
+		).
+		Scan(ctx, &organisationIDs)
+	if err != nil {
+		return "", err
+	}
+	if len(organisationIDs) == 0 {
+		return "", nil
+	}
+	return organisationIDs[0], nil
+}
+
+// DeductOneOrganisationCreditWithTx atomically deducts one credit from an
+// organisation's pool, preferring free credits over paid credits.
+// Returns an error if the pool is empty.
+func (r *CreditRepository) DeductOneOrganisationCreditWithTx(
+	ctx context.Context,
+	tx *bun.Tx,
+	organisationID string,
+) error {
+	result, err := tx.NewUpdate().
+		Model(&models.Organisation{}).
+		Set("free_credits = CASE WHEN free_credits > 0 THEN free_credits - 1 ELSE free_credits END").
+		Set("paid_credits = CASE WHEN free_credits = 0 AND paid_credits > 0 THEN paid_credits - 1 ELSE paid_credits END").
+		Where("id = ? AND (free_credits > 0 OR paid_credits > 0)", organisationID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("insufficient credits to start team")
+	}
+
+	return nil
+}
+
+// GetCreditAdjustmentsByOrganisationID returns all credit adjustments for an
+// organisation's pool.
+func (r *CreditRepository) GetCreditAdjustmentsByOrganisationID(
+	ctx context.Context,
+	organisationID string,
+) ([]models.CreditAdjustments, error) {
+	var adjustments []models.CreditAdjustments
+	err := r.db.NewSelect().
+		Model(&adjustments).
+		Relation("CreditPurchase").
+		Where("credit_adjustments.organisation_id = ?", organisationID).
+		Order("credit_adjustments.created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return adjustments, nil
+}
+
+// TopUpOrganisationsWithTx raises every organisation's free credits to its
+// monthly limit, logging an adjustment for each.
+func (r *CreditRepository) TopUpOrganisationsWithTx(ctx context.Context, tx *bun.Tx, reason string) error {
+	var organisations []models.Organisation
+	err := tx.NewSelect().
+		Model(&organisations).
+		Column("id", "free_credits", "monthly_credit_limit").
+		Where("free_credits < monthly_credit_limit").
+		Scan(ctx)
+	if err != nil {
+		return err
+	}
+	if len(organisations) == 0 {
+		return nil
+	}
+
+	adjustments := make([]models.CreditAdjustments, len(organisations))
+	for i, org := range organisations {
+		adjustments[i] = models.CreditAdjustments{
+			ID:             uuid.NewString(),
+			OrganisationID: org.ID,
+			Credits:        org.MonthlyCreditLimit - org.FreeCredits,
+			Reason:         reason,
+		}
+	}
+	_, err = tx.NewInsert().
+		Model(&adjustments).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NewUpdate().
+		Model(&models.Organisation{}).
+		Set("free_credits = monthly_credit_limit").
+		Where("free_credits < monthly_credit_limit").
+		Exec(ctx)
+	return err
+}
+
 // DeleteCreditAdjustmentsByUserID deletes all credit adjustments for a user within a transaction.
+// Adjustments to an organisation's pool are kept in its billing history.
 func (r *CreditRepository) DeleteCreditAdjustmentsByUserID(ctx context.Context, tx *bun.Tx, userID string) error {
 	_, err := tx.NewDelete().
 		Model(&models.CreditAdjustments{}).
 		Where("user_id = ?", userID).
+		Where("organisation_id = ''").
 		Exec(ctx)
 	return err
 }
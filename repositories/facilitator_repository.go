@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/nathanhollows/Rapua/v6/models"
 	"github.com/uptrace/bun"
 )
@@ -18,6 +20,9 @@ func NewFacilitatorTokenRepo(db *bun.DB) FacilitatorTokenRepo {
 
 // SaveToken saves a facilitator token.
 func (r *FacilitatorTokenRepo) SaveToken(ctx context.Context, token models.FacilitatorToken) error {
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
 	_, err := r.db.NewInsert().Model(&token).Exec(ctx)
 	return err
 }
@@ -32,6 +37,53 @@ func (r *FacilitatorTokenRepo) GetToken(ctx context.Context, token string) (*mod
 	return &facToken, nil
 }
 
+// FindActiveByInstanceID returns the instance's tokens that have not
+// expired, newest first.
+func (r *FacilitatorTokenRepo) FindActiveByInstanceID(
+	ctx context.Context,
+	instanceID string,
+) ([]models.FacilitatorToken, error) {
+	var tokens []models.FacilitatorToken
+	err := r.db.NewSelect().
+		Model(&tokens).
+		Where("instance_id = ?", instanceID).
+		Where("expires_at > ?", time.Now().UTC().Format("2006-01-02 15:04:05")).
+		Order("created_at DESC").
+		Scan(ctx)
+	return tokens, err
+}
+
+// TouchLastUsed records when a token was last used.
+func (r *FacilitatorTokenRepo) TouchLastUsed(ctx context.Context, token string, at time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.FacilitatorToken)(nil)).
+		Set("last_used_at = ?", at).
+		Where("token = ?", token).
+		Exec(ctx)
+	return err
+}
+
+// Delete removes a token, which ends any session using it. It returns
+// sql.ErrNoRows if the instance has no token with that ID.
+func (r *FacilitatorTokenRepo) Delete(ctx context.Context, instanceID, id string) error {
+	result, err := r.db.NewDelete().
+		Model((*models.FacilitatorToken)(nil)).
+		Where("id = ?", id).
+		Where("instance_id = ?", instanceID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 func (r *FacilitatorTokenRepo) CleanUpExpiredTokens(ctx context.Context) error {
 	currentTime := time.Now().UTC().Format("2006-01-02 15:04:05")
 	_, err := r.db.NewDelete().
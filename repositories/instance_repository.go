@@ -20,11 +20,17 @@ type InstanceRepository interface {
 	GetByID(ctx context.Context, id string) (*models.Instance, error)
 	// FindByUserID finds all instances associated with a user ID
 	FindByUserID(ctx context.Context, userID string) ([]models.Instance, error)
-	// FindTemplates finds all instances that are templates
+	// FindSharedWithUser finds the games other users have shared with a user
+	// through an organisation
+	FindSharedWithUser(ctx context.Context, userID string) ([]models.Instance, error)
+	// FindTemplates finds the templates a user owns or that are shared with them
 	FindTemplates(ctx context.Context, userID string) ([]models.Instance, error)
 
 	// Update updates an instance in the database
 	Update(ctx context.Context, instance *models.Instance) error
+	// SetOrganisation shares an instance with an organisation, or stops
+	// sharing it when organisationID is empty
+	SetOrganisation(ctx context.Context, instanceID, organisationID string) error
 
 	// Delete deletes an instance from the database.
 	// Deleting an instance cascades to all related data.
@@ -116,11 +122,31 @@ func (r *instanceRepository) FindByUserID(ctx context.Context, userID string) ([
 	return instances, nil
 }
 
+func (r *instanceRepository) FindSharedWithUser(ctx context.Context, userID string) ([]models.Instance, error) {
+	instances := []models.Instance{}
+	err := r.db.NewSelect().
+		Model(&instances).
+		Relation("Organisation").
+		Where("instance.organisation_id IN (?)", memberOrganisationIDs(r.db, userID)).
+		Where("instance.user_id != ? AND instance.is_template = ?", userID, false).
+		Order("instance.name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
 func (r *instanceRepository) FindTemplates(ctx context.Context, userID string) ([]models.Instance, error) {
 	instances := []models.Instance{}
 	err := r.db.NewSelect().
 		Model(&instances).
-		Where("user_id = ? AND is_template = ?", userID, true).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("user_id = ?", userID).
+				WhereOr("organisation_id IN (?)", memberOrganisationIDs(r.db, userID))
+		}).
+		Where("is_template = ?", true).
 		Order("created_at DESC").
 		Scan(ctx)
 	if err != nil {
@@ -171,6 +197,16 @@ func (r *instanceRepository) DeleteByUser(ctx context.Context, tx *bun.Tx, userI
 	return nil
 }
 
+// SetOrganisation shares an instance with an organisation.
+func (r *instanceRepository) SetOrganisation(ctx context.Context, instanceID, organisationID string) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.Instance)(nil)).
+		Set("organisation_id = ?", organisationID).
+		Where("id = ?", instanceID).
+		Exec(ctx)
+	return err
+}
+
 // DismissQuickstart marks the user as having dismissed the quickstart.
 func (r *instanceRepository) DismissQuickstart(ctx context.Context, instanceID string) error {
 	_, err := r.db.NewUpdate().
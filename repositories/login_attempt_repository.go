@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// LoginAttemptRepository stores the audit log of attempts to log in.
+type LoginAttemptRepository struct {
+	db *bun.DB
+}
+
+func NewLoginAttemptRepository(db *bun.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		db: db,
+	}
+}
+
+// Create saves a login attempt.
+func (r *LoginAttemptRepository) Create(ctx context.Context, attempt *models.LoginAttempt) error {
+	_, err := r.db.NewInsert().Model(attempt).Exec(ctx)
+	return err
+}
+
+// FindByUserID returns a user's most recent login attempts, newest first.
+func (r *LoginAttemptRepository) FindByUserID(
+	ctx context.Context,
+	userID string,
+	limit int,
+) ([]models.LoginAttempt, error) {
+	var attempts []models.LoginAttempt
+	err := r.db.NewSelect().
+		Model(&attempts).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Scan(ctx)
+	return attempts, err
+}
+
+// LastSuccessAt returns when the email address last logged in successfully,
+// or the zero time if it never has.
+func (r *LoginAttemptRepository) LastSuccessAt(ctx context.Context, email string) (time.Time, error) {
+	var attempt models.LoginAttempt
+	err := r.db.NewSelect().
+		Model(&attempt).
+		Column("created_at").
+		Where("email = ?", email).
+		Where("success = ?", true).
+		Order("created_at DESC").
+		Limit(1).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	return attempt.CreatedAt, err
+}
+
+// CountFailuresSince counts failed attempts for the email address after the
+// given time, not counting attempts refused because of a lockout.
+func (r *LoginAttemptRepository) CountFailuresSince(ctx context.Context, email string, since time.Time) (int, error) {
+	return r.db.NewSelect().
+		Model((*models.LoginAttempt)(nil)).
+		Where("email = ?", email).
+		Where("success = ?", false).
+		Where("reason != ?", models.LoginFailureLocked).
+		Where("created_at > ?", since).
+		Count(ctx)
+}
+
+// DeleteBefore removes attempts older than the given time.
+func (r *LoginAttemptRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	_, err := r.db.NewDelete().
+		Model((*models.LoginAttempt)(nil)).
+		Where("created_at < ?", before).
+		Exec(ctx)
+	return err
+}
+
+// DeleteByUserIDWithTx removes a user's login attempts.
+func (r *LoginAttemptRepository) DeleteByUserIDWithTx(ctx context.Context, tx *bun.Tx, userID string) error {
+	_, err := tx.NewDelete().
+		Model((*models.LoginAttempt)(nil)).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	return err
+}
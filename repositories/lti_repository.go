@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// LTIRepository stores LMS platform registrations, the LMS users linked to
+// teams, and the keys Rapua signs requests to platforms with.
+type LTIRepository struct {
+	db *bun.DB
+}
+
+func NewLTIRepository(db *bun.DB) *LTIRepository {
+	return &LTIRepository{
+		db: db,
+	}
+}
+
+// CreatePlatform saves a new platform registration.
+func (r *LTIRepository) CreatePlatform(ctx context.Context, platform *models.LTIPlatform) error {
+	_, err := r.db.NewInsert().Model(platform).Exec(ctx)
+	return err
+}
+
+// UpdatePlatform saves changes to a platform registration.
+func (r *LTIRepository) UpdatePlatform(ctx context.Context, platform *models.LTIPlatform) error {
+	platform.UpdatedAt = time.Now()
+	_, err := r.db.NewUpdate().
+		Model(platform).
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+// GetPlatform finds one of an instance's platform registrations.
+func (r *LTIRepository) GetPlatform(ctx context.Context, instanceID, id string) (*models.LTIPlatform, error) {
+	platform := &models.LTIPlatform{}
+	err := r.db.NewSelect().
+		Model(platform).
+		Where("id = ?", id).
+		Where("instance_id = ?", instanceID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return platform, nil
+}
+
+// FindPlatformsByIssuer returns the registrations for a platform. A client ID
+// narrows them down to one; without it there may be several.
+func (r *LTIRepository) FindPlatformsByIssuer(
+	ctx context.Context,
+	issuer, clientID string,
+) ([]models.LTIPlatform, error) {
+	var platforms []models.LTIPlatform
+	query := r.db.NewSelect().
+		Model(&platforms).
+		Where("issuer = ?", issuer)
+	if clientID != "" {
+		query = query.Where("client_id = ?", clientID)
+	}
+	err := query.Scan(ctx)
+	return platforms, err
+}
+
+// FindPlatformsByInstanceID returns an instance's registrations with their
+// linked users, oldest first.
+func (r *LTIRepository) FindPlatformsByInstanceID(
+	ctx context.Context,
+	instanceID string,
+) ([]models.LTIPlatform, error) {
+	var platforms []models.LTIPlatform
+	err := r.db.NewSelect().
+		Model(&platforms).
+		Where("instance_id = ?", instanceID).
+		Relation("Users", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("name ASC")
+		}).
+		Order("created_at ASC").
+		Scan(ctx)
+	return platforms, err
+}
+
+// DeletePlatform removes a registration and its linked users. It returns
+// sql.ErrNoRows if the instance has no registration with that ID.
+func (r *LTIRepository) DeletePlatform(ctx context.Context, instanceID, id string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewDelete().
+			Model((*models.LTIPlatform)(nil)).
+			Where("id = ?", id).
+			Where("instance_id = ?", instanceID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return sql.ErrNoRows
+		}
+		_, err = tx.NewDelete().
+			Model((*models.LTIUser)(nil)).
+			Where("platform_id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+// DeleteByInstanceIDWithTx removes an instance's registrations and their
+// linked users.
+func (r *LTIRepository) DeleteByInstanceIDWithTx(ctx context.Context, tx *bun.Tx, instanceID string) error {
+	_, err := tx.NewDelete().
+		Model((*models.LTIUser)(nil)).
+		Where("platform_id IN (?)", tx.NewSelect().
+			Model((*models.LTIPlatform)(nil)).
+			Column("id").
+			Where("instance_id = ?", instanceID)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.NewDelete().
+		Model((*models.LTIPlatform)(nil)).
+		Where("instance_id = ?", instanceID).
+		Exec(ctx)
+	return err
+}
+
+// GetUser finds the user a platform knows by subject.
+func (r *LTIRepository) GetUser(ctx context.Context, platformID, subject string) (*models.LTIUser, error) {
+	user := &models.LTIUser{}
+	err := r.db.NewSelect().
+		Model(user).
+		Where("platform_id = ?", platformID).
+		Where("subject = ?", subject).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// FindTeamCodeByKey returns the team played as by the platform's users with
+// the given team key, most recently linked first. It returns sql.ErrNoRows if
+// no user has the key.
+func (r *LTIRepository) FindTeamCodeByKey(ctx context.Context, platformID, teamKey string) (string, error) {
+	var teamCode string
+	err := r.db.NewSelect().
+		Model((*models.LTIUser)(nil)).
+		Column("team_code").
+		Where("platform_id = ?", platformID).
+		Where("team_key = ?", teamKey).
+		OrderExpr("updated_at DESC").
+		Limit(1).
+		Scan(ctx, &teamCode)
+	return teamCode, err
+}
+
+// CreateUser links an LMS user to a team.
+func (r *LTIRepository) CreateUser(ctx context.Context, user *models.LTIUser) error {
+	_, err := r.db.NewInsert().Model(user).Exec(ctx)
+	return err
+}
+
+// UpdateUser saves changes to a linked user.
+func (r *LTIRepository) UpdateUser(ctx context.Context, user *models.LTIUser) error {
+	user.UpdatedAt = time.Now()
+	_, err := r.db.NewUpdate().
+		Model(user).
+		ExcludeColumn("created_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+// FindUsersToGrade returns the linked users with a gradebook column whose
+// platform sends grades, with their platforms.
+func (r *LTIRepository) FindUsersToGrade(ctx context.Context) ([]models.LTIUser, error) {
+	var users []models.LTIUser
+	err := r.db.NewSelect().
+		Model(&users).
+		Relation("Platform").
+		Where("lti_user.line_item_url != ''").
+		Where("platform.grade_mode != ?", models.GradeNone).
+		Scan(ctx)
+	return users, err
+}
+
+// GetLatestKey returns the newest signing key.
+func (r *LTIRepository) GetLatestKey(ctx context.Context) (*models.LTIKey, error) {
+	key := &models.LTIKey{}
+	err := r.db.NewSelect().
+		Model(key).
+		Order("created_at DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// CreateKey saves a new signing key.
+func (r *LTIRepository) CreateKey(ctx context.Context, key *models.LTIKey) error {
+	_, err := r.db.NewInsert().Model(key).Exec(ctx)
+	return err
+}
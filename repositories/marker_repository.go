@@ -22,22 +22,29 @@ type MarkerRepository interface {
 	GetByCode(ctx context.Context, code string) (*models.Marker, error)
 	// FindNotInInstance finds markers that are not in an instance
 	FindNotInInstance(ctx context.Context, instanceID string, otherInstances []string) ([]models.Marker, error)
+	// FindByOwnerID finds the markers in an owner's marker library with the
+	// locations that use them
+	FindByOwnerID(ctx context.Context, ownerID string) ([]models.Marker, error)
 
 	// Update updates a marker in the database
 	Update(ctx context.Context, marker *models.Marker) error
 	// UpdateCoords updates the latitude and longitude of a marker
 	UpdateCoords(ctx context.Context, marker *models.Marker, lat, lng float64) error
+	// SetOwner adds a marker to an owner's library, or removes it from a
+	// library when ownerID is empty
+	SetOwner(ctx context.Context, tx *bun.Tx, code, ownerID string) error
+	// ClearOwner removes all markers from an owner's library
+	ClearOwner(ctx context.Context, tx *bun.Tx, ownerID string) error
 
 	// Delete deletes a marker from the database
 	// NOTE: Scheduled for removal
 	Delete(ctx context.Context, code string) error
-	// Deletes all unused markers
+	// Deletes all unused markers that are not in a marker library
 	DeleteUnused(ctx context.Context, tx *bun.Tx) error
 
 	// IsShared checks if a marker is used by more than one location
 	IsShared(ctx context.Context, code string) (bool, error)
 	// UserOwnsMarker checks if a user owns a marker
-	// NOTE: Scheduled for removal
 	UserOwnsMarker(ctx context.Context, userID, markerCode string) (bool, error)
 }
 
@@ -80,7 +87,7 @@ func (r *markerRepository) Update(ctx context.Context, marker *models.Marker) er
 	_, err := r.db.
 		NewUpdate().
 		Model(marker).
-		Column("name", "lat", "lng", "total_visits", "current_count", "avg_duration").
+		Column("name", "lat", "lng", "total_visits", "current_count", "avg_duration", "notes", "image_url").
 		WherePK("code").
 		Exec(ctx)
 
@@ -94,6 +101,7 @@ func (r *markerRepository) Delete(ctx context.Context, markerCode string) error
 }
 
 // DeleteUnused deletes all markers that are not used by any location.
+// Markers in a marker library are kept.
 func (r *markerRepository) DeleteUnused(ctx context.Context, tx *bun.Tx) error {
 	subq := tx.NewSelect().
 		Model((*models.Location)(nil)).
@@ -102,6 +110,28 @@ func (r *markerRepository) DeleteUnused(ctx context.Context, tx *bun.Tx) error {
 	_, err := tx.NewDelete().
 		Model((*models.Marker)(nil)).
 		Where("code NOT IN (?)", subq).
+		Where("owner_id = ''").
+		Exec(ctx)
+	return err
+}
+
+// SetOwner adds a marker to an owner's library, or removes it from a library
+// when ownerID is empty.
+func (r *markerRepository) SetOwner(ctx context.Context, tx *bun.Tx, code, ownerID string) error {
+	_, err := tx.NewUpdate().
+		Model((*models.Marker)(nil)).
+		Set("owner_id = ?", ownerID).
+		Where("code = ?", code).
+		Exec(ctx)
+	return err
+}
+
+// ClearOwner removes all markers from an owner's library.
+func (r *markerRepository) ClearOwner(ctx context.Context, tx *bun.Tx, ownerID string) error {
+	_, err := tx.NewUpdate().
+		Model((*models.Marker)(nil)).
+		Set("owner_id = ''").
+		Where("owner_id = ?", ownerID).
 		Exec(ctx)
 	return err
 }
@@ -134,6 +164,19 @@ func (r *markerRepository) FindNotInInstance(
 	return markers, err
 }
 
+// FindByOwnerID finds the markers in an owner's marker library with the
+// locations that use them.
+func (r *markerRepository) FindByOwnerID(ctx context.Context, ownerID string) ([]models.Marker, error) {
+	var markers []models.Marker
+	err := r.db.NewSelect().
+		Model(&markers).
+		Relation("Locations").
+		Where("owner_id = ?", ownerID).
+		Order("name ASC").
+		Scan(ctx)
+	return markers, err
+}
+
 // UpdateCoords updates the latitude and longitude of a marker in the database.
 func (r *markerRepository) UpdateCoords(ctx context.Context, marker *models.Marker, lat, lng float64) error {
 	if marker == nil {
@@ -158,14 +201,22 @@ func (r *markerRepository) IsShared(ctx context.Context, code string) (bool, err
 	return count > 1, nil
 }
 
-// UserOwnsMarker checks if a user owns a marker.
-func (r *markerRepository) UserOwnsMarker(ctx context.Context, userID, _ string) (bool, error) {
-	var count int
+// UserOwnsMarker checks if a user owns a marker, either through a game they
+// can access or their marker library.
+func (r *markerRepository) UserOwnsMarker(ctx context.Context, userID, markerCode string) (bool, error) {
+	code := strings.ToUpper(markerCode)
 	count, err := r.db.
 		NewSelect().
-		Model(&models.Instance{}).
-		Join("JOIN locations ON locations.instance_id = instance.id").
-		Where("instance.user_id = ?", userID).
+		Model((*models.Marker)(nil)).
+		Where("code = ?", code).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("owner_id = ?", userID).
+				WhereOr(
+					"code IN (SELECT marker_id FROM locations WHERE instance_id IN (?))",
+					instanceIDsForUser(r.db, userID),
+				)
+		}).
 		Count(ctx)
 	if err != nil {
 		return false, err
@@ -394,5 +394,40 @@ func TestMarkerRepository_DeleteUnused(t *testing.T) {
 	}
 }
 
+func TestMarkerRepository_LibraryMarkersSurviveDeleteUnused(t *testing.T) {
+	repo, transactor, cleanup := setupMarkerRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ownerID := gofakeit.UUID()
+	kept := models.Marker{Name: "Library Marker", Lat: -45.86, Lng: 170.51}
+	unused := models.Marker{Name: "Unused Marker", Lat: -45.87, Lng: 170.52}
+	require.NoError(t, repo.Create(ctx, &kept))
+	require.NoError(t, repo.Create(ctx, &unused))
+
+	tx, err := transactor.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.SetOwner(ctx, tx, kept.Code, ownerID))
+	require.NoError(t, repo.DeleteUnused(ctx, tx))
+	require.NoError(t, tx.Commit())
+
+	_, err = repo.GetByCode(ctx, unused.Code)
+	require.Error(t, err)
+	library, err := repo.FindByOwnerID(ctx, ownerID)
+	require.NoError(t, err)
+	require.Len(t, library, 1)
+	assert.Equal(t, kept.Code, library[0].Code)
+
+	// Clearing the owner leaves the marker to be cleaned up
+	tx, err = transactor.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.ClearOwner(ctx, tx, ownerID))
+	require.NoError(t, repo.DeleteUnused(ctx, tx))
+	require.NoError(t, tx.Commit())
+
+	_, err = repo.GetByCode(ctx, kept.Code)
+	require.Error(t, err)
+}
+
 // NOTE: IsShared and UserOwnsMarker are not yet tested here.
 // TODO: Add test cases for IsShared and UserOwnsMarker. These require more setup and teardown logic as they involve relationships between markers and locations.
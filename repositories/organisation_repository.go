@@ -0,0 +1,331 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// OrganisationRepository stores organisations and their members.
+type OrganisationRepository struct {
+	db *bun.DB
+}
+
+func NewOrganisationRepository(db *bun.DB) *OrganisationRepository {
+	return &OrganisationRepository{
+		db: db,
+	}
+}
+
+// memberOrganisationIDs selects the organisations a user belongs to, limited
+// to the given roles when any are passed.
+func memberOrganisationIDs(db bun.IDB, userID string, roles ...models.OrganisationRole) *bun.SelectQuery {
+	q := db.NewSelect().
+		Model((*models.OrganisationMember)(nil)).
+		Column("organisation_id").
+		Where("user_id = ?", userID)
+	if len(roles) > 0 {
+		q = q.Where("role IN (?)", bun.In(roles))
+	}
+	return q
+}
+
+// instanceIDsForUser selects the games a user created or that are shared with
+// them, limited to organisations where they have one of the given roles when
+// any are passed.
+func instanceIDsForUser(db bun.IDB, userID string, roles ...models.OrganisationRole) *bun.SelectQuery {
+	return db.NewSelect().
+		Model((*models.Instance)(nil)).
+		Column("id").
+		Where("user_id = ?", userID).
+		WhereOr("organisation_id IN (?)", memberOrganisationIDs(db, userID, roles...))
+}
+
+// editableInstanceIDs selects the games a user may change.
+func editableInstanceIDs(db bun.IDB, userID string) *bun.SelectQuery {
+	return instanceIDsForUser(db, userID, models.RoleOwner, models.RoleEditor)
+}
+
+// CreateWithTx saves an organisation within a transaction.
+func (r *OrganisationRepository) CreateWithTx(ctx context.Context, tx *bun.Tx, org *models.Organisation) error {
+	_, err := tx.NewInsert().Model(org).Exec(ctx)
+	return err
+}
+
+// AddMember gives a user a role in an organisation. The join time is set
+// here so members added within the same second keep their order.
+func (r *OrganisationRepository) AddMember(ctx context.Context, member *models.OrganisationMember) error {
+	member.CreatedAt = time.Now().UTC()
+	_, err := r.db.NewInsert().Model(member).Exec(ctx)
+	return err
+}
+
+// AddMemberWithTx gives a user a role in an organisation within a transaction.
+func (r *OrganisationRepository) AddMemberWithTx(
+	ctx context.Context,
+	tx *bun.Tx,
+	member *models.OrganisationMember,
+) error {
+	member.CreatedAt = time.Now().UTC()
+	_, err := tx.NewInsert().Model(member).Exec(ctx)
+	return err
+}
+
+// GetByID fetches an organisation with its members and shared games.
+func (r *OrganisationRepository) GetByID(ctx context.Context, id string) (*models.Organisation, error) {
+	org := new(models.Organisation)
+	err := r.db.NewSelect().
+		Model(org).
+		Where("organisation.id = ?", id).
+		Relation("Members", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("organisation_member.created_at ASC")
+		}).
+		Relation("Members.User").
+		Relation("Instances", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("is_template ASC", "name ASC")
+		}).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// GetMember fetches a user's membership of an organisation.
+func (r *OrganisationRepository) GetMember(
+	ctx context.Context,
+	organisationID, userID string,
+) (*models.OrganisationMember, error) {
+	member := new(models.OrganisationMember)
+	err := r.db.NewSelect().
+		Model(member).
+		Where("organisation_id = ?", organisationID).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// FindMembershipsByUserID fetches the organisations a user belongs to.
+func (r *OrganisationRepository) FindMembershipsByUserID(
+	ctx context.Context,
+	userID string,
+) ([]models.OrganisationMember, error) {
+	var members []models.OrganisationMember
+	err := r.db.NewSelect().
+		Model(&members).
+		Where("organisation_member.user_id = ?", userID).
+		Relation("Organisation").
+		Order("organisation.name ASC").
+		Scan(ctx)
+	return members, err
+}
+
+// CountOwners counts the owners of an organisation.
+func (r *OrganisationRepository) CountOwners(ctx context.Context, organisationID string) (int, error) {
+	return r.db.NewSelect().
+		Model((*models.OrganisationMember)(nil)).
+		Where("organisation_id = ?", organisationID).
+		Where("role = ?", models.RoleOwner).
+		Count(ctx)
+}
+
+// InstanceRole fetches the role a user has in the organisation a game is
+// shared with.
+func (r *OrganisationRepository) InstanceRole(
+	ctx context.Context,
+	userID, instanceID string,
+) (models.OrganisationRole, error) {
+	var role models.OrganisationRole
+	err := r.db.NewSelect().
+		Model((*models.OrganisationMember)(nil)).
+		Column("organisation_member.role").
+		Join("JOIN instances ON instances.organisation_id = organisation_member.organisation_id").
+		Where("instances.id = ?", instanceID).
+		Where("organisation_member.user_id = ?", userID).
+		Scan(ctx, &role)
+	return role, err
+}
+
+// Update saves an organisation's name.
+func (r *OrganisationRepository) Update(ctx context.Context, org *models.Organisation) error {
+	org.UpdatedAt = time.Now().UTC()
+	_, err := r.db.NewUpdate().
+		Model(org).
+		Column("name", "updated_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+// UpdateRequireTwoFactor sets whether members must use two-factor
+// authentication.
+func (r *OrganisationRepository) UpdateRequireTwoFactor(
+	ctx context.Context,
+	organisationID string,
+	require bool,
+) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.Organisation)(nil)).
+		Set("require_two_factor = ?", require).
+		Set("updated_at = ?", time.Now().UTC()).
+		Where("id = ?", organisationID).
+		Exec(ctx)
+	return err
+}
+
+// RequiresTwoFactor reports whether any organisation the user belongs to
+// requires two-factor authentication.
+func (r *OrganisationRepository) RequiresTwoFactor(ctx context.Context, userID string) (bool, error) {
+	return r.db.NewSelect().
+		Model((*models.Organisation)(nil)).
+		Where("id IN (?)", memberOrganisationIDs(r.db, userID)).
+		Where("require_two_factor = ?", true).
+		Exists(ctx)
+}
+
+// UpdateStripeCustomerID saves the Stripe customer that pays for an
+// organisation's credits.
+func (r *OrganisationRepository) UpdateStripeCustomerID(ctx context.Context, organisationID, customerID string) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.Organisation)(nil)).
+		Set("stripe_customer_id = ?", customerID).
+		Set("updated_at = ?", time.Now().UTC()).
+		Where("id = ?", organisationID).
+		Exec(ctx)
+	return err
+}
+
+// UpdateMemberRole changes a member's role.
+func (r *OrganisationRepository) UpdateMemberRole(
+	ctx context.Context,
+	organisationID, userID string,
+	role models.OrganisationRole,
+) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.OrganisationMember)(nil)).
+		Set("role = ?", role).
+		Where("organisation_id = ?", organisationID).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	return err
+}
+
+// UpdateMemberCreditLimit changes how many credits a member may draw from
+// the pool each month.
+func (r *OrganisationRepository) UpdateMemberCreditLimit(
+	ctx context.Context,
+	organisationID, userID string,
+	limit int,
+) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.OrganisationMember)(nil)).
+		Set("credit_limit = ?", limit).
+		Where("organisation_id = ?", organisationID).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	return err
+}
+
+// RemoveMember takes a user out of an organisation.
+func (r *OrganisationRepository) RemoveMember(ctx context.Context, organisationID, userID string) error {
+	_, err := r.db.NewDelete().
+		Model((*models.OrganisationMember)(nil)).
+		Where("organisation_id = ?", organisationID).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	return err
+}
+
+// DeleteWithTx deletes an organisation and its memberships. Its games go
+// back to being used only by the users who created them.
+func (r *OrganisationRepository) DeleteWithTx(ctx context.Context, tx *bun.Tx, organisationID string) error {
+	_, err := tx.NewUpdate().
+		Model((*models.Instance)(nil)).
+		Set("organisation_id = ''").
+		Where("organisation_id = ?", organisationID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.NewDelete().
+		Model((*models.OrganisationMember)(nil)).
+		Where("organisation_id = ?", organisationID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.NewDelete().
+		Model((*models.Organisation)(nil)).
+		Where("id = ?", organisationID).
+		Exec(ctx)
+	return err
+}
+
+// RemoveUserWithTx takes a user out of every organisation, deleting any
+// organisation left without members.
+func (r *OrganisationRepository) RemoveUserWithTx(ctx context.Context, tx *bun.Tx, userID string) error {
+	var organisationIDs []string
+	err := memberOrganisationIDs(tx, userID).Scan(ctx, &organisationIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NewDelete().
+		Model((*models.OrganisationMember)(nil)).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, organisationID := range organisationIDs {
+		count, err := tx.NewSelect().
+			Model((*models.OrganisationMember)(nil)).
+			Where("organisation_id = ?", organisationID).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			err = r.DeleteWithTx(ctx, tx, organisationID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// An organisation always keeps an owner, so the longest-standing
+		// member takes over from the last one
+		owners, err := tx.NewSelect().
+			Model((*models.OrganisationMember)(nil)).
+			Where("organisation_id = ?", organisationID).
+			Where("role = ?", models.RoleOwner).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+		if owners > 0 {
+			continue
+		}
+		_, err = tx.NewUpdate().
+			Model((*models.OrganisationMember)(nil)).
+			Set("role = ?", models.RoleOwner).
+			Where("organisation_id = ?", organisationID).
+			Where("user_id = (?)", tx.NewSelect().
+				Model((*models.OrganisationMember)(nil)).
+				Column("user_id").
+				Where("organisation_id = ?", organisationID).
+				Order("created_at ASC").
+				Limit(1)).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// PushRepository stores players' Web Push subscriptions and the key push
+// requests are signed with.
+type PushRepository struct {
+	db *bun.DB
+}
+
+func NewPushRepository(db *bun.DB) *PushRepository {
+	return &PushRepository{
+		db: db,
+	}
+}
+
+// SaveSubscription saves a device's subscription. A device that subscribes
+// again, perhaps for another team, replaces its old subscription.
+func (r *PushRepository) SaveSubscription(ctx context.Context, subscription *models.PushSubscription) error {
+	if subscription.ID == "" {
+		subscription.ID = uuid.New().String()
+	}
+	_, err := r.db.NewInsert().
+		Model(subscription).
+		On("CONFLICT (endpoint) DO UPDATE").
+		Set("instance_id = EXCLUDED.instance_id").
+		Set("team_code = EXCLUDED.team_code").
+		Set("p256dh = EXCLUDED.p256dh").
+		Set("auth = EXCLUDED.auth").
+		Exec(ctx)
+	return err
+}
+
+// FindByTeamCode returns the devices subscribed for a team.
+func (r *PushRepository) FindByTeamCode(ctx context.Context, teamCode string) ([]models.PushSubscription, error) {
+	var subscriptions []models.PushSubscription
+	err := r.db.NewSelect().
+		Model(&subscriptions).
+		Where("team_code = ?", teamCode).
+		Scan(ctx)
+	return subscriptions, err
+}
+
+// Delete removes a device's subscription to a team's alerts.
+func (r *PushRepository) Delete(ctx context.Context, teamCode, endpoint string) error {
+	_, err := r.db.NewDelete().
+		Model((*models.PushSubscription)(nil)).
+		Where("endpoint = ?", endpoint).
+		Where("team_code = ?", teamCode).
+		Exec(ctx)
+	return err
+}
+
+// GetLatestKey returns the newest signing key.
+func (r *PushRepository) GetLatestKey(ctx context.Context) (*models.VAPIDKey, error) {
+	key := &models.VAPIDKey{}
+	err := r.db.NewSelect().
+		Model(key).
+		Order("created_at DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// CreateKey saves a new signing key.
+func (r *PushRepository) CreateKey(ctx context.Context, key *models.VAPIDKey) error {
+	_, err := r.db.NewInsert().Model(key).Exec(ctx)
+	return err
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// RecoveryCodeRepository stores the one-time codes users can log in with
+// when they don't have their authenticator app.
+type RecoveryCodeRepository struct {
+	db *bun.DB
+}
+
+func NewRecoveryCodeRepository(db *bun.DB) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		db: db,
+	}
+}
+
+// Replace swaps a user's recovery codes for a new set.
+func (r *RecoveryCodeRepository) Replace(ctx context.Context, userID string, codes []models.RecoveryCode) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := r.DeleteByUserIDWithTx(ctx, &tx, userID)
+		if err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		_, err = tx.NewInsert().Model(&codes).Exec(ctx)
+		return err
+	})
+}
+
+// Use marks a user's unused code with the given hash as used. It returns
+// sql.ErrNoRows if there is no such code.
+func (r *RecoveryCodeRepository) Use(ctx context.Context, userID, hash string) error {
+	result, err := r.db.NewUpdate().
+		Model((*models.RecoveryCode)(nil)).
+		Set("used_at = ?", time.Now().UTC()).
+		Where("user_id = ?", userID).
+		Where("code_hash = ?", hash).
+		Where("used_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CountUnused counts the codes a user has left.
+func (r *RecoveryCodeRepository) CountUnused(ctx context.Context, userID string) (int, error) {
+	return r.db.NewSelect().
+		Model((*models.RecoveryCode)(nil)).
+		Where("user_id = ?", userID).
+		Where("used_at IS NULL").
+		Count(ctx)
+}
+
+// DeleteByUserID removes all of a user's codes.
+func (r *RecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	_, err := r.db.NewDelete().
+		Model((*models.RecoveryCode)(nil)).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	return err
+}
+
+// DeleteByUserIDWithTx removes all of a user's codes within a transaction.
+func (r *RecoveryCodeRepository) DeleteByUserIDWithTx(ctx context.Context, tx *bun.Tx, userID string) error {
+	_, err := tx.NewDelete().
+		Model((*models.RecoveryCode)(nil)).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// ScheduledNotificationRepository stores announcements waiting to be sent,
+// and the record of those that were.
+type ScheduledNotificationRepository struct {
+	db *bun.DB
+}
+
+func NewScheduledNotificationRepository(db *bun.DB) *ScheduledNotificationRepository {
+	return &ScheduledNotificationRepository{
+		db: db,
+	}
+}
+
+// Create saves a new scheduled notification, generating an ID if it doesn't
+// have one.
+func (r *ScheduledNotificationRepository) Create(
+	ctx context.Context,
+	notification *models.ScheduledNotification,
+) error {
+	if notification.ID == "" {
+		notification.ID = uuid.New().String()
+	}
+	_, err := r.db.NewInsert().Model(notification).Exec(ctx)
+	return err
+}
+
+// FindByInstanceID returns an instance's scheduled notifications, newest
+// first.
+func (r *ScheduledNotificationRepository) FindByInstanceID(
+	ctx context.Context,
+	instanceID string,
+) ([]models.ScheduledNotification, error) {
+	var notifications []models.ScheduledNotification
+	err := r.db.NewSelect().
+		Model(&notifications).
+		Where("instance_id = ?", instanceID).
+		Order("created_at DESC").
+		Scan(ctx)
+	return notifications, err
+}
+
+// FindPending returns the notifications that haven't been sent yet across
+// all instances, oldest first.
+func (r *ScheduledNotificationRepository) FindPending(ctx context.Context) ([]models.ScheduledNotification, error) {
+	var notifications []models.ScheduledNotification
+	err := r.db.NewSelect().
+		Model(&notifications).
+		Where("sent_at IS NULL").
+		Order("created_at ASC").
+		Scan(ctx)
+	return notifications, err
+}
+
+// MarkSent records when a notification was sent or skipped, and to how many
+// teams.
+func (r *ScheduledNotificationRepository) MarkSent(
+	ctx context.Context,
+	notification *models.ScheduledNotification,
+) error {
+	_, err := r.db.NewUpdate().
+		Model(notification).
+		Column("sent_at", "recipients", "skipped").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+// DeletePending removes a notification that hasn't been sent yet. It returns
+// sql.ErrNoRows if the instance has no pending notification with that ID.
+func (r *ScheduledNotificationRepository) DeletePending(ctx context.Context, instanceID, id string) error {
+	result, err := r.db.NewDelete().
+		Model((*models.ScheduledNotification)(nil)).
+		Where("id = ?", id).
+		Where("instance_id = ?", instanceID).
+		Where("sent_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
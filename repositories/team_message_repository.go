@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// TeamMessageRepository stores the conversations between teams and the
+// people running their game.
+type TeamMessageRepository struct {
+	db *bun.DB
+}
+
+func NewTeamMessageRepository(db *bun.DB) *TeamMessageRepository {
+	return &TeamMessageRepository{
+		db: db,
+	}
+}
+
+// Create saves a new message, generating an ID if it doesn't have one.
+func (r *TeamMessageRepository) Create(ctx context.Context, message *models.TeamMessage) error {
+	if message.ID == "" {
+		message.ID = uuid.New().String()
+	}
+	_, err := r.db.NewInsert().Model(message).Exec(ctx)
+	return err
+}
+
+// FindByTeamCode returns a team's messages, oldest first.
+func (r *TeamMessageRepository) FindByTeamCode(
+	ctx context.Context,
+	instanceID, teamCode string,
+) ([]models.TeamMessage, error) {
+	var messages []models.TeamMessage
+	err := r.db.NewSelect().
+		Model(&messages).
+		Where("instance_id = ?", instanceID).
+		Where("team_code = ?", teamCode).
+		Order("created_at ASC").
+		Scan(ctx)
+	return messages, err
+}
+
+// CountUnread returns how many of each team's messages from the sender are
+// unread, keyed by team code. Teams with none are left out.
+func (r *TeamMessageRepository) CountUnread(
+	ctx context.Context,
+	instanceID string,
+	sender models.MessageSender,
+) (map[string]int, error) {
+	var rows []struct {
+		TeamCode string `bun:"team_code"`
+		Count    int    `bun:"count"`
+	}
+	err := r.db.NewSelect().
+		Model((*models.TeamMessage)(nil)).
+		Column("team_code").
+		ColumnExpr("COUNT(*) AS count").
+		Where("instance_id = ?", instanceID).
+		Where("sender = ?", sender).
+		Where("read_at IS NULL").
+		Group("team_code").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.TeamCode] = row.Count
+	}
+	return counts, nil
+}
+
+// MarkRead marks a team's unread messages from the sender as read.
+func (r *TeamMessageRepository) MarkRead(
+	ctx context.Context,
+	instanceID, teamCode string,
+	sender models.MessageSender,
+	readAt time.Time,
+) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.TeamMessage)(nil)).
+		Set("read_at = ?", readAt).
+		Where("instance_id = ?", instanceID).
+		Where("team_code = ?", teamCode).
+		Where("sender = ?", sender).
+		Where("read_at IS NULL").
+		Exec(ctx)
+	return err
+}
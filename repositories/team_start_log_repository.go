@@ -24,6 +24,7 @@ func (r *TeamStartLogRepository) GetByUserID(ctx context.Context, userID string)
 	err := r.db.NewSelect().
 		Model(&logs).
 		Where("user_id = ?", userID).
+		Where("organisation_id = ''").
 		Order("created_at DESC").
 		Scan(ctx)
 	if err != nil {
@@ -97,6 +98,36 @@ func (r *TeamStartLogRepository) CreateWithTx(ctx context.Context, tx *bun.Tx, l
 	return err
 }
 
+// CountByOrganisationID counts the team starts paid from an organisation's
+// pool within a timeframe, keyed by the user whose game started them.
+func (r *TeamStartLogRepository) CountByOrganisationID(
+	ctx context.Context,
+	organisationID string,
+	startTime, endTime time.Time,
+) (map[string]int, error) {
+	var rows []struct {
+		UserID string `bun:"user_id"`
+		Count  int    `bun:"count"`
+	}
+	err := r.db.NewSelect().
+		Model((*models.TeamStartLog)(nil)).
+		Column("user_id").
+		ColumnExpr("COUNT(*) AS count").
+		Where("organisation_id = ?", organisationID).
+		Where("created_at >= ? AND created_at < ?", startTime, endTime).
+		Group("user_id").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+	return counts, nil
+}
+
 // DeleteByUserID deletes all team start logs for a user within a transaction.
 func (r *TeamStartLogRepository) DeleteByUserID(ctx context.Context, tx *bun.Tx, userID string) error {
 	_, err := tx.NewDelete().
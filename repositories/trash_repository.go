@@ -0,0 +1,378 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// TrashRepository stores deleted games, locations and teams until they are
+// purged. Deleted rows are collected into a snapshot, removed from their
+// tables, and inserted again when the item is restored.
+type TrashRepository struct {
+	db *bun.DB
+}
+
+func NewTrashRepository(db *bun.DB) *TrashRepository {
+	return &TrashRepository{
+		db: db,
+	}
+}
+
+// CreateWithTx saves a trash item within a transaction.
+func (r *TrashRepository) CreateWithTx(ctx context.Context, tx *bun.Tx, item *models.TrashItem) error {
+	_, err := tx.NewInsert().Model(item).Exec(ctx)
+	return err
+}
+
+// GetByID fetches one of a user's trash items.
+func (r *TrashRepository) GetByID(ctx context.Context, userID, itemID string) (*models.TrashItem, error) {
+	item := new(models.TrashItem)
+	err := r.db.NewSelect().
+		Model(item).
+		Where("id = ?", itemID).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// FindByUserID fetches a user's trash items, most recently deleted first.
+func (r *TrashRepository) FindByUserID(ctx context.Context, userID string) ([]models.TrashItem, error) {
+	var items []models.TrashItem
+	err := r.db.NewSelect().
+		Model(&items).
+		ExcludeColumn("data").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Scan(ctx)
+	return items, err
+}
+
+// FindByUserIDWithTx fetches all of a user's trash items with their contents
+// within a transaction.
+func (r *TrashRepository) FindByUserIDWithTx(
+	ctx context.Context,
+	tx *bun.Tx,
+	userID string,
+) ([]models.TrashItem, error) {
+	var items []models.TrashItem
+	err := tx.NewSelect().
+		Model(&items).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+	return items, err
+}
+
+// FindByInstanceIDWithTx fetches the locations and teams trashed from a game
+// before the game itself was deleted.
+func (r *TrashRepository) FindByInstanceIDWithTx(
+	ctx context.Context,
+	tx *bun.Tx,
+	instanceID string,
+) ([]models.TrashItem, error) {
+	var items []models.TrashItem
+	err := tx.NewSelect().
+		Model(&items).
+		Where("instance_id = ?", instanceID).
+		Where("type != ?", models.TrashInstance).
+		Scan(ctx)
+	return items, err
+}
+
+// FindExpired fetches the items due to be purged.
+func (r *TrashRepository) FindExpired(ctx context.Context, now time.Time) ([]models.TrashItem, error) {
+	var items []models.TrashItem
+	err := r.db.NewSelect().
+		Model(&items).
+		Where("purge_at <= ?", now).
+		Scan(ctx)
+	return items, err
+}
+
+// DeleteWithTx removes a trash item within a transaction.
+func (r *TrashRepository) DeleteWithTx(ctx context.Context, tx *bun.Tx, itemID string) error {
+	_, err := tx.NewDelete().
+		Model((*models.TrashItem)(nil)).
+		Where("id = ?", itemID).
+		Exec(ctx)
+	return err
+}
+
+// CollectInstanceWithTx gathers an instance with its settings, locations,
+// blocks, teams, progress, uploads and messages.
+func (r *TrashRepository) CollectInstanceWithTx(
+	ctx context.Context,
+	tx *bun.Tx,
+	instanceID string,
+) (*models.TrashSnapshot, error) {
+	snapshot := new(models.TrashSnapshot)
+	err := tx.NewSelect().Model(&snapshot.Instances).Where("id = ?", instanceID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshot.Instances) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	err = tx.NewSelect().Model(&snapshot.InstanceSettings).Where("instance_id = ?", instanceID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.NewSelect().Model(&snapshot.Locations).Where("instance_id = ?", instanceID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.NewSelect().Model(&snapshot.Teams).Where("instance_id = ?", instanceID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start and finish pages belong to the instance itself
+	ownerIDs := []string{instanceID}
+	for _, location := range snapshot.Locations {
+		ownerIDs = append(ownerIDs, location.ID)
+	}
+	err = r.collectContent(ctx, tx, snapshot, ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	blockIDs := snapshotBlockIDs(snapshot)
+	teamCodes := make([]string, len(snapshot.Teams))
+	for i, team := range snapshot.Teams {
+		teamCodes[i] = team.Code
+	}
+	if len(blockIDs) > 0 || len(teamCodes) > 0 {
+		err = tx.NewSelect().
+			Model(&snapshot.BlockStates).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				if len(blockIDs) > 0 {
+					q = q.WhereOr("block_id IN (?)", bun.In(blockIDs))
+				}
+				if len(teamCodes) > 0 {
+					q = q.WhereOr("team_code IN (?)", bun.In(teamCodes))
+				}
+				return q
+			}).
+			Scan(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.NewSelect().Model(&snapshot.CheckIns).Where("instance_id = ?", instanceID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.NewSelect().Model(&snapshot.Uploads).Where("instance_id = ?", instanceID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.NewSelect().Model(&snapshot.TeamMessages).Where("instance_id = ?", instanceID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// CollectLocationWithTx gathers a location with its marker, blocks, the
+// progress made on them and any uploads.
+func (r *TrashRepository) CollectLocationWithTx(
+	ctx context.Context,
+	tx *bun.Tx,
+	locationID string,
+) (*models.TrashSnapshot, error) {
+	snapshot := new(models.TrashSnapshot)
+	err := tx.NewSelect().Model(&snapshot.Locations).Where("id = ?", locationID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshot.Locations) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	err = r.collectContent(ctx, tx, snapshot, []string{locationID})
+	if err != nil {
+		return nil, err
+	}
+
+	blockIDs := snapshotBlockIDs(snapshot)
+	if len(blockIDs) > 0 {
+		err = tx.NewSelect().
+			Model(&snapshot.BlockStates).
+			Where("block_id IN (?)", bun.In(blockIDs)).
+			Scan(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.NewSelect().Model(&snapshot.CheckIns).Where("location_id = ?", locationID).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := tx.NewSelect().Model(&snapshot.Uploads).Where("location_id = ?", locationID)
+	if len(blockIDs) > 0 {
+		query = query.WhereOr("block_id IN (?)", bun.In(blockIDs))
+	}
+	err = query.Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// CollectTeamWithTx gathers a team with its check-ins, block progress,
+// uploads and messages.
+func (r *TrashRepository) CollectTeamWithTx(
+	ctx context.Context,
+	tx *bun.Tx,
+	instanceID, teamCode string,
+) (*models.TrashSnapshot, error) {
+	snapshot := new(models.TrashSnapshot)
+	err := tx.NewSelect().
+		Model(&snapshot.Teams).
+		Where("instance_id = ?", instanceID).
+		Where("code = ?", teamCode).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshot.Teams) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	err = tx.NewSelect().Model(&snapshot.BlockStates).Where("team_code = ?", teamCode).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.NewSelect().
+		Model(&snapshot.CheckIns).
+		Where("instance_id = ?", instanceID).
+		Where("team_code = ?", teamCode).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.NewSelect().Model(&snapshot.Uploads).Where("team_code = ?", teamCode).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.NewSelect().
+		Model(&snapshot.TeamMessages).
+		Where("instance_id = ?", instanceID).
+		Where("team_code = ?", teamCode).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// collectContent gathers the markers of the snapshot's locations and the
+// blocks belonging to the given owners.
+func (r *TrashRepository) collectContent(
+	ctx context.Context,
+	tx *bun.Tx,
+	snapshot *models.TrashSnapshot,
+	ownerIDs []string,
+) error {
+	markerCodes := make([]string, 0, len(snapshot.Locations))
+	for _, location := range snapshot.Locations {
+		markerCodes = append(markerCodes, location.MarkerID)
+	}
+	if len(markerCodes) > 0 {
+		err := tx.NewSelect().
+			Model(&snapshot.Markers).
+			Where("code IN (?)", bun.In(markerCodes)).
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.NewSelect().
+		Model(&snapshot.Blocks).
+		Where("owner_id IN (?)", bun.In(ownerIDs)).
+		Scan(ctx)
+}
+
+// RemoveWithTx deletes the snapshot's rows. Markers are left for the caller
+// to clean up, as they may be shared with other locations.
+func (r *TrashRepository) RemoveWithTx(ctx context.Context, tx *bun.Tx, snapshot *models.TrashSnapshot) error {
+	removals := []struct {
+		model any
+		count int
+	}{
+		{&snapshot.TeamMessages, len(snapshot.TeamMessages)},
+		{&snapshot.Uploads, len(snapshot.Uploads)},
+		{&snapshot.CheckIns, len(snapshot.CheckIns)},
+		{&snapshot.BlockStates, len(snapshot.BlockStates)},
+		{&snapshot.Blocks, len(snapshot.Blocks)},
+		{&snapshot.Teams, len(snapshot.Teams)},
+		{&snapshot.Locations, len(snapshot.Locations)},
+		{&snapshot.InstanceSettings, len(snapshot.InstanceSettings)},
+		{&snapshot.Instances, len(snapshot.Instances)},
+	}
+	for _, removal := range removals {
+		if removal.count == 0 {
+			continue
+		}
+		_, err := tx.NewDelete().Model(removal.model).WherePK().Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreWithTx inserts the snapshot's rows again. Markers that still exist
+// are kept as they are.
+func (r *TrashRepository) RestoreWithTx(ctx context.Context, tx *bun.Tx, snapshot *models.TrashSnapshot) error {
+	if len(snapshot.Markers) > 0 {
+		_, err := tx.NewInsert().Model(&snapshot.Markers).Ignore().Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	inserts := []struct {
+		model any
+		count int
+	}{
+		{&snapshot.Instances, len(snapshot.Instances)},
+		{&snapshot.InstanceSettings, len(snapshot.InstanceSettings)},
+		{&snapshot.Locations, len(snapshot.Locations)},
+		{&snapshot.Teams, len(snapshot.Teams)},
+		{&snapshot.Blocks, len(snapshot.Blocks)},
+		{&snapshot.BlockStates, len(snapshot.BlockStates)},
+		{&snapshot.CheckIns, len(snapshot.CheckIns)},
+		{&snapshot.Uploads, len(snapshot.Uploads)},
+		{&snapshot.TeamMessages, len(snapshot.TeamMessages)},
+	}
+	for _, insert := range inserts {
+		if insert.count == 0 {
+			continue
+		}
+		_, err := tx.NewInsert().Model(insert.model).Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func snapshotBlockIDs(snapshot *models.TrashSnapshot) []string {
+	blockIDs := make([]string, len(snapshot.Blocks))
+	for i, block := range snapshot.Blocks {
+		blockIDs[i] = block.ID
+	}
+	return blockIDs
+}
@@ -0,0 +1,78 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/nathanhollows/Rapua/v6/db"
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/nathanhollows/Rapua/v6/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashRepository_CollectRemoveRestore(t *testing.T) {
+	dbc, cleanup := setupDB(t)
+	defer cleanup()
+	repo := repositories.NewTrashRepository(dbc)
+	transactor := db.NewTransactor(dbc)
+	ctx := context.Background()
+
+	instanceID := gofakeit.UUID()
+	team := &models.Team{
+		ID:              gofakeit.UUID(),
+		Code:            gofakeit.LetterN(5),
+		InstanceID:      instanceID,
+		Points:          42,
+		SkippedGroupIDs: []string{"group-1"},
+	}
+	_, err := dbc.NewInsert().Model(team).Exec(ctx)
+	require.NoError(t, err)
+	_, err = dbc.NewInsert().Model(&models.TeamBlockState{
+		TeamCode: team.Code,
+		BlockID:  gofakeit.UUID(),
+	}).Exec(ctx)
+	require.NoError(t, err)
+	require.NoError(t, repositories.NewTeamMessageRepository(dbc).Create(ctx, &models.TeamMessage{
+		InstanceID: instanceID,
+		TeamCode:   team.Code,
+		Sender:     models.MessageFromTeam,
+		Content:    "Help",
+	}))
+
+	tx, err := transactor.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	snapshot, err := repo.CollectTeamWithTx(ctx, tx, instanceID, team.Code)
+	require.NoError(t, err)
+	require.Len(t, snapshot.Teams, 1)
+	assert.Len(t, snapshot.BlockStates, 1)
+	assert.Len(t, snapshot.TeamMessages, 1)
+	require.NoError(t, repo.RemoveWithTx(ctx, tx, snapshot))
+	require.NoError(t, tx.Commit())
+
+	count, err := dbc.NewSelect().Model((*models.Team)(nil)).Where("code = ?", team.Code).Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	tx, err = transactor.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.RestoreWithTx(ctx, tx, snapshot))
+	require.NoError(t, tx.Commit())
+
+	restored := new(models.Team)
+	require.NoError(t, dbc.NewSelect().Model(restored).Where("code = ?", team.Code).Scan(ctx))
+	assert.Equal(t, team.ID, restored.ID)
+	assert.Equal(t, 42, restored.Points)
+	assert.Equal(t, []string{"group-1"}, restored.SkippedGroupIDs)
+	count, err = dbc.NewSelect().Model((*models.TeamMessage)(nil)).Where("team_code = ?", team.Code).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// Missing teams cannot be trashed
+	tx, err = transactor.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+	_, err = repo.CollectTeamWithTx(ctx, tx, instanceID, "NOPE1")
+	require.Error(t, err)
+}
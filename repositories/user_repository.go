@@ -29,6 +29,8 @@ type UserRepository interface {
 
 	// Update updates a user in the database
 	Update(ctx context.Context, user *models.User) error
+	// UpdateTwoFactor saves a user's two-factor authentication settings
+	UpdateTwoFactor(ctx context.Context, user *models.User) error
 
 	// Delete deletes a user from the database
 	// Requires a transaction as related data will also need to be deleted
@@ -76,6 +78,25 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 	return err
 }
 
+// UpdateTwoFactor saves a user's two-factor authentication settings. These
+// are kept out of Update so that saving a profile can't switch them off.
+func (r *userRepository) UpdateTwoFactor(ctx context.Context, user *models.User) error {
+	user.UpdatedAt = time.Now().UTC()
+	res, err := r.db.NewUpdate().
+		Model(user).
+		Column("totp_secret", "totp_enabled", "totp_last_step", "updated_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if rows == 0 {
+		return ErrUserNotFound
+	}
+	return err
+}
+
 // Create creates a new user in the database.
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	if user.ID == "" {
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/models"
+	"github.com/uptrace/bun"
+)
+
+// WebhookRepository stores webhooks and the log of events sent to them.
+type WebhookRepository struct {
+	db *bun.DB
+}
+
+func NewWebhookRepository(db *bun.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+// Create saves a new webhook.
+func (r *WebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	_, err := r.db.NewInsert().Model(webhook).Exec(ctx)
+	return err
+}
+
+// Update saves a webhook's URL, events and whether it is enabled.
+func (r *WebhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	webhook.UpdatedAt = time.Now()
+	_, err := r.db.NewUpdate().
+		Model(webhook).
+		Column("url", "events", "enabled", "updated_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+// GetByID finds one of an instance's webhooks.
+func (r *WebhookRepository) GetByID(ctx context.Context, instanceID, id string) (*models.Webhook, error) {
+	webhook := &models.Webhook{}
+	err := r.db.NewSelect().
+		Model(webhook).
+		Where("id = ?", id).
+		Where("instance_id = ?", instanceID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// FindByInstanceID returns an instance's webhooks, oldest first.
+func (r *WebhookRepository) FindByInstanceID(ctx context.Context, instanceID string) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.NewSelect().
+		Model(&webhooks).
+		Where("instance_id = ?", instanceID).
+		Order("created_at ASC").
+		Scan(ctx)
+	return webhooks, err
+}
+
+// Delete removes a webhook and its delivery log. It returns sql.ErrNoRows if
+// the instance has no webhook with that ID.
+func (r *WebhookRepository) Delete(ctx context.Context, instanceID, id string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewDelete().
+			Model((*models.Webhook)(nil)).
+			Where("id = ?", id).
+			Where("instance_id = ?", instanceID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return sql.ErrNoRows
+		}
+		_, err = tx.NewDelete().
+			Model((*models.WebhookDelivery)(nil)).
+			Where("webhook_id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+// DeleteByInstanceIDWithTx removes all of an instance's webhooks and their
+// delivery logs.
+func (r *WebhookRepository) DeleteByInstanceIDWithTx(ctx context.Context, tx *bun.Tx, instanceID string) error {
+	_, err := tx.NewDelete().
+		Model((*models.WebhookDelivery)(nil)).
+		Where("instance_id = ?", instanceID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.NewDelete().
+		Model((*models.Webhook)(nil)).
+		Where("instance_id = ?", instanceID).
+		Exec(ctx)
+	return err
+}
+
+// CreateDelivery queues a delivery. A delivery whose dedupe key has already
+// been used for the webhook is skipped.
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	_, err := r.db.NewInsert().Model(delivery).Ignore().Exec(ctx)
+	return err
+}
+
+// CancelPending removes the webhook's queued deliveries of an event that have
+// not been attempted yet.
+func (r *WebhookRepository) CancelPending(ctx context.Context, webhookID string, event models.WebhookEvent) error {
+	_, err := r.db.NewDelete().
+		Model((*models.WebhookDelivery)(nil)).
+		Where("webhook_id = ?", webhookID).
+		Where("event = ?", event).
+		Where("status = ?", models.DeliveryPending).
+		Where("attempts = 0").
+		Exec(ctx)
+	return err
+}
+
+// FindDue returns pending deliveries that are ready to send, with their
+// webhooks, oldest first.
+func (r *WebhookRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.NewSelect().
+		Model(&deliveries).
+		Where("status = ?", models.DeliveryPending).
+		Where("next_attempt_at <= ?", now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	return deliveries, err
+}
+
+// UpdateDelivery saves the outcome of an attempt.
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	delivery.UpdatedAt = time.Now()
+	_, err := r.db.NewUpdate().
+		Model(delivery).
+		Column("status", "attempts", "next_attempt_at", "last_attempt_at", "response_status", "error", "updated_at").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+// FindDeliveries returns the most recent deliveries for an instance's
+// webhooks, including those still waiting to be sent, newest first.
+func (r *WebhookRepository) FindDeliveries(
+	ctx context.Context,
+	instanceID string,
+	limit int,
+) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.NewSelect().
+		Model(&deliveries).
+		Where("instance_id = ?", instanceID).
+		Order("created_at DESC").
+		Limit(limit).
+		Scan(ctx)
+	return deliveries, err
+}
+
+// DeleteDeliveriesBefore removes finished deliveries older than the cutoff.
+func (r *WebhookRepository) DeleteDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := r.db.NewDelete().
+		Model((*models.WebhookDelivery)(nil)).
+		Where("status != ?", models.DeliveryPending).
+		Where("created_at < ?", cutoff).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
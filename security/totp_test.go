@@ -0,0 +1,72 @@
+package security_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/nathanhollows/Rapua/v6/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA-1 test key from RFC 6238 appendix B, base32 encoded.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	t.Parallel()
+	// RFC 6238 test vectors, truncated to six digits
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tc := range testCases {
+		code, err := security.TOTPCode(rfcSecret, security.TOTPStep(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tc.code, code, "code at %d", tc.unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	t.Parallel()
+	secret, err := security.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	step := security.TOTPStep(now)
+	code, err := security.TOTPCode(secret, step)
+	require.NoError(t, err)
+
+	matched, ok := security.ValidateTOTP(secret, code, now)
+	assert.True(t, ok, "current code should be accepted")
+	assert.Equal(t, step, matched)
+
+	_, ok = security.ValidateTOTP(secret, code[:3]+" "+code[3:], now)
+	assert.True(t, ok, "spaces should be ignored")
+
+	_, ok = security.ValidateTOTP(secret, code, now.Add(security.TOTPPeriod))
+	assert.True(t, ok, "the previous code should be accepted for clock drift")
+
+	_, ok = security.ValidateTOTP(secret, code, now.Add(3*security.TOTPPeriod))
+	assert.False(t, ok, "old codes should be refused")
+
+	_, ok = security.ValidateTOTP(secret, "12345", now)
+	assert.False(t, ok, "short codes should be refused")
+}
+
+func TestTOTPURI(t *testing.T) {
+	t.Parallel()
+	uri, err := url.Parse(security.TOTPURI("Rapua", "ada@example.com", rfcSecret))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Rapua:ada@example.com", uri.Path)
+	assert.Equal(t, rfcSecret, uri.Query().Get("secret"))
+	assert.Equal(t, "Rapua", uri.Query().Get("issuer"))
+}
@@ -0,0 +1,239 @@
+/**
+ * MapBlock - Renders and edits Map blocks.
+ * Usage:
+ *   1. Include this file after mapbox-gl.js
+ *   2. Any element with the .map-block-canvas class is initialised on load
+ *      and after every htmx swap
+ *
+ * Tiles come from MAP_TILE_URL when it is set (a raster XYZ template such as
+ * http://localhost:8081/{z}/{x}/{y}.png), otherwise from Mapbox.
+ */
+const MapBlock = (function() {
+	const _featureColour = '#3b82f6';
+	const _emptyCollection = { type: 'FeatureCollection', features: [] };
+
+	// Build a map style from the configured tile server or fall back to Mapbox
+	function _style(el) {
+		const tileURL = el.dataset.tileUrl;
+		if (tileURL) {
+			return {
+				version: 8,
+				sources: {
+					tiles: {
+						type: 'raster',
+						tiles: [tileURL],
+						tileSize: 256,
+						attribution: el.dataset.tileAttribution || '',
+					},
+				},
+				layers: [{ id: 'tiles', type: 'raster', source: 'tiles' }],
+			};
+		}
+		const keyEl = document.getElementById('mapbox_key');
+		if (keyEl) {
+			mapboxgl.accessToken = keyEl.dataset.key;
+		}
+		return window.matchMedia && window.matchMedia('(prefers-color-scheme: dark)').matches
+			? 'mapbox://styles/nathanhollows/cl9w3nxff002m14sy9fco4vnr'
+			: 'mapbox://styles/nathanhollows/clszboe2y005i01oid8ca37jm';
+	}
+
+	function _parse(text) {
+		try {
+			const parsed = JSON.parse(text);
+			if (parsed && parsed.type === 'FeatureCollection' && Array.isArray(parsed.features)) {
+				return parsed;
+			}
+			if (parsed && parsed.type === 'Feature') {
+				return { type: 'FeatureCollection', features: [parsed] };
+			}
+		} catch (e) {
+			// Invalid JSON is reported by the server on save
+		}
+		return JSON.parse(JSON.stringify(_emptyCollection));
+	}
+
+	function _escape(text) {
+		const div = document.createElement('div');
+		div.textContent = text;
+		return div.innerHTML;
+	}
+
+	// Extend bounds with every position in a geometry
+	function _extend(bounds, coords) {
+		if (typeof coords[0] === 'number') {
+			bounds.extend(coords);
+			return;
+		}
+		coords.forEach(c => _extend(bounds, c));
+	}
+
+	// Draw points as markers and everything else as GeoJSON layers
+	function _draw(map, collection, markers) {
+		markers.forEach(m => m.remove());
+		markers.length = 0;
+
+		collection.features.forEach(feature => {
+			if (!feature.geometry || feature.geometry.type !== 'Point') {
+				return;
+			}
+			const props = feature.properties || {};
+			const marker = new mapboxgl.Marker({ color: props.color || _featureColour })
+				.setLngLat(feature.geometry.coordinates);
+			if (props.name || props.description) {
+				let html = '';
+				if (props.name) html += '<h3 class="font-bold">' + _escape(props.name) + '</h3>';
+				if (props.description) html += '<p>' + _escape(props.description) + '</p>';
+				marker.setPopup(new mapboxgl.Popup().setHTML(html));
+			}
+			marker.addTo(map);
+			markers.push(marker);
+		});
+
+		const shapes = {
+			type: 'FeatureCollection',
+			features: collection.features.filter(f => f.geometry && f.geometry.type !== 'Point' && f.geometry.type !== 'MultiPoint'),
+		};
+		const source = map.getSource('map-block-features');
+		if (source) {
+			source.setData(shapes);
+			return;
+		}
+		map.addSource('map-block-features', { type: 'geojson', data: shapes });
+		map.addLayer({
+			id: 'map-block-fill',
+			type: 'fill',
+			source: 'map-block-features',
+			filter: ['in', ['geometry-type'], ['literal', ['Polygon', 'MultiPolygon']]],
+			paint: {
+				'fill-color': ['coalesce', ['get', 'color'], _featureColour],
+				'fill-opacity': 0.25,
+			},
+		});
+		map.addLayer({
+			id: 'map-block-line',
+			type: 'line',
+			source: 'map-block-features',
+			paint: {
+				'line-color': ['coalesce', ['get', 'color'], _featureColour],
+				'line-width': 3,
+			},
+		});
+	}
+
+	function _fit(map, el, collection) {
+		if (el.dataset.hasCenter === 'true') {
+			return;
+		}
+		if (collection.features.length === 0) {
+			return;
+		}
+		const bounds = new mapboxgl.LngLatBounds();
+		collection.features.forEach(f => f.geometry && _extend(bounds, f.geometry.coordinates));
+		map.fitBounds(bounds, { padding: 40, maxZoom: 17, duration: 0 });
+	}
+
+	// Wire up the admin editor: click to pin, import files, and sync the view
+	function _edit(map, el, markers) {
+		const form = el.closest('form');
+		const textarea = form.querySelector('textarea[name="geojson"]');
+		const save = () => htmx.trigger(form, 'save');
+
+		const redraw = () => {
+			_draw(map, _parse(textarea.value), markers);
+		};
+
+		map.on('click', (e) => {
+			const name = window.prompt('Label for this pin (optional)');
+			if (name === null) {
+				return;
+			}
+			const collection = _parse(textarea.value);
+			collection.features.push({
+				type: 'Feature',
+				geometry: { type: 'Point', coordinates: [e.lngLat.lng, e.lngLat.lat] },
+				properties: name ? { name: name } : {},
+			});
+			textarea.value = JSON.stringify(collection, null, 2);
+			redraw();
+			save();
+		});
+
+		const viewButton = form.querySelector('.map-block-use-view');
+		if (viewButton) {
+			viewButton.addEventListener('click', () => {
+				const center = map.getCenter();
+				form.querySelector('input[name="center_lat"]').value = center.lat.toFixed(6);
+				form.querySelector('input[name="center_lng"]').value = center.lng.toFixed(6);
+				form.querySelector('input[name="zoom"]').value = map.getZoom().toFixed(1);
+				save();
+			});
+		}
+
+		textarea.addEventListener('change', redraw);
+
+		const importInput = form.querySelector('input[name="geojson_file"]');
+		if (importInput) {
+			importInput.addEventListener('change', () => {
+				const file = importInput.files[0];
+				if (!file) return;
+				file.text().then(text => {
+					const current = _parse(textarea.value);
+					const imported = _parse(text);
+					current.features = current.features.concat(imported.features);
+					textarea.value = JSON.stringify(current, null, 2);
+					importInput.value = '';
+					redraw();
+					save();
+				});
+			});
+		}
+
+		const clearButton = form.querySelector('.map-block-clear');
+		if (clearButton) {
+			clearButton.addEventListener('click', () => {
+				textarea.value = JSON.stringify(_emptyCollection, null, 2);
+				redraw();
+				save();
+			});
+		}
+	}
+
+	function init(el) {
+		if (el.dataset.initialised === 'true') {
+			return;
+		}
+		el.dataset.initialised = 'true';
+
+		const collection = _parse(el.dataset.features || '');
+		const center = [parseFloat(el.dataset.lng) || 0, parseFloat(el.dataset.lat) || 0];
+		// Zoom 0 shows the whole world, so only a missing zoom gets the default
+		const zoom = parseFloat(el.dataset.zoom);
+		const map = new mapboxgl.Map({
+			container: el,
+			style: _style(el),
+			center: center,
+			zoom: isNaN(zoom) ? 15 : zoom,
+			cooperativeGestures: el.dataset.editable !== 'true',
+		});
+		map.addControl(new mapboxgl.NavigationControl({ showCompass: false }));
+
+		const markers = [];
+		map.on('load', () => {
+			_draw(map, collection, markers);
+			_fit(map, el, collection);
+			if (el.dataset.editable === 'true') {
+				_edit(map, el, markers);
+			}
+		});
+	}
+
+	function initAll(root) {
+		(root || document).querySelectorAll('.map-block-canvas').forEach(init);
+	}
+
+	document.addEventListener('DOMContentLoaded', () => initAll(document));
+	document.addEventListener('htmx:load', (e) => initAll(e.target));
+
+	return { init: init, initAll: initAll };
+})();
@@ -0,0 +1,105 @@
+// Push alerts for players. Registers the service worker and lets players
+// turn on alerts, so announcements reach them while their phone is locked.
+function initPushAlerts() {
+	const button = document.getElementById('push-toggle');
+	if (!button) {
+		return;
+	}
+	if (!('serviceWorker' in navigator) || !('PushManager' in window) || !('Notification' in window)) {
+		return;
+	}
+
+	function csrfHeaders() {
+		const headers = { 'Content-Type': 'application/json' };
+		const hxHeaders = document.body.getAttribute('hx-headers');
+		if (hxHeaders) {
+			headers['X-CSRF-TOKEN'] = JSON.parse(hxHeaders)['X-CSRF-TOKEN'];
+		}
+		return headers;
+	}
+
+	function send(method, subscription) {
+		return fetch('/push/', {
+			method: method,
+			credentials: 'same-origin',
+			headers: csrfHeaders(),
+			body: JSON.stringify(subscription),
+		}).then(response => {
+			if (!response.ok) {
+				throw new Error('Push request failed: ' + response.status);
+			}
+		});
+	}
+
+	// The key is base64url encoded, but subscribe() needs the raw bytes
+	function decodeKey(key) {
+		const base64 = (key + '='.repeat((4 - key.length % 4) % 4)).replace(/-/g, '+').replace(/_/g, '/');
+		return Uint8Array.from(atob(base64), c => c.charCodeAt(0));
+	}
+
+	function show(subscribed) {
+		button.textContent = subscribed ? 'Turn off alerts' : 'Turn on alerts';
+		button.dataset.subscribed = subscribed ? 'true' : 'false';
+		button.classList.remove('hidden');
+		button.previousElementSibling?.classList.remove('hidden');
+	}
+
+	function subscribe(registration) {
+		return fetch('/push/key', { credentials: 'same-origin' })
+			.then(response => response.json())
+			.then(data => registration.pushManager.subscribe({
+				userVisibleOnly: true,
+				applicationServerKey: decodeKey(data.key),
+			}))
+			.then(subscription => send('POST', subscription.toJSON()));
+	}
+
+	function unsubscribe(registration) {
+		return registration.pushManager.getSubscription().then(subscription => {
+			if (!subscription) {
+				return;
+			}
+			const json = subscription.toJSON();
+			return subscription.unsubscribe().then(() => send('DELETE', json));
+		});
+	}
+
+	navigator.serviceWorker.register('/sw.js', { scope: '/' }).then(registration => {
+		registration.pushManager.getSubscription().then(subscription => {
+			// Resend an existing subscription, as the device may have joined
+			// another team since
+			if (subscription && Notification.permission === 'granted') {
+				send('POST', subscription.toJSON()).catch(err => console.warn(err));
+			}
+			show(!!subscription);
+		});
+
+		button.addEventListener('click', () => {
+			button.disabled = true;
+			const change = button.dataset.subscribed === 'true'
+				? unsubscribe(registration).then(() => show(false))
+				: Notification.requestPermission().then(permission => {
+					if (permission !== 'granted') {
+						throw new Error('Notifications are blocked');
+					}
+					return subscribe(registration).then(() => show(true));
+				});
+			change
+				.catch(err => {
+					console.warn('Changing alerts failed:', err);
+					button.textContent = Notification.permission === 'denied'
+						? 'Alerts blocked in browser settings'
+						: 'Alerts unavailable';
+				})
+				.finally(() => {
+					button.disabled = false;
+				});
+		});
+	}).catch(err => console.warn('Service worker registration failed:', err));
+}
+
+if (document.readyState === 'loading') {
+	document.addEventListener('DOMContentLoaded', initPushAlerts);
+} else {
+	initPushAlerts();
+}
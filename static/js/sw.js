@@ -0,0 +1,50 @@
+// Service worker for player alerts. Shows pushed announcements while the
+// page is closed or the phone is locked, and opens the game when tapped.
+
+self.addEventListener('install', () => {
+	self.skipWaiting();
+});
+
+self.addEventListener('activate', event => {
+	event.waitUntil(self.clients.claim());
+});
+
+self.addEventListener('push', event => {
+	let message = {};
+	if (event.data) {
+		try {
+			message = event.data.json();
+		} catch (e) {
+			message = { body: event.data.text() };
+		}
+	}
+
+	event.waitUntil(
+		self.registration.showNotification(message.title || 'Game alert', {
+			body: message.body || '',
+			icon: '/static/images/favicon.png',
+			badge: '/static/images/favicon.png',
+			tag: 'rapua-alert',
+			renotify: true,
+			requireInteraction: true,
+			data: { url: message.url || '/next' },
+		})
+	);
+});
+
+self.addEventListener('notificationclick', event => {
+	event.notification.close();
+	const url = new URL(event.notification.data.url || '/next', self.location.origin);
+
+	// Focus an open game tab if there is one, otherwise open a new one
+	event.waitUntil(
+		self.clients.matchAll({ type: 'window', includeUncontrolled: true }).then(windows => {
+			for (const client of windows) {
+				if (new URL(client.url).origin === url.origin && 'focus' in client) {
+					return client.navigate(url.href).then(c => (c || client).focus());
+				}
+			}
+			return self.clients.openWindow(url.href);
+		})
+	);
+});